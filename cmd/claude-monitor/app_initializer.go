@@ -97,9 +97,8 @@ func initializeReporting(dbPath string) error {
 	
 	// Initialize database connection
 	var err error
-	connConfig := &sqlite.ConnectionConfig{
-		DBPath: dbPath,
-	}
+	// Same defaults as the daemon so times are stored in one timezone
+	connConfig := sqlite.DefaultConnectionConfig(dbPath)
 	unifiedDB, err = sqlite.NewSQLiteDB(connConfig)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
@@ -121,8 +120,63 @@ func initializeReporting(dbPath string) error {
 	
 	// Initialize reporting services with repositories
 	unifiedReportingSvc = reporting.NewSQLiteReportingService(sessionRepo, workBlockRepo, activityRepo, projectRepo)
+	unifiedReportingSvc.EnableGoalTracking(sqlite.NewGoalRepository(unifiedDB.DB()))
 	unifiedAnalytics = reporting.NewWorkAnalyticsEngine(workBlockRepo, activityRepo, projectRepo)
+	configureInsightRules()
+	
+	return nil
+}
+
+/**
+ * CONTEXT:   Apply user insight rules to the reporting services
+ * INPUT:     Rules file from configuration or ~/.claude-monitor/insight_rules.json
+ * OUTPUT:    Reporting services using file rules, or built-in defaults on error
+ * BUSINESS:  Teams tune insight thresholds without recompiling
+ * CHANGE:    Initial insight rules configuration
+ * RISK:      Low - Invalid rules files fall back to defaults with a warning
+ */
+func configureInsightRules() {
+	rulesPath := insightRulesPath()
+	if _, err := os.Stat(rulesPath); err != nil {
+		return
+	}
+	
+	rules, err := reporting.LoadInsightRules(rulesPath)
+	if err != nil {
+		warningColor.Printf("⚠️  Ignoring insight rules: %v\n", err)
+		return
+	}
 	
+	unifiedReportingSvc.SetInsightRules(rules)
+	unifiedAnalytics.SetInsightRules(rules)
+}
+
+// insightRulesPath resolves the configured rules file or the default location
+func insightRulesPath() string {
+	if config, err := loadConfiguration(); err == nil && config.Reporting.InsightRulesPath != "" {
+		return expandPath(config.Reporting.InsightRulesPath)
+	}
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".claude-monitor", "insight_rules.json")
+}
+
+/**
+ * CONTEXT:   Initialize reporting against the default installation database
+ * INPUT:     Installed configuration directory (~/.claude-monitor)
+ * OUTPUT:    Initialized reporting system; caller must defer closeReporting()
+ * BUSINESS:  Shared bootstrap for read-only CLI commands
+ * CHANGE:    Extracted from command handlers to avoid repeating database setup
+ * RISK:      Low - Wrapper around initializeReporting with friendly errors
+ */
+func initializeDefaultReporting() error {
+	configDir, err := createConfigurationDirectory()
+	if err != nil {
+		return fmt.Errorf("configuration directory not found - run 'claude-monitor install' first")
+	}
+	
+	if err := initializeReporting(filepath.Join(configDir, "monitor.db")); err != nil {
+		return fmt.Errorf("failed to initialize reporting system: %w", err)
+	}
 	return nil
 }
 
@@ -193,7 +247,7 @@ func generateUnifiedDailyReport(userID string, date time.Time) error {
  */
 func displayEnhancedDailyReport(report *reporting.EnhancedDailyReport, date time.Time) error {
 	// Use professional display system from reporting package
-	reporting.DisplayProfessionalHeader("DAILY REPORT", date.Format("Monday, January 2, 2006"))
+	reporting.DisplayProfessionalHeader("DAILY REPORT", date.Format("Monday, January 2, 2006")+reportOptionsSuffix())
 	
 	if report.TotalWorkHours == 0 {
 		reporting.DisplayProfessionalEmptyState("No work activity recorded for this date.")
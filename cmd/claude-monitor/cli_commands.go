@@ -11,10 +11,13 @@ package main
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"runtime"
 
+	"github.com/claude-monitor/system/internal/logging"
+	"github.com/claude-monitor/system/internal/reporting"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
@@ -130,7 +133,7 @@ func runVersionCommand(cmd *cobra.Command, args []string) {
  * INPUT:     Global flags and command hierarchy setup
  * OUTPUT:    Fully configured command tree with validation
  * BUSINESS:  Proper command structure enables intuitive user experience
- * CHANGE:    Extracted command initialization from main function
+ * CHANGE:    Registered the prompt command
  * RISK:      Low - Command setup with proper flag validation
  */
 func initializeCommands() {
@@ -147,14 +150,18 @@ func initializeCommands() {
 	
 	// Daemon command flags  
 	daemonCmd.Flags().String("listen", "localhost:9193", "HTTP server listen address")
-	daemonCmd.Flags().String("log-level", "info", "logging level")
+	daemonCmd.Flags().String("log-level", "info", "logging level (debug, info, warn, error); overrides logging.level")
 	daemonCmd.Flags().Bool("cors", false, "enable CORS")
 	daemonCmd.Flags().Int("max-requests", 100, "maximum concurrent requests")
+	daemonCmd.Flags().BoolVar(&daemonContainer, "container", false, "container mode: configure from CLAUDE_MONITOR_* env vars, bind 0.0.0.0 with token auth, log JSON to stdout")
 	
 	// Today command flags
 	todayCmd.Flags().String("date", "", "specific date (YYYY-MM-DD)")
 	todayCmd.Flags().Bool("json", false, "output as JSON")
 	todayCmd.Flags().Bool("csv", false, "output as CSV")
+	todayCmd.Flags().StringVar(&reportBranch, "branch", "", "only count work on this git branch (glob allowed, e.g. feature/*)")
+	todayCmd.Flags().StringArrayVar(&reportTags, "tag", nil, "only count work carrying this tag (repeatable)")
+	todayCmd.Flags().StringVar(&reportGroupBy, "group-by", reporting.GroupByProject, "roll project hours up to client, parent or project")
 	
 	// Version command flags
 	versionCmd.Flags().BoolVar(&verbose, "verbose", false, "show detailed system information")
@@ -163,7 +170,19 @@ func initializeCommands() {
 	rootCmd.AddCommand(installCmd)
 	rootCmd.AddCommand(daemonCmd) 
 	rootCmd.AddCommand(todayCmd)
+	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(promptCmd)
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(goalCmd)
+	rootCmd.AddCommand(hookCmd)
+	rootCmd.AddCommand(healthcheckCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(projectCmd)
+	rootCmd.AddCommand(tagCmd)
+	rootCmd.AddCommand(entryCmd)
+	rootCmd.AddCommand(dbCmd)
 	rootCmd.AddCommand(serviceCmd) // Will be imported from service.go
 	
 	// Configure colors
@@ -177,10 +196,12 @@ func initializeCommands() {
  * INPUT:     Parsed command line arguments and environment
  * OUTPUT:    Command execution with appropriate exit codes
  * BUSINESS:  Proper command execution enables reliable automation
- * CHANGE:    Extracted command execution from main function
+ * CHANGE:    CLI commands log warnings only; the daemon replaces this logger
  * RISK:      Low - Command execution with proper error handling
  */
 func executeRootCommand() error {
+	slog.SetDefault(logging.NewCLILogger())
+	
 	// Initialize dependency injection before command execution
 	if err := initializeDependencyInjection(); err != nil {
 		return fmt.Errorf("failed to initialize dependencies: %w", err)
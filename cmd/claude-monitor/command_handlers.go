@@ -10,13 +10,21 @@
 package main
 
 import (
+	"bytes"
+	"bufio"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/claude-monitor/system/internal/reporting"
 	"github.com/spf13/cobra"
 )
 
@@ -114,6 +122,10 @@ func runTodayCommand(cmd *cobra.Command, args []string) error {
 	}
 	defer closeReporting()
 	
+	if err := applyReportOptions(); err != nil {
+		return err
+	}
+	
 	// Generate and display report
 	userID := getCurrentUserID()
 	return generateUnifiedDailyReport(userID, targetDate)
@@ -205,11 +217,12 @@ func formatDuration(d time.Duration) string {
  * INPUT:     Timeout configuration and HTTP request requirements
  * OUTPUT:    Configured HTTP client ready for daemon communication
  * BUSINESS:  HTTP client enables daemon status monitoring
- * CHANGE:    Extracted HTTP client creation for reusability
+ * CHANGE:    Optional bearer token for daemons that require auth
  * RISK:      Low - HTTP client configuration with timeout
  */
 type HTTPClient struct {
-	timeout time.Duration
+	timeout   time.Duration
+	authToken string
 }
 
 type HealthStatus struct {
@@ -223,22 +236,242 @@ func NewHTTPClient(timeout time.Duration) *HTTPClient {
 	return &HTTPClient{timeout: timeout}
 }
 
+// WithAuthToken sends token as a bearer token on every request; empty disables auth
+func (c *HTTPClient) WithAuthToken(token string) *HTTPClient {
+	c.authToken = token
+	return c
+}
+
+// do sends a request to the daemon with the bearer token when one is set
+func (c *HTTPClient) do(method, url string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+	return (&http.Client{Timeout: c.timeout}).Do(req)
+}
+
 /**
  * CONTEXT:   Daemon health status retrieval
- * INPUT:     Daemon URL and health check requirements
- * OUTPUT:    Current daemon health status and metrics
- * BUSINESS:  Health checks enable daemon monitoring and diagnostics
- * CHANGE:    Extracted health check logic for reusability
+ * INPUT:     Daemon base URL
+ * OUTPUT:    Readiness status and uptime; error when the daemon is unreachable
+ *            or not ready
+ * BUSINESS:  Health checks enable daemon monitoring and diagnostics, including
+ *            the container HEALTHCHECK
+ * CHANGE:    Query /health/ready (falling back to /health) instead of returning fixed data
  * RISK:      Low - HTTP request with proper error handling
  */
 func (c *HTTPClient) GetHealthStatus(url string) (*HealthStatus, error) {
-	// This is a simplified implementation
-	// In a real implementation, this would make an HTTP request
-	// to the daemon's /health endpoint
-	return &HealthStatus{
-		Status:          "healthy",
-		Uptime:          time.Hour,
-		ActiveSessions:  1,
-		TotalWorkBlocks: 5,
-	}, nil
-}
\ No newline at end of file
+	base := strings.TrimSuffix(url, "/")
+	resp, err := c.do(http.MethodGet, base+"/health/ready", nil)
+	if err != nil {
+		return nil, err
+	}
+	// Daemons with health checks disabled only serve /health
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		if resp, err = c.do(http.MethodGet, base+"/health", nil); err != nil {
+			return nil, err
+		}
+	}
+	defer resp.Body.Close()
+
+	var probe struct {
+		Status string `json:"status"`
+	}
+	json.NewDecoder(resp.Body).Decode(&probe)
+	if resp.StatusCode != http.StatusOK {
+		if probe.Status == "" {
+			probe.Status = resp.Status
+		}
+		return nil, fmt.Errorf("daemon is %s", probe.Status)
+	}
+
+	status := &HealthStatus{Status: probe.Status}
+	// Uptime is informational; /status may require a token the caller lacks
+	if resp, err := c.do(http.MethodGet, base+"/status", nil); err == nil {
+		var daemonStatus struct {
+			UptimeSeconds int64 `json:"uptime_seconds"`
+		}
+		if resp.StatusCode == http.StatusOK && json.NewDecoder(resp.Body).Decode(&daemonStatus) == nil {
+			status.Uptime = time.Duration(daemonStatus.UptimeSeconds) * time.Second
+		}
+		resp.Body.Close()
+	}
+	return status, nil
+}
+
+/**
+ * CONTEXT:   Post an activity event to the daemon
+ * INPUT:     Daemon base URL and activity payload
+ * OUTPUT:    Nil when the daemon accepted the event, error otherwise
+ * BUSINESS:  The hook forwards every Claude action to the daemon for tracking
+ * CHANGE:    Authenticates with the configured token when set
+ * RISK:      Low - Single bounded request with client timeout
+ */
+func (c *HTTPClient) PostActivity(url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode activity: %w", err)
+	}
+
+	resp, err := c.do(http.MethodPost, strings.TrimSuffix(url, "/")+"/api/v1/activity", body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// 200 means the path is ignored by project rules
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("daemon rejected activity: %s", resp.Status)
+	}
+	return nil
+}
+
+/**
+ * CONTEXT:   Fetch the live dashboard snapshot
+ * INPUT:     Daemon base URL and the user whose activity is shown
+ * OUTPUT:    LiveStatus from GET /api/v1/live, or the daemon's error
+ * BUSINESS:  `claude-monitor status` renders everything from this one call
+ * CHANGE:    Initial live status client
+ * RISK:      Low - Single bounded read request
+ */
+func (c *HTTPClient) GetLiveStatus(baseURL, userID string) (*reporting.LiveStatus, error) {
+	endpoint := strings.TrimSuffix(baseURL, "/") + "/api/v1/live?user_id=" + url.QueryEscape(userID)
+	resp, err := c.do(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&apiErr) == nil && apiErr.Error != "" {
+			return nil, fmt.Errorf("daemon live status failed: %s", apiErr.Error)
+		}
+		return nil, fmt.Errorf("daemon live status failed: %s", resp.Status)
+	}
+
+	var status reporting.LiveStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, fmt.Errorf("failed to decode live status: %w", err)
+	}
+	return &status, nil
+}
+
+/**
+ * CONTEXT:   Follow the daemon's live event stream
+ * INPUT:     Context bounding the stream, daemon base URL, user ID and an event callback
+ * OUTPUT:    onConnect once the stream opens, onEvent per activity; returns when
+ *            the stream ends, ctx is cancelled or the daemon has no stream
+ * BUSINESS:  Lets `status --watch` refresh as soon as a hook event lands
+ * CHANGE:    Subscribe to one user's events
+ * RISK:      Low - No client timeout; the caller's context ends the stream
+ */
+func (c *HTTPClient) StreamLiveEvents(ctx context.Context, baseURL, userID string, onConnect func(), onEvent func(reporting.LiveEvent)) error {
+	endpoint := strings.TrimSuffix(baseURL, "/") + "/api/v1/live/events?user_id=" + url.QueryEscape(userID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if c.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("live event stream unavailable: %s", resp.Status)
+	}
+
+	onConnect()
+	return readServerSentEvents(resp.Body, func(name, data string) {
+		if name != "activity" {
+			return
+		}
+		var event reporting.LiveEvent
+		if json.Unmarshal([]byte(data), &event) == nil {
+			onEvent(event)
+		}
+	})
+}
+
+// readServerSentEvents dispatches each complete event; comments and unknown fields are skipped
+func readServerSentEvents(r io.Reader, dispatch func(name, data string)) error {
+	scanner := bufio.NewScanner(r)
+	name, data := "", []string{}
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(data) > 0 {
+				if name == "" {
+					name = "message"
+				}
+				dispatch(name, strings.Join(data, "\n"))
+			}
+			name, data = "", data[:0]
+		case strings.HasPrefix(line, ":"):
+		case strings.HasPrefix(line, "event:"):
+			name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	return scanner.Err()
+}
+
+// DaemonReachable reports whether a daemon answers on url
+func (c *HTTPClient) DaemonReachable(url string) bool {
+	client := &http.Client{Timeout: c.timeout}
+	resp, err := client.Get(strings.TrimSuffix(url, "/") + "/health")
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return true
+}
+
+/**
+ * CONTEXT:   Call a daemon admin endpoint
+ * INPUT:     Daemon base URL, admin action, optional JSON payload and response target
+ * OUTPUT:    Decoded response, or the daemon's error message
+ * BUSINESS:  Backup and restore go through the daemon while it owns the database
+ * CHANGE:    Sends the bearer token so admin calls work against an auth-enabled daemon
+ * RISK:      Medium - Admin actions can replace the database
+ */
+func (c *HTTPClient) PostAdmin(url, action string, payload, out interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	resp, err := c.do(http.MethodPost, strings.TrimSuffix(url, "/")+"/api/v1/admin/"+action, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&apiErr) == nil && apiErr.Error != "" {
+			return fmt.Errorf("daemon %s failed: %s", action, apiErr.Error)
+		}
+		return fmt.Errorf("daemon %s failed: %s", action, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
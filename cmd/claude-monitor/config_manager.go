@@ -13,6 +13,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"time"
 
 	cfg "github.com/claude-monitor/system/internal/config"
 )
@@ -22,7 +23,7 @@ import (
  * INPUT:     Configuration parameters for daemon, session, reporting, projects
  * OUTPUT:    Runtime configuration enabling all application modes
  * BUSINESS:  Unified configuration ensures consistent behavior across CLI and daemon
- * CHANGE:    Extracted AppConfig type definition for better organization
+ * CHANGE:    Added the user a calendar token is issued for
  * RISK:      Low - Configuration structure with safe defaults
  */
 type AppConfig struct {
@@ -32,6 +33,10 @@ type AppConfig struct {
 		LogLevel               string `json:"log_level"`
 		EnableCORS             bool   `json:"enable_cors"`
 		MaxConcurrentRequests  int    `json:"max_concurrent_requests"`
+		CalendarToken          string `json:"calendar_token"`
+		CalendarUser           string `json:"calendar_user,omitempty"`
+		AuthToken              string `json:"auth_token,omitempty"`
+		StatusFile             string `json:"status_file"`
 	} `json:"daemon"`
 	
 	Session struct {
@@ -44,13 +49,30 @@ type AppConfig struct {
 		EnableColors         bool   `json:"enable_colors"`
 		MaxProjectsDisplay   int    `json:"max_projects_display"`
 		TimeFormat           string `json:"time_format"`
+		InsightRulesPath     string `json:"insight_rules_path"`
 	} `json:"reporting"`
 	
 	Projects struct {
 		AutoDetect        bool              `json:"auto_detect"`
 		CustomNames       map[string]string `json:"custom_names"`
 		TrackGitBranches  bool              `json:"track_git_branches"`
+		Rules             []cfg.ProjectRuleConfig `json:"rules"`
 	} `json:"projects"`
+	
+	Retention cfg.RetentionConfig `json:"retention"`
+	
+	Backup struct {
+		Enabled        bool   `json:"enabled"`
+		IntervalHours  int    `json:"interval_hours"`
+		Path           string `json:"path"`
+		KeepLast       int    `json:"keep_last"`
+		KeepDaily      int    `json:"keep_daily"`
+		KeepWeekly     int    `json:"keep_weekly"`
+	} `json:"backup"`
+	
+	Alerts cfg.AlertsConfig `json:"alerts"`
+	
+	Logging cfg.LoggingConfig `json:"logging"`
 }
 
 /**
@@ -58,7 +80,7 @@ type AppConfig struct {
  * INPUT:     Configuration file path and environment settings
  * OUTPUT:    Validated application configuration ready for use
  * BUSINESS:  Configuration loading enables customization while ensuring defaults
- * CHANGE:    Extracted configuration loading from main startup sequence
+ * CHANGE:    Default the prompt status file next to the database
  * RISK:      Low - Configuration loading with error handling and fallbacks
  */
 func loadConfiguration() (*AppConfig, error) {
@@ -74,6 +96,11 @@ func loadConfiguration() (*AppConfig, error) {
 	// Daemon configuration
 	appConfig.Daemon.ListenAddr = daemonConfig.GetServerAddr()
 	appConfig.Daemon.DatabasePath = daemonConfig.GetDatabasePath()
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		// Daemon writes the database the CLI reports read (see initializeDefaultReporting)
+		appConfig.Daemon.DatabasePath = filepath.Join(homeDir, ".claude-monitor", "monitor.db")
+	}
+	appConfig.Daemon.StatusFile = filepath.Join(filepath.Dir(appConfig.Daemon.DatabasePath), "status.json")
 	appConfig.Daemon.LogLevel = daemonConfig.Logging.Level
 	appConfig.Daemon.EnableCORS = true
 	appConfig.Daemon.MaxConcurrentRequests = daemonConfig.Performance.MaxConcurrentRequests
@@ -93,6 +120,27 @@ func loadConfiguration() (*AppConfig, error) {
 	appConfig.Projects.CustomNames = make(map[string]string)
 	appConfig.Projects.TrackGitBranches = false
 	
+	// Retention configuration (keeps everything until days are set)
+	appConfig.Retention = daemonConfig.Retention
+	
+	// Backup configuration (daily rotating backups next to the database)
+	appConfig.Backup.Enabled = daemonConfig.Database.BackupEnabled
+	appConfig.Backup.IntervalHours = int(daemonConfig.Database.BackupInterval / time.Hour)
+	appConfig.Backup.Path = filepath.Join(filepath.Dir(appConfig.Daemon.DatabasePath), "backups")
+	appConfig.Backup.KeepLast = daemonConfig.Database.BackupKeepLast
+	appConfig.Backup.KeepDaily = daemonConfig.Database.BackupKeepDaily
+	appConfig.Backup.KeepWeekly = daemonConfig.Database.BackupKeepWeekly
+	
+	// Alerts configuration (work alert thresholds; no channels until configured)
+	appConfig.Alerts = daemonConfig.Alerts
+	
+	// Logging configuration (empty level falls back to daemon.log_level)
+	appConfig.Logging = daemonConfig.Logging
+	appConfig.Logging.Level = ""
+	if appConfig.Logging.OutputFile == "" {
+		appConfig.Logging.OutputFile = filepath.Join(filepath.Dir(appConfig.Daemon.DatabasePath), "logs", "daemon.log")
+	}
+	
 	// Try to load custom configuration file if it exists
 	configPath := getConfigPath()
 	if _, err := os.Stat(configPath); err == nil {
@@ -102,6 +150,17 @@ func loadConfiguration() (*AppConfig, error) {
 		}
 	}
 	
+	// Environment keeps the calendar feed and API secrets out of config files
+	if token := os.Getenv("CLAUDE_MONITOR_CALENDAR_TOKEN"); token != "" {
+		appConfig.Daemon.CalendarToken = token
+	}
+	if token := os.Getenv("CLAUDE_MONITOR_AUTH_TOKEN"); token != "" {
+		appConfig.Daemon.AuthToken = token
+	}
+	if statusFile := os.Getenv("CLAUDE_MONITOR_STATUS_FILE"); statusFile != "" {
+		appConfig.Daemon.StatusFile = statusFile
+	}
+	
 	return appConfig, nil
 }
 
@@ -0,0 +1,153 @@
+/**
+ * CONTEXT:   Container image support: environment-only daemon and healthcheck
+ * INPUT:     CLAUDE_MONITOR_* environment variables and the daemon health probes
+ * OUTPUT:    `daemon --container` startup and `healthcheck` exit codes for Docker
+ * BUSINESS:  The image runs without a home directory or config.json; the
+ *            runtime restarts the container when the healthcheck fails
+ * CHANGE:    An empty configured token no longer clears the environment token
+ * RISK:      Medium - The container daemon listens on all interfaces
+ */
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	cfg "github.com/claude-monitor/system/internal/config"
+	"github.com/claude-monitor/system/internal/daemon"
+	"github.com/spf13/cobra"
+)
+
+var (
+	daemonContainer    bool
+	healthcheckURL     string
+	healthcheckTimeout time.Duration
+)
+
+/**
+ * CONTEXT:   Healthcheck command for container runtimes
+ * INPUT:     Optional daemon URL; otherwise CLAUDE_MONITOR_LISTEN_ADDR or config.json
+ * OUTPUT:    Exit 0 when the daemon is ready, 1 otherwise
+ * BUSINESS:  Docker HEALTHCHECK needs a probe that works in a scratch image
+ *            without curl
+ * CHANGE:    Initial healthcheck command
+ * RISK:      Low - Read-only probe bounded by its timeout
+ */
+var healthcheckCmd = &cobra.Command{
+	Use:   "healthcheck",
+	Short: "Exit 0 when the daemon is ready, 1 otherwise",
+	Long: `Probe the daemon's readiness endpoint and exit 0 when it is ready.
+
+The daemon address comes from --url, then CLAUDE_MONITOR_LISTEN_ADDR, then
+daemon.listen_addr in ~/.claude/config.json. A wildcard bind address such
+as 0.0.0.0 is probed on loopback. Health probes need no auth token.
+
+In a Dockerfile:
+
+  HEALTHCHECK --interval=30s --timeout=5s CMD ["claude-monitor", "healthcheck"]`,
+	Example: `  claude-monitor healthcheck
+  claude-monitor healthcheck --url http://127.0.0.1:9193`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runHealthcheck,
+}
+
+// init registers the healthcheck flags
+func init() {
+	healthcheckCmd.Flags().StringVar(&healthcheckURL, "url", "", "daemon base URL (default from CLAUDE_MONITOR_LISTEN_ADDR or config)")
+	healthcheckCmd.Flags().DurationVar(&healthcheckTimeout, "timeout", 3*time.Second, "maximum time to wait for the daemon")
+}
+
+/**
+ * CONTEXT:   Healthcheck handler
+ * INPUT:     Resolved daemon URL
+ * OUTPUT:    One status line; error (exit 1) when unreachable or not ready
+ * BUSINESS:  Builds on HTTPClient.GetHealthStatus so `service status` and the
+ *            container probe agree on what healthy means
+ * CHANGE:    Initial healthcheck handler
+ * RISK:      Low - Single bounded request per endpoint
+ */
+func runHealthcheck(cmd *cobra.Command, args []string) error {
+	url, token := healthcheckTarget()
+	health, err := NewHTTPClient(healthcheckTimeout).WithAuthToken(token).GetHealthStatus(url)
+	if err != nil {
+		return fmt.Errorf("unhealthy: %w", err)
+	}
+	fmt.Printf("%s (uptime %s)\n", health.Status, health.Uptime)
+	return nil
+}
+
+// healthcheckTarget resolves the daemon URL and optional token for the probe; a
+// configured token wins over CLAUDE_MONITOR_AUTH_TOKEN, an empty one does not
+func healthcheckTarget() (string, string) {
+	listenAddr := os.Getenv("CLAUDE_MONITOR_LISTEN_ADDR")
+	token := os.Getenv("CLAUDE_MONITOR_AUTH_TOKEN")
+	if config, err := loadConfiguration(); err == nil {
+		if listenAddr == "" {
+			listenAddr = config.Daemon.ListenAddr
+		}
+		if config.Daemon.AuthToken != "" {
+			token = config.Daemon.AuthToken
+		}
+	}
+	if healthcheckURL != "" {
+		return healthcheckURL, token
+	}
+	if listenAddr == "" {
+		listenAddr = cfg.DefaultListenAddr
+	}
+	return "http://" + probeAddr(listenAddr), token
+}
+
+// probeAddr maps a wildcard bind address to loopback so it can be dialled
+func probeAddr(listenAddr string) string {
+	host, port, err := net.SplitHostPort(listenAddr)
+	if err != nil {
+		return listenAddr
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+/**
+ * CONTEXT:   Container daemon startup
+ * INPUT:     CLAUDE_MONITOR_* environment only; ~/.claude/config.json is never read
+ * OUTPUT:    Running daemon on 0.0.0.0 with token auth, JSON logs on stdout and
+ *            its database on the data volume
+ * BUSINESS:  One image serves every user; settings travel as environment
+ *            variables and secrets instead of a baked-in config file
+ * CHANGE:    Initial container startup
+ * RISK:      High - Refuses to start without an auth token
+ */
+func runContainerDaemon(cmd *cobra.Command) error {
+	cmd.SilenceUsage = true
+	daemonConfig, err := cfg.LoadContainerConfig()
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("log-level") {
+		daemonConfig.Logging.Level, _ = cmd.Flags().GetString("log-level")
+	}
+
+	// Stdout carries JSON logs only; skip the interactive banners
+	daemonService = true
+
+	if err := initializeDatabaseWithDeps(daemonDeps.Database, daemonConfig.Database.Path); err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	orchestrator, err := daemon.NewOrchestrator(daemon.OrchestratorConfig{DaemonConfig: daemonConfig})
+	if err != nil {
+		return fmt.Errorf("failed to create daemon orchestrator: %w", err)
+	}
+
+	appConfig := &AppConfig{}
+	appConfig.Daemon.ListenAddr = net.JoinHostPort(daemonConfig.Server.Host, fmt.Sprint(daemonConfig.Server.Port))
+	return startDaemon(orchestrator, appConfig)
+}
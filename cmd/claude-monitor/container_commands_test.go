@@ -0,0 +1,154 @@
+/**
+ * CONTEXT:   Test suite for container mode and the healthcheck
+ * INPUT:     Fake daemons served by httptest and CLAUDE_MONITOR_* variables
+ * OUTPUT:    Validation of readiness probing, token forwarding and container defaults
+ * BUSINESS:  Docker restarts the container on a failed healthcheck, so a ready
+ *            daemon must never report unhealthy and a not-ready one must
+ * CHANGE:    Cover config-file tokens and an explicit backup path
+ * RISK:      Low - Local test servers and temp dirs only
+ */
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cfg "github.com/claude-monitor/system/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHealthStatusReady(t *testing.T) {
+	var statusAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health/ready":
+			w.Write([]byte(`{"status":"ready"}`))
+		case "/status":
+			statusAuth = r.Header.Get("Authorization")
+			w.Write([]byte(`{"status":"healthy","uptime_seconds":90}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	health, err := NewHTTPClient(time.Second).WithAuthToken("s3cret").GetHealthStatus(server.URL + "/")
+	require.NoError(t, err)
+	assert.Equal(t, "ready", health.Status)
+	assert.Equal(t, 90*time.Second, health.Uptime)
+	assert.Equal(t, "Bearer s3cret", statusAuth)
+}
+
+func TestGetHealthStatusNotReady(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"status":"not_ready"}`))
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPClient(time.Second).GetHealthStatus(server.URL)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not_ready")
+}
+
+func TestGetHealthStatusFallsBackToHealth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			w.Write([]byte(`{"status":"healthy"}`))
+		case "/status":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	health, err := NewHTTPClient(time.Second).GetHealthStatus(server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "healthy", health.Status)
+	assert.Zero(t, health.Uptime, "uptime is optional when /status is protected")
+}
+
+func TestGetHealthStatusUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	_, err := NewHTTPClient(time.Second).GetHealthStatus(url)
+	assert.Error(t, err)
+}
+
+func TestProbeAddr(t *testing.T) {
+	assert.Equal(t, "127.0.0.1:9193", probeAddr("0.0.0.0:9193"))
+	assert.Equal(t, "127.0.0.1:9193", probeAddr(":9193"))
+	assert.Equal(t, "127.0.0.1:9193", probeAddr("[::]:9193"))
+	assert.Equal(t, "localhost:9193", probeAddr("localhost:9193"))
+	assert.Equal(t, "10.0.0.5:80", probeAddr("10.0.0.5:80"))
+}
+
+func TestHealthcheckTargetUsesEnvironment(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("CLAUDE_MONITOR_LISTEN_ADDR", "0.0.0.0:8080")
+	t.Setenv("CLAUDE_MONITOR_AUTH_TOKEN", "s3cret")
+
+	url, token := healthcheckTarget()
+	assert.Equal(t, "http://127.0.0.1:8080", url)
+	assert.Equal(t, "s3cret", token)
+}
+
+func TestHealthcheckTargetUsesConfigToken(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("CLAUDE_MONITOR_LISTEN_ADDR", "")
+	t.Setenv("CLAUDE_MONITOR_AUTH_TOKEN", "")
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".claude"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".claude", "config.json"),
+		[]byte(`{"daemon":{"listen_addr":"localhost:9300","auth_token":"from-config"}}`), 0600))
+
+	url, token := healthcheckTarget()
+	assert.Equal(t, "http://localhost:9300", url)
+	assert.Equal(t, "from-config", token)
+}
+
+func TestLoadContainerConfig(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv("CLAUDE_MONITOR_DATA_DIR", dataDir)
+	t.Setenv("CLAUDE_MONITOR_LISTEN_ADDR", "")
+	t.Setenv("CLAUDE_MONITOR_DB_PATH", "")
+	t.Setenv("CLAUDE_MONITOR_BACKUP_PATH", "")
+	t.Setenv("CLAUDE_MONITOR_LOG_FILE", filepath.Join(dataDir, "daemon.log"))
+	t.Setenv("CLAUDE_MONITOR_AUTH_TOKEN", "")
+	t.Setenv("CLAUDE_MONITOR_AUTH_TOKEN_FILE", "")
+
+	_, err := cfg.LoadContainerConfig()
+	require.Error(t, err, "an exposed daemon requires a token")
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("from-secret\n"), 0600))
+	t.Setenv("CLAUDE_MONITOR_AUTH_TOKEN_FILE", tokenFile)
+
+	config, err := cfg.LoadContainerConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "from-secret", config.Server.AuthToken)
+	assert.Equal(t, "0.0.0.0", config.Server.Host)
+	assert.Equal(t, 9193, config.Server.Port)
+	assert.Equal(t, filepath.Join(dataDir, "claude_monitor.db"), config.Database.Path)
+	assert.Equal(t, filepath.Join(dataDir, "backups"), config.Database.BackupPath)
+	assert.Equal(t, "json", config.Logging.Format)
+	assert.Empty(t, config.Logging.OutputFile, "container logs go to stdout")
+
+	t.Setenv("CLAUDE_MONITOR_LISTEN_ADDR", "0.0.0.0:8080")
+	backupDir := filepath.Join(t.TempDir(), "offsite")
+	t.Setenv("CLAUDE_MONITOR_BACKUP_PATH", backupDir)
+	config, err = cfg.LoadContainerConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 8080, config.Server.Port)
+	assert.Equal(t, backupDir, config.Database.BackupPath, "an explicit backup path is kept")
+}
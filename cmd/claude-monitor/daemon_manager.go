@@ -12,10 +12,12 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -57,7 +59,7 @@ var (
  * INPUT:     Command arguments and daemon configuration flags
  * OUTPUT:    Running HTTP daemon with graceful shutdown capability
  * BUSINESS:  Daemon mode provides background service for continuous work tracking
- * CHANGE:    Applied dependency injection with interface abstractions
+ * CHANGE:    --container takes configuration from the environment instead of config.json
  * RISK:      High - Service startup and lifecycle management
  */
 func runDaemonCommand(cmd *cobra.Command, args []string) error {
@@ -66,10 +68,17 @@ func runDaemonCommand(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("daemon dependencies not initialized")
 	}
 	
+	if daemonContainer {
+		return runContainerDaemon(cmd)
+	}
+	
 	config, err := loadConfigurationWithDeps(daemonDeps.FileSystem)
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
+	if cmd.Flags().Changed("log-level") {
+		config.Logging.Level, _ = cmd.Flags().GetString("log-level")
+	}
 	
 	// Check if running as Windows service
 	if runtime.GOOS == "windows" {
@@ -100,7 +109,7 @@ func runStandardDaemon(config *AppConfig) error {
 	}
 	
 	// Override config with command line flags
-	if daemonPort != cfg.DefaultDaemonPort {
+	if daemonPort != "" && daemonPort != cfg.DefaultDaemonPort {
 		config.Daemon.ListenAddr = fmt.Sprintf("%s:%s", daemonHost, daemonPort)
 	}
 	
@@ -127,16 +136,9 @@ func runStandardDaemon(config *AppConfig) error {
  * RISK:      Medium - Component initialization and dependency setup
  */
 func createDaemonOrchestratorWithDeps(config *AppConfig, deps *DaemonDependencies) (*daemon.Orchestrator, error) {
-	daemonConfig := &cfg.DaemonConfig{
-		Server: cfg.ServerConfig{
-			ListenAddr: config.Daemon.ListenAddr,
-		},
-		Database: cfg.DatabaseConfig{
-			Path: config.Daemon.DatabasePath,
-		},
-		Performance: cfg.PerformanceConfig{
-			MaxConcurrentRequests: 100, // Default value
-		},
+	daemonConfig, err := buildDaemonConfig(config)
+	if err != nil {
+		return nil, err
 	}
 	
 	orchestratorConfig := daemon.OrchestratorConfig{
@@ -157,16 +159,9 @@ func createDaemonOrchestratorWithDeps(config *AppConfig, deps *DaemonDependencie
  * RISK:      Medium - Uses concrete dependencies without abstraction
  */
 func createDaemonOrchestrator(config *AppConfig) (*daemon.Orchestrator, error) {
-	daemonConfig := &cfg.DaemonConfig{
-		Server: cfg.ServerConfig{
-			ListenAddr: config.Daemon.ListenAddr,
-		},
-		Database: cfg.DatabaseConfig{
-			Path: config.Daemon.DatabasePath,
-		},
-		Performance: cfg.PerformanceConfig{
-			MaxConcurrentRequests: 100, // Default value
-		},
+	daemonConfig, err := buildDaemonConfig(config)
+	if err != nil {
+		return nil, err
 	}
 	
 	orchestratorConfig := daemon.OrchestratorConfig{
@@ -176,12 +171,77 @@ func createDaemonOrchestrator(config *AppConfig) (*daemon.Orchestrator, error) {
 	return daemon.NewOrchestrator(orchestratorConfig)
 }
 
+/**
+ * CONTEXT:   Build daemon configuration from application settings
+ * INPUT:     Application configuration with listen address and database path
+ * OUTPUT:    Daemon configuration based on defaults, ready for validation
+ * BUSINESS:  Daemon must bind the address and open the database the CLI uses
+ * CHANGE:    Copy the user the calendar token is issued for
+ * RISK:      Low - Only overrides address, port, database path, calendar token and user, auth token,
+ *            status file, project rules, retention, backups, alerts and logging
+ */
+func buildDaemonConfig(config *AppConfig) (*cfg.DaemonConfig, error) {
+	daemonConfig := cfg.NewDefaultConfig()
+	daemonConfig.Performance.MaxConcurrentRequests = 100
+	daemonConfig.Calendar.Token = config.Daemon.CalendarToken
+	daemonConfig.Calendar.User = config.Daemon.CalendarUser
+	daemonConfig.Server.AuthToken = config.Daemon.AuthToken
+	daemonConfig.WorkTracking.StatusFile = expandPath(config.Daemon.StatusFile)
+	daemonConfig.Projects.Rules = config.Projects.Rules
+	daemonConfig.Projects.CustomNames = config.Projects.CustomNames
+	daemonConfig.Retention = config.Retention
+	daemonConfig.Alerts = config.Alerts
+	for i := range daemonConfig.Alerts.Channels {
+		if path := daemonConfig.Alerts.Channels[i].Path; path != "" {
+			daemonConfig.Alerts.Channels[i].Path = expandPath(path)
+		}
+	}
+	daemonConfig.Logging = config.Logging
+	if daemonConfig.Logging.Level == "" {
+		daemonConfig.Logging.Level = config.Daemon.LogLevel
+	}
+	if daemonConfig.Logging.Level == "" {
+		daemonConfig.Logging.Level = "info"
+	}
+	if daemonConfig.Logging.OutputFile != "" {
+		daemonConfig.Logging.OutputFile = expandPath(daemonConfig.Logging.OutputFile)
+	}
+	daemonConfig.Database.BackupEnabled = config.Backup.Enabled
+	daemonConfig.Database.BackupInterval = time.Duration(config.Backup.IntervalHours) * time.Hour
+	daemonConfig.Database.BackupKeepLast = config.Backup.KeepLast
+	daemonConfig.Database.BackupKeepDaily = config.Backup.KeepDaily
+	daemonConfig.Database.BackupKeepWeekly = config.Backup.KeepWeekly
+	if config.Backup.Path != "" {
+		daemonConfig.Database.BackupPath = expandPath(config.Backup.Path)
+	}
+	
+	if config.Daemon.DatabasePath != "" {
+		daemonConfig.Database.Path = expandPath(config.Daemon.DatabasePath)
+	}
+	
+	if config.Daemon.ListenAddr != "" {
+		host, portStr, err := net.SplitHostPort(config.Daemon.ListenAddr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid listen address %q: %w", config.Daemon.ListenAddr, err)
+		}
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port in listen address %q: %w", config.Daemon.ListenAddr, err)
+		}
+		daemonConfig.Server.ListenAddr = config.Daemon.ListenAddr
+		daemonConfig.Server.Host = host
+		daemonConfig.Server.Port = port
+	}
+	
+	return daemonConfig, nil
+}
+
 /**
  * CONTEXT:   Start daemon with graceful shutdown handling
  * INPUT:     Configured orchestrator and application settings
  * OUTPUT:    Running daemon with signal handling and cleanup
  * BUSINESS:  Proper daemon startup enables reliable background operation
- * CHANGE:    Extracted daemon startup with signal handling
+ * CHANGE:    Wait for the orchestrator's graceful shutdown before exiting
  * RISK:      High - Signal handling and graceful shutdown coordination
  */
 func startDaemon(orchestrator *daemon.Orchestrator, config *AppConfig) error {
@@ -212,12 +272,20 @@ func startDaemon(orchestrator *daemon.Orchestrator, config *AppConfig) error {
 		}
 		cancel()
 		
-		// Graceful shutdown with timeout
-		_, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		// Graceful shutdown with timeout (server drain limit plus margin)
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.NewDefaultConfig().Server.ShutdownTimeout+5*time.Second)
 		defer shutdownCancel()
 		
-		// Daemon will stop naturally when context is cancelled
-		// The Run() method handles graceful shutdown
+		// Run() receives the same signal; wait for it to drain requests,
+		// close the database and flush the log file
+		select {
+		case err := <-errChan:
+			if err != nil {
+				return err
+			}
+		case <-shutdownCtx.Done():
+			return fmt.Errorf("daemon did not stop within shutdown timeout")
+		}
 		if !daemonService {
 			successColor.Println("✅ Daemon stopped gracefully")
 		}
@@ -244,9 +312,7 @@ func initializeDatabase(dbPath string) error {
 	
 	// Initialize global database connection
 	var err error
-	connConfig := &sqlite.ConnectionConfig{
-		DBPath: dbPath,
-	}
+	connConfig := sqlite.DefaultConnectionConfig(dbPath)
 	unifiedDB, err = sqlite.NewSQLiteDB(connConfig)
 	if err != nil {
 		return fmt.Errorf("failed to create database connection: %w", err)
@@ -260,7 +326,9 @@ func initializeDatabase(dbPath string) error {
 	
 	// Initialize reporting services
 	unifiedReportingSvc = reporting.NewSQLiteReportingService(sessionRepo, workBlockRepo, activityRepo, projectRepo)
+	unifiedReportingSvc.EnableGoalTracking(sqlite.NewGoalRepository(unifiedDB.DB()))
 	unifiedAnalytics = reporting.NewWorkAnalyticsEngine(workBlockRepo, activityRepo, projectRepo)
+	configureInsightRules()
 	
 	return nil
 }
@@ -288,6 +356,10 @@ func loadConfigurationWithDeps(fs FileSystemProvider) (*AppConfig, error) {
  * RISK:      Medium - Database initialization affecting data persistence
  */
 func initializeDatabaseWithDeps(db DatabaseProvider, databasePath string) error {
+	// No provider outside the CLI reporting path; the orchestrator opens its own connection
+	if db == nil {
+		return nil
+	}
 	// Use injected database provider instead of concrete implementation
 	return db.Connect(databasePath)
 }
@@ -0,0 +1,576 @@
+/**
+ * CONTEXT:   Database maintenance command group
+ * INPUT:     Retention and backup settings from configuration, overridable by flags
+ * OUTPUT:    Purge previews, purged rows, backups, restores and doctor reports
+ * BUSINESS:  Users bound database growth and can roll back to a known-good copy
+ * CHANGE:    Admin calls send the daemon admin token; restore takes manifest backups only
+ * RISK:      High - Purge deletes data and restore replaces it; both preview first
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/claude-monitor/system/internal/daemon"
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/spf13/cobra"
+)
+
+var (
+	purgeDryRun        bool
+	purgeActivityDays  int
+	purgeWorkBlockDays int
+	purgeBatchSize     int
+	purgeVacuum        bool
+	restoreYes         bool
+	doctorFix          bool
+)
+
+// adminRequestTimeout bounds backup and restore requests to the daemon
+const adminRequestTimeout = 5 * time.Minute
+
+/**
+ * CONTEXT:   Database command group for maintenance tasks
+ * INPUT:     Database subcommand selection
+ * OUTPUT:    Routed database subcommand execution
+ * BUSINESS:  Maintenance lives in one place next to the data it changes
+ * CHANGE:    Initial db command group
+ * RISK:      Low - Command routing only
+ */
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Database maintenance",
+	Long: `Maintain the Claude Monitor database.
+
+Retention is configured in the "retention" section of the config file:
+
+  "retention": {
+    "activity_events_days": 90,
+    "work_blocks_days": 365,
+    "interval_hours": 24,
+    "batch_size": 500,
+    "vacuum": true
+  }
+
+Zero days keeps a table forever, which is the default. When retention is
+set, the daemon purges on the configured interval in small batches and
+reclaims space with an incremental vacuum. Purged work blocks are kept
+as daily per-project rollups, which are never purged.
+
+Backups are configured in the "backup" section:
+
+  "backup": {
+    "enabled": true,
+    "interval_hours": 24,
+    "path": "~/.claude-monitor/backups",
+    "keep_last": 7,
+    "keep_daily": 7,
+    "keep_weekly": 4
+  }
+
+The daemon writes timestamped backups on the interval and rotates them:
+a backup is kept if it is one of the newest keep_last, the newest of one
+of the last keep_daily days, or the newest of one of the last keep_weekly
+weeks. Checksums are recorded in manifest.json in the backup directory.`,
+	Example: `  claude-monitor db purge --dry-run
+  claude-monitor db purge --activity-days 90 --work-block-days 365
+  claude-monitor db purge --no-vacuum
+  claude-monitor db backup
+  claude-monitor db backups
+  claude-monitor db restore monitor-20261018T090000Z.db
+  claude-monitor db restore monitor-20261018T090000Z.db --yes
+  claude-monitor db doctor
+  claude-monitor db doctor --fix`,
+}
+
+/**
+ * CONTEXT:   Database command initialization with subcommands and flags
+ * INPUT:     Cobra command definitions and flag configurations
+ * OUTPUT:    Complete db command structure
+ * BUSINESS:  Purge flags default to the configured retention policy
+ * CHANGE:    Added the doctor subcommand
+ * RISK:      Low - Command structure initialization with no side effects
+ */
+func init() {
+	dbPurgeCmd := &cobra.Command{
+		Use:   "purge",
+		Short: "Delete data older than the retention policy",
+		Long: `Delete activity events and finished work blocks older than the
+retention policy, roll purged work blocks up into daily totals and
+reclaim the freed space with VACUUM.
+
+Flags override the configured retention days for this run.`,
+		Args: cobra.NoArgs,
+		RunE: runDBPurge,
+	}
+	dbPurgeCmd.Flags().BoolVar(&purgeDryRun, "dry-run", false, "show what would be purged without deleting")
+	dbPurgeCmd.Flags().IntVar(&purgeActivityDays, "activity-days", 0, "keep activity events for this many days (0 keeps them forever)")
+	dbPurgeCmd.Flags().IntVar(&purgeWorkBlockDays, "work-block-days", 0, "keep work blocks for this many days (0 keeps them forever)")
+	dbPurgeCmd.Flags().IntVar(&purgeBatchSize, "batch-size", 0, "rows deleted per transaction")
+	dbPurgeCmd.Flags().BoolVar(&purgeVacuum, "vacuum", true, "run VACUUM after purging")
+	dbPurgeCmd.Flags().Bool("no-vacuum", false, "skip VACUUM after purging")
+
+	dbBackupCmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Create a backup now",
+		Args:  cobra.NoArgs,
+		RunE:  runDBBackup,
+	}
+
+	dbBackupsCmd := &cobra.Command{
+		Use:   "backups",
+		Short: "List backups",
+		Args:  cobra.NoArgs,
+		RunE:  runDBBackups,
+	}
+
+	dbRestoreCmd := &cobra.Command{
+		Use:   "restore <file>",
+		Short: "Restore the database from a backup",
+		Long: `Restore the database from a backup name from 'db backups' or the
+path of a backup in the backup directory. Files that are not listed in
+the backup manifest, or no longer match its checksum, are rejected.
+
+The backup is checked first (integrity check, checksum from the manifest
+and schema version) and the current database is backed up before it is
+replaced. While the daemon runs, the restore goes through the daemon;
+otherwise the database file is swapped atomically. Backups from older
+releases are upgraded to the current schema after restore.
+
+Without --yes only the checks are shown.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runDBRestore,
+	}
+	dbRestoreCmd.Flags().BoolVar(&restoreYes, "yes", false, "perform the restore (without it only the checks are shown)")
+
+	dbDoctorCmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check database consistency and repair drift",
+		Long: `Check the database for problems:
+
+  integrity           SQLite integrity check
+  foreign_keys        rows referencing missing parents
+  active_sessions     more than one active session per user
+  session_bounds      work blocks running past their session's end
+  overlapping_blocks  overlapping work blocks of the same user
+  counter_drift       activity counters that disagree with activity events
+  orphaned_projects   projects with no data or settings
+
+With --fix, each check's repairs are applied in one transaction. Work
+block repairs are recorded in 'entry history'. Nothing is repaired while
+the integrity or foreign key checks fail; restore a backup instead.
+Take a backup with 'db backup' before repairing.`,
+		Args: cobra.NoArgs,
+		RunE: runDBDoctor,
+	}
+	dbDoctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "apply repairs")
+
+	dbCmd.AddCommand(dbPurgeCmd)
+	dbCmd.AddCommand(dbBackupCmd)
+	dbCmd.AddCommand(dbBackupsCmd)
+	dbCmd.AddCommand(dbRestoreCmd)
+	dbCmd.AddCommand(dbDoctorCmd)
+}
+
+/**
+ * CONTEXT:   Database purge command handler
+ * INPUT:     Retention configuration and flag overrides
+ * OUTPUT:    Preview or purge counts, followed by a full VACUUM
+ * BUSINESS:  A manual purge reclaims space immediately; the daemon's scheduled
+ *            purge uses incremental vacuum instead
+ * CHANGE:    Initial purge handler
+ * RISK:      High - Deletes data; VACUUM briefly blocks the daemon's writes
+ */
+func runDBPurge(cmd *cobra.Command, args []string) error {
+	config, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	policy := sqlite.RetentionPolicy{
+		ActivityEventsDays: config.Retention.ActivityEventsDays,
+		WorkBlocksDays:     config.Retention.WorkBlocksDays,
+		BatchSize:          config.Retention.BatchSize,
+	}
+	if cmd.Flags().Changed("activity-days") {
+		policy.ActivityEventsDays = purgeActivityDays
+	}
+	if cmd.Flags().Changed("work-block-days") {
+		policy.WorkBlocksDays = purgeWorkBlockDays
+	}
+	if cmd.Flags().Changed("batch-size") {
+		policy.BatchSize = purgeBatchSize
+	}
+	vacuum := config.Retention.Vacuum
+	if cmd.Flags().Changed("vacuum") {
+		vacuum = purgeVacuum
+	}
+	if skip, _ := cmd.Flags().GetBool("no-vacuum"); skip {
+		vacuum = false
+	}
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	if policy.IsEmpty() {
+		infoColor.Println("Retention keeps all data - set retention days in the config file or pass --activity-days / --work-block-days.")
+		return nil
+	}
+
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	ctx := context.Background()
+	repo := sqlite.NewRetentionRepository(unifiedDB)
+	var result *sqlite.PurgeResult
+	if purgeDryRun {
+		result, err = repo.Preview(ctx, policy, unifiedDB.Now())
+	} else {
+		result, err = repo.Purge(ctx, policy, unifiedDB.Now())
+	}
+	if err != nil {
+		return err
+	}
+
+	var vacuumResult *sqlite.VacuumResult
+	if vacuum && !purgeDryRun && result.Total() > 0 {
+		if vacuumResult, err = repo.Vacuum(ctx, true); err != nil {
+			return err
+		}
+	}
+
+	if outputFormat == "json" {
+		return printJSON(map[string]interface{}{
+			"purge":  result,
+			"vacuum": vacuumResult,
+		})
+	}
+
+	printPurgeResult(result, policy)
+	if vacuumResult != nil {
+		dimColor.Printf("   VACUUM (%s) freed %s\n", vacuumResult.Mode, formatBytes(vacuumResult.FreedBytes))
+	}
+	return nil
+}
+
+// printPurgeResult prints purge or preview counts per table
+func printPurgeResult(result *sqlite.PurgeResult, policy sqlite.RetentionPolicy) {
+	verb := "Purged"
+	if result.DryRun {
+		verb = "Would purge"
+		headerColor.Println("🧹 Retention purge preview (dry run)")
+	} else {
+		headerColor.Println("🧹 Retention purge")
+	}
+
+	if result.ActivityEventsCutoff != nil {
+		fmt.Printf("   %s %d activity event(s) before %s (%d days)\n",
+			verb, result.ActivityEvents, formatPurgeCutoff(*result.ActivityEventsCutoff), policy.ActivityEventsDays)
+	} else {
+		dimColor.Println("   Activity events are kept forever")
+	}
+	if result.WorkBlocksCutoff != nil {
+		fmt.Printf("   %s %d work block(s) finished before %s (%d days)\n",
+			verb, result.WorkBlocks, formatPurgeCutoff(*result.WorkBlocksCutoff), policy.WorkBlocksDays)
+		fmt.Printf("   %s %d session(s) left without work blocks\n", verb, result.Sessions)
+		dimColor.Printf("   %d daily rollup row(s) keep the purged totals\n", result.RollupRows)
+	} else {
+		dimColor.Println("   Work blocks are kept forever")
+	}
+
+	if result.DryRun {
+		infoColor.Println("   Run without --dry-run to delete.")
+	} else if result.Total() > 0 {
+		successColor.Printf("✅ Removed %d row(s) in %d batch(es)\n", result.Total(), result.Batches)
+	}
+}
+
+// formatPurgeCutoff formats a retention cutoff as a local date and time
+func formatPurgeCutoff(cutoff time.Time) string {
+	return cutoff.Local().Format("2006-01-02 15:04")
+}
+
+// adminBackupResponse is the daemon's reply to a backup request
+type adminBackupResponse struct {
+	Backup  *sqlite.BackupInfo  `json:"backup"`
+	Removed []sqlite.BackupInfo `json:"removed"`
+}
+
+// adminRestoreResponse is the daemon's reply to a restore request
+type adminRestoreResponse struct {
+	Restored         *sqlite.BackupCheck `json:"restored"`
+	PreRestoreBackup *sqlite.BackupInfo  `json:"pre_restore_backup"`
+}
+
+// backupManager returns the configured backup manager for db; listing and
+// resolving names only read the manifest, so db may be nil for those
+func backupManager(config *AppConfig, db *sqlite.SQLiteDB) *sqlite.BackupManager {
+	return sqlite.NewBackupManager(db, expandPath(config.Backup.Path), sqlite.BackupPolicy{
+		KeepLast:   config.Backup.KeepLast,
+		KeepDaily:  config.Backup.KeepDaily,
+		KeepWeekly: config.Backup.KeepWeekly,
+	})
+}
+
+// adminToken returns the token for daemon admin calls: the auth token if one is
+// configured, otherwise the token the daemon wrote next to the database
+func adminToken(config *AppConfig) string {
+	if config.Daemon.AuthToken != "" {
+		return config.Daemon.AuthToken
+	}
+	return daemon.ReadAdminToken(expandPath(config.Daemon.DatabasePath))
+}
+
+// runningDaemonURL returns the daemon base URL when a daemon is running, or ""
+func runningDaemonURL(config *AppConfig) string {
+	url := fmt.Sprintf("http://%s", config.Daemon.ListenAddr)
+	if NewHTTPClient(2 * time.Second).DaemonReachable(url) {
+		return url
+	}
+	return ""
+}
+
+/**
+ * CONTEXT:   Backup command handler
+ * INPUT:     Backup configuration
+ * OUTPUT:    New backup and the backups removed by rotation
+ * BUSINESS:  A running daemon takes the backup so one process owns the manifest
+ * CHANGE:    Send the admin token to the daemon
+ * RISK:      Low - VACUUM INTO copy; rotation only removes backups outside the policy
+ */
+func runDBBackup(cmd *cobra.Command, args []string) error {
+	config, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	var resp adminBackupResponse
+	if url := runningDaemonURL(config); url != "" {
+		if err := NewHTTPClient(adminRequestTimeout).WithAuthToken(adminToken(config)).PostAdmin(url, "backup", nil, &resp); err != nil {
+			return err
+		}
+	} else {
+		if err := initializeDefaultReporting(); err != nil {
+			return err
+		}
+		defer closeReporting()
+		if resp.Backup, resp.Removed, err = backupManager(config, unifiedDB).Create(context.Background(), sqlite.BackupReasonManual); err != nil {
+			return err
+		}
+	}
+
+	if outputFormat == "json" {
+		return printJSON(resp)
+	}
+	successColor.Printf("✅ Backup written to %s (%s)\n", resp.Backup.Path, formatBytes(resp.Backup.SizeBytes))
+	for _, removed := range resp.Removed {
+		dimColor.Printf("   Rotated out %s\n", removed.File)
+	}
+	return nil
+}
+
+/**
+ * CONTEXT:   Backup listing command handler
+ * INPUT:     Backup configuration
+ * OUTPUT:    Backups newest first with size, schema version and reason
+ * BUSINESS:  Users pick a restore point by name
+ * CHANGE:    Initial backup listing
+ * RISK:      Low - Reads the manifest only
+ */
+func runDBBackups(cmd *cobra.Command, args []string) error {
+	config, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	manager := backupManager(config, nil)
+	backups, err := manager.List()
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == "json" {
+		return printJSON(backups)
+	}
+	if len(backups) == 0 {
+		infoColor.Printf("No backups in %s - create one with 'claude-monitor db backup'.\n", manager.Dir())
+		return nil
+	}
+
+	headerColor.Printf("🗄️  Backups in %s\n", manager.Dir())
+	for _, backup := range backups {
+		fmt.Printf("   %-34s %s  %8s  v%-3d %s\n",
+			backup.File,
+			backup.CreatedAt.Local().Format("2006-01-02 15:04"),
+			formatBytes(backup.SizeBytes),
+			backup.SchemaVersion,
+			dimColor.Sprint(backup.Reason))
+	}
+	return nil
+}
+
+/**
+ * CONTEXT:   Restore command handler
+ * INPUT:     Backup file or name, --yes to perform the restore
+ * OUTPUT:    Verification result, and after --yes the restored database
+ * BUSINESS:  A restore is previewed, then taken through the daemon while it runs or
+ *            by swapping the file while it is stopped, after a pre-restore backup
+ * CHANGE:    Resolve the argument against the backup manifest only
+ * RISK:      High - Replaces all tracked data
+ */
+func runDBRestore(cmd *cobra.Command, args []string) error {
+	config, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	path, err := backupManager(config, nil).Resolve(args[0])
+	if err != nil {
+		return err
+	}
+	check, err := sqlite.VerifyBackup(ctx, path)
+	if err != nil {
+		return err
+	}
+	url := runningDaemonURL(config)
+
+	if !restoreYes {
+		if outputFormat == "json" {
+			return printJSON(map[string]interface{}{"check": check, "daemon_running": url != ""})
+		}
+		printBackupCheck(check)
+		if url != "" {
+			fmt.Println("   The running daemon will restore it without a restart.")
+		} else {
+			fmt.Println("   The daemon is stopped; the database file will be replaced.")
+		}
+		infoColor.Println("   Run again with --yes to restore. The current database is backed up first.")
+		return nil
+	}
+
+	var resp adminRestoreResponse
+	if url != "" {
+		if err := NewHTTPClient(adminRequestTimeout).WithAuthToken(adminToken(config)).PostAdmin(url, "restore", map[string]string{"path": check.Path}, &resp); err != nil {
+			return err
+		}
+	} else if resp, err = restoreDatabaseOffline(ctx, config, check.Path); err != nil {
+		return err
+	}
+
+	if outputFormat == "json" {
+		return printJSON(resp)
+	}
+	printBackupCheck(resp.Restored)
+	successColor.Printf("✅ Database restored from %s\n", resp.Restored.Path)
+	dimColor.Printf("   Previous data saved to %s\n", resp.PreRestoreBackup.Path)
+	return nil
+}
+
+// restoreDatabaseOffline backs up the current database, swaps in the backup
+// and reopens the database so older backups are upgraded to the current schema
+func restoreDatabaseOffline(ctx context.Context, config *AppConfig, path string) (adminRestoreResponse, error) {
+	var resp adminRestoreResponse
+	if err := initializeDefaultReporting(); err != nil {
+		return resp, err
+	}
+	dbPath := unifiedDB.DBPath()
+	safety, _, err := backupManager(config, unifiedDB).Create(ctx, sqlite.BackupReasonPreRestore)
+	closeReporting()
+	if err != nil {
+		return resp, fmt.Errorf("pre-restore backup failed: %w", err)
+	}
+	resp.PreRestoreBackup = safety
+
+	if resp.Restored, err = sqlite.RestoreDatabaseFile(ctx, path, dbPath); err != nil {
+		return resp, err
+	}
+	if err := initializeDefaultReporting(); err != nil {
+		return resp, fmt.Errorf("restored database failed to open: %w", err)
+	}
+	closeReporting()
+	return resp, nil
+}
+
+// printBackupCheck prints the verification result of a backup
+func printBackupCheck(check *sqlite.BackupCheck) {
+	headerColor.Printf("🗄️  %s\n", check.Path)
+	fmt.Printf("   Integrity:       %s\n", successColor.Sprint("ok"))
+	if check.ChecksumVerified {
+		fmt.Printf("   Checksum:        %s\n", successColor.Sprint("matches manifest"))
+	} else {
+		fmt.Printf("   Checksum:        %s\n", warningColor.Sprint("not in a manifest"))
+	}
+	fmt.Printf("   Schema version:  %d (current %d)\n", check.SchemaVersion, sqlite.LatestSchemaVersion())
+}
+
+/**
+ * CONTEXT:   Doctor command handler
+ * INPUT:     --fix to apply repairs
+ * OUTPUT:    Report per check; non-zero exit while problems remain
+ * BUSINESS:  Scripts can run the doctor and alert on drift
+ * CHANGE:    Initial doctor handler
+ * RISK:      Medium - --fix modifies sessions, work blocks and projects
+ */
+func runDBDoctor(cmd *cobra.Command, args []string) error {
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	report, err := sqlite.NewDoctor(unifiedDB).Run(context.Background(), doctorFix)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == "json" {
+		if err := printJSON(report); err != nil {
+			return err
+		}
+	} else {
+		printDoctorReport(report)
+	}
+	if problems := report.Problems(); problems > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d problem(s) remain", problems)
+	}
+	return nil
+}
+
+// printDoctorReport prints each check with its issues
+func printDoctorReport(report *sqlite.DoctorReport) {
+	headerColor.Println("🩺 Database doctor")
+	fixable := 0
+	for _, check := range report.Checks {
+		switch {
+		case check.Error != "":
+			errorColor.Printf("   ❌ %-20s %s\n", check.Name, check.Error)
+		case len(check.Issues) == 0:
+			successColor.Printf("   ✅ %-20s ", check.Name)
+			dimColor.Println(check.Description)
+		case check.Severity == sqlite.DoctorSeverityError:
+			errorColor.Printf("   ❌ %-20s %d issue(s): %s\n", check.Name, len(check.Issues), check.Description)
+		default:
+			warningColor.Printf("   ⚠️  %-20s %d issue(s): %s\n", check.Name, len(check.Issues), check.Description)
+		}
+
+		for _, issue := range check.Issues {
+			status := ""
+			switch {
+			case issue.Fixed:
+				status = successColor.Sprint(" [fixed]")
+			case issue.Fixable:
+				fixable++
+			}
+			fmt.Printf("        %s  %s%s\n", issue.Subject, truncateText(issue.Message, 90), status)
+		}
+	}
+
+	if fixable > 0 && !report.Fix {
+		infoColor.Printf("   %d issue(s) can be repaired with --fix.\n", fixable)
+	}
+}
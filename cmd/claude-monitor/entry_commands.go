@@ -0,0 +1,472 @@
+/**
+ * CONTEXT:   Entry command group for manual time and work block corrections
+ * INPUT:     Time ranges, projects and reasons for added, edited or deleted work blocks
+ * OUTPUT:    Manual work blocks, corrected work blocks and the audit history
+ * BUSINESS:  Tracked time must be fixable: blocks left open overnight, work done with
+ *            Claude on another machine, time that should not have been tracked
+ * CHANGE:    Initial entry command group with add, edit, delete and history subcommands
+ * RISK:      Medium - Changes tracked time; every change is audited with a reason
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/spf13/cobra"
+)
+
+var (
+	entryProject  string
+	entryStart    string
+	entryEnd      string
+	entryDuration time.Duration
+	entryReason   string
+	entryNote     string
+	entryTags     []string
+	entryLast     bool
+	entryLimit    int
+)
+
+// entryTimeLayouts are the absolute time formats accepted by --start and --end
+var entryTimeLayouts = []string{
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+}
+
+/**
+ * CONTEXT:   Entry command group for manual time
+ * INPUT:     Entry subcommand selection
+ * OUTPUT:    Routed entry subcommand execution
+ * BUSINESS:  Corrections live next to tracking so reports stay trustworthy
+ * CHANGE:    Initial entry command group
+ * RISK:      Low - Command routing only
+ */
+var entryCmd = &cobra.Command{
+	Use:   "entry",
+	Short: "Add manual time and correct tracked work blocks",
+	Long: `Add work blocks by hand and correct tracked ones.
+
+Manual entries cover work the hook could not see, such as a session
+with Claude on another machine. Edits fix start or end times and the
+project of any work block, for example a block left open overnight.
+
+Every add, edit and delete needs --reason and is recorded in an audit
+trail with the values before and after the change; 'entry history'
+shows it. Reports mark manual blocks with [M] and edited ones with [E].
+
+Times are HH:MM (today), "yesterday HH:MM", "YYYY-MM-DD HH:MM" or
+RFC 3339. Entries cannot lie in the future or overlap other work blocks.`,
+	Example: `  claude-monitor entry add --project api --start 09:00 --end 11:30 --reason "worked on laptop"
+  claude-monitor entry add --project ~/work/api --start "yesterday 14:00" --duration 2h --reason "pairing session"
+  claude-monitor entry edit --last --end "yesterday 18:30" --reason "left open overnight"
+  claude-monitor entry edit block_3f9a1c2b7d4e5f60 --project web --reason "wrong project"
+  claude-monitor entry delete block_3f9a1c2b7d4e5f60 --reason "test run"
+  claude-monitor entry history`,
+}
+
+/**
+ * CONTEXT:   Entry command initialization with subcommands and flags
+ * INPUT:     Cobra command definitions and flag configurations
+ * OUTPUT:    Complete entry command structure
+ * BUSINESS:  --reason is required on every change so the audit trail is meaningful
+ * CHANGE:    Initial command setup
+ * RISK:      Low - Command structure initialization with no side effects
+ */
+func init() {
+	entryAddCmd := &cobra.Command{
+		Use:   "add --project <project> --start <time> (--end <time> | --duration <d>) --reason <text>",
+		Short: "Add a manual work block",
+		Args:  cobra.NoArgs,
+		RunE:  runEntryAdd,
+	}
+	entryAddCmd.Flags().StringVar(&entryProject, "project", "", "project ID, name or path; unknown paths create the project")
+	entryAddCmd.Flags().StringVar(&entryStart, "start", "", "start time")
+	entryAddCmd.Flags().StringVar(&entryEnd, "end", "", "end time")
+	entryAddCmd.Flags().DurationVar(&entryDuration, "duration", 0, "length of the entry instead of --end (e.g. 1h30m)")
+	entryAddCmd.Flags().StringVar(&entryNote, "note", "", "what the time was spent on")
+	entryAddCmd.Flags().StringArrayVar(&entryTags, "tag", nil, "tag the entry (repeatable)")
+	entryAddCmd.MarkFlagRequired("project")
+	entryAddCmd.MarkFlagRequired("start")
+
+	entryEditCmd := &cobra.Command{
+		Use:   "edit (<block-id> | --last) [--start <time>] [--end <time>] [--project <project>] --reason <text>",
+		Short: "Correct the start, end or project of a work block",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  runEntryEdit,
+	}
+	entryEditCmd.Flags().StringVar(&entryProject, "project", "", "move the block to this project (ID, name or path)")
+	entryEditCmd.Flags().StringVar(&entryStart, "start", "", "new start time")
+	entryEditCmd.Flags().StringVar(&entryEnd, "end", "", "new end time; finishes an open block")
+
+	entryDeleteCmd := &cobra.Command{
+		Use:     "delete (<block-id> | --last) --reason <text>",
+		Aliases: []string{"rm"},
+		Short:   "Delete a work block",
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    runEntryDelete,
+	}
+
+	for _, cmd := range []*cobra.Command{entryAddCmd, entryEditCmd, entryDeleteCmd} {
+		cmd.Flags().StringVar(&entryReason, "reason", "", "why the change is made (recorded in the audit trail)")
+		cmd.MarkFlagRequired("reason")
+	}
+	for _, cmd := range []*cobra.Command{entryEditCmd, entryDeleteCmd} {
+		cmd.Flags().BoolVar(&entryLast, "last", false, "most recent work block")
+	}
+
+	entryHistoryCmd := &cobra.Command{
+		Use:   "history [<block-id>]",
+		Short: "Show the audit trail of manual entries and corrections",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  runEntryHistory,
+	}
+	entryHistoryCmd.Flags().IntVar(&entryLimit, "limit", 20, "maximum number of changes to show (0 for all)")
+
+	entryCmd.AddCommand(entryAddCmd)
+	entryCmd.AddCommand(entryEditCmd)
+	entryCmd.AddCommand(entryDeleteCmd)
+	entryCmd.AddCommand(entryHistoryCmd)
+}
+
+/**
+ * CONTEXT:   Entry add command handler
+ * INPUT:     Project, start, end or duration, reason and optional note and tags
+ * OUTPUT:    Created manual work block
+ * BUSINESS:  Unknown project paths are created so work from other machines can be logged
+ * CHANGE:    Initial entry add handler
+ * RISK:      Medium - Adds tracked time
+ */
+func runEntryAdd(cmd *cobra.Command, args []string) error {
+	now := time.Now()
+	start, err := parseEntryTime(entryStart, now)
+	if err != nil {
+		return err
+	}
+	var end time.Time
+	switch {
+	case entryEnd != "" && entryDuration != 0:
+		return fmt.Errorf("use either --end or --duration, not both")
+	case entryEnd != "":
+		if end, err = parseEntryTime(entryEnd, now); err != nil {
+			return err
+		}
+	case entryDuration > 0:
+		end = start.Add(entryDuration)
+	default:
+		return fmt.Errorf("specify --end or a positive --duration")
+	}
+	tags, err := sqlite.ParseTags(entryTags...)
+	if err != nil {
+		return err
+	}
+
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	ctx := context.Background()
+	project, err := resolveEntryProject(ctx, entryProject)
+	if err != nil {
+		return err
+	}
+
+	block, err := sqlite.NewEntryRepository(unifiedDB).Add(ctx, sqlite.ManualEntry{
+		UserID:      getCurrentUserID(),
+		ProjectID:   project.ID,
+		StartTime:   start,
+		EndTime:     end,
+		Description: entryNote,
+		Reason:      entryReason,
+	})
+	if err != nil {
+		return err
+	}
+	if len(tags) > 0 {
+		if _, err := sqlite.NewTagRepository(unifiedDB.DB()).TagWorkBlock(ctx, block.ID, tags, sqlite.TagSourceManual); err != nil {
+			return err
+		}
+		block.Tags = tags
+	}
+
+	if outputFormat == "json" {
+		return printJSON(block)
+	}
+	successColor.Printf("✅ Added manual work block %s\n", block.ID)
+	printEntryBlock(block, project.Name)
+	return nil
+}
+
+/**
+ * CONTEXT:   Entry edit command handler
+ * INPUT:     Work block ID or --last, new start, end or project, and a reason
+ * OUTPUT:    Updated work block
+ * BUSINESS:  Fixes blocks left open overnight and time booked to the wrong project
+ * CHANGE:    Initial entry edit handler
+ * RISK:      Medium - Rewrites tracked time; the previous values stay in the audit trail
+ */
+func runEntryEdit(cmd *cobra.Command, args []string) error {
+	now := time.Now()
+	var change sqlite.WorkBlockChange
+	if entryStart != "" {
+		start, err := parseEntryTime(entryStart, now)
+		if err != nil {
+			return err
+		}
+		change.StartTime = &start
+	}
+	if entryEnd != "" {
+		end, err := parseEntryTime(entryEnd, now)
+		if err != nil {
+			return err
+		}
+		change.EndTime = &end
+	}
+
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	ctx := context.Background()
+	blockID, err := resolveEntryBlock(ctx, args)
+	if err != nil {
+		return err
+	}
+	if entryProject != "" {
+		project, err := resolveEntryProject(ctx, entryProject)
+		if err != nil {
+			return err
+		}
+		change.ProjectID = project.ID
+	}
+
+	block, err := sqlite.NewEntryRepository(unifiedDB).Edit(ctx, blockID, change, getCurrentUserID(), entryReason)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == "json" {
+		return printJSON(block)
+	}
+	successColor.Printf("✅ Updated work block %s\n", block.ID)
+	printEntryBlock(block, entryProjectName(ctx, block.ProjectID))
+	return nil
+}
+
+/**
+ * CONTEXT:   Entry delete command handler
+ * INPUT:     Work block ID or --last and a reason
+ * OUTPUT:    Confirmation with the removed time range
+ * BUSINESS:  Removes time that should not count, e.g. test runs
+ * CHANGE:    Initial entry delete handler
+ * RISK:      Medium - Deletes tracked time; the audit trail keeps the removed values
+ */
+func runEntryDelete(cmd *cobra.Command, args []string) error {
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	ctx := context.Background()
+	blockID, err := resolveEntryBlock(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	block, err := sqlite.NewEntryRepository(unifiedDB).Delete(ctx, blockID, getCurrentUserID(), entryReason)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == "json" {
+		return printJSON(map[string]interface{}{"deleted": block})
+	}
+	successColor.Printf("✅ Deleted work block %s\n", block.ID)
+	printEntryBlock(block, entryProjectName(ctx, block.ProjectID))
+	return nil
+}
+
+/**
+ * CONTEXT:   Entry history command handler
+ * INPUT:     Optional work block ID and --limit
+ * OUTPUT:    Audit records with action, changed values and reason
+ * BUSINESS:  Corrections are visible so reports can be trusted and explained
+ * CHANGE:    Initial audit history listing
+ * RISK:      Low - Read-only query
+ */
+func runEntryHistory(cmd *cobra.Command, args []string) error {
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	blockID := ""
+	if len(args) == 1 {
+		blockID = args[0]
+	}
+	records, err := sqlite.NewEntryRepository(unifiedDB).History(context.Background(), blockID, entryLimit)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == "json" {
+		return printJSON(records)
+	}
+	if len(records) == 0 {
+		infoColor.Println("No manual entries or corrections recorded.")
+		return nil
+	}
+
+	headerColor.Printf("%-16s %-7s %-23s %s\n", "WHEN", "ACTION", "WORK BLOCK", "CHANGE")
+	for _, record := range records {
+		fmt.Printf("%-16s %-7s %-23s %s\n",
+			record.CreatedAt.Local().Format("2006-01-02 15:04"), record.Action,
+			truncateText(record.WorkBlockID, 23), describeAuditChange(record))
+		dimColor.Printf("%-16s %-7s %-23s reason: %s\n", "", "", "", record.Reason)
+	}
+	return nil
+}
+
+// resolveEntryBlock returns the work block ID argument or the latest block with --last
+func resolveEntryBlock(ctx context.Context, args []string) (string, error) {
+	if (len(args) == 1) == entryLast {
+		return "", fmt.Errorf("specify either a work block ID or --last")
+	}
+	if len(args) == 1 {
+		return args[0], nil
+	}
+
+	block, err := sqlite.NewWorkBlockRepository(unifiedDB.DB()).GetLatestByUser(ctx, getCurrentUserID())
+	if err != nil {
+		return "", err
+	}
+	if block == nil {
+		return "", fmt.Errorf("no work blocks recorded yet")
+	}
+	return block.ID, nil
+}
+
+// resolveEntryProject finds a project by ID, name or path, creating it for unknown paths
+func resolveEntryProject(ctx context.Context, ref string) (*sqlite.Project, error) {
+	projectRepo := sqlite.NewProjectRepository(unifiedDB.DB())
+	project, err := resolveProjectReference(ctx, projectRepo, ref)
+	if err == nil {
+		return project, nil
+	}
+	if !strings.ContainsRune(ref, '/') {
+		return nil, err
+	}
+	return projectRepo.GetOrCreate(ctx, expandPath(ref))
+}
+
+// entryProjectName returns the project name for messages, or the ID if it cannot be read
+func entryProjectName(ctx context.Context, projectID string) string {
+	project, err := sqlite.NewProjectRepository(unifiedDB.DB()).GetByID(ctx, projectID)
+	if err != nil || project == nil {
+		return projectID
+	}
+	return project.Name
+}
+
+/**
+ * CONTEXT:   Parse an entry start or end time
+ * INPUT:     HH:MM, "yesterday HH:MM", "YYYY-MM-DD HH:MM" or RFC 3339 text
+ * OUTPUT:    Time in the local timezone
+ * BUSINESS:  Short clock times cover the common "fix today's block" case
+ * CHANGE:    Initial entry time parsing
+ * RISK:      Low - Pure parsing
+ */
+func parseEntryTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	day := now
+	if rest, ok := strings.CutPrefix(value, "yesterday "); ok {
+		day = now.AddDate(0, 0, -1)
+		value = strings.TrimSpace(rest)
+	} else if rest, ok := strings.CutPrefix(value, "today "); ok {
+		value = strings.TrimSpace(rest)
+	}
+
+	if clock, err := time.ParseInLocation("15:04", value, now.Location()); err == nil {
+		return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location()), nil
+	}
+	if day.Equal(now) {
+		for _, layout := range entryTimeLayouts {
+			if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+				return t, nil
+			}
+		}
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t.In(now.Location()), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q - use HH:MM, \"yesterday HH:MM\", \"YYYY-MM-DD HH:MM\" or RFC 3339", value)
+}
+
+// printEntryBlock prints the time range, duration and project of a work block
+func printEntryBlock(block *sqlite.WorkBlock, projectName string) {
+	end := "open"
+	duration := time.Since(block.StartTime)
+	if block.EndTime != nil {
+		end = block.EndTime.Local().Format("15:04")
+		duration = block.EndTime.Sub(block.StartTime)
+	}
+	fmt.Printf("   %s-%s  %s  %s\n",
+		block.StartTime.Local().Format("2006-01-02 15:04"), end, formatDuration(duration), projectName)
+	if len(block.Tags) > 0 {
+		dimColor.Printf("   #%s\n", strings.Join(block.Tags, " #"))
+	}
+}
+
+// describeAuditChange summarizes the difference between an audit record's snapshots
+func describeAuditChange(record *sqlite.WorkBlockAudit) string {
+	switch {
+	case record.Before == nil && record.After != nil:
+		return "+ " + describeSnapshotRange(record.After)
+	case record.After == nil && record.Before != nil:
+		return "- " + describeSnapshotRange(record.Before)
+	case record.Before == nil:
+		return ""
+	}
+
+	before, after := record.Before, record.After
+	var changes []string
+	if !before.StartTime.Equal(after.StartTime) {
+		changes = append(changes, fmt.Sprintf("start %s → %s",
+			formatSnapshotTime(&before.StartTime), formatSnapshotTime(&after.StartTime)))
+	}
+	if formatSnapshotTime(before.EndTime) != formatSnapshotTime(after.EndTime) {
+		changes = append(changes, fmt.Sprintf("end %s → %s",
+			formatSnapshotTime(before.EndTime), formatSnapshotTime(after.EndTime)))
+	}
+	if before.ProjectID != after.ProjectID {
+		changes = append(changes, fmt.Sprintf("project %s → %s", before.ProjectID, after.ProjectID))
+	}
+	if len(changes) == 0 {
+		return "no change"
+	}
+	return strings.Join(changes, ", ")
+}
+
+// describeSnapshotRange formats a snapshot as a time range with duration
+func describeSnapshotRange(snapshot *sqlite.WorkBlockSnapshot) string {
+	text := fmt.Sprintf("%s-%s", formatSnapshotTime(&snapshot.StartTime), formatSnapshotTime(snapshot.EndTime))
+	if snapshot.EndTime != nil {
+		text += " (" + formatDuration(snapshot.EndTime.Sub(snapshot.StartTime)) + ")"
+	}
+	return text
+}
+
+// formatSnapshotTime formats an audited time, "open" for a missing end
+func formatSnapshotTime(t *time.Time) string {
+	if t == nil {
+		return "open"
+	}
+	return t.Local().Format("Jan 2 15:04")
+}
@@ -0,0 +1,198 @@
+/**
+ * CONTEXT:   Export command group for moving tracked work into other tools
+ * INPUT:     Export format or subcommand, date range and output destination
+ * OUTPUT:    Exported data written to a file or stdout
+ * BUSINESS:  Exports let teams reuse tracked work outside Claude Monitor and
+ *            move full histories between machines
+ * CHANGE:    Added full-history export in JSON Lines and SQLite formats
+ * RISK:      Low - Read-only database access
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/reporting"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFrom     string
+	exportTo       string
+	exportOutput   string
+	exportSessions bool
+	exportFormat   string
+)
+
+/**
+ * CONTEXT:   Export command group and full-history export
+ * INPUT:     Export subcommand selection, or --format for a full export
+ * OUTPUT:    Routed export subcommand execution or a portable history file
+ * BUSINESS:  One entry point for every export format; full exports feed
+ *            'claude-monitor import' on another machine
+ * CHANGE:    Added --format jsonl|sqlite-dump full-history export
+ * RISK:      Low - Command routing and read-only database access
+ */
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export tracked work to other formats",
+	Long: `Export tracked work blocks and sessions for use in other tools.
+
+Run with --format to export the complete history for another machine:
+
+  jsonl        One JSON object per line: a header, then every row of every
+               table. Streams to stdout unless -o is given.
+  sqlite-dump  A standalone SQLite database file. Requires -o.
+
+Import the result elsewhere with 'claude-monitor import FILE'.`,
+	Example: `  claude-monitor export --format jsonl -o laptop.jsonl
+  claude-monitor export --format jsonl | gzip > laptop.jsonl.gz
+  claude-monitor export --format sqlite-dump -o laptop.db`,
+	RunE: runExportHistory,
+}
+
+/**
+ * CONTEXT:   Export command initialization with subcommands and flags
+ * INPUT:     Cobra command definitions and flag configurations
+ * OUTPUT:    Complete export command structure
+ * BUSINESS:  Command initialization enables calendar and history export from the CLI
+ * CHANGE:    Added --format and --output flags on the export group
+ * RISK:      Low - Command structure initialization with no side effects
+ */
+func init() {
+	exportICalCmd := &cobra.Command{
+		Use:   "ical",
+		Short: "Export work blocks as an iCalendar (.ics) file",
+		Long: `Export work blocks, and optionally 5-hour sessions, as iCalendar events.
+
+Each event carries the project name, duration and activity count. Dates
+are inclusive; without --from the export covers the last 30 days.
+
+The daemon serves the same feed for calendar subscriptions at
+/api/v1/calendar.ics?token=TOKEN once daemon.calendar_token is set in
+~/.claude/config.json or CLAUDE_MONITOR_CALENDAR_TOKEN is exported. The
+feed only shows the daemon user's work, or daemon.calendar_user's if set.`,
+		Example: `  claude-monitor export ical > claude-work.ics
+  claude-monitor export ical --from 2026-10-01 --to 2026-10-31 --sessions -o october.ics`,
+		RunE: runExportICal,
+	}
+
+	exportICalCmd.Flags().StringVar(&exportFrom, "from", "", "first day to export (YYYY-MM-DD, default 30 days ago)")
+	exportICalCmd.Flags().StringVar(&exportTo, "to", "", "last day to export (YYYY-MM-DD, default today)")
+	exportICalCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "write to file instead of stdout")
+	exportICalCmd.Flags().BoolVar(&exportSessions, "sessions", false, "include 5-hour sessions as events")
+
+	exportCmd.Flags().StringVar(&exportFormat, "format", "", "full-history format: jsonl or sqlite-dump")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "write to file instead of stdout")
+
+	exportCmd.AddCommand(exportICalCmd)
+}
+
+/**
+ * CONTEXT:   Full-history export command handler
+ * INPUT:     Format and output path flags
+ * OUTPUT:    JSON Lines stream or SQLite file, with row counts on stderr
+ * BUSINESS:  Moves every user, project, session, work block and event to
+ *            another machine
+ * CHANGE:    Initial history export handler
+ * RISK:      Low - Read-only; sqlite-dump refuses to overwrite an existing file
+ */
+func runExportHistory(cmd *cobra.Command, args []string) error {
+	switch exportFormat {
+	case "":
+		return cmd.Help()
+	case sqlite.TransferFormatJSONL, sqlite.TransferFormatSQLiteDump:
+	default:
+		return fmt.Errorf("unknown export format %q (use jsonl or sqlite-dump)", exportFormat)
+	}
+	if exportFormat == sqlite.TransferFormatSQLiteDump && exportOutput == "" {
+		return fmt.Errorf("--format sqlite-dump requires --output")
+	}
+
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	ctx := context.Background()
+	var stats *sqlite.TransferStats
+	var err error
+	if exportFormat == sqlite.TransferFormatSQLiteDump {
+		stats, err = unifiedDB.ExportSQLite(ctx, expandPath(exportOutput))
+	} else {
+		var out io.Writer = os.Stdout
+		if exportOutput != "" {
+			file, createErr := os.Create(expandPath(exportOutput))
+			if createErr != nil {
+				return fmt.Errorf("failed to create output file: %w", createErr)
+			}
+			defer file.Close()
+			out = file
+		}
+		stats, err = unifiedDB.ExportJSONL(ctx, out)
+	}
+	if err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	if exportOutput != "" {
+		var rows int64
+		for _, table := range stats.Tables {
+			rows += table.Rows
+		}
+		successColor.Fprintf(os.Stderr, "✅ Exported %d rows from %d tables to %s\n", rows, len(stats.Tables), exportOutput)
+	}
+	return nil
+}
+
+/**
+ * CONTEXT:   iCalendar export command handler
+ * INPUT:     Date range, sessions flag and output path
+ * OUTPUT:    .ics document on stdout or in the output file
+ * BUSINESS:  Calendar import shows Claude focus time next to meetings
+ * CHANGE:    Initial ical export handler
+ * RISK:      Low - Read-only export; output file is overwritten
+ */
+func runExportICal(cmd *cobra.Command, args []string) error {
+	period, err := reporting.CalendarPeriod(exportFrom, exportTo, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	exporter := reporting.NewCalendarExporter(
+		sqlite.NewSessionRepository(unifiedDB),
+		sqlite.NewWorkBlockRepository(unifiedDB.DB()),
+		sqlite.NewProjectRepository(unifiedDB.DB()),
+	)
+
+	var out io.Writer = os.Stdout
+	if exportOutput != "" {
+		file, err := os.Create(expandPath(exportOutput))
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	options := reporting.CalendarOptions{IncludeSessions: exportSessions}
+	if err := exporter.Export(context.Background(), out, getCurrentUserID(), period, options); err != nil {
+		return fmt.Errorf("failed to export calendar: %w", err)
+	}
+
+	if exportOutput != "" {
+		successColor.Fprintf(os.Stderr, "✅ Calendar written to %s\n", exportOutput)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+/**
+ * CONTEXT:   Goal command group for targets and budgets on tracked hours
+ * INPUT:     Goal definitions (period, kind, hours, optional project) and goal IDs
+ * OUTPUT:    Persisted goals and progress listing with current period status
+ * BUSINESS:  Goals turn tracked time into commitments like "30 focused hours per week"
+ * CHANGE:    Initial goal command group with add/list/rm subcommands
+ * RISK:      Low - Writes only to the goals table
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/reporting"
+	"github.com/spf13/cobra"
+)
+
+var (
+	goalName    string
+	goalPeriod  string
+	goalKind    string
+	goalHours   float64
+	goalMetric  string
+	goalProject string
+)
+
+/**
+ * CONTEXT:   Goal command group for goal management
+ * INPUT:     Goal subcommand selection
+ * OUTPUT:    Routed goal subcommand execution
+ * BUSINESS:  Goals and budgets make weekly planning measurable
+ * CHANGE:    Initial goal command group
+ * RISK:      Low - Command routing only
+ */
+var goalCmd = &cobra.Command{
+	Use:   "goal",
+	Short: "Manage work goals and budgets",
+	Long: `Manage daily, weekly and monthly goals on tracked hours.
+
+A target goal is met once the tracked hours reach the given amount.
+A cap goal is a budget: it is met when the period closes without
+exceeding the given amount. Goals apply overall or to one project.`,
+	Example: `  claude-monitor goal add --name "Focused week" --period weekly --hours 30 --metric deep_work_hours
+  claude-monitor goal add --name "Side project budget" --period weekly --kind cap --hours 10 --project ~/code/side
+  claude-monitor goal list
+  claude-monitor goal rm goal_3f9a1c2b7d4e5f60`,
+}
+
+/**
+ * CONTEXT:   Goal command initialization with subcommands and flags
+ * INPUT:     Cobra command definitions and flag configurations
+ * OUTPUT:    Complete goal command structure
+ * BUSINESS:  Command initialization enables goal management from the CLI
+ * CHANGE:    Initial command setup with add/list/rm
+ * RISK:      Low - Command structure initialization with no side effects
+ */
+func init() {
+	goalAddCmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add a goal or budget",
+		RunE:  runGoalAdd,
+	}
+	goalAddCmd.Flags().StringVar(&goalName, "name", "", "goal name")
+	goalAddCmd.Flags().StringVar(&goalPeriod, "period", sqlite.GoalPeriodWeekly, "goal period (daily, weekly, monthly)")
+	goalAddCmd.Flags().StringVar(&goalKind, "kind", sqlite.GoalKindTarget, "goal kind (target, cap)")
+	goalAddCmd.Flags().Float64Var(&goalHours, "hours", 0, "target or cap in hours")
+	goalAddCmd.Flags().StringVar(&goalMetric, "metric", sqlite.GoalMetricWorkHours, "measured hours (work_hours, deep_work_hours)")
+	goalAddCmd.Flags().StringVar(&goalProject, "project", "", "limit goal to a project (ID, path or name)")
+	goalAddCmd.MarkFlagRequired("name")
+	goalAddCmd.MarkFlagRequired("hours")
+
+	goalListCmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List goals with current progress",
+		RunE:    runGoalList,
+	}
+
+	goalRemoveCmd := &cobra.Command{
+		Use:     "rm <goal-id>",
+		Aliases: []string{"remove"},
+		Short:   "Remove a goal",
+		Args:    cobra.ExactArgs(1),
+		RunE:    runGoalRemove,
+	}
+
+	goalCmd.AddCommand(goalAddCmd)
+	goalCmd.AddCommand(goalListCmd)
+	goalCmd.AddCommand(goalRemoveCmd)
+}
+
+/**
+ * CONTEXT:   Goal add command handler
+ * INPUT:     Goal flags from the command line
+ * OUTPUT:    Persisted goal with confirmation message
+ * BUSINESS:  Adding goals is the entry point for progress tracking
+ * CHANGE:    Initial goal creation handler
+ * RISK:      Low - Validated insert into goals table
+ */
+func runGoalAdd(cmd *cobra.Command, args []string) error {
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	ctx := context.Background()
+	goal := &sqlite.Goal{
+		UserID:      getCurrentUserID(),
+		Name:        goalName,
+		Period:      strings.ToLower(goalPeriod),
+		Kind:        strings.ToLower(goalKind),
+		Metric:      strings.ToLower(goalMetric),
+		TargetHours: goalHours,
+	}
+
+	if goalProject != "" {
+		project, err := resolveProjectReference(ctx, sqlite.NewProjectRepository(unifiedDB.DB()), goalProject)
+		if err != nil {
+			return err
+		}
+		goal.ProjectID = project.ID
+	}
+
+	if err := sqlite.NewGoalRepository(unifiedDB.DB()).Create(ctx, goal); err != nil {
+		return err
+	}
+
+	if outputFormat == "json" {
+		return printJSON(goal)
+	}
+
+	successColor.Printf("✅ Goal added: %s\n", goal.ID)
+	fmt.Printf("   %s %s of %.1fh %s\n", goal.Period, goal.Kind, goal.TargetHours, strings.ReplaceAll(goal.Metric, "_", " "))
+	return nil
+}
+
+/**
+ * CONTEXT:   Goal list command handler
+ * INPUT:     Current user and global output format
+ * OUTPUT:    Goals with progress in the current period
+ * BUSINESS:  Listing with progress answers "am I on track" without a full report
+ * CHANGE:    Initial goal listing handler
+ * RISK:      Low - Read-only evaluation
+ */
+func runGoalList(cmd *cobra.Command, args []string) error {
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	statuses, err := unifiedReportingSvc.GetGoalStatus(context.Background(), getCurrentUserID(), time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to evaluate goals: %w", err)
+	}
+
+	if outputFormat == "json" {
+		return printJSON(statuses)
+	}
+
+	if len(statuses) == 0 {
+		infoColor.Println("No goals defined. Add one with 'claude-monitor goal add'.")
+		return nil
+	}
+
+	reporting.DisplayProfessionalGoalProgress(statuses)
+	for _, status := range statuses {
+		scope := "overall"
+		if status.ProjectName != "" {
+			scope = status.ProjectName
+		}
+		dimColor.Printf("  %s  %s %s, %s\n", status.GoalID, status.Period, status.Kind, scope)
+	}
+	return nil
+}
+
+/**
+ * CONTEXT:   Goal remove command handler
+ * INPUT:     Goal ID argument
+ * OUTPUT:    Deleted goal with confirmation message
+ * BUSINESS:  Users can retire goals that no longer apply
+ * CHANGE:    Initial goal removal handler
+ * RISK:      Low - Single-row delete scoped to current user
+ */
+func runGoalRemove(cmd *cobra.Command, args []string) error {
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	if err := sqlite.NewGoalRepository(unifiedDB.DB()).Delete(context.Background(), getCurrentUserID(), args[0]); err != nil {
+		return err
+	}
+
+	successColor.Printf("✅ Goal removed: %s\n", args[0])
+	return nil
+}
+
+/**
+ * CONTEXT:   Resolve a project reference given on the command line
+ * INPUT:     Project repository and reference (project ID, path or name)
+ * OUTPUT:    Matching project or descriptive error
+ * BUSINESS:  Users refer to projects by whatever is handiest
+ * CHANGE:    Initial project reference resolution
+ * RISK:      Low - Read-only lookups; ambiguous names are rejected
+ */
+func resolveProjectReference(ctx context.Context, projectRepo *sqlite.ProjectRepository, ref string) (*sqlite.Project, error) {
+	if project, err := projectRepo.GetByID(ctx, ref); err == nil && project != nil {
+		return project, nil
+	}
+
+	if project, err := projectRepo.GetByPath(ctx, expandPath(ref)); err == nil && project != nil {
+		return project, nil
+	}
+
+	projects, err := projectRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	var matches []*sqlite.Project
+	for _, project := range projects {
+		if strings.EqualFold(project.Name, ref) {
+			matches = append(matches, project)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("project %q not found", ref)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("project name %q is ambiguous (%d matches) - use the project ID or path", ref, len(matches))
+	}
+}
@@ -0,0 +1,230 @@
+/**
+ * CONTEXT:   Hook command invoked by Claude Code hooks on every Claude action
+ * INPUT:     Claude Code hook JSON on stdin (session, cwd, event and tool name)
+ * OUTPUT:    Activity event posted to the local daemon
+ * BUSINESS:  Hooks are the activity source for sessions, work blocks and tool breakdowns
+ * CHANGE:    Forward tags from CLAUDE_MONITOR_TAGS
+ * RISK:      Low - Never fails the hook; errors are only reported with --verbose
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	cfg "github.com/claude-monitor/system/internal/config"
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/tracking"
+	"github.com/spf13/cobra"
+)
+
+// maxHookCommandLen bounds the command text stored per activity
+const maxHookCommandLen = 200
+
+// hookTagsEnv names the environment variable with comma-separated tags for every event
+const hookTagsEnv = "CLAUDE_MONITOR_TAGS"
+
+var (
+	hookType    string
+	hookTimeout time.Duration
+)
+
+// claudeHookInput holds the fields of the Claude Code hook payload we use
+type claudeHookInput struct {
+	SessionID     string                 `json:"session_id"`
+	Cwd           string                 `json:"cwd"`
+	HookEventName string                 `json:"hook_event_name"`
+	ToolName      string                 `json:"tool_name"`
+	ToolInput     map[string]interface{} `json:"tool_input"`
+	Prompt        string                 `json:"prompt"`
+}
+
+/**
+ * CONTEXT:   Hook command for Claude Code integration
+ * INPUT:     Hook payload on stdin and optional event type flag
+ * OUTPUT:    Silent activity submission to the daemon
+ * BUSINESS:  One hook entry in Claude Code settings enables all tracking
+ * CHANGE:    Initial hook command
+ * RISK:      Low - Exits successfully even when the daemon is down
+ */
+var hookCmd = &cobra.Command{
+	Use:   "hook",
+	Short: "Record Claude Code activity (called from Claude Code hooks)",
+	Long: `Record one Claude Code activity with the local daemon.
+
+Claude Code passes the hook payload as JSON on stdin. The tool name
+(Edit, Bash, Read, ...) is stored with the activity and drives the
+tool and activity-type breakdown in reports. With
+projects.track_git_branches enabled in ~/.claude/config.json the
+current branch and HEAD commit are read from .git and recorded too.
+Tags listed in CLAUDE_MONITOR_TAGS (comma-separated) are attached to
+the work block, so a shell can be pre-labelled before starting Claude.
+The command never fails the hook: if the daemon is unreachable the
+event is dropped.
+
+Add it to ~/.claude/settings.json:
+
+  "hooks": {
+    "PostToolUse": [{"matcher": "*", "hooks": [{"type": "command", "command": "claude-monitor hook"}]}],
+    "UserPromptSubmit": [{"hooks": [{"type": "command", "command": "claude-monitor hook"}]}]
+  }`,
+	Example: `  echo '{"cwd":"'$PWD'","hook_event_name":"PostToolUse","tool_name":"Edit"}' | claude-monitor hook
+  claude-monitor hook --type=pre-request
+  export CLAUDE_MONITOR_TAGS="incident-4312,on call"`,
+	RunE: runHookCommand,
+}
+
+/**
+ * CONTEXT:   Hook command initialization with flags
+ * INPUT:     Cobra flag definitions
+ * OUTPUT:    Configured hook command
+ * BUSINESS:  Flags keep older hook scripts using --type working
+ * CHANGE:    Initial hook flags
+ * RISK:      Low - Flag setup with no side effects
+ */
+func init() {
+	hookCmd.Flags().StringVar(&hookType, "type", "", "hook event name when the payload has none (e.g. pre-request)")
+	hookCmd.Flags().DurationVar(&hookTimeout, "timeout", time.Second, "maximum time to wait for the daemon")
+}
+
+/**
+ * CONTEXT:   Hook command handler
+ * INPUT:     Hook payload on stdin, flags and daemon configuration
+ * OUTPUT:    Activity posted to the daemon; always exits successfully
+ * BUSINESS:  Tracking must never block or break the user's Claude session
+ * CHANGE:    Authenticate with daemon.auth_token
+ * RISK:      Low - Errors are swallowed unless --verbose is set
+ */
+func runHookCommand(cmd *cobra.Command, args []string) error {
+	input, err := readHookInput(os.Stdin)
+	if err != nil {
+		reportHookError(err)
+		return nil
+	}
+
+	event := buildHookActivity(input)
+	event.Tags = hookEnvTags()
+	if event.ProjectPath == "" {
+		if cwd, err := os.Getwd(); err == nil {
+			event.ProjectPath = cwd
+		}
+	}
+
+	listenAddr := cfg.DefaultListenAddr
+	client := NewHTTPClient(hookTimeout)
+	if config, err := loadConfiguration(); err == nil {
+		if config.Daemon.ListenAddr != "" {
+			listenAddr = config.Daemon.ListenAddr
+		}
+		client.WithAuthToken(config.Daemon.AuthToken)
+		if config.Projects.TrackGitBranches {
+			addGitInfo(&event)
+		}
+	}
+
+	if err := client.PostActivity(fmt.Sprintf("http://%s", listenAddr), event); err != nil {
+		reportHookError(err)
+	}
+	return nil
+}
+
+// readHookInput decodes the hook payload when stdin is piped
+func readHookInput(stdin *os.File) (*claudeHookInput, error) {
+	input := &claudeHookInput{}
+
+	info, err := stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice != 0 {
+		return input, nil
+	}
+
+	if err := json.NewDecoder(stdin).Decode(input); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid hook payload: %w", err)
+	}
+	return input, nil
+}
+
+/**
+ * CONTEXT:   Convert a Claude Code hook payload into a tracker activity event
+ * INPUT:     Decoded hook payload and --type flag
+ * OUTPUT:    Activity event with tool name, command and target description
+ * BUSINESS:  Only short identifying details are kept, never prompt or file content
+ * CHANGE:    Initial payload mapping
+ * RISK:      Low - Pure mapping with length limits
+ */
+func buildHookActivity(input *claudeHookInput) tracking.ActivityEvent {
+	event := tracking.ActivityEvent{
+		UserID:          getCurrentUserID(),
+		ProjectPath:     input.Cwd,
+		HookEvent:       input.HookEventName,
+		ToolName:        input.ToolName,
+		ClaudeSessionID: input.SessionID,
+	}
+	if event.HookEvent == "" {
+		event.HookEvent = hookType
+	}
+
+	if command, ok := input.ToolInput["command"].(string); ok {
+		event.Command = truncateText(command, maxHookCommandLen)
+	}
+	for _, key := range []string{"file_path", "notebook_path", "path", "pattern", "url", "query", "description"} {
+		if value, ok := input.ToolInput[key].(string); ok && value != "" {
+			event.Description = truncateText(value, maxHookCommandLen)
+			break
+		}
+	}
+
+	if input.Prompt != "" {
+		event.Metadata = map[string]string{"prompt_length": strconv.Itoa(len(input.Prompt))}
+	}
+	return event
+}
+
+// addGitInfo attaches the branch and HEAD commit of the event's working directory
+func addGitInfo(event *tracking.ActivityEvent) {
+	info, err := tracking.ReadGitInfo(event.ProjectPath)
+	if err != nil {
+		return // not a repository; track without branch
+	}
+	event.GitBranch = info.Branch
+	event.GitCommit = info.Commit
+}
+
+// hookEnvTags reads CLAUDE_MONITOR_TAGS, dropping invalid tags rather than the event
+func hookEnvTags() []string {
+	var tags []string
+	for _, value := range strings.Split(os.Getenv(hookTagsEnv), ",") {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		tag, err := sqlite.NormalizeTag(value)
+		if err != nil {
+			reportHookError(err)
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// truncateText shortens text to max runes
+func truncateText(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max])
+}
+
+// reportHookError prints hook failures only in verbose mode
+func reportHookError(err error) {
+	if verbose {
+		fmt.Fprintf(os.Stderr, "claude-monitor hook: %v\n", err)
+	}
+}
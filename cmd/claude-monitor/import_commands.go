@@ -0,0 +1,131 @@
+/**
+ * CONTEXT:   Import command merging another machine's history into this database
+ * INPUT:     Path to a JSON Lines or SQLite export
+ * OUTPUT:    Merged rows and per-table import statistics
+ * BUSINESS:  Combines a laptop's history with a workstation's; importing the
+ *            same file twice adds nothing the second time
+ * CHANGE:    Initial import command
+ * RISK:      Medium - Writes to the local database; conflicting IDs are remapped
+ *            and existing rows are never modified
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/spf13/cobra"
+)
+
+// sqliteFileHeader starts every SQLite database file
+var sqliteFileHeader = []byte("SQLite format 3\x00")
+
+/**
+ * CONTEXT:   Import command definition
+ * INPUT:     Export file argument
+ * OUTPUT:    Import summary table or JSON statistics
+ * BUSINESS:  Counterpart of 'claude-monitor export --format'
+ * CHANGE:    Initial import command
+ * RISK:      Medium - Writes imported rows in batches
+ */
+var importCmd = &cobra.Command{
+	Use:   "import FILE",
+	Short: "Import history exported from another machine",
+	Long: `Merge a history export into the local database.
+
+The format is detected from the file: a SQLite database (export --format
+sqlite-dump, or a copied monitor.db) or JSON Lines (export --format jsonl).
+Use - to read JSON Lines from stdin.
+
+Records that already exist here, matched by username, project path or
+session and work block start time, are recognised and skipped. Records
+whose ID is already used by a different record are imported under a new
+ID, and references to them are rewritten. Rows are streamed and committed
+in batches, so large histories import with constant memory and an
+interrupted import can simply be run again.`,
+	Example: `  claude-monitor import laptop.jsonl
+  gunzip -c laptop.jsonl.gz | claude-monitor import -
+  claude-monitor import laptop.db -f json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+/**
+ * CONTEXT:   Import command handler
+ * INPUT:     Export file path or - for stdin
+ * OUTPUT:    Import statistics
+ * BUSINESS:  Streams the export through the importer matching its format
+ * CHANGE:    Initial import handler
+ * RISK:      Medium - Local database writes
+ */
+func runImport(cmd *cobra.Command, args []string) error {
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	ctx := context.Background()
+	importer := sqlite.NewImporter(unifiedDB)
+
+	var stats *sqlite.TransferStats
+	if args[0] == "-" {
+		var err error
+		if stats, err = importer.ImportJSONL(ctx, os.Stdin); err != nil {
+			return fmt.Errorf("import failed: %w", err)
+		}
+	} else {
+		path := expandPath(args[0])
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", args[0], err)
+		}
+		defer file.Close()
+
+		header := make([]byte, len(sqliteFileHeader))
+		n, err := io.ReadFull(file, header)
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			return fmt.Errorf("failed to read %s: %w", args[0], err)
+		}
+
+		if bytes.Equal(header[:n], sqliteFileHeader) {
+			stats, err = importer.ImportSQLite(ctx, path)
+		} else {
+			stats, err = importer.ImportJSONL(ctx, io.MultiReader(bytes.NewReader(header[:n]), file))
+		}
+		if err != nil {
+			return fmt.Errorf("import failed: %w", err)
+		}
+	}
+
+	if outputFormat == "json" {
+		return printJSON(stats)
+	}
+	printImportStats(stats, args[0])
+	return nil
+}
+
+// printImportStats prints per-table import counts
+func printImportStats(stats *sqlite.TransferStats, source string) {
+	headerColor.Printf("📥 Import from %s (%s, schema v%d)\n", source, stats.Format, stats.SchemaVersion)
+	fmt.Printf("   %-18s %9s %9s %10s %9s %8s\n", "TABLE", "ROWS", "IMPORTED", "DUPLICATES", "REMAPPED", "SKIPPED")
+
+	var imported, duplicates, remapped int64
+	for _, table := range stats.Tables {
+		fmt.Printf("   %-18s %9d %9d %10d %9d %8d\n",
+			table.Table, table.Rows, table.Imported, table.Duplicates, table.Remapped, table.Skipped)
+		imported += table.Imported
+		duplicates += table.Duplicates
+		remapped += table.Remapped
+	}
+
+	if imported == 0 {
+		infoColor.Printf("✅ Nothing new to import (%d row(s) already present)\n", duplicates)
+		return
+	}
+	successColor.Printf("✅ Imported %d row(s); %d already present, %d given new IDs\n", imported, duplicates, remapped)
+}
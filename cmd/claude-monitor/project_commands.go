@@ -0,0 +1,795 @@
+/**
+ * CONTEXT:   Project command group for cleaning up auto-detected projects
+ * INPUT:     Project references (ID, path or name) and management subcommands
+ * OUTPUT:    Listed, renamed, merged, archived, aliased or re-parented projects
+ * BUSINESS:  Auto-detection creates duplicates and odd names that users need to fix,
+ *            and client work needs a client -> product -> repository tree
+ * CHANGE:    Added project hierarchy and client commands
+ * RISK:      Medium - Merge rewrites tracked history; it requires --yes
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/tracking"
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectListAll     bool
+	projectMergeYes    bool
+	projectUnarchive   bool
+	projectAliasRemove bool
+	projectClearParent bool
+	projectClearClient bool
+)
+
+// projectListing is one row of `project list` JSON output
+type projectListing struct {
+	*sqlite.Project
+	Aliases  []string               `json:"aliases"`
+	Summary  *sqlite.ProjectSummary `json:"summary"`
+	Lineage  []*sqlite.Project      `json:"-"`
+	Children []*sqlite.Project      `json:"-"`
+}
+
+/**
+ * CONTEXT:   Project command group
+ * INPUT:     Project subcommand selection
+ * OUTPUT:    Routed project subcommand execution
+ * BUSINESS:  Projects group work blocks in every report
+ * CHANGE:    Initial project command group
+ * RISK:      Low - Command routing only
+ */
+var projectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "List and manage tracked projects",
+	Long: `List and manage the projects created automatically from working directories.
+
+Projects can be referred to by ID, path or name. Merging moves all work
+blocks, activities, goals and aliases of the source project to the target
+and keeps the source path as an alias, so future activity there is
+tracked under the target.
+
+Path rules in ~/.claude/config.json decide how new activity maps to
+projects. Rules are checked in order and the first match wins; entries
+in projects.custom_names are checked last:
+
+  "projects": {
+    "rules": [
+      {"match": "/tmp/**", "ignore": true},
+      {"match": "~/clients/acme/*", "group": "Acme", "tags": ["billable"]},
+      {"regex": "^/srv/(?P<team>[^/]+)/(?P<repo>[^/]+)", "name": "${repo}", "group": "${team}"}
+    ]
+  }
+
+A glob rule uses the shortest matching ancestor as the project root
+(* matches one path segment, ** any number). A regex rule uses the
+matched prefix and expands $1 or ${name} in name and group. Check a
+path with 'project resolve' before relying on a rule.
+
+Projects can form a tree: put repositories or sub-paths under a product
+with 'project parent' and set the client with 'project client'. A
+project without its own client inherits the nearest ancestor's client.
+Reports roll hours up with --group-by client|parent|project, and a goal
+on a parent project counts the time of all its sub-projects.`,
+	Example: `  claude-monitor project list
+  claude-monitor project resolve ~/clients/acme/portal/src
+  claude-monitor project rename ~/code/api "Billing API"
+  claude-monitor project merge Unknown "Billing API" --yes
+  claude-monitor project alias "Billing API" ~/worktrees/api-hotfix
+  claude-monitor project archive "Old Prototype"
+  claude-monitor project client "Acme Portal" Acme
+  claude-monitor project parent ~/clients/acme/portal/api "Acme Portal"
+  claude-monitor project tree`,
+}
+
+/**
+ * CONTEXT:   Project command initialization with subcommands and flags
+ * INPUT:     Cobra command definitions and flag configurations
+ * OUTPUT:    Complete project command structure
+ * BUSINESS:  Command initialization enables project cleanup from the CLI
+ * CHANGE:    Added parent, client and tree subcommands
+ * RISK:      Low - Command structure initialization with no side effects
+ */
+func init() {
+	projectListCmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List projects with tracked hours",
+		Args:    cobra.NoArgs,
+		RunE:    runProjectList,
+	}
+	projectListCmd.Flags().BoolVar(&projectListAll, "all", false, "include archived projects")
+
+	projectShowCmd := &cobra.Command{
+		Use:   "show <project>",
+		Short: "Show project details, aliases and totals",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runProjectShow,
+	}
+
+	projectRenameCmd := &cobra.Command{
+		Use:   "rename <project> <new-name>",
+		Short: "Rename a project",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runProjectRename,
+	}
+
+	projectMergeCmd := &cobra.Command{
+		Use:   "merge <source> <target>",
+		Short: "Merge a duplicate project into another",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runProjectMerge,
+	}
+	projectMergeCmd.Flags().BoolVar(&projectMergeYes, "yes", false, "perform the merge (without it only a preview is shown)")
+
+	projectArchiveCmd := &cobra.Command{
+		Use:   "archive <project>",
+		Short: "Hide a project from listings, keeping its history",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runProjectArchive,
+	}
+	projectArchiveCmd.Flags().BoolVar(&projectUnarchive, "undo", false, "restore an archived project")
+
+	projectAliasCmd := &cobra.Command{
+		Use:   "alias <project> <path>",
+		Short: "Track another path as part of a project",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runProjectAlias,
+	}
+	projectAliasCmd.Flags().BoolVar(&projectAliasRemove, "remove", false, "remove the alias instead of adding it")
+
+	projectResolveCmd := &cobra.Command{
+		Use:   "resolve <path>",
+		Short: "Show how path rules map a directory to a project (dry run)",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runProjectResolve,
+	}
+
+	projectParentCmd := &cobra.Command{
+		Use:   "parent <project> [<parent>]",
+		Short: "Place a project under a parent project",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE:  runProjectParent,
+	}
+	projectParentCmd.Flags().BoolVar(&projectClearParent, "clear", false, "make the project top-level again")
+
+	projectClientCmd := &cobra.Command{
+		Use:   "client <project> [<client>]",
+		Short: "Set the client a project belongs to",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE:  runProjectClient,
+	}
+	projectClientCmd.Flags().BoolVar(&projectClearClient, "clear", false, "remove the client (inherit from the parent again)")
+
+	projectTreeCmd := &cobra.Command{
+		Use:   "tree",
+		Short: "Show the project hierarchy with clients and hours",
+		Args:  cobra.NoArgs,
+		RunE:  runProjectTree,
+	}
+	projectTreeCmd.Flags().BoolVar(&projectListAll, "all", false, "include archived projects")
+
+	projectCmd.AddCommand(projectListCmd)
+	projectCmd.AddCommand(projectShowCmd)
+	projectCmd.AddCommand(projectRenameCmd)
+	projectCmd.AddCommand(projectMergeCmd)
+	projectCmd.AddCommand(projectArchiveCmd)
+	projectCmd.AddCommand(projectAliasCmd)
+	projectCmd.AddCommand(projectResolveCmd)
+	projectCmd.AddCommand(projectParentCmd)
+	projectCmd.AddCommand(projectClientCmd)
+	projectCmd.AddCommand(projectTreeCmd)
+}
+
+/**
+ * CONTEXT:   Project list command handler
+ * INPUT:     --all flag and global output format
+ * OUTPUT:    Projects with work blocks, hours and last activity
+ * BUSINESS:  Listing reveals duplicates and "Unknown" projects to clean up
+ * CHANGE:    Initial project listing
+ * RISK:      Low - Read-only queries
+ */
+func runProjectList(cmd *cobra.Command, args []string) error {
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(unifiedDB.DB())
+	projects, err := projectRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	listings := make([]projectListing, 0, len(projects))
+	for _, project := range projects {
+		if project.ArchivedAt != nil && !projectListAll {
+			continue
+		}
+		listing, err := loadProjectListing(ctx, projectRepo, project)
+		if err != nil {
+			return err
+		}
+		listings = append(listings, listing)
+	}
+
+	if outputFormat == "json" {
+		return printJSON(listings)
+	}
+
+	if len(listings) == 0 {
+		if len(projects) > 0 {
+			infoColor.Println("All projects are archived. Use --all to list them.")
+		} else {
+			infoColor.Println("No projects tracked yet.")
+		}
+		return nil
+	}
+
+	headerColor.Printf("%-28s %-24s %7s %8s  %-16s %s\n", "ID", "NAME", "BLOCKS", "HOURS", "LAST ACTIVE", "PATH")
+	for _, listing := range listings {
+		lastActive := "-"
+		if listing.Summary.LastActivity != nil {
+			lastActive = listing.Summary.LastActivity.Format("2006-01-02 15:04")
+		}
+		name := listing.Name
+		if listing.ArchivedAt != nil {
+			name += " (archived)"
+		}
+		fmt.Printf("%-28s %-24s %7d %8.1f  %-16s %s\n",
+			listing.ID, truncateText(name, 24), listing.Summary.WorkBlocks,
+			listing.Summary.TotalHours, lastActive, listing.Path)
+		if len(listing.Aliases) > 0 {
+			dimColor.Printf("%-28s also: %s\n", "", strings.Join(listing.Aliases, ", "))
+		}
+	}
+	return nil
+}
+
+/**
+ * CONTEXT:   Project show command handler
+ * INPUT:     Project reference
+ * OUTPUT:    Project details with aliases and tracked totals
+ * BUSINESS:  Details help choose the canonical project before merging
+ * CHANGE:    Initial project details view
+ * RISK:      Low - Read-only queries
+ */
+func runProjectShow(cmd *cobra.Command, args []string) error {
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(unifiedDB.DB())
+	project, err := resolveProjectReference(ctx, projectRepo, args[0])
+	if err != nil {
+		return err
+	}
+
+	listing, err := loadProjectListing(ctx, projectRepo, project)
+	if err != nil {
+		return err
+	}
+	if listing.Lineage, err = projectRepo.GetAncestors(ctx, project.ID); err != nil {
+		return err
+	}
+	if listing.Children, err = projectRepo.GetChildren(ctx, project.ID); err != nil {
+		return err
+	}
+
+	if outputFormat == "json" {
+		return printJSON(listing)
+	}
+
+	headerColor.Printf("📁 %s\n", project.Name)
+	fmt.Printf("   ID:          %s\n", project.ID)
+	fmt.Printf("   Path:        %s\n", project.Path)
+	for _, alias := range listing.Aliases {
+		fmt.Printf("   Alias:       %s\n", alias)
+	}
+	if project.Group != "" {
+		fmt.Printf("   Group:       %s\n", project.Group)
+	}
+	if len(project.Tags) > 0 {
+		fmt.Printf("   Tags:        %s\n", strings.Join(project.Tags, ", "))
+	}
+	if len(listing.Lineage) > 1 {
+		fmt.Printf("   Parent:      %s\n", listing.Lineage[1].Name)
+	}
+	if client, inherited := effectiveProjectClient(listing.Lineage); client != "" {
+		if inherited {
+			client += " (inherited)"
+		}
+		fmt.Printf("   Client:      %s\n", client)
+	}
+	for _, child := range listing.Children {
+		fmt.Printf("   Child:       %s\n", child.Name)
+	}
+	if project.ArchivedAt != nil {
+		warningColor.Printf("   Archived:    %s\n", project.ArchivedAt.Format("2006-01-02 15:04"))
+	}
+	fmt.Printf("   Created:     %s\n", project.CreatedAt.Format("2006-01-02 15:04"))
+	fmt.Printf("   Work blocks: %d\n", listing.Summary.WorkBlocks)
+	fmt.Printf("   Activities:  %d\n", listing.Summary.Activities)
+	fmt.Printf("   Hours:       %.1f\n", listing.Summary.TotalHours)
+	if listing.Summary.FirstActivity != nil {
+		fmt.Printf("   Active:      %s → %s\n",
+			listing.Summary.FirstActivity.Format("2006-01-02"), listing.Summary.LastActivity.Format("2006-01-02"))
+	}
+	return nil
+}
+
+/**
+ * CONTEXT:   Project rename command handler
+ * INPUT:     Project reference and new display name
+ * OUTPUT:    Renamed project
+ * BUSINESS:  Fixes names mangled by automatic detection
+ * CHANGE:    Initial project rename
+ * RISK:      Low - Single-row update
+ */
+func runProjectRename(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[1])
+	if name == "" {
+		return fmt.Errorf("project name cannot be empty")
+	}
+
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(unifiedDB.DB())
+	project, err := resolveProjectReference(ctx, projectRepo, args[0])
+	if err != nil {
+		return err
+	}
+
+	oldName := project.Name
+	project.Name = name
+	project.UpdatedAt = time.Now()
+	if err := projectRepo.Update(ctx, project); err != nil {
+		return err
+	}
+
+	successColor.Printf("✅ Renamed %q to %q\n", oldName, name)
+	return nil
+}
+
+/**
+ * CONTEXT:   Project merge command handler
+ * INPUT:     Source and target project references and --yes flag
+ * OUTPUT:    Merge preview, or merged project with moved row counts
+ * BUSINESS:  Consolidates duplicates so reports show one project per repository
+ * CHANGE:    Initial project merge with preview
+ * RISK:      High - Deletes the source project; requires --yes
+ */
+func runProjectMerge(cmd *cobra.Command, args []string) error {
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(unifiedDB.DB())
+	source, err := resolveProjectReference(ctx, projectRepo, args[0])
+	if err != nil {
+		return err
+	}
+	target, err := resolveProjectReference(ctx, projectRepo, args[1])
+	if err != nil {
+		return err
+	}
+	if source.ID == target.ID {
+		return fmt.Errorf("source and target are the same project (%s)", source.ID)
+	}
+
+	if !projectMergeYes {
+		summary, err := projectRepo.GetSummary(ctx, source.ID)
+		if err != nil {
+			return err
+		}
+		infoColor.Printf("Merging %q (%s) into %q (%s) would move %d work blocks (%.1fh) and delete %q.\n",
+			source.Name, source.ID, target.Name, target.ID, summary.WorkBlocks, summary.TotalHours, source.Name)
+		fmt.Println("Run again with --yes to merge.")
+		return nil
+	}
+
+	result, err := projectRepo.Merge(ctx, source.ID, target.ID)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == "json" {
+		return printJSON(result)
+	}
+
+	successColor.Printf("✅ Merged %q into %q\n", source.Name, target.Name)
+	fmt.Printf("   Moved %d work blocks, %d activities, %d goals and %d aliases\n",
+		result.WorkBlocks, result.Activities, result.Goals, result.Aliases)
+	fmt.Printf("   %s is now an alias of %s\n", source.Path, target.Name)
+	return nil
+}
+
+/**
+ * CONTEXT:   Project archive command handler
+ * INPUT:     Project reference and --undo flag
+ * OUTPUT:    Archived or restored project
+ * BUSINESS:  Finished projects stop cluttering listings without losing history
+ * CHANGE:    Initial project archiving
+ * RISK:      Low - Single-row update
+ */
+func runProjectArchive(cmd *cobra.Command, args []string) error {
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(unifiedDB.DB())
+	project, err := resolveProjectReference(ctx, projectRepo, args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := projectRepo.SetArchived(ctx, project.ID, !projectUnarchive, time.Now()); err != nil {
+		return err
+	}
+
+	if projectUnarchive {
+		successColor.Printf("✅ Restored project %q\n", project.Name)
+	} else {
+		successColor.Printf("✅ Archived project %q\n", project.Name)
+	}
+	return nil
+}
+
+/**
+ * CONTEXT:   Project alias command handler
+ * INPUT:     Project reference, path and --remove flag
+ * OUTPUT:    Added or removed path alias
+ * BUSINESS:  Worktrees and second checkouts are tracked under one project
+ * CHANGE:    Initial project aliases
+ * RISK:      Low - Alias rows only; existing history is not moved
+ */
+func runProjectAlias(cmd *cobra.Command, args []string) error {
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(unifiedDB.DB())
+	project, err := resolveProjectReference(ctx, projectRepo, args[0])
+	if err != nil {
+		return err
+	}
+	path := expandPath(args[1])
+
+	if projectAliasRemove {
+		if err := projectRepo.RemoveAlias(ctx, path); err != nil {
+			return err
+		}
+		successColor.Printf("✅ Removed alias %s\n", path)
+		return nil
+	}
+
+	if err := projectRepo.AddAlias(ctx, project.ID, path); err != nil {
+		return err
+	}
+	successColor.Printf("✅ %s is now tracked as %q\n", path, project.Name)
+	return nil
+}
+
+/**
+ * CONTEXT:   Project resolve command handler
+ * INPUT:     Directory path and project rules from configuration
+ * OUTPUT:    Matching rule, project root, name, group, tags or ignore decision
+ * BUSINESS:  Users verify rules before the daemon applies them to real activity
+ * CHANGE:    Initial dry-run for path rules
+ * RISK:      Low - Read-only; database lookup is optional
+ */
+func runProjectResolve(cmd *cobra.Command, args []string) error {
+	config, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	rules, err := tracking.NewProjectRules(config.Projects.Rules, config.Projects.CustomNames)
+	if err != nil {
+		return fmt.Errorf("invalid project rules: %w", err)
+	}
+
+	resolution := rules.Resolve(expandPath(args[0]))
+
+	// Show the project activity would land in when a database exists
+	var existing *sqlite.Project
+	if err := initializeDefaultReporting(); err == nil {
+		defer closeReporting()
+		existing, _ = sqlite.NewProjectRepository(unifiedDB.DB()).GetByPath(context.Background(), resolution.ProjectPath)
+	}
+
+	if outputFormat == "json" {
+		return printJSON(map[string]interface{}{
+			"resolution":       resolution,
+			"existing_project": existing,
+		})
+	}
+
+	fmt.Printf("Path:     %s\n", resolution.Path)
+	if resolution.Rule == "" {
+		dimColor.Println("Rule:     (none - the path itself is the project)")
+	} else {
+		fmt.Printf("Rule:     #%d %s\n", resolution.RuleIndex+1, resolution.Rule)
+	}
+	if resolution.Ignored {
+		warningColor.Println("Result:   ignored - activity here is not tracked")
+		return nil
+	}
+
+	fmt.Printf("Project:  %s\n", resolution.ProjectPath)
+	switch {
+	case existing != nil:
+		fmt.Printf("Name:     %s (existing project %s)\n", existing.Name, existing.ID)
+	case resolution.Name != "":
+		fmt.Printf("Name:     %s (new project)\n", resolution.Name)
+	default:
+		fmt.Println("Name:     derived from the directory (new project)")
+	}
+	if resolution.Group != "" {
+		fmt.Printf("Group:    %s\n", resolution.Group)
+	}
+	if len(resolution.Tags) > 0 {
+		fmt.Printf("Tags:     %s\n", strings.Join(resolution.Tags, ", "))
+	}
+	return nil
+}
+
+/**
+ * CONTEXT:   Project parent command handler
+ * INPUT:     Project reference, parent reference and --clear flag
+ * OUTPUT:    Project moved under the parent or back to top level
+ * BUSINESS:  Monorepo sub-paths and repositories roll up into products
+ * CHANGE:    Initial project hierarchy command
+ * RISK:      Low - Single-row update; cycles are rejected
+ */
+func runProjectParent(cmd *cobra.Command, args []string) error {
+	if len(args) == 1 && !projectClearParent {
+		return fmt.Errorf("specify a parent project or use --clear")
+	}
+	if len(args) == 2 && projectClearParent {
+		return fmt.Errorf("--clear does not take a parent project")
+	}
+
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(unifiedDB.DB())
+	project, err := resolveProjectReference(ctx, projectRepo, args[0])
+	if err != nil {
+		return err
+	}
+
+	if projectClearParent {
+		if err := projectRepo.SetParent(ctx, project.ID, ""); err != nil {
+			return err
+		}
+		successColor.Printf("✅ %q is now a top-level project\n", project.Name)
+		return nil
+	}
+
+	parent, err := resolveProjectReference(ctx, projectRepo, args[1])
+	if err != nil {
+		return err
+	}
+	if err := projectRepo.SetParent(ctx, project.ID, parent.ID); err != nil {
+		return err
+	}
+	successColor.Printf("✅ %q is now under %q\n", project.Name, parent.Name)
+	return nil
+}
+
+/**
+ * CONTEXT:   Project client command handler
+ * INPUT:     Project reference, client name and --clear flag
+ * OUTPUT:    Client set on or removed from the project
+ * BUSINESS:  Client totals drive billing with --group-by client
+ * CHANGE:    Initial project client command
+ * RISK:      Low - Single-row update
+ */
+func runProjectClient(cmd *cobra.Command, args []string) error {
+	client := ""
+	if len(args) == 2 {
+		client = strings.TrimSpace(args[1])
+	}
+	if client == "" && !projectClearClient {
+		return fmt.Errorf("specify a client name or use --clear")
+	}
+	if client != "" && projectClearClient {
+		return fmt.Errorf("--clear does not take a client name")
+	}
+
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(unifiedDB.DB())
+	project, err := resolveProjectReference(ctx, projectRepo, args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := projectRepo.SetClient(ctx, project.ID, client); err != nil {
+		return err
+	}
+	if client == "" {
+		successColor.Printf("✅ Removed client from %q\n", project.Name)
+	} else {
+		successColor.Printf("✅ %q now belongs to client %q\n", project.Name, client)
+	}
+	return nil
+}
+
+/**
+ * CONTEXT:   Project tree command handler
+ * INPUT:     --all flag and global output format
+ * OUTPUT:    Indented project hierarchy with clients and own and total hours
+ * BUSINESS:  Shows how client, product and repository totals roll up
+ * CHANGE:    Initial project tree view
+ * RISK:      Low - Read-only queries
+ */
+func runProjectTree(cmd *cobra.Command, args []string) error {
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(unifiedDB.DB())
+	projects, err := projectRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[string]*projectTreeNode, len(projects))
+	for _, project := range projects {
+		if project.ArchivedAt != nil && !projectListAll {
+			continue
+		}
+		summary, err := projectRepo.GetSummary(ctx, project.ID)
+		if err != nil {
+			return err
+		}
+		byID[project.ID] = &projectTreeNode{Project: project, Hours: summary.TotalHours, Children: []*projectTreeNode{}}
+	}
+
+	var roots []*projectTreeNode
+	for _, project := range projects {
+		node, ok := byID[project.ID]
+		if !ok {
+			continue
+		}
+		if parent, ok := byID[project.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	sortProjectTree(roots)
+	for _, root := range roots {
+		root.total()
+	}
+
+	if outputFormat == "json" {
+		return printJSON(roots)
+	}
+
+	if len(roots) == 0 {
+		infoColor.Println("No projects tracked yet.")
+		return nil
+	}
+	for _, root := range roots {
+		printProjectTree(root, "", "", "")
+	}
+	return nil
+}
+
+// projectTreeNode is one project in `project tree` output
+type projectTreeNode struct {
+	*sqlite.Project
+	Hours      float64            `json:"hours"`
+	TotalHours float64            `json:"total_hours"`
+	Children   []*projectTreeNode `json:"children"`
+}
+
+// total sums own and descendant hours into TotalHours
+func (n *projectTreeNode) total() float64 {
+	n.TotalHours = n.Hours
+	for _, child := range n.Children {
+		n.TotalHours += child.total()
+	}
+	return n.TotalHours
+}
+
+// sortProjectTree orders every level of the tree by name
+func sortProjectTree(nodes []*projectTreeNode) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	for _, node := range nodes {
+		sortProjectTree(node.Children)
+	}
+}
+
+// printProjectTree prints a node with box-drawing connectors and its inherited client
+func printProjectTree(node *projectTreeNode, client, prefix, connector string) {
+	if node.Client != "" {
+		client = node.Client
+	}
+
+	label := node.Name
+	if node.ArchivedAt != nil {
+		label += " (archived)"
+	}
+	fmt.Printf("%s%s%s", prefix, connector, label)
+	if node.Client != "" {
+		infoColor.Printf("  [%s]", node.Client)
+	}
+	if len(node.Children) > 0 {
+		dimColor.Printf("  %.1fh (%.1fh own)\n", node.TotalHours, node.Hours)
+	} else {
+		dimColor.Printf("  %.1fh\n", node.Hours)
+	}
+
+	childPrefix := prefix
+	switch connector {
+	case "├── ":
+		childPrefix += "│   "
+	case "└── ":
+		childPrefix += "    "
+	}
+	for i, child := range node.Children {
+		next := "├── "
+		if i == len(node.Children)-1 {
+			next = "└── "
+		}
+		printProjectTree(child, client, childPrefix, next)
+	}
+}
+
+// effectiveProjectClient returns the nearest client in a lineage and whether it was inherited
+func effectiveProjectClient(lineage []*sqlite.Project) (string, bool) {
+	for i, project := range lineage {
+		if project.Client != "" {
+			return project.Client, i > 0
+		}
+	}
+	return "", false
+}
+
+// loadProjectListing gathers aliases and totals for one project
+func loadProjectListing(ctx context.Context, projectRepo *sqlite.ProjectRepository, project *sqlite.Project) (projectListing, error) {
+	aliases, err := projectRepo.GetAliases(ctx, project.ID)
+	if err != nil {
+		return projectListing{}, err
+	}
+	summary, err := projectRepo.GetSummary(ctx, project.ID)
+	if err != nil {
+		return projectListing{}, err
+	}
+	return projectListing{Project: project, Aliases: aliases, Summary: summary}, nil
+}
@@ -0,0 +1,135 @@
+/**
+ * CONTEXT:   Shell prompt and status bar integration
+ * INPUT:     Status snapshot file written by the daemon and a preset or Go template
+ * OUTPUT:    One line for PS1, starship, tmux, i3blocks or waybar
+ * BUSINESS:  Shows "project · 2h14m today · session 3h10m left" on every prompt
+ *            without touching SQLite or the daemon API
+ * CHANGE:    Initial prompt command
+ * RISK:      Low - Reads one small file; never fails the user's prompt
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/claude-monitor/system/internal/reporting"
+	"github.com/spf13/cobra"
+)
+
+var (
+	promptFormat string
+	promptFile   string
+	promptList   bool
+)
+
+/**
+ * CONTEXT:   Prompt command definition
+ * INPUT:     --format preset or template, optional --file and --list
+ * OUTPUT:    Rendered prompt line
+ * BUSINESS:  Ready-made presets cover the common shells and bars
+ * CHANGE:    Initial prompt command
+ * RISK:      Low - Command definition only
+ */
+var promptCmd = &cobra.Command{
+	Use:   "prompt",
+	Short: "Print a status line for shell prompts and status bars",
+	Long: `Print a one-line tracking status for shell prompts and status bars.
+
+The daemon rewrites a small snapshot file (daemon.status_file, by default
+status.json next to the database) whenever an activity is recorded for the
+user the daemon runs as; other users' activity does not change it. This
+command only reads that file, so it is cheap enough to run on every prompt.
+Countdowns are computed when the line is printed. Nothing is printed when
+the daemon is stopped or has not written a snapshot yet.
+
+--format takes a preset name or a Go template. Presets:
+
+  plain     api · 2h14m today · session 3h10m left
+  bash      [api · 2h14m today · session 3h10m left] (no colors, safe in PS1)
+  zsh       colored with %F{...}; red in the last 30 minutes of a session
+  starship  plain text for a custom module
+  tmux      colored with #[fg=...]
+  waybar    JSON with text, tooltip, class (active, idle, stopped) and percentage
+
+Template fields: .Running .Active .InSession .Project .ProjectPath .Branch
+.Tags .Instances .LastTool .Today .Sessions .SessionLeft .SessionEnd .IdleIn
+.Working .UpdatedAt, and methods .Summary .Tooltip .Class .SessionEnding
+.SessionPercent.
+
+With parallel Claude instances the block fields describe the most recently
+active one and .Instances counts them; presets show "api +1". .Today is
+wall-clock time, so overlapping instances count once.
+Functions: dur (2h14m), hours (2.2), json, trunc N, zshesc, tmuxesc.
+
+Integrations:
+
+  bash   (~/.bashrc)     PS1='$(claude-monitor prompt -f bash)'"$PS1"
+  zsh    (~/.zshrc)      setopt PROMPT_SUBST; PROMPT='$(claude-monitor prompt -f zsh)'"$PROMPT"
+  starship (starship.toml)
+      [custom.claude]
+      command = "claude-monitor prompt -f starship"
+      when = true
+      format = "[$output]($style) "
+  tmux   (~/.tmux.conf)  set -g status-right '#(claude-monitor prompt -f tmux) %H:%M'
+  waybar (config)
+      "custom/claude": {
+        "exec": "claude-monitor prompt -f waybar",
+        "return-type": "json",
+        "interval": 30
+      }
+  i3blocks               command=claude-monitor prompt
+                         interval=30`,
+	Example: `  claude-monitor prompt
+  claude-monitor prompt -f zsh
+  claude-monitor prompt -f '{{if .Active}}{{.Project}} {{dur .IdleIn}}{{end}}'
+  claude-monitor prompt --list`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runPromptCommand,
+}
+
+// init registers the prompt flags; --format shadows the global output format
+func init() {
+	promptCmd.Flags().StringVarP(&promptFormat, "format", "f", "plain", "preset name or Go template")
+	promptCmd.Flags().StringVar(&promptFile, "file", "", "status snapshot file (default daemon.status_file)")
+	promptCmd.Flags().BoolVar(&promptList, "list", false, "list the presets and their templates")
+}
+
+/**
+ * CONTEXT:   Prompt command handler
+ * INPUT:     Snapshot path from --file or config and the selected format
+ * OUTPUT:    Rendered line; only a bad template is an error
+ * BUSINESS:  A missing or unreadable snapshot renders as "daemon not running"
+ *            so the user's prompt never shows an error
+ * CHANGE:    Initial handler
+ * RISK:      Low - Single file read
+ */
+func runPromptCommand(cmd *cobra.Command, args []string) error {
+	if promptList {
+		for _, name := range reporting.PromptPresetNames() {
+			preset, _ := reporting.PromptPreset(name)
+			fmt.Printf("%-9s %s\n", name, preset)
+		}
+		return nil
+	}
+
+	path := promptFile
+	if path == "" {
+		if config, err := loadConfiguration(); err == nil {
+			path = expandPath(config.Daemon.StatusFile)
+		}
+	}
+
+	var snapshot *reporting.StatusSnapshot
+	if path != "" {
+		var err error
+		if snapshot, err = reporting.ReadStatusSnapshot(path); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "claude-monitor prompt: %v\n", err)
+		}
+	}
+
+	return reporting.RenderPrompt(os.Stdout, promptFormat, reporting.NewPromptData(snapshot, time.Now()))
+}
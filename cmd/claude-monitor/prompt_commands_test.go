@@ -0,0 +1,140 @@
+/**
+ * CONTEXT:   Test suite for prompt output from the status snapshot
+ * INPUT:     Snapshots written to temp dirs and fixed evaluation instants
+ * OUTPUT:    Validation of atomic writes, countdowns and preset rendering
+ * BUSINESS:  Prompts run on every command; they must stay correct between
+ *            daemon writes and go quiet when the daemon stops
+ * CHANGE:    Initial prompt tests
+ * RISK:      Low - Temp files only
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/claude-monitor/system/internal/reporting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// promptTestSnapshot is a running daemon with an open block on "api"
+func promptTestSnapshot(now time.Time) *reporting.StatusSnapshot {
+	return reporting.NewStatusSnapshot(&reporting.LiveStatus{
+		UserID:      "alice",
+		GeneratedAt: now,
+		Session: &reporting.LiveSession{
+			StartTime: now.Add(-110 * time.Minute),
+			EndTime:   now.Add(190 * time.Minute),
+		},
+		WorkBlock: &reporting.LiveWorkBlock{
+			ProjectName: "api",
+			GitBranch:   "main",
+			StartTime:   now.Add(-20 * time.Minute),
+			IdleAt:      now.Add(5 * time.Minute),
+		},
+		Today:  reporting.LiveToday{WorkHours: 2.25, Sessions: 2},
+		Recent: []reporting.LiveEvent{{ToolName: "Edit"}},
+	}, reporting.SnapshotDaemonRunning)
+}
+
+// renderPrompt renders format and trims the trailing newline
+func renderPrompt(t *testing.T, format string, data reporting.PromptData) string {
+	t.Helper()
+	var out strings.Builder
+	require.NoError(t, reporting.RenderPrompt(&out, format, data))
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+func TestStatusSnapshotRoundTrip(t *testing.T) {
+	now := time.Date(2026, 3, 4, 14, 0, 0, 0, time.Local)
+	path := filepath.Join(t.TempDir(), "nested", "status.json")
+
+	require.NoError(t, reporting.WriteStatusSnapshot(path, promptTestSnapshot(now)))
+	require.NoError(t, reporting.WriteStatusSnapshot(path, promptTestSnapshot(now.Add(time.Minute))))
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "temp files are renamed into place")
+
+	snapshot, err := reporting.ReadStatusSnapshot(path)
+	require.NoError(t, err)
+	assert.Equal(t, reporting.StatusSnapshotVersion, snapshot.Version)
+	assert.Equal(t, "api", snapshot.Project)
+	assert.Equal(t, "Edit", snapshot.LastTool)
+	assert.True(t, snapshot.UpdatedAt.Equal(now.Add(time.Minute)))
+
+	_, err = reporting.ReadStatusSnapshot(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestPromptDataCountdowns(t *testing.T) {
+	now := time.Date(2026, 3, 4, 14, 0, 0, 0, time.Local)
+	snapshot := promptTestSnapshot(now)
+
+	data := reporting.NewPromptData(snapshot, now.Add(10*time.Minute))
+	assert.True(t, data.Running)
+	assert.Equal(t, 180*time.Minute, data.SessionLeft)
+	assert.Equal(t, "2h15m today · session 3h00m left", data.Summary())
+	assert.Equal(t, "idle", data.Class(), "the block went idle five minutes after the last event")
+	assert.Empty(t, data.Project)
+
+	data = reporting.NewPromptData(snapshot, now.Add(2*time.Minute))
+	assert.Equal(t, "active", data.Class())
+	assert.Equal(t, 3*time.Minute, data.IdleIn)
+	assert.Equal(t, 22*time.Minute, data.Working)
+
+	snapshot.Instances = 2
+	data = reporting.NewPromptData(snapshot, now.Add(2*time.Minute))
+	assert.Equal(t, "api +1 · 2h15m today · session 3h08m left", data.Summary())
+	assert.Contains(t, data.Tooltip(), "2 Claude instances active")
+	snapshot.Instances = 0
+
+	data = reporting.NewPromptData(snapshot, now.Add(170*time.Minute))
+	assert.True(t, data.SessionEnding())
+	assert.Equal(t, 93, data.SessionPercent())
+
+	data = reporting.NewPromptData(snapshot, now.Add(24*time.Hour))
+	assert.Equal(t, "0m today", data.Summary(), "yesterday's totals and the expired session drop off")
+
+	snapshot.Daemon = reporting.SnapshotDaemonStopped
+	assert.Empty(t, reporting.NewPromptData(snapshot, now).Summary())
+	assert.Equal(t, "stopped", reporting.NewPromptData(nil, now).Class())
+}
+
+func TestRenderPromptPresets(t *testing.T) {
+	now := time.Date(2026, 3, 4, 14, 0, 0, 0, time.Local)
+	snapshot := promptTestSnapshot(now)
+	snapshot.Project = "100%#done"
+	data := reporting.NewPromptData(snapshot, now)
+
+	assert.Equal(t, "[100%#done · 2h15m today · session 3h10m left] ", renderPrompt(t, "bash", data))
+	assert.Equal(t, "%F{cyan}100%%#done · 2h15m today · session 3h10m left%f ", renderPrompt(t, "zsh", data))
+	assert.Equal(t, "#[fg=colour39]100%##done · 2h15m today · session 3h10m left#[default]", renderPrompt(t, "tmux", data))
+
+	var waybar struct {
+		Text       string `json:"text"`
+		Tooltip    string `json:"tooltip"`
+		Class      string `json:"class"`
+		Percentage int    `json:"percentage"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(renderPrompt(t, "waybar", data)), &waybar))
+	assert.Equal(t, "100%#done · 2h15m today · session 3h10m left", waybar.Text)
+	assert.Equal(t, "active", waybar.Class)
+	assert.Equal(t, 36, waybar.Percentage)
+	assert.Contains(t, waybar.Tooltip, "Working on 100%#done (main)")
+
+	stopped := reporting.NewPromptData(nil, now)
+	for _, preset := range []string{"plain", "bash", "zsh", "starship", "tmux"} {
+		assert.Empty(t, renderPrompt(t, preset, stopped), preset)
+	}
+
+	assert.Equal(t, "api 5m", renderPrompt(t, "{{trunc 3 .Project}} {{dur .IdleIn}}",
+		reporting.NewPromptData(promptTestSnapshot(now), now)))
+	assert.Error(t, reporting.RenderPrompt(&strings.Builder{}, "{{.Missing", data))
+}
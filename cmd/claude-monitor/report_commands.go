@@ -0,0 +1,287 @@
+/**
+ * CONTEXT:   Report command group for period-based analytics beyond the daily report
+ * INPUT:     Period specifications (ISO weeks, months, days, custom ranges) and output format
+ * OUTPUT:    Professional comparison reports or JSON for scripting
+ * BUSINESS:  Period comparisons show whether work patterns improved between periods
+ * CHANGE:    Report command group with compare, rules and branches subcommands
+ * RISK:      Low - Read-only reporting commands
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/reporting"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportComparePeriodA string
+	reportComparePeriodB string
+	reportRulesFile      string
+	reportRulesDefaults  bool
+	reportBranch         string
+	reportBranchesPeriod string
+	reportGroupBy        string
+	reportTags           []string
+)
+
+/**
+ * CONTEXT:   Report command group for analytics reports
+ * INPUT:     Report subcommand selection
+ * OUTPUT:    Routed report subcommand execution
+ * BUSINESS:  Grouping reports keeps the root command surface small
+ * CHANGE:    Initial report command group
+ * RISK:      Low - Command routing only
+ */
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate analytics reports",
+	Long:  `Generate analytics reports that go beyond the daily summary.`,
+}
+
+/**
+ * CONTEXT:   Report command initialization with subcommands and flags
+ * INPUT:     Cobra command definitions and flag configurations
+ * OUTPUT:    Complete report command structure
+ * BUSINESS:  Command initialization enables period comparison from the CLI
+ * CHANGE:    Initial command setup with compare subcommand
+ * RISK:      Low - Command structure initialization with no side effects
+ */
+func init() {
+	reportCompareCmd := &cobra.Command{
+		Use:   "compare",
+		Short: "Compare two periods side by side",
+		Long: `Compare total hours, deep work, focus score, per-project hours,
+context switches and peak hours between two periods.
+
+Periods can be ISO weeks (2026-W40), months (2026-10), single days
+(2026-10-05) or custom ranges (2026-10-01..2026-10-15). Changes are
+reported for period B relative to period A. Hours per tag are compared
+when work in either period is tagged.`,
+		Example: `  claude-monitor report compare --a 2026-W40 --b 2026-W41
+  claude-monitor report compare --a 2026-W40 --b 2026-W41 --tag "code review"
+  claude-monitor report compare --a 2026-09 --b 2026-10
+  claude-monitor report compare --a 2026-10-01..2026-10-07 --b 2026-10-08..2026-10-14 -f json`,
+		RunE: runReportCompare,
+	}
+
+	reportCompareCmd.Flags().StringVar(&reportComparePeriodA, "a", "", "baseline period (YYYY-Www, YYYY-MM, YYYY-MM-DD or FROM..TO)")
+	reportCompareCmd.Flags().StringVar(&reportComparePeriodB, "b", "", "comparison period (YYYY-Www, YYYY-MM, YYYY-MM-DD or FROM..TO)")
+	reportCompareCmd.Flags().StringVar(&reportBranch, "branch", "", "only count work on this git branch (glob allowed, e.g. feature/*)")
+	reportCompareCmd.Flags().StringArrayVar(&reportTags, "tag", nil, "only count work carrying this tag (repeatable)")
+	reportCompareCmd.Flags().StringVar(&reportGroupBy, "group-by", reporting.GroupByProject, "roll project hours up to client, parent or project")
+	reportCompareCmd.MarkFlagRequired("a")
+	reportCompareCmd.MarkFlagRequired("b")
+
+	reportRulesCmd := &cobra.Command{
+		Use:   "rules",
+		Short: "Show or validate insight rules",
+		Long: `Print the insight rules used for report insights, recommendations,
+trends and deep work classification as a rules file.
+
+Rules are read from reporting.insight_rules_path in the configuration or
+from ~/.claude-monitor/insight_rules.json. Rules in the file replace
+built-in rules with the same name and new names are appended. Set
+"replace_defaults": true to start from an empty rule set.`,
+		Example: `  claude-monitor report rules --defaults > ~/.claude-monitor/insight_rules.json
+  claude-monitor report rules --file ./team-rules.json`,
+		RunE: runReportRules,
+	}
+
+	reportRulesCmd.Flags().StringVar(&reportRulesFile, "file", "", "validate and show rules from this file")
+	reportRulesCmd.Flags().BoolVar(&reportRulesDefaults, "defaults", false, "show only the built-in rules")
+
+	reportBranchesCmd := &cobra.Command{
+		Use:   "branches",
+		Short: "Show time spent per git branch",
+		Long: `Show hours, work blocks and distinct HEAD commits per git branch and
+project.
+
+Branches are recorded when projects.track_git_branches is enabled in
+~/.claude/config.json; the hook then reads the branch from .git and a
+branch switch starts a new work block. Without --period the report
+covers the last 30 days.`,
+		Example: `  claude-monitor report branches
+  claude-monitor report branches --period 2026-10 --branch 'feature/*'
+  claude-monitor today --branch feature/login`,
+		RunE: runReportBranches,
+	}
+
+	reportBranchesCmd.Flags().StringVar(&reportBranchesPeriod, "period", "", "period (YYYY-Www, YYYY-MM, YYYY-MM-DD or FROM..TO, default last 30 days)")
+	reportBranchesCmd.Flags().StringVar(&reportBranch, "branch", "", "only show branches matching this name or glob")
+	reportBranchesCmd.Flags().StringArrayVar(&reportTags, "tag", nil, "only count work carrying this tag (repeatable)")
+
+	reportCmd.AddCommand(reportCompareCmd)
+	reportCmd.AddCommand(reportRulesCmd)
+	reportCmd.AddCommand(reportBranchesCmd)
+}
+
+/**
+ * CONTEXT:   Report compare command handler
+ * INPUT:     Period flags and global output format
+ * OUTPUT:    Comparison report as table or JSON
+ * BUSINESS:  Side-by-side comparison reveals trends between concrete periods
+ * CHANGE:    Initial compare handler using reporting service
+ * RISK:      Low - Read-only reporting with user-friendly error handling
+ */
+func runReportCompare(cmd *cobra.Command, args []string) error {
+	periodA, err := reporting.ParseReportPeriod(reportComparePeriodA, nil)
+	if err != nil {
+		return fmt.Errorf("invalid --a period: %w", err)
+	}
+	periodB, err := reporting.ParseReportPeriod(reportComparePeriodB, nil)
+	if err != nil {
+		return fmt.Errorf("invalid --b period: %w", err)
+	}
+
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	if err := applyReportOptions(); err != nil {
+		return err
+	}
+
+	comparison, err := unifiedReportingSvc.ComparePeriods(context.Background(), getCurrentUserID(), periodA, periodB)
+	if err != nil {
+		return fmt.Errorf("failed to compare periods: %w", err)
+	}
+
+	if outputFormat == "json" {
+		return printJSON(comparison)
+	}
+
+	return reporting.DisplayProfessionalPeriodComparison(comparison)
+}
+
+/**
+ * CONTEXT:   Report branches command handler
+ * INPUT:     Optional period and branch pattern plus global output format
+ * OUTPUT:    Branch report as table or JSON
+ * BUSINESS:  Shows how long each feature branch took across projects
+ * CHANGE:    Initial branches handler using reporting service
+ * RISK:      Low - Read-only reporting with user-friendly error handling
+ */
+func runReportBranches(cmd *cobra.Command, args []string) error {
+	period, err := reporting.CalendarPeriod("", "", time.Now())
+	if reportBranchesPeriod != "" {
+		period, err = reporting.ParseReportPeriod(reportBranchesPeriod, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid --period: %w", err)
+	}
+
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	if err := applyReportOptions(); err != nil {
+		return err
+	}
+
+	report, err := unifiedReportingSvc.GenerateBranchReport(context.Background(), getCurrentUserID(), period)
+	if err != nil {
+		return fmt.Errorf("failed to generate branch report: %w", err)
+	}
+
+	if outputFormat == "json" {
+		return printJSON(report)
+	}
+
+	return reporting.DisplayProfessionalBranchReport(report)
+}
+
+// reportFilterFromFlags builds the report filter from the --branch and --tag flags
+func reportFilterFromFlags() (reporting.ReportFilter, error) {
+	tags, err := sqlite.ParseTags(reportTags...)
+	if err != nil {
+		return reporting.ReportFilter{}, err
+	}
+	filter := reporting.ReportFilter{Branch: reportBranch, Tags: tags}
+	return filter, filter.Validate()
+}
+
+// applyReportOptions applies the --branch, --tag and --group-by flags to the initialized reporting service
+func applyReportOptions() error {
+	filter, err := reportFilterFromFlags()
+	if err != nil {
+		return err
+	}
+	grouping, err := reporting.ParseProjectGrouping(reportGroupBy)
+	if err != nil {
+		return err
+	}
+	unifiedReportingSvc.SetFilter(filter)
+	unifiedReportingSvc.SetProjectGrouping(grouping)
+	return nil
+}
+
+// reportOptionsSuffix describes non-default report options for report headers
+func reportOptionsSuffix() string {
+	var suffix string
+	if filter, err := reportFilterFromFlags(); err == nil && !filter.IsEmpty() {
+		suffix += " · " + filter.Describe()
+	}
+	if grouping, err := reporting.ParseProjectGrouping(reportGroupBy); err == nil && grouping != reporting.GroupByProject {
+		suffix += " · by " + grouping
+	}
+	return suffix
+}
+
+/**
+ * CONTEXT:   Report rules command handler
+ * INPUT:     Optional rules file and defaults flag
+ * OUTPUT:    Effective rules as a JSON rules file, or validation error
+ * BUSINESS:  Users start tuning from the built-in rules and verify edits before use
+ * CHANGE:    Initial rules inspection handler
+ * RISK:      Low - Read-only file access
+ */
+func runReportRules(cmd *cobra.Command, args []string) error {
+	rules := reporting.DefaultInsightRuleEngine()
+
+	switch {
+	case reportRulesDefaults:
+	case reportRulesFile != "":
+		loaded, err := reporting.LoadInsightRules(expandPath(reportRulesFile))
+		if err != nil {
+			return err
+		}
+		rules = loaded
+	default:
+		if path := insightRulesPath(); fileExists(path) {
+			loaded, err := reporting.LoadInsightRules(path)
+			if err != nil {
+				return err
+			}
+			rules = loaded
+		}
+	}
+
+	return printJSON(reporting.InsightRuleFile{
+		ReplaceDefaults: true,
+		Rules:           rules.Rules(),
+	})
+}
+
+// fileExists reports whether a regular file exists at path
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// printJSON writes an indented JSON document to stdout for --format json
+func printJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(v)
+}
@@ -3,19 +3,24 @@
  * INPUT:     Cobra commands for install, start, stop, restart, status, logs, uninstall
  * OUTPUT:    Service management operations using segregated interfaces (Interface Segregation Principle)
  * BUSINESS:  Service commands provide professional daemon management with cross-platform support
- * CHANGE:    Extracted from service.go - focused command handlers using segregated interfaces
+ * CHANGE:    User-level install by default, with linger guidance
  * RISK:      Medium - Service commands modify system configuration and affect daemon lifecycle
  */
 
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
+	"github.com/claude-monitor/system/internal/logging"
 	"github.com/claude-monitor/system/internal/service/interfaces"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -26,11 +31,12 @@ import (
  * INPUT:     Installation flags, system permissions, target configuration
  * OUTPUT:    Installed and configured system service ready for operation
  * BUSINESS:  Service installation enables professional daemon deployment
- * CHANGE:    Updated to use segregated interfaces instead of fat ServiceManager
+ * CHANGE:    Default to a user unit; --system opts in to a root-managed unit
  * RISK:      High - System modification requiring careful validation and rollback
  */
 func runServiceInstall(cmd *cobra.Command, args []string) error {
 	startTime := time.Now()
+	resolveServiceScope(cmd)
 	
 	headerColor.Println("🚀 Claude Monitor Service Installation")
 	fmt.Println(strings.Repeat("═", 60))
@@ -84,6 +90,13 @@ func runServiceInstall(cmd *cobra.Command, args []string) error {
 	infoColor.Printf("📂 Executable: %s\n", config.ExecutablePath)
 	infoColor.Printf("🏠 Working Dir: %s\n", config.WorkingDir)
 	infoColor.Printf("👤 User: %s\n", getUserDisplayName(config.User))
+	if config.LinuxService.SystemdUnit {
+		scope := "user (systemctl --user)"
+		if !serviceUserLevel {
+			scope = "system (systemctl)"
+		}
+		infoColor.Printf("🔐 Scope: %s\n", scope)
+	}
 	
 	// Create working directory if needed
 	if err := os.MkdirAll(config.WorkingDir, 0755); err != nil {
@@ -135,11 +148,22 @@ func runServiceInstall(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  Stop:       claude-monitor service stop\n")
 	fmt.Printf("  Restart:    claude-monitor service restart\n")
 	fmt.Printf("  Logs:       claude-monitor service logs\n")
+	fmt.Printf("  Doctor:     claude-monitor service doctor\n")
 	fmt.Printf("  Uninstall:  claude-monitor service uninstall\n")
 	
+	printLingerGuidance()
 	return nil
 }
 
+// resolveServiceScope applies --system over the --user-service default and
+// records whether the scope was chosen explicitly
+func resolveServiceScope(cmd *cobra.Command) {
+	serviceScopeExplicit = cmd.Flags().Changed("system") || cmd.Flags().Changed("user-service")
+	if cmd.Flags().Changed("system") {
+		serviceUserLevel = !serviceSystemMode
+	}
+}
+
 /**
  * CONTEXT:   Service start command using ServiceController interface
  * INPUT:     Start command parameters
@@ -187,7 +211,7 @@ func runServiceRestart(cmd *cobra.Command, args []string) error {
  * INPUT:     Status command parameters
  * OUTPUT:    Service status using focused interfaces
  * BUSINESS:  Status command uses installer and monitor interfaces (Interface Segregation Principle)
- * CHANGE:    Updated to use segregated interfaces instead of fat ServiceManager
+ * CHANGE:    Report the daemon's real readiness instead of placeholder session counts
  * RISK:      Low - Read-only status operations with improved interface segregation
  */
 func runServiceStatus(cmd *cobra.Command, args []string) error {
@@ -232,15 +256,13 @@ func runServiceStatus(cmd *cobra.Command, args []string) error {
 	
 	config, err := loadConfiguration()
 	if err == nil {
-		client := NewHTTPClient(2 * time.Second)
+		client := NewHTTPClient(2 * time.Second).WithAuthToken(config.Daemon.AuthToken)
 		daemonURL := fmt.Sprintf("http://%s", config.Daemon.ListenAddr)
 		
 		if health, err := client.GetHealthStatus(daemonURL); err != nil {
-			warningColor.Printf("⚠️  Daemon API: Unreachable (%v)\n", err)
+			warningColor.Printf("⚠️  Daemon API: Unhealthy (%v)\n", err)
 		} else {
-			successColor.Printf("✅ Daemon API: Healthy (uptime: %s)\n", health.Uptime)
-			infoColor.Printf("📊 Active Sessions: %d\n", health.ActiveSessions)
-			infoColor.Printf("🔄 Work Blocks: %d\n", health.TotalWorkBlocks)
+			successColor.Printf("✅ Daemon API: %s (uptime: %s)\n", health.Status, health.Uptime)
 		}
 	}
 	
@@ -248,58 +270,140 @@ func runServiceStatus(cmd *cobra.Command, args []string) error {
 }
 
 /**
- * CONTEXT:   Service logs command using ServiceInstaller and ServiceMonitor interfaces
- * INPUT:     Log retrieval parameters and line count
- * OUTPUT:    Service logs using focused interfaces
- * BUSINESS:  Logs command uses installer and monitor interfaces (Interface Segregation Principle)
- * CHANGE:    Updated to use segregated interfaces instead of fat ServiceManager
- * RISK:      Low - Read-only log retrieval with improved interface segregation
+ * CONTEXT:   Service logs command reading the journal or the daemon's log files
+ * INPUT:     --lines, --follow, --since, --level, --grep and --request-id flags
+ * OUTPUT:    Filtered log entries, as colored lines or JSON (-f json)
+ * BUSINESS:  User-level installs and containers have no journal, so the daemon's
+ *            own rotated log files are read instead
+ * CHANGE:    Fall back to log files when journald is unavailable; add filters
+ * RISK:      Low - Read-only log retrieval
  */
 func runServiceLogs(cmd *cobra.Command, args []string) error {
-	// Create composite service manager
-	compositeManager, err := NewCompositeServiceManager()
+	filter, err := newLogFilter(serviceLogSince, serviceLogLevel, serviceLogGrep, serviceLogRequest, time.Now())
 	if err != nil {
-		return fmt.Errorf("failed to create service manager: %w", err)
+		return err
 	}
 	
-	// Use ServiceInstaller interface for installation check
-	var installer interfaces.ServiceInstaller = compositeManager
-	// Use ServiceMonitor interface for log retrieval
-	var monitor interfaces.ServiceMonitor = compositeManager
-	
-	if !installer.IsInstalled() {
-		return fmt.Errorf("service is not installed")
+	source, manager, logPath, err := resolveLogSource()
+	if err != nil {
+		return err
 	}
 	
-	logs, err := monitor.GetLogs(serviceLogLines)
-	if err != nil {
-		return fmt.Errorf("failed to retrieve logs: %w", err)
+	var logs []LogEntry
+	if source == logSourceJournal {
+		window := serviceLogLines
+		if filter.active() || window <= 0 {
+			window = journalFilterWindow
+		}
+		entries, err := manager.GetLogs(window)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve logs: %w", err)
+		}
+		for _, entry := range entries {
+			if filter.matches(entry) {
+				logs = append(logs, entry)
+			}
+		}
+		if serviceLogLines > 0 && len(logs) > serviceLogLines {
+			logs = logs[len(logs)-serviceLogLines:]
+		}
+	} else {
+		if logs, err = readLogFiles(logPath, serviceLogLines, filter); err != nil {
+			return err
+		}
 	}
 	
-	headerColor.Printf("📋 Service Logs (last %d entries)\n", len(logs))
-	fmt.Println(strings.Repeat("═", 50))
+	if outputFormat == "json" && !serviceFollow {
+		if logs == nil {
+			logs = []LogEntry{}
+		}
+		return printJSON(logs)
+	}
 	
+	if outputFormat != "json" {
+		if source == logSourceJournal {
+			headerColor.Printf("📋 Service Logs (journal, last %d entries)\n", len(logs))
+		} else {
+			headerColor.Printf("📋 Service Logs (%s, last %d entries)\n", logPath, len(logs))
+		}
+		fmt.Println(strings.Repeat("═", 50))
+	}
 	for _, entry := range logs {
-		var logColor *color.Color
-		switch strings.ToLower(entry.Level) {
-		case "error":
-			logColor = errorColor
-		case "warn", "warning":
-			logColor = warningColor
-		case "info":
-			logColor = infoColor
-		default:
-			logColor = dimColor
+		printServiceLogEntry(entry)
+	}
+	if !serviceFollow {
+		return nil
+	}
+	
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if source == logSourceJournal {
+		return followJournal(ctx, "claude-monitor", serviceIsUserLevel(manager), filter, printServiceLogEntry)
+	}
+	return followLogFile(ctx, logPath, filter, printServiceLogEntry)
+}
+
+// resolveLogSource picks the journal when the systemd service is installed and
+// journald runs, otherwise the daemon's configured log file
+func resolveLogSource() (string, ServiceManager, string, error) {
+	if journaldAvailable() {
+		if manager, err := NewServiceManager(); err == nil && manager.IsInstalled() {
+			return logSourceJournal, manager, "", nil
 		}
-		
-		logColor.Printf("%s [%s] %s: %s\n",
-			entry.Timestamp.Format("2006-01-02 15:04:05"),
-			entry.Level,
-			entry.Source,
-			entry.Message)
 	}
 	
-	return nil
+	logPath := daemonLogFilePath()
+	if logPath == "" {
+		return "", nil, "", fmt.Errorf("journald is unavailable and no log file is configured (logging.output_file)")
+	}
+	backups, _ := logging.BackupFiles(logPath)
+	if _, err := os.Stat(logPath); os.IsNotExist(err) && len(backups) == 0 && !serviceFollow {
+		return "", nil, "", fmt.Errorf("journald is unavailable and %s does not exist yet - has the daemon run?", logPath)
+	}
+	return logSourceFile, nil, logPath, nil
+}
+
+// daemonLogFilePath returns the log file the daemon writes with this configuration
+func daemonLogFilePath() string {
+	config, err := loadConfiguration()
+	if err != nil {
+		return ""
+	}
+	if daemonConfig, err := buildDaemonConfig(config); err == nil {
+		return daemonConfig.Logging.OutputFile
+	}
+	return expandPath(config.Logging.OutputFile)
+}
+
+// printServiceLogEntry prints one entry as a colored line, or JSON with -f json
+func printServiceLogEntry(entry LogEntry) {
+	if outputFormat == "json" {
+		data, _ := json.Marshal(entry)
+		fmt.Println(string(data))
+		return
+	}
+	
+	var logColor *color.Color
+	switch strings.ToLower(entry.Level) {
+	case "error":
+		logColor = errorColor
+	case "warn", "warning":
+		logColor = warningColor
+	case "info":
+		logColor = infoColor
+	default:
+		logColor = dimColor
+	}
+	
+	timestamp := "-------------------"
+	if !entry.Timestamp.IsZero() {
+		timestamp = entry.Timestamp.Local().Format("2006-01-02 15:04:05")
+	}
+	logColor.Printf("%s [%s] %s: %s", timestamp, strings.ToUpper(entry.Level), entry.Source, entry.Message)
+	if fields := formatLogFields(entry.Fields); fields != "" {
+		dimColor.Printf("  %s", fields)
+	}
+	fmt.Println()
 }
 
 /**
@@ -0,0 +1,317 @@
+/**
+ * CONTEXT:   Service doctor comparing the installed unit with the daemon configuration
+ * INPUT:     Installed systemd unit text, resolved database/log/backup paths, linger state
+ * OUTPUT:    Checks explaining sandbox and scope mismatches, as text or JSON
+ * BUSINESS:  A unit whose sandbox hides the database directory starts, then
+ *            fails every write; the doctor names the path and the directive
+ * CHANGE:    Initial service doctor
+ * RISK:      Low - Reads the unit file and configuration only
+ */
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Doctor check outcomes
+const (
+	serviceCheckOK    = "ok"
+	serviceCheckWarn  = "warn"
+	serviceCheckError = "error"
+)
+
+// serviceDoctorCheck is one finding of `service doctor`
+type serviceDoctorCheck struct {
+	Name    string   `json:"name"`
+	Status  string   `json:"status"`
+	Message string   `json:"message"`
+	Hints   []string `json:"hints,omitempty"`
+}
+
+// serviceDoctorReport is the JSON output of `service doctor`
+type serviceDoctorReport struct {
+	Unit        string               `json:"unit"`
+	UserService bool                 `json:"user_service"`
+	Checks      []serviceDoctorCheck `json:"checks"`
+}
+
+// servicePath is a directory the daemon writes at runtime
+type servicePath struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// unitSandbox holds the unit directives that decide where the daemon may write
+type unitSandbox struct {
+	ProtectSystem  string
+	ProtectHome    string
+	ReadWritePaths []string
+}
+
+/**
+ * CONTEXT:   Directories the daemon writes with the current configuration
+ * INPUT:     config.json resolved the same way the daemon resolves it
+ * OUTPUT:    Database, log and backup directories as absolute paths
+ * BUSINESS:  Unit sandbox paths must follow the configuration, not a fixed
+ *            WorkingDir/data guess
+ * CHANGE:    Initial path resolution
+ * RISK:      Low - Returns no paths when the configuration cannot be resolved
+ */
+func resolveServicePaths() []servicePath {
+	appConfig, err := loadConfiguration()
+	if err != nil {
+		return nil
+	}
+	daemonConfig, err := buildDaemonConfig(appConfig)
+	if err != nil {
+		return nil
+	}
+
+	var paths []servicePath
+	add := func(name, dir string) {
+		if dir == "" {
+			return
+		}
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		paths = append(paths, servicePath{Name: name, Path: filepath.Clean(dir)})
+	}
+	add("database", filepath.Dir(daemonConfig.Database.Path))
+	if daemonConfig.Logging.OutputFile != "" {
+		add("logs", filepath.Dir(daemonConfig.Logging.OutputFile))
+	}
+	if daemonConfig.Database.BackupEnabled {
+		add("backups", daemonConfig.Database.BackupPath)
+	}
+	return paths
+}
+
+// serviceWritablePaths returns the working directory and configured daemon
+// directories, dropping any nested inside an earlier entry
+func serviceWritablePaths(workingDir string) []string {
+	candidates := []string{workingDir}
+	for _, path := range resolveServicePaths() {
+		candidates = append(candidates, path.Path)
+	}
+
+	var writable []string
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		covered := false
+		for _, existing := range writable {
+			if pathWithin(candidate, existing) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			writable = append(writable, filepath.Clean(candidate))
+		}
+	}
+	return writable
+}
+
+// pathWithin reports whether path equals dir or lies beneath it
+func pathWithin(path, dir string) bool {
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// isHomePath reports whether ProtectHome applies to path
+func isHomePath(path string) bool {
+	for _, dir := range []string{"/home", "/root", "/run/user"} {
+		if pathWithin(path, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseUnitSandbox reads the [Service] directives relevant to writable paths
+func parseUnitSandbox(unit string) unitSandbox {
+	var sandbox unitSandbox
+	scanner := bufio.NewScanner(strings.NewReader(unit))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || strings.HasPrefix(line, "#") {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "ProtectSystem":
+			sandbox.ProtectSystem = value
+		case "ProtectHome":
+			sandbox.ProtectHome = value
+		case "ReadWritePaths":
+			for _, path := range strings.Fields(value) {
+				// A leading '-' only makes a missing path non-fatal
+				sandbox.ReadWritePaths = append(sandbox.ReadWritePaths, strings.TrimPrefix(path, "-"))
+			}
+		}
+	}
+	return sandbox
+}
+
+/**
+ * CONTEXT:   Sandbox diagnosis for one configured directory
+ * INPUT:     Unit sandbox directives and a directory the daemon writes
+ * OUTPUT:    A check explaining whether and why the directory is writable
+ * BUSINESS:  ProtectHome=read-only with ReadWritePaths=<WorkingDir>/data made
+ *            databases under the home directory read-only
+ * CHANGE:    Initial sandbox check
+ * RISK:      Low - Mirrors systemd's documented semantics; does not run systemd
+ */
+func diagnoseServicePath(sandbox unitSandbox, path servicePath) serviceDoctorCheck {
+	check := serviceDoctorCheck{Name: path.Name, Status: serviceCheckOK}
+	listed := false
+	for _, writable := range sandbox.ReadWritePaths {
+		if pathWithin(path.Path, writable) {
+			listed = true
+			break
+		}
+	}
+	reinstall := "Reinstall the unit so its paths follow the configuration: claude-monitor service uninstall && claude-monitor service install"
+
+	switch {
+	case isHomePath(path.Path) && (sandbox.ProtectHome == "yes" || sandbox.ProtectHome == "tmpfs"):
+		check.Status = serviceCheckError
+		check.Message = fmt.Sprintf("%s is hidden from the service by ProtectHome=%s", path.Path, sandbox.ProtectHome)
+		check.Hints = []string{"Use ProtectHome=read-only with ReadWritePaths, or a user unit", reinstall}
+	case listed:
+		check.Message = fmt.Sprintf("%s is writable (ReadWritePaths)", path.Path)
+	case sandbox.ProtectSystem == "strict":
+		check.Status = serviceCheckError
+		check.Message = fmt.Sprintf("%s is read-only: ProtectSystem=strict and it is not in ReadWritePaths (%s)", path.Path, strings.Join(sandbox.ReadWritePaths, " "))
+		check.Hints = []string{reinstall}
+	case isHomePath(path.Path) && sandbox.ProtectHome == "read-only":
+		check.Status = serviceCheckError
+		check.Message = fmt.Sprintf("%s is read-only: ProtectHome=read-only and it is not in ReadWritePaths (%s)", path.Path, strings.Join(sandbox.ReadWritePaths, " "))
+		check.Hints = []string{reinstall}
+	default:
+		check.Message = fmt.Sprintf("%s is not restricted by the unit sandbox", path.Path)
+	}
+	return check
+}
+
+/**
+ * CONTEXT:   Full service diagnosis
+ * INPUT:     Unit file text and scope, configured paths, linger state ("yes", "no" or "")
+ * OUTPUT:    Checks for every configured directory plus scope and linger advice
+ * BUSINESS:  One command explains why an installed service cannot write its data
+ * CHANGE:    Initial diagnosis
+ * RISK:      Low - Pure function over its inputs
+ */
+func diagnoseService(unit string, userService bool, paths []servicePath, linger string) []serviceDoctorCheck {
+	sandbox := parseUnitSandbox(unit)
+	var checks []serviceDoctorCheck
+
+	scope := serviceDoctorCheck{Name: "scope", Status: serviceCheckOK, Message: "user unit (systemctl --user)"}
+	if !userService {
+		scope.Message = "system unit (systemctl)"
+		for _, path := range paths {
+			if isHomePath(path.Path) {
+				scope.Status = serviceCheckWarn
+				scope.Message = "system unit writing under a home directory"
+				scope.Hints = []string{"A user unit avoids home directory sandboxing: claude-monitor service install --user-service"}
+				break
+			}
+		}
+	}
+	checks = append(checks, scope)
+
+	if userService {
+		switch linger {
+		case "yes":
+			checks = append(checks, serviceDoctorCheck{Name: "linger", Status: serviceCheckOK, Message: "lingering enabled; the service runs without a login session"})
+		case "no":
+			checks = append(checks, serviceDoctorCheck{
+				Name:    "linger",
+				Status:  serviceCheckWarn,
+				Message: "lingering disabled; the service stops when your last session ends",
+				Hints:   []string{"loginctl enable-linger $USER"},
+			})
+		}
+	}
+
+	if len(paths) == 0 {
+		checks = append(checks, serviceDoctorCheck{Name: "config", Status: serviceCheckError, Message: "could not resolve the daemon configuration"})
+	}
+	for _, path := range paths {
+		checks = append(checks, diagnoseServicePath(sandbox, path))
+	}
+	return checks
+}
+
+/**
+ * CONTEXT:   Service doctor command
+ * INPUT:     Installed unit located by scope; -f json for machine output
+ * OUTPUT:    Check list; non-zero exit when any check is an error
+ * BUSINESS:  Scripts and users can verify an install before relying on it
+ * CHANGE:    Initial doctor handler
+ * RISK:      Low - Read-only
+ */
+func runServiceDoctor(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	unitPath, userService, err := locateServiceUnit()
+	if err != nil {
+		return err
+	}
+	unit, err := os.ReadFile(unitPath)
+	if err != nil {
+		return fmt.Errorf("failed to read unit file: %w", err)
+	}
+
+	report := serviceDoctorReport{
+		Unit:        unitPath,
+		UserService: userService,
+		Checks:      diagnoseService(string(unit), userService, resolveServicePaths(), lingerState()),
+	}
+
+	if outputFormat == "json" {
+		if err := printJSON(report); err != nil {
+			return err
+		}
+	} else {
+		printServiceDoctorReport(report)
+	}
+
+	problems := 0
+	for _, check := range report.Checks {
+		if check.Status == serviceCheckError {
+			problems++
+		}
+	}
+	if problems > 0 {
+		return fmt.Errorf("%d problem(s) found in %s", problems, unitPath)
+	}
+	return nil
+}
+
+// printServiceDoctorReport prints each check with its hints
+func printServiceDoctorReport(report serviceDoctorReport) {
+	headerColor.Println("🩺 Service doctor")
+	dimColor.Printf("   %s\n", report.Unit)
+	for _, check := range report.Checks {
+		switch check.Status {
+		case serviceCheckError:
+			errorColor.Printf("   ❌ %-10s %s\n", check.Name, check.Message)
+		case serviceCheckWarn:
+			warningColor.Printf("   ⚠️  %-10s %s\n", check.Name, check.Message)
+		default:
+			successColor.Printf("   ✅ %-10s %s\n", check.Name, check.Message)
+		}
+		for _, hint := range check.Hints {
+			infoColor.Printf("        💡 %s\n", hint)
+		}
+	}
+}
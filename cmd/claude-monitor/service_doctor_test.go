@@ -0,0 +1,91 @@
+/**
+ * CONTEXT:   Test suite for the service doctor
+ * INPUT:     Unit file texts with different sandbox settings and configured paths
+ * OUTPUT:    Validation of sandbox, scope and linger diagnoses
+ * BUSINESS:  The doctor must explain why a unit cannot write the configured database
+ * CHANGE:    Initial service doctor tests
+ * RISK:      Low - Pure functions only
+ */
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// legacySystemUnit is what older installs wrote: the sandbox only allowed WorkingDir/data
+const legacySystemUnit = `[Service]
+Type=simple
+ExecStart=/usr/local/bin/claude-monitor daemon
+# Security settings
+ProtectSystem=strict
+ProtectHome=read-only
+ReadWritePaths=/home/alice/.claude-monitor/data
+`
+
+func checkByName(t *testing.T, checks []serviceDoctorCheck, name string) serviceDoctorCheck {
+	t.Helper()
+	for _, check := range checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	require.Failf(t, "missing check", "no %q check in %+v", name, checks)
+	return serviceDoctorCheck{}
+}
+
+func TestDiagnoseServiceFindsSandboxMismatch(t *testing.T) {
+	paths := []servicePath{
+		{Name: "database", Path: "/home/alice/.claude"},
+		{Name: "logs", Path: "/home/alice/.claude-monitor/data/logs"},
+	}
+	checks := diagnoseService(legacySystemUnit, false, paths, "")
+
+	database := checkByName(t, checks, "database")
+	assert.Equal(t, serviceCheckError, database.Status)
+	assert.Contains(t, database.Message, "ProtectSystem=strict")
+	assert.Contains(t, database.Message, "/home/alice/.claude-monitor/data")
+	assert.NotEmpty(t, database.Hints)
+
+	assert.Equal(t, serviceCheckOK, checkByName(t, checks, "logs").Status, "nested in a ReadWritePaths entry")
+	assert.Equal(t, serviceCheckWarn, checkByName(t, checks, "scope").Status, "system unit writing under home")
+}
+
+func TestDiagnoseServiceProtectHomeVariants(t *testing.T) {
+	path := servicePath{Name: "database", Path: "/home/alice/.claude"}
+
+	check := diagnoseServicePath(unitSandbox{ProtectHome: "yes", ReadWritePaths: []string{"/home/alice/.claude"}}, path)
+	assert.Equal(t, serviceCheckError, check.Status, "ReadWritePaths cannot expose a hidden home")
+
+	check = diagnoseServicePath(unitSandbox{ProtectHome: "read-only"}, path)
+	assert.Equal(t, serviceCheckError, check.Status)
+
+	check = diagnoseServicePath(unitSandbox{ProtectHome: "read-only"}, servicePath{Name: "database", Path: "/var/lib/claude-monitor"})
+	assert.Equal(t, serviceCheckOK, check.Status, "ProtectHome does not apply outside home directories")
+
+	check = diagnoseServicePath(parseUnitSandbox("ProtectSystem=strict\nReadWritePaths=-/home/alice/.claude /tmp\n"), path)
+	assert.Equal(t, serviceCheckOK, check.Status)
+}
+
+func TestDiagnoseUserServiceLinger(t *testing.T) {
+	paths := []servicePath{{Name: "database", Path: "/home/alice/.claude"}}
+
+	checks := diagnoseService("[Service]\nType=notify\n", true, paths, "no")
+	linger := checkByName(t, checks, "linger")
+	assert.Equal(t, serviceCheckWarn, linger.Status)
+	assert.Equal(t, []string{"loginctl enable-linger $USER"}, linger.Hints)
+	assert.Equal(t, serviceCheckOK, checkByName(t, checks, "database").Status)
+
+	checks = diagnoseService("[Service]\n", true, paths, "yes")
+	assert.Equal(t, serviceCheckOK, checkByName(t, checks, "linger").Status)
+}
+
+func TestPathWithin(t *testing.T) {
+	assert.True(t, pathWithin("/home/alice/.claude", "/home/alice/.claude"))
+	assert.True(t, pathWithin("/home/alice/.claude/data", "/home/alice"))
+	assert.False(t, pathWithin("/home/alice/.claude-monitor", "/home/alice/.claude"))
+	assert.False(t, pathWithin("/home", "/home/alice"))
+}
@@ -15,6 +15,7 @@ import (
 	"bufio"
 	"fmt"
 	"io/fs"
+	"net"
 	"os"
 	"os/exec"
 	"os/user"
@@ -41,20 +42,31 @@ type LinuxServiceManager struct {
 	fileSystem    FileSystemProvider
 }
 
-// Constructor with dependency injection
+// Constructor with dependency injection; without an explicit --system or
+// --user-service flag the scope of an already installed unit wins
 func NewLinuxServiceManagerWithDeps(cmdExec CommandExecutor, fs FileSystemProvider) (*LinuxServiceManager, error) {
 	serviceName := "claude-monitor"
 	isUserService := serviceUserLevel
 	
-	var unitFilePath string
-	if isUserService {
-		homeDir, err := fs.GetUserHomeDir()
-		if err != nil {
-			return nil, fmt.Errorf("cannot determine home directory: %w", err)
+	systemUnitPath := filepath.Join("/etc/systemd/system", serviceName+".service")
+	userUnitPath := ""
+	if homeDir, err := fs.GetUserHomeDir(); err == nil {
+		userUnitPath = filepath.Join(homeDir, ".config/systemd/user", serviceName+".service")
+	} else if isUserService {
+		return nil, fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	
+	if !serviceScopeExplicit {
+		if isUserService && !fs.FileExists(userUnitPath) && fs.FileExists(systemUnitPath) {
+			isUserService = false
+		} else if !isUserService && userUnitPath != "" && !fs.FileExists(systemUnitPath) && fs.FileExists(userUnitPath) {
+			isUserService = true
 		}
-		unitFilePath = filepath.Join(homeDir, ".config/systemd/user", serviceName+".service")
-	} else {
-		unitFilePath = filepath.Join("/etc/systemd/system", serviceName+".service")
+	}
+	
+	unitFilePath := systemUnitPath
+	if isUserService {
+		unitFilePath = userUnitPath
 	}
 	
 	return &LinuxServiceManager{
@@ -107,7 +119,7 @@ func newLinuxServiceManagerLegacy() (*LinuxServiceManager, error) {
  * INPUT:     Service configuration with Linux-specific systemd settings
  * OUTPUT:    Installed systemd service with proper unit file and permissions
  * BUSINESS:  Service installation enables professional Linux deployment
- * CHANGE:    Initial service installation with systemd unit generation
+ * CHANGE:    Write and enable the .socket unit when socket activation is requested
  * RISK:      High - systemd unit file creation requires proper syntax and permissions
  */
 func (l *LinuxServiceManager) Install(config ServiceConfig) error {
@@ -127,17 +139,38 @@ func (l *LinuxServiceManager) Install(config ServiceConfig) error {
 		return fmt.Errorf("failed to write unit file: %w", err)
 	}
 	
+	// Socket unit holding the listen address for socket activation
+	socketActivated := config.LinuxService.SocketActivation
+	if socketActivated {
+		socketContent, err := l.generateSystemdSocket(config)
+		if err != nil {
+			l.fileSystem.RemoveFile(l.unitFilePath)
+			return fmt.Errorf("failed to generate systemd socket: %w", err)
+		}
+		if err := l.fileSystem.WriteFile(l.socketFilePath(), []byte(socketContent), 0644); err != nil {
+			l.fileSystem.RemoveFile(l.unitFilePath)
+			return fmt.Errorf("failed to write socket unit file: %w", err)
+		}
+	}
+	
 	// Reload systemd daemon
 	if err := l.systemctlCommand("daemon-reload"); err != nil {
 		// Clean up on failure using injected file system
 		l.fileSystem.RemoveFile(l.unitFilePath)
+		if socketActivated {
+			l.fileSystem.RemoveFile(l.socketFilePath())
+		}
 		return fmt.Errorf("failed to reload systemd daemon: %w", err)
 	}
 	
-	// Enable service if auto-start configured
+	// Enable service (or its socket, which starts the service on demand) if auto-start configured
 	if config.StartMode == StartModeAuto {
-		if err := l.systemctlCommand("enable", config.Name); err != nil {
-			return fmt.Errorf("failed to enable service: %w", err)
+		unit := config.Name
+		if socketActivated {
+			unit = config.Name + ".socket"
+		}
+		if err := l.systemctlCommand("enable", unit); err != nil {
+			return fmt.Errorf("failed to enable %s: %w", unit, err)
 		}
 	}
 	
@@ -165,6 +198,19 @@ func (l *LinuxServiceManager) Uninstall() error {
 		return fmt.Errorf("service is not installed")
 	}
 	
+	// Stop the socket first so a hook cannot re-activate the service
+	socketInstalled := l.fileSystem.FileExists(l.socketFilePath())
+	if socketInstalled {
+		socketUnit := l.serviceName + ".socket"
+		l.systemctlCommand("stop", socketUnit)
+		if err := l.systemctlCommand("disable", socketUnit); err != nil {
+			fmt.Printf("Warning: failed to disable socket: %v\n", err)
+		}
+		if err := l.fileSystem.RemoveFile(l.socketFilePath()); err != nil {
+			return fmt.Errorf("failed to remove socket unit file: %w", err)
+		}
+	}
+	
 	// Stop service if running
 	if l.IsRunning() {
 		if err := l.systemctlCommand("stop", l.serviceName); err != nil {
@@ -314,7 +360,7 @@ func (l *LinuxServiceManager) IsRunning() bool {
  * INPUT:     journalctl commands and service log filtering
  * OUTPUT:    Structured log entries from systemd journal
  * BUSINESS:  Log retrieval enables troubleshooting and monitoring
- * CHANGE:    Initial log retrieval with journalctl integration
+ * CHANGE:    Parse journal JSON into structured entries
  * RISK:      Medium - Journal parsing requires proper command handling
  */
 func (l *LinuxServiceManager) GetLogs(lines int) ([]LogEntry, error) {
@@ -355,7 +401,8 @@ func (l *LinuxServiceManager) GetLogs(lines int) ([]LogEntry, error) {
  * INPUT:     Service configuration with Linux-specific settings
  * OUTPUT:    Complete systemd unit file with proper syntax and options
  * BUSINESS:  Unit file generation enables reliable systemd service operation
- * CHANGE:    Initial unit file generation with security and reliability options
+ * CHANGE:    ReadWritePaths follow the resolved database, log and backup
+ *            directories instead of WorkingDir/data
  * RISK:      High - Unit file syntax errors can prevent service operation
  */
 func (l *LinuxServiceManager) generateSystemdUnit(config ServiceConfig) (string, error) {
@@ -373,6 +420,11 @@ func (l *LinuxServiceManager) generateSystemdUnit(config ServiceConfig) (string,
 		unit.WriteString("After=network.target\n")
 	}
 	
+	if config.LinuxService.SocketActivation {
+		unit.WriteString(fmt.Sprintf("Requires=%s.socket\n", config.Name))
+		unit.WriteString(fmt.Sprintf("After=%s.socket\n", config.Name))
+	}
+	
 	if len(config.LinuxService.Before) > 0 {
 		unit.WriteString(fmt.Sprintf("Before=%s\n", strings.Join(config.LinuxService.Before, " ")))
 	}
@@ -387,7 +439,12 @@ func (l *LinuxServiceManager) generateSystemdUnit(config ServiceConfig) (string,
 	
 	// [Service] section
 	unit.WriteString("[Service]\n")
-	unit.WriteString("Type=simple\n")
+	// The daemon reports READY=1 once its API is listening and pings the watchdog
+	unit.WriteString("Type=notify\n")
+	unit.WriteString("NotifyAccess=main\n")
+	if config.LinuxService.WatchdogSec > 0 {
+		unit.WriteString(fmt.Sprintf("WatchdogSec=%d\n", config.LinuxService.WatchdogSec))
+	}
 	
 	// User and group
 	if config.User != "" && !l.isUserService {
@@ -435,14 +492,15 @@ func (l *LinuxServiceManager) generateSystemdUnit(config ServiceConfig) (string,
 		unit.WriteString("ProtectSystem=strict\n")
 		unit.WriteString("ProtectHome=read-only\n")
 		
-		// Read/write paths
-		if config.WorkingDir != "" {
-			unit.WriteString(fmt.Sprintf("ReadWritePaths=%s\n", config.WorkingDir))
+		// Read/write paths resolved from the daemon config (database, logs, backups);
+		// '-' keeps a directory that does not exist yet from failing the start
+		writable := config.LinuxService.ReadWritePaths
+		if len(writable) == 0 && config.WorkingDir != "" {
+			writable = []string{config.WorkingDir}
+		}
+		for _, path := range writable {
+			unit.WriteString(fmt.Sprintf("ReadWritePaths=-%s\n", path))
 		}
-		
-		// Add database directory to read-write paths
-		dbPath := filepath.Join(config.WorkingDir, "data")
-		unit.WriteString(fmt.Sprintf("ReadWritePaths=%s\n", dbPath))
 		
 		// Capabilities if specified
 		if len(config.LinuxService.Capabilities) > 0 {
@@ -472,9 +530,70 @@ func (l *LinuxServiceManager) generateSystemdUnit(config ServiceConfig) (string,
 		}
 	}
 	
+	if config.LinuxService.SocketActivation {
+		unit.WriteString(fmt.Sprintf("Also=%s.socket\n", config.Name))
+	}
+	
 	return unit.String(), nil
 }
 
+/**
+ * CONTEXT:   systemd socket unit generation for socket activation
+ * INPUT:     Service configuration with the daemon listen address
+ * OUTPUT:    .socket unit listening on the daemon address
+ * BUSINESS:  systemd holds the port and starts the daemon on the first hook
+ *            connection, so an idle machine runs no daemon at all
+ * CHANGE:    Initial socket unit generation
+ * RISK:      Medium - The address must match the daemon config or hooks miss it
+ */
+func (l *LinuxServiceManager) generateSystemdSocket(config ServiceConfig) (string, error) {
+	listen, err := socketListenStream(config.LinuxService.ListenAddress)
+	if err != nil {
+		return "", err
+	}
+	
+	var socket strings.Builder
+	socket.WriteString("[Unit]\n")
+	socket.WriteString(fmt.Sprintf("Description=%s socket\n", config.Description))
+	socket.WriteString("\n")
+	socket.WriteString("[Socket]\n")
+	socket.WriteString(fmt.Sprintf("ListenStream=%s\n", listen))
+	socket.WriteString(fmt.Sprintf("Service=%s.service\n", config.Name))
+	socket.WriteString("NoDelay=true\n")
+	socket.WriteString("\n")
+	socket.WriteString("[Install]\n")
+	socket.WriteString("WantedBy=sockets.target\n")
+	
+	return socket.String(), nil
+}
+
+// socketListenStream converts host:port into a ListenStream value; systemd
+// does not resolve host names, so localhost maps to the loopback address
+func socketListenStream(addr string) (string, error) {
+	if addr == "" {
+		return "", fmt.Errorf("socket activation requires a listen address")
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid listen address %q: %w", addr, err)
+	}
+	switch host {
+	case "", "0.0.0.0", "::":
+		return port, nil
+	case "localhost":
+		host = "127.0.0.1"
+	}
+	if net.ParseIP(host) == nil {
+		return "", fmt.Errorf("listen address %q must use an IP address for socket activation", addr)
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// socketFilePath places the .socket unit next to the service unit
+func (l *LinuxServiceManager) socketFilePath() string {
+	return strings.TrimSuffix(l.unitFilePath, ".service") + ".socket"
+}
+
 /**
  * CONTEXT:   Execute systemctl command with injected executor
  * INPUT:     systemctl command arguments
@@ -648,26 +767,17 @@ func (l *LinuxServiceManager) getProcessMetrics(pid int) (*LinuxProcessMetrics,
 	return metrics, nil
 }
 
+// parseJournalOutput parses `journalctl --output=json` lines, including the
+// daemon's own JSON records carried in MESSAGE
 func (l *LinuxServiceManager) parseJournalOutput(output string) ([]LogEntry, error) {
 	var logs []LogEntry
 	
 	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 64*1024), maxLogLineBytes)
 	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
+		if entry, ok := parseJournalLine(scanner.Text(), l.serviceName); ok {
+			logs = append(logs, entry)
 		}
-		
-		// Simple journal parsing - real implementation would parse JSON
-		// For now, create basic log entries
-		entry := LogEntry{
-			Timestamp: time.Now(), // Would parse from JSON
-			Level:     "info",     // Would parse from JSON
-			Message:   line,
-			Source:    l.serviceName,
-		}
-		
-		logs = append(logs, entry)
 	}
 	
 	return logs, scanner.Err()
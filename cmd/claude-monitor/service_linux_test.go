@@ -0,0 +1,145 @@
+//go:build linux
+
+/**
+ * CONTEXT:   Test suite for systemd unit generation and installation
+ * INPUT:     Service configurations installed through mocked systemctl and files
+ * OUTPUT:    Validation of the notify unit, watchdog and optional socket unit
+ * BUSINESS:  systemd must wait for READY, restart a hung daemon and, when asked,
+ *            start it on the first hook connection
+ * CHANGE:    Added scope detection and config-driven ReadWritePaths
+ * RISK:      Low - Mocked command executor and file system only
+ */
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useServiceScope pins the install scope for one test
+func useServiceScope(t *testing.T, userLevel, explicit bool) {
+	savedLevel, savedExplicit := serviceUserLevel, serviceScopeExplicit
+	serviceUserLevel, serviceScopeExplicit = userLevel, explicit
+	t.Cleanup(func() { serviceUserLevel, serviceScopeExplicit = savedLevel, savedExplicit })
+}
+
+func testLinuxServiceConfig() ServiceConfig {
+	return ServiceConfig{
+		Name:             "claude-monitor",
+		Description:      "Claude Monitor",
+		ExecutablePath:   "/usr/local/bin/claude-monitor",
+		Arguments:        []string{"daemon"},
+		StartMode:        StartModeAuto,
+		RestartOnFailure: true,
+		LinuxService:     LinuxServiceConfig{WatchdogSec: defaultWatchdogSec},
+	}
+}
+
+func TestSystemdUnitUsesNotifyAndWatchdog(t *testing.T) {
+	manager, err := NewLinuxServiceManagerWithDeps(NewMockCommandExecutor(), NewMockFileSystemProvider())
+	require.NoError(t, err)
+
+	unit, err := manager.generateSystemdUnit(testLinuxServiceConfig())
+	require.NoError(t, err)
+	assert.Contains(t, unit, "Type=notify\nNotifyAccess=main\nWatchdogSec=120\n")
+	assert.NotContains(t, unit, "Type=simple")
+	assert.NotContains(t, unit, ".socket")
+
+	config := testLinuxServiceConfig()
+	config.LinuxService.WatchdogSec = 0
+	unit, err = manager.generateSystemdUnit(config)
+	require.NoError(t, err)
+	assert.NotContains(t, unit, "WatchdogSec")
+}
+
+func TestInstallWithSocketActivation(t *testing.T) {
+	useServiceScope(t, false, true)
+	exec := NewMockCommandExecutor()
+	files := NewMockFileSystemProvider()
+	manager, err := NewLinuxServiceManagerWithDeps(exec, files)
+	require.NoError(t, err)
+
+	config := testLinuxServiceConfig()
+	config.LinuxService.SocketActivation = true
+	config.LinuxService.ListenAddress = "localhost:9193"
+	require.NoError(t, manager.Install(config))
+
+	socketPath := "/etc/systemd/system/claude-monitor.socket"
+	require.Contains(t, files.Files, socketPath)
+	socket := string(files.Files[socketPath])
+	assert.Contains(t, socket, "ListenStream=127.0.0.1:9193\n")
+	assert.Contains(t, socket, "Service=claude-monitor.service\n")
+	assert.Contains(t, socket, "WantedBy=sockets.target\n")
+
+	unit := string(files.Files[manager.unitFilePath])
+	assert.Contains(t, unit, "Requires=claude-monitor.socket\n")
+	assert.Contains(t, unit, "Also=claude-monitor.socket\n")
+
+	require.NoError(t, manager.Uninstall())
+	assert.NotContains(t, files.Files, socketPath)
+	assert.NotContains(t, files.Files, manager.unitFilePath)
+}
+
+func TestSocketListenStream(t *testing.T) {
+	for addr, want := range map[string]string{
+		"localhost:9193": "127.0.0.1:9193",
+		"127.0.0.1:8080": "127.0.0.1:8080",
+		"[::1]:9193":     "[::1]:9193",
+		"0.0.0.0:9193":   "9193",
+		":9193":          "9193",
+	} {
+		got, err := socketListenStream(addr)
+		require.NoError(t, err, addr)
+		assert.Equal(t, want, got, addr)
+	}
+
+	for _, addr := range []string{"", "9193", "monitor.local:9193"} {
+		_, err := socketListenStream(addr)
+		assert.Error(t, err, addr)
+	}
+}
+
+func TestServiceScopeDefaultsToUserAndDetectsInstalledUnit(t *testing.T) {
+	useServiceScope(t, true, false)
+	files := NewMockFileSystemProvider()
+
+	manager, err := NewLinuxServiceManagerWithDeps(NewMockCommandExecutor(), files)
+	require.NoError(t, err)
+	assert.True(t, manager.isUserService)
+	assert.Equal(t, "/mock/home/user/.config/systemd/user/claude-monitor.service", manager.unitFilePath)
+
+	// An existing system unit is managed as-is unless a scope flag was given
+	files.Files["/etc/systemd/system/claude-monitor.service"] = []byte("[Unit]\n")
+	manager, err = NewLinuxServiceManagerWithDeps(NewMockCommandExecutor(), files)
+	require.NoError(t, err)
+	assert.False(t, manager.isUserService)
+	assert.Equal(t, []string{"systemctl", "stop"}, manager.buildSystemctlArgs("stop"))
+
+	useServiceScope(t, true, true)
+	manager, err = NewLinuxServiceManagerWithDeps(NewMockCommandExecutor(), files)
+	require.NoError(t, err)
+	assert.True(t, manager.isUserService)
+}
+
+func TestSystemUnitReadWritePathsFollowConfig(t *testing.T) {
+	useServiceScope(t, false, true)
+	manager, err := NewLinuxServiceManagerWithDeps(NewMockCommandExecutor(), NewMockFileSystemProvider())
+	require.NoError(t, err)
+
+	config := testLinuxServiceConfig()
+	config.WorkingDir = "/home/alice/.claude-monitor"
+	config.LinuxService.ReadWritePaths = []string{"/home/alice/.claude-monitor", "/home/alice/.claude/data"}
+	unit, err := manager.generateSystemdUnit(config)
+	require.NoError(t, err)
+	assert.Contains(t, unit, "ProtectHome=read-only\n")
+	assert.Contains(t, unit, "ReadWritePaths=-/home/alice/.claude-monitor\nReadWritePaths=-/home/alice/.claude/data\n")
+	assert.NotContains(t, unit, ".claude-monitor/data")
+
+	checks := diagnoseService(unit, false, []servicePath{{Name: "database", Path: "/home/alice/.claude/data"}}, "")
+	for _, check := range checks {
+		assert.NotEqual(t, serviceCheckError, check.Status, check.Message)
+	}
+}
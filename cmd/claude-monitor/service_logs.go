@@ -0,0 +1,476 @@
+/**
+ * CONTEXT:   Daemon log retrieval from journald or the daemon's own log files
+ * INPUT:     Journal JSON output or rotated JSON/text log files, plus filters
+ * OUTPUT:    Parsed LogEntry values, filtered by time, level, text and request ID
+ * BUSINESS:  User-level installs and containers have no journal; the daemon's
+ *            rotated log files are the only record of what it did
+ * CHANGE:    Initial file-based logs with filtering and rotation-aware follow
+ * RISK:      Low - Read-only; follow polls the file and tolerates rotation
+ */
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/claude-monitor/system/internal/logging"
+)
+
+// Log reading limits
+const (
+	followPollInterval = 500 * time.Millisecond
+	maxLogLineBytes    = 1024 * 1024
+	// journalFilterWindow is how many journal lines are scanned when filtering
+	journalFilterWindow = 5000
+)
+
+// Daemon log sources reported by `service logs`
+const (
+	logSourceJournal = "journal"
+	logSourceFile    = "file"
+)
+
+// logLevelRank orders level names so --level keeps that level and above
+var logLevelRank = map[string]int{
+	"debug":   0,
+	"info":    1,
+	"warn":    2,
+	"warning": 2,
+	"error":   3,
+}
+
+/**
+ * CONTEXT:   Filters for `service logs`
+ * INPUT:     --since, --level, --grep and --request-id flag values
+ * OUTPUT:    Predicate applied to every parsed entry
+ * BUSINESS:  "What happened to this hook call" is answered by one request ID
+ * CHANGE:    Initial log filters
+ * RISK:      Low - Pure matching
+ */
+type logFilter struct {
+	since     time.Time
+	minLevel  int
+	grep      *regexp.Regexp
+	requestID string
+}
+
+// newLogFilter validates flag values; since accepts a duration ("2h") or a time
+func newLogFilter(since, level, grep, requestID string, now time.Time) (*logFilter, error) {
+	filter := &logFilter{requestID: requestID}
+
+	if since != "" {
+		if duration, err := time.ParseDuration(since); err == nil {
+			filter.since = now.Add(-duration)
+		} else if t, err := parseEntryTime(since, now); err == nil {
+			filter.since = t
+		} else {
+			return nil, fmt.Errorf("invalid --since %q - use a duration like 2h or a time like \"2006-01-02 15:04\"", since)
+		}
+	}
+	if level != "" {
+		rank, ok := logLevelRank[strings.ToLower(level)]
+		if !ok {
+			return nil, fmt.Errorf("invalid --level %q - use debug, info, warn or error", level)
+		}
+		filter.minLevel = rank
+	}
+	if grep != "" {
+		pattern, err := regexp.Compile(grep)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --grep pattern: %w", err)
+		}
+		filter.grep = pattern
+	}
+	return filter, nil
+}
+
+// active reports whether any filter is set
+func (f *logFilter) active() bool {
+	return !f.since.IsZero() || f.minLevel > 0 || f.grep != nil || f.requestID != ""
+}
+
+// matches reports whether entry passes every filter
+func (f *logFilter) matches(entry LogEntry) bool {
+	if !f.since.IsZero() && !entry.Timestamp.IsZero() && entry.Timestamp.Before(f.since) {
+		return false
+	}
+	if f.minLevel > 0 {
+		rank, ok := logLevelRank[strings.ToLower(entry.Level)]
+		if ok && rank < f.minLevel {
+			return false
+		}
+	}
+	if f.requestID != "" && entry.Fields[logging.KeyRequestID] != f.requestID {
+		return false
+	}
+	if f.grep != nil && !f.grep.MatchString(entry.Message) && !f.grep.MatchString(formatLogFields(entry.Fields)) {
+		return false
+	}
+	return true
+}
+
+/**
+ * CONTEXT:   Parse one daemon log line
+ * INPUT:     slog JSON record, slog text record or unstructured text
+ * OUTPUT:    LogEntry with time, level, message and remaining attributes as fields
+ * BUSINESS:  Logs written before the JSON format (or with format "text") stay readable
+ * CHANGE:    Initial parser
+ * RISK:      Low - Unparseable lines are returned as plain messages
+ */
+func parseDaemonLogLine(line, source string) LogEntry {
+	line = strings.TrimSpace(line)
+	entry := LogEntry{Level: "info", Message: line, Source: source}
+
+	var attrs map[string]interface{}
+	if strings.HasPrefix(line, "{") {
+		decoder := json.NewDecoder(strings.NewReader(line))
+		decoder.UseNumber()
+		if decoder.Decode(&attrs) != nil {
+			return entry
+		}
+	} else if strings.HasPrefix(line, "time=") {
+		attrs = parseTextRecord(line)
+	} else {
+		return entry
+	}
+
+	fields := make(map[string]string, len(attrs))
+	for key, value := range attrs {
+		switch key {
+		case "time":
+			if text, ok := value.(string); ok {
+				if t, err := time.Parse(time.RFC3339Nano, text); err == nil {
+					entry.Timestamp = t
+				}
+			}
+		case "level":
+			entry.Level = strings.ToLower(fmt.Sprint(value))
+		case "msg":
+			entry.Message = fmt.Sprint(value)
+		default:
+			if nested, ok := value.(map[string]interface{}); ok {
+				data, _ := json.Marshal(nested)
+				fields[key] = string(data)
+			} else {
+				fields[key] = fmt.Sprint(value)
+			}
+		}
+	}
+	if len(fields) > 0 {
+		entry.Fields = fields
+	}
+	return entry
+}
+
+// parseTextRecord splits a slog text record into key/value pairs
+func parseTextRecord(line string) map[string]interface{} {
+	attrs := make(map[string]interface{})
+	for rest := line; rest != ""; {
+		rest = strings.TrimLeft(rest, " ")
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			break
+		}
+		key := rest[:eq]
+		rest = rest[eq+1:]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				value, rest = rest, ""
+			} else {
+				value, _ = strconv.Unquote(quoted)
+				rest = rest[len(quoted):]
+			}
+		} else if space := strings.IndexByte(rest, ' '); space >= 0 {
+			value, rest = rest[:space], rest[space:]
+		} else {
+			value, rest = rest, ""
+		}
+		attrs[key] = value
+	}
+	return attrs
+}
+
+/**
+ * CONTEXT:   Parse one line of `journalctl --output=json`
+ * INPUT:     Journal JSON object with MESSAGE, PRIORITY and __REALTIME_TIMESTAMP
+ * OUTPUT:    LogEntry; a daemon JSON record inside MESSAGE is parsed as well
+ * BUSINESS:  The daemon writes JSON to stdout, so journal and file entries match
+ * CHANGE:    Initial journal parser replacing raw line output
+ * RISK:      Low - Non-JSON lines are returned as plain messages
+ */
+func parseJournalLine(line, source string) (LogEntry, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return LogEntry{}, false
+	}
+
+	var record map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &record); err != nil {
+		return LogEntry{Level: "info", Message: line, Source: source}, true
+	}
+
+	message, _ := record["MESSAGE"].(string)
+	entry := parseDaemonLogLine(message, source)
+	// Lines that are not daemon records take time and level from the journal
+	if entry.Timestamp.IsZero() {
+		if micros, err := strconv.ParseInt(fmt.Sprint(record["__REALTIME_TIMESTAMP"]), 10, 64); err == nil {
+			entry.Timestamp = time.UnixMicro(micros)
+		}
+		if priority, err := strconv.Atoi(fmt.Sprint(record["PRIORITY"])); err == nil {
+			entry.Level = journalPriorityLevel(priority)
+		}
+	}
+	return entry, true
+}
+
+// journalPriorityLevel maps syslog priorities to log level names
+func journalPriorityLevel(priority int) string {
+	switch {
+	case priority <= 3:
+		return "error"
+	case priority == 4:
+		return "warn"
+	case priority == 7:
+		return "debug"
+	default:
+		return "info"
+	}
+}
+
+/**
+ * CONTEXT:   Read the daemon log file and its rotated backups
+ * INPUT:     Live log path, maximum entries (0 for all) and filter
+ * OUTPUT:    Matching entries, oldest first, limited to the last lines entries
+ * BUSINESS:  A query like --since 2d spans several rotated files
+ * CHANGE:    Initial rotated file reader
+ * RISK:      Low - Streams each file; backups older than --since are skipped
+ */
+func readLogFiles(path string, lines int, filter *logFilter) ([]LogEntry, error) {
+	backups, err := logging.BackupFiles(path)
+	if err != nil {
+		return nil, err
+	}
+	files := append(backups, path)
+
+	var entries []LogEntry
+	for _, name := range files {
+		info, err := os.Stat(name)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read log file: %w", err)
+		}
+		// A backup's last write is its newest entry
+		if name != path && !filter.since.IsZero() && info.ModTime().Before(filter.since) {
+			continue
+		}
+
+		err = scanLogFile(name, func(entry LogEntry) {
+			if !filter.matches(entry) {
+				return
+			}
+			entries = append(entries, entry)
+			if lines > 0 && len(entries) > 2*lines {
+				entries = append(entries[:0], entries[len(entries)-lines:]...)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if lines > 0 && len(entries) > lines {
+		entries = entries[len(entries)-lines:]
+	}
+	return entries, nil
+}
+
+// scanLogFile parses every line of one log file
+func scanLogFile(name string, emit func(LogEntry)) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxLogLineBytes)
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		emit(parseDaemonLogLine(scanner.Text(), logSourceFile))
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return nil
+}
+
+/**
+ * CONTEXT:   Follow the daemon log file across rotation
+ * INPUT:     Live log path, filter and a callback for new entries
+ * OUTPUT:    Entries appended after the call, until ctx is cancelled
+ * BUSINESS:  `service logs --follow` must keep working when the daemon rotates
+ * CHANGE:    Initial polling follower
+ * RISK:      Low - When the path points to a new file (rotation) or shrinks
+ *            (truncation), the old file is drained and the new one read from
+ *            the start
+ */
+func followLogFile(ctx context.Context, path string, filter *logFilter, emit func(LogEntry)) error {
+	ticker := time.NewTicker(followPollInterval)
+	defer ticker.Stop()
+
+	var (
+		file    *os.File
+		reader  *bufio.Reader
+		offset  int64
+		partial string
+	)
+	defer func() {
+		if file != nil {
+			file.Close()
+		}
+	}()
+
+	open := func(fromEnd bool) error {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		offset = 0
+		if fromEnd {
+			if offset, err = f.Seek(0, io.SeekEnd); err != nil {
+				f.Close()
+				return err
+			}
+		}
+		file, reader, partial = f, bufio.NewReader(f), ""
+		return nil
+	}
+
+	drain := func() {
+		for {
+			line, err := reader.ReadString('\n')
+			offset += int64(len(line))
+			if err != nil {
+				partial += line
+				return
+			}
+			line, partial = partial+line, ""
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			if entry := parseDaemonLogLine(line, logSourceFile); filter.matches(entry) {
+				emit(entry)
+			}
+		}
+	}
+
+	if err := open(true); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	for {
+		if file == nil {
+			// Wait for the daemon to create the file
+			if err := open(false); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to open log file: %w", err)
+			}
+		}
+		if file != nil {
+			drain()
+
+			current, statErr := os.Stat(path)
+			opened, _ := file.Stat()
+			rotated := statErr == nil && opened != nil && !os.SameFile(current, opened)
+			truncated := statErr == nil && !rotated && current.Size() < offset
+			if rotated || truncated || os.IsNotExist(statErr) {
+				drain()
+				file.Close()
+				file = nil
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// journaldAvailable reports whether journald is running and queryable
+func journaldAvailable() bool {
+	if _, err := exec.LookPath("journalctl"); err != nil {
+		return false
+	}
+	info, err := os.Stat("/run/systemd/journal")
+	return err == nil && info.IsDir()
+}
+
+// followJournal streams new journal entries for the service until ctx ends
+func followJournal(ctx context.Context, unit string, userService bool, filter *logFilter, emit func(LogEntry)) error {
+	args := []string{"--unit=" + unit, "--follow", "--lines=0", "--output=json", "--no-pager"}
+	if userService {
+		args = append(args, "--user")
+	}
+	cmd := exec.CommandContext(ctx, "journalctl", args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to follow journal: %w", err)
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), maxLogLineBytes)
+	for scanner.Scan() {
+		if entry, ok := parseJournalLine(scanner.Text(), unit); ok && filter.matches(entry) {
+			emit(entry)
+		}
+	}
+	if err := cmd.Wait(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("journalctl exited: %w", err)
+	}
+	return nil
+}
+
+// formatLogFields renders fields as sorted key=value pairs
+func formatLogFields(fields map[string]string) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := fields[key]
+		if strings.ContainsAny(value, " \"=") {
+			value = strconv.Quote(value)
+		}
+		parts = append(parts, key+"="+value)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,174 @@
+/**
+ * CONTEXT:   Test suite for file-based service logs
+ * INPUT:     Daemon log lines, journal JSON and rotated log files in temp dirs
+ * OUTPUT:    Validation of parsing, filters, rotated reads and follow across rotation
+ * BUSINESS:  `service logs` must work without journald and survive rotation
+ * CHANGE:    Initial service logs tests
+ * RISK:      Low - Temp files only
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/claude-monitor/system/internal/logging"
+)
+
+func TestParseDaemonAndJournalLines(t *testing.T) {
+	entry := parseDaemonLogLine(`{"time":"2026-10-18T15:05:19.29Z","level":"INFO","msg":"Activity recorded","tool":"Edit","count":3,"request_id":"abc"}`, logSourceFile)
+	assert.Equal(t, "info", entry.Level)
+	assert.Equal(t, "Activity recorded", entry.Message)
+	assert.Equal(t, time.Date(2026, 10, 18, 15, 5, 19, 290000000, time.UTC), entry.Timestamp.UTC())
+	assert.Equal(t, map[string]string{"tool": "Edit", "count": "3", "request_id": "abc"}, entry.Fields)
+
+	entry = parseDaemonLogLine(`time=2026-10-18T15:05:19.290Z level=WARN msg="Failed to count sessions" state=active error="no such table"`, logSourceFile)
+	assert.Equal(t, "warn", entry.Level)
+	assert.Equal(t, "Failed to count sessions", entry.Message)
+	assert.Equal(t, "no such table", entry.Fields["error"])
+
+	entry = parseDaemonLogLine("🔄 Starting Claude Monitor Daemon", logSourceFile)
+	assert.Equal(t, "🔄 Starting Claude Monitor Daemon", entry.Message)
+	assert.True(t, entry.Timestamp.IsZero())
+
+	journal, ok := parseJournalLine(`{"__REALTIME_TIMESTAMP":"1792335919000000","PRIORITY":"3","MESSAGE":"plain stderr line"}`, "claude-monitor")
+	require.True(t, ok)
+	assert.Equal(t, "error", journal.Level)
+	assert.Equal(t, "plain stderr line", journal.Message)
+	assert.Equal(t, int64(1792335919), journal.Timestamp.Unix())
+
+	journal, _ = parseJournalLine(`{"__REALTIME_TIMESTAMP":"1","PRIORITY":"6","MESSAGE":"{\"time\":\"2026-10-18T15:05:19Z\",\"level\":\"DEBUG\",\"msg\":\"Updated session\"}"}`, "claude-monitor")
+	assert.Equal(t, "debug", journal.Level, "the daemon's own level wins over the journal priority")
+	assert.Equal(t, 2026, journal.Timestamp.Year())
+}
+
+func TestLogFilter(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.Local)
+	filter, err := newLogFilter("2h", "warn", "sess(ion)?", "abc", now)
+	require.NoError(t, err)
+
+	match := LogEntry{Timestamp: now.Add(-time.Hour), Level: "error", Message: "Failed to update session", Fields: map[string]string{logging.KeyRequestID: "abc"}}
+	assert.True(t, filter.matches(match))
+
+	old := match
+	old.Timestamp = now.Add(-3 * time.Hour)
+	assert.False(t, filter.matches(old))
+
+	info := match
+	info.Level = "info"
+	assert.False(t, filter.matches(info))
+
+	other := match
+	other.Fields = map[string]string{logging.KeyRequestID: "xyz"}
+	assert.False(t, filter.matches(other))
+
+	fieldMatch := match
+	fieldMatch.Message = "Failed"
+	fieldMatch.Fields = map[string]string{logging.KeyRequestID: "abc", "session_id": "s1"}
+	assert.True(t, filter.matches(fieldMatch), "--grep also searches fields")
+
+	filter, err = newLogFilter("2026-10-18 11:30", "", "", "", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 18, 11, 30, 0, 0, time.Local), filter.since)
+
+	_, err = newLogFilter("", "loud", "", "", now)
+	assert.Error(t, err)
+	_, err = newLogFilter("", "", "(", "", now)
+	assert.Error(t, err)
+	_, err = newLogFilter("last tuesday", "", "", "", now)
+	assert.Error(t, err)
+}
+
+func TestReadLogFilesAcrossBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "daemon.log")
+	writeLines := func(name string, from, to int) {
+		file, err := os.Create(name)
+		require.NoError(t, err)
+		defer file.Close()
+		for i := from; i < to; i++ {
+			level := "INFO"
+			if i%5 == 0 {
+				level = "ERROR"
+			}
+			fmt.Fprintf(file, `{"time":"2026-10-18T10:%02d:00Z","level":"%s","msg":"entry %d","request_id":"req-%d"}`+"\n", i, level, i, i%3)
+		}
+	}
+	writeLines(filepath.Join(dir, "daemon-20261018T100000.000.log"), 0, 20)
+	writeLines(filepath.Join(dir, "daemon-20261018T102000.000.log"), 20, 40)
+	writeLines(path, 40, 50)
+
+	all, err := readLogFiles(path, 0, &logFilter{})
+	require.NoError(t, err)
+	require.Len(t, all, 50)
+	assert.Equal(t, "entry 0", all[0].Message)
+	assert.Equal(t, "entry 49", all[49].Message)
+
+	last, err := readLogFiles(path, 7, &logFilter{})
+	require.NoError(t, err)
+	require.Len(t, last, 7)
+	assert.Equal(t, "entry 43", last[0].Message)
+
+	errors, err := readLogFiles(path, 3, &logFilter{minLevel: logLevelRank["error"]})
+	require.NoError(t, err)
+	require.Len(t, errors, 3)
+	assert.Equal(t, []string{"entry 35", "entry 40", "entry 45"}, []string{errors[0].Message, errors[1].Message, errors[2].Message})
+
+	byRequest, err := readLogFiles(path, 0, &logFilter{requestID: "req-1"})
+	require.NoError(t, err)
+	assert.Len(t, byRequest, 17)
+}
+
+func TestFollowLogFileAcrossRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.log")
+	file, err := logging.OpenRotatingFile(path, 1, 5, 0)
+	require.NoError(t, err)
+	defer file.Close()
+	fmt.Fprintln(file, `{"level":"INFO","msg":"before follow"}`)
+
+	var mu sync.Mutex
+	var got []string
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- followLogFile(ctx, path, &logFilter{}, func(entry LogEntry) {
+			mu.Lock()
+			got = append(got, entry.Message)
+			mu.Unlock()
+		})
+	}()
+	received := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), got...)
+	}
+
+	time.Sleep(2 * followPollInterval)
+	fmt.Fprintln(file, `{"level":"INFO","msg":"first"}`)
+	assert.Eventually(t, func() bool { return len(received()) == 1 }, 5*time.Second, 50*time.Millisecond)
+
+	// Lines written just before a rotation are still delivered, then the new file is read
+	fmt.Fprint(file, `{"level":"INFO","msg":"last before rotation"}`+"\n")
+	require.NoError(t, file.Rotate())
+	fmt.Fprintln(file, `{"level":"INFO","msg":"after rotation"}`)
+
+	assert.Eventually(t, func() bool { return len(received()) == 3 }, 5*time.Second, 50*time.Millisecond)
+	assert.Equal(t, []string{"first", "last before rotation", "after rotation"}, received())
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("follow did not stop after cancel")
+	}
+}
@@ -0,0 +1,41 @@
+//go:build !linux
+
+/**
+ * CONTEXT:   Non-Linux stand-ins for the systemd bridge helpers
+ * INPUT:     Service command calls on platforms without systemd
+ * OUTPUT:    The composite's built-in platform manager and no-op linger handling
+ * BUSINESS:  Platform stubs enable cross-platform compilation
+ * CHANGE:    Initial stubs for the systemd bridge
+ * RISK:      Low - No system changes
+ */
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/claude-monitor/system/internal/service"
+)
+
+// newPlatformServiceManager defers to the composite's own platform manager
+func newPlatformServiceManager() (service.PlatformServiceManager, error) {
+	return nil, nil
+}
+
+// locateServiceUnit is only meaningful for systemd units
+func locateServiceUnit() (string, bool, error) {
+	return "", false, fmt.Errorf("service doctor checks systemd units and is only available on Linux")
+}
+
+// serviceIsUserLevel returns the --user-service flag
+func serviceIsUserLevel(manager ServiceManager) bool {
+	return serviceUserLevel
+}
+
+// lingerState is unknown without loginctl
+func lingerState() string {
+	return ""
+}
+
+// printLingerGuidance has nothing to explain without systemd
+func printLingerGuidance() {}
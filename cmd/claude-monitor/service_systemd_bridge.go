@@ -0,0 +1,167 @@
+//go:build linux
+
+/**
+ * CONTEXT:   Bridge from the composite service manager to the systemd manager
+ * INPUT:     Segregated-interface calls from service commands
+ * OUTPUT:    Real systemctl/unit-file operations through LinuxServiceManager
+ * BUSINESS:  `service install` must write an actual unit; the composite's
+ *            built-in Linux manager only records state in memory
+ * CHANGE:    Initial bridge, plus linger and unit location helpers
+ * RISK:      Medium - Every service command on Linux goes through this bridge
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/claude-monitor/system/internal/service"
+	"github.com/claude-monitor/system/internal/service/interfaces"
+)
+
+// systemdPlatformManager adapts LinuxServiceManager to service.PlatformServiceManager
+type systemdPlatformManager struct {
+	manager *LinuxServiceManager
+}
+
+// newPlatformServiceManager returns the systemd bridge for the composite manager
+func newPlatformServiceManager() (service.PlatformServiceManager, error) {
+	manager, err := NewLinuxServiceManager()
+	if err != nil {
+		return nil, err
+	}
+	return &systemdPlatformManager{manager: manager}, nil
+}
+
+// Install rebuilds the full service configuration, since the interfaces
+// config carries no systemd settings, and applies the caller's values on top
+func (s *systemdPlatformManager) Install(config interfaces.ServiceConfig) error {
+	full, err := getDefaultServiceConfig()
+	if err != nil {
+		return err
+	}
+	full.Name = config.Name
+	full.DisplayName = config.DisplayName
+	full.Description = config.Description
+	full.ExecutablePath = config.ExecutablePath
+	full.Arguments = config.Arguments
+	full.WorkingDir = config.WorkingDir
+	full.User = config.User
+	full.Group = config.Group
+	full.StartMode = ServiceStartMode(config.StartMode)
+	full.RestartOnFailure = config.RestartOnFailure
+	full.LogLevel = config.LogLevel
+	full.Environment = config.Environment
+	return s.manager.Install(full)
+}
+
+func (s *systemdPlatformManager) Uninstall() error  { return s.manager.Uninstall() }
+func (s *systemdPlatformManager) IsInstalled() bool { return s.manager.IsInstalled() }
+func (s *systemdPlatformManager) Start() error      { return s.manager.Start() }
+func (s *systemdPlatformManager) Stop() error       { return s.manager.Stop() }
+func (s *systemdPlatformManager) Restart() error    { return s.manager.Restart() }
+func (s *systemdPlatformManager) IsRunning() bool   { return s.manager.IsRunning() }
+
+func (s *systemdPlatformManager) Status() (interfaces.ServiceStatus, error) {
+	status, err := s.manager.Status()
+	if err != nil {
+		return interfaces.ServiceStatus{}, err
+	}
+	return interfaces.ServiceStatus{
+		Name:        status.Name,
+		DisplayName: status.DisplayName,
+		State:       interfaces.ServiceState(status.State),
+		PID:         status.PID,
+		Uptime:      status.Uptime,
+		StartTime:   status.StartTime,
+		Memory:      status.Memory,
+		CPU:         status.CPU,
+		LastError:   status.LastError,
+	}, nil
+}
+
+func (s *systemdPlatformManager) GetLogs(lines int) ([]interfaces.LogEntry, error) {
+	entries, err := s.manager.GetLogs(lines)
+	if err != nil {
+		return nil, err
+	}
+	logs := make([]interfaces.LogEntry, 0, len(entries))
+	for _, entry := range entries {
+		logs = append(logs, interfaces.LogEntry{
+			Timestamp: entry.Timestamp,
+			Level:     entry.Level,
+			Message:   entry.Message,
+			Source:    entry.Source,
+			Fields:    entry.Fields,
+		})
+	}
+	return logs, nil
+}
+
+func (s *systemdPlatformManager) HealthCheck() error {
+	if !s.manager.IsInstalled() {
+		return fmt.Errorf("service is not installed")
+	}
+	if !s.manager.IsRunning() {
+		return fmt.Errorf("service is not running")
+	}
+	return nil
+}
+
+func (s *systemdPlatformManager) GetUptime() (time.Duration, error) {
+	status, err := s.manager.Status()
+	if err != nil {
+		return 0, err
+	}
+	if status.State != ServiceStateRunning {
+		return 0, fmt.Errorf("service is not running")
+	}
+	return status.Uptime, nil
+}
+
+// locateServiceUnit returns the installed unit file and whether it is a user unit
+func locateServiceUnit() (string, bool, error) {
+	manager, err := NewLinuxServiceManager()
+	if err != nil {
+		return "", false, err
+	}
+	if !manager.IsInstalled() {
+		return "", false, fmt.Errorf("no claude-monitor unit is installed (checked user and system units); run: claude-monitor service install")
+	}
+	return manager.unitFilePath, manager.isUserService, nil
+}
+
+// serviceIsUserLevel reports whether the installed unit is a user unit
+func serviceIsUserLevel(manager ServiceManager) bool {
+	if linux, ok := manager.(*LinuxServiceManager); ok {
+		return linux.isUserService
+	}
+	return serviceUserLevel
+}
+
+// lingerState returns "yes" or "no" from loginctl, or "" when it is unavailable
+func lingerState() string {
+	username := os.Getenv("USER")
+	if username == "" {
+		return ""
+	}
+	output, err := (&DefaultCommandExecutor{}).Execute("loginctl", "show-user", username, "--property=Linger", "--value")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// printLingerGuidance explains lingering after a user-level install
+func printLingerGuidance() {
+	if !serviceUserLevel || lingerState() != "no" {
+		return
+	}
+	fmt.Println()
+	warningColor.Println("⚠️  User services stop when your last login session ends")
+	infoColor.Println("💡 Keep the daemon running after logout and start it at boot with:")
+	infoColor.Println("   loginctl enable-linger $USER")
+}
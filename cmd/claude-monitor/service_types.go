@@ -97,8 +97,20 @@ type LinuxServiceConfig struct {
 	After           []string `json:"after,omitempty"`
 	Before          []string `json:"before,omitempty"`
 	Capabilities    []string `json:"capabilities,omitempty"`
+	
+	// WatchdogSec is the systemd watchdog timeout; 0 disables the watchdog
+	WatchdogSec      int    `json:"watchdog_sec,omitempty"`
+	// SocketActivation installs a .socket unit that starts the daemon on the first hook connection
+	SocketActivation bool   `json:"socket_activation,omitempty"`
+	ListenAddress    string `json:"listen_address,omitempty"`
+	// ReadWritePaths are the directories the daemon writes, resolved from its configuration
+	ReadWritePaths   []string `json:"read_write_paths,omitempty"`
 }
 
+// defaultWatchdogSec leaves room for the health monitor to notice a stall
+// (three missed 30s rounds) before systemd restarts the daemon
+const defaultWatchdogSec = 120
+
 /**
  * CONTEXT:   Service status information for monitoring and troubleshooting
  * INPUT:     Platform service manager status queries
@@ -131,10 +143,11 @@ const (
 )
 
 type LogEntry struct {
-	Timestamp time.Time `json:"timestamp"`
-	Level     string    `json:"level"`
-	Message   string    `json:"message"`
-	Source    string    `json:"source"`
+	Timestamp time.Time         `json:"timestamp"`
+	Level     string            `json:"level"`
+	Message   string            `json:"message"`
+	Source    string            `json:"source"`
+	Fields    map[string]string `json:"fields,omitempty"`
 }
 
 // =============================================================================
@@ -146,8 +159,16 @@ var (
 	serviceUser       string
 	serviceUserLevel  bool
 	serviceSystemMode bool
+	// serviceScopeExplicit is set when --system or --user-service was given;
+	// otherwise the scope of an already installed unit is detected
+	serviceScopeExplicit bool
+	serviceSocket     bool
 	serviceLogLines   int
 	serviceFollow     bool
+	serviceLogSince   string
+	serviceLogLevel   string
+	serviceLogGrep    string
+	serviceLogRequest string
 )
 
 //go:embed service_templates/*
@@ -191,6 +212,9 @@ Supports both Windows Service Control Manager and Linux systemd.`,
   # View service logs
   claude-monitor service logs --lines=50
   
+  # Check the unit's sandbox against the configured paths
+  claude-monitor service doctor
+  
   # Remove service
   claude-monitor service uninstall`,
 }
@@ -200,7 +224,8 @@ Supports both Windows Service Control Manager and Linux systemd.`,
  * INPUT:     Cobra command definitions and flag configurations
  * OUTPUT:    Complete service command structure with all subcommands
  * BUSINESS:  Command initialization enables user-friendly service management
- * CHANGE:    Initial command setup with install/start/stop/restart/status/logs/uninstall
+ * CHANGE:    User-level install is the default (--system opts in to root);
+ *            added the doctor subcommand
  * RISK:      Low - Command structure initialization with no side effects
  */
 func init() {
@@ -215,14 +240,19 @@ This will:
 - Configure automatic startup on boot
 - Set appropriate security permissions
 - Create service logs directory
-- Verify service installation`,
+- Verify service installation
+
+On Linux the default is a systemctl --user unit, which needs no root. Run
+"loginctl enable-linger $USER" so it keeps running after you log out, or
+pass --system to install a root-managed unit instead.`,
 		RunE: runServiceInstall,
 	}
 	
 	serviceInstallCmd.Flags().BoolVar(&serviceAutoStart, "auto-start", true, "start service automatically after install")
 	serviceInstallCmd.Flags().StringVar(&serviceUser, "user", "", "run service as specific user (Linux only)")
-	serviceInstallCmd.Flags().BoolVar(&serviceUserLevel, "user-service", false, "install as user-level service")
-	serviceInstallCmd.Flags().BoolVar(&serviceSystemMode, "system", true, "install as system-level service")
+	serviceInstallCmd.Flags().BoolVar(&serviceUserLevel, "user-service", true, "install as a systemctl --user service (default, no root needed)")
+	serviceInstallCmd.Flags().BoolVar(&serviceSystemMode, "system", false, "install as system-level service (requires root)")
+	serviceInstallCmd.Flags().BoolVar(&serviceSocket, "socket-activation", false, "install a systemd .socket unit that starts the daemon on the first hook (Linux only)")
 	
 	// Service management subcommands
 	serviceStartCmd := &cobra.Command{
@@ -252,11 +282,26 @@ This will:
 	serviceLogsCmd := &cobra.Command{
 		Use:   "logs",
 		Short: "View service logs",
-		RunE:  runServiceLogs,
+		Long: `View daemon logs from the systemd journal, or from the daemon's own
+rotated log files (logging.output_file) when journald is unavailable.`,
+		RunE: runServiceLogs,
 	}
 	
-	serviceLogsCmd.Flags().IntVar(&serviceLogLines, "lines", 20, "number of log lines to show")
-	serviceLogsCmd.Flags().BoolVarP(&serviceFollow, "follow", "f", false, "follow log output")
+	serviceLogsCmd.Flags().IntVar(&serviceLogLines, "lines", 20, "number of log lines to show (0 for all)")
+	serviceLogsCmd.Flags().BoolVarP(&serviceFollow, "follow", "F", false, "follow log output, including across rotation")
+	serviceLogsCmd.Flags().StringVar(&serviceLogSince, "since", "", "only entries after a duration ago (2h) or a time (\"2006-01-02 15:04\")")
+	serviceLogsCmd.Flags().StringVar(&serviceLogLevel, "level", "", "minimum level: debug, info, warn or error")
+	serviceLogsCmd.Flags().StringVar(&serviceLogGrep, "grep", "", "only entries whose message or fields match this regular expression")
+	serviceLogsCmd.Flags().StringVar(&serviceLogRequest, "request-id", "", "only entries for this request ID")
+	
+	serviceDoctorCmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the installed unit against the daemon configuration",
+		Long: `Compare the installed systemd unit with the resolved configuration: the
+database, log and backup directories must be writable inside the unit's
+sandbox, and user units need lingering to run without a login session.`,
+		RunE: runServiceDoctor,
+	}
 	
 	serviceUninstallCmd := &cobra.Command{
 		Use:   "uninstall",
@@ -271,6 +316,7 @@ This will:
 	serviceCmd.AddCommand(serviceRestartCmd)
 	serviceCmd.AddCommand(serviceStatusCmd)
 	serviceCmd.AddCommand(serviceLogsCmd)
+	serviceCmd.AddCommand(serviceDoctorCmd)
 	serviceCmd.AddCommand(serviceUninstallCmd)
 }
 
@@ -283,10 +329,17 @@ This will:
  * INPUT:     Platform detection and service configuration requirements
  * OUTPUT:    Composite service manager with all interface implementations
  * BUSINESS:  Factory provides access to segregated interfaces while maintaining compatibility
- * CHANGE:    Updated to use Interface Segregation Principle with composite pattern
+ * CHANGE:    Use the systemd bridge on Linux instead of the in-memory placeholder
  * RISK:      Medium - Service manager creation affecting all service functionality
  */
 func NewCompositeServiceManager() (*service.CompositeServiceManager, error) {
+	platformManager, err := newPlatformServiceManager()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create platform service manager: %w", err)
+	}
+	if platformManager != nil {
+		return service.NewCompositeServiceManagerWith(platformManager), nil
+	}
 	return service.NewCompositeServiceManager()
 }
 
@@ -295,7 +348,7 @@ func NewCompositeServiceManager() (*service.CompositeServiceManager, error) {
  * INPUT:     Binary path, system information, user preferences
  * OUTPUT:    Complete service configuration with platform optimizations
  * BUSINESS:  Default configuration ensures reliable service operation
- * CHANGE:    Initial configuration with security and performance defaults
+ * CHANGE:    User units by default; sandbox paths come from the resolved config
  * RISK:      Medium - Default configuration affects service security and reliability
  */
 func getDefaultServiceConfig() (ServiceConfig, error) {
@@ -312,7 +365,7 @@ func getDefaultServiceConfig() (ServiceConfig, error) {
 		DisplayName:      "Claude Monitor Work Tracking Service",
 		Description:      "Work hour tracking daemon for Claude Code users with project analytics and session management",
 		ExecutablePath:   executable,
-		Arguments:        []string{"daemon", "--log-level=info"},
+		Arguments:        []string{"daemon"},
 		WorkingDir:       configDir,
 		StartMode:        StartModeAuto,
 		RestartOnFailure: true,
@@ -335,10 +388,22 @@ func getDefaultServiceConfig() (ServiceConfig, error) {
 	case "linux":
 		config.LinuxService = LinuxServiceConfig{
 			SystemdUnit:   true,
-			UserService:   serviceUserLevel,
-			SystemService: serviceSystemMode,
-			WantedBy:      []string{"multi-user.target"},
-			After:         []string{"network.target"},
+			UserService:    serviceUserLevel,
+			SystemService:  !serviceUserLevel,
+			After:          []string{"network.target"},
+			WatchdogSec:    defaultWatchdogSec,
+			ReadWritePaths: serviceWritablePaths(configDir),
+		}
+		if !serviceUserLevel {
+			config.LinuxService.WantedBy = []string{"multi-user.target"}
+		}
+		
+		if serviceSocket {
+			config.LinuxService.SocketActivation = true
+			config.LinuxService.ListenAddress = "localhost:9193"
+			if appConfig, err := loadConfiguration(); err == nil && appConfig.Daemon.ListenAddr != "" {
+				config.LinuxService.ListenAddress = appConfig.Daemon.ListenAddr
+			}
 		}
 		
 		if serviceUser != "" {
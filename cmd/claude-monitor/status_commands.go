@@ -0,0 +1,192 @@
+/**
+ * CONTEXT:   Live status dashboard command
+ * INPUT:     Daemon live snapshot and event stream
+ * OUTPUT:    One-shot or continuously refreshed terminal dashboard
+ * BUSINESS:  Replaces combining `service status`, `curl /status` and `today`
+ *            to see the session window, active work and idle countdown
+ * CHANGE:    Initial status command with --watch
+ * RISK:      Low - Read-only daemon calls
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	cfg "github.com/claude-monitor/system/internal/config"
+	"github.com/claude-monitor/system/internal/reporting"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// statusStreamRefresh refetches the snapshot while the event stream is
+	// quiet, so sessions and blocks that expire without an event drop off
+	statusStreamRefresh = time.Minute
+
+	// clearScreen moves the cursor home and clears the terminal
+	clearScreen = "\033[H\033[2J"
+)
+
+var (
+	statusWatch    bool
+	statusInterval time.Duration
+)
+
+/**
+ * CONTEXT:   Status command definition
+ * INPUT:     --watch and --interval flags
+ * OUTPUT:    Dashboard rendered with the professional display palette
+ * BUSINESS:  The one place to look at what the daemon is tracking right now
+ * CHANGE:    Initial status command
+ * RISK:      Low - Command definition only
+ */
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the live tracking dashboard",
+	Long: `Show daemon health, the current 5-hour session and time remaining in
+its window, the active work block with its idle countdown, today's totals
+and the most recent events.
+
+With --watch the dashboard stays open and redraws every second. It
+refreshes from the daemon's live event stream as hook events arrive and
+falls back to polling every --interval when the stream is unavailable.`,
+	Example: `  claude-monitor status
+  claude-monitor status --watch
+  claude-monitor status --watch --interval 10s
+  claude-monitor status -f json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runStatusCommand,
+}
+
+// init registers the status flags
+func init() {
+	statusCmd.Flags().BoolVarP(&statusWatch, "watch", "w", false, "keep the dashboard open and refresh it live")
+	statusCmd.Flags().DurationVar(&statusInterval, "interval", 5*time.Second, "polling interval when the live event stream is unavailable")
+}
+
+/**
+ * CONTEXT:   Status command handler
+ * INPUT:     Daemon address and token from config.json
+ * OUTPUT:    Dashboard, JSON snapshot, or an error when the daemon is unreachable
+ * BUSINESS:  A one-shot status exits non-zero when the daemon is down so it can
+ *            be scripted
+ * CHANGE:    Initial handler
+ * RISK:      Low - Single read request unless watching
+ */
+func runStatusCommand(cmd *cobra.Command, args []string) error {
+	baseURL, client := statusDaemonClient()
+	userID := getCurrentUserID()
+
+	if statusWatch {
+		if statusInterval < time.Second {
+			return fmt.Errorf("--interval must be at least 1s")
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return watchStatus(ctx, client, baseURL, userID)
+	}
+
+	status, err := client.GetLiveStatus(baseURL, userID)
+	if err != nil {
+		return fmt.Errorf("daemon unreachable at %s: %w", baseURL, err)
+	}
+	if outputFormat == "json" {
+		return printJSON(status)
+	}
+	reporting.RenderLiveStatus(os.Stdout, status, time.Now(), "snapshot")
+	return nil
+}
+
+// statusDaemonClient resolves the daemon URL and an authenticated client from config
+func statusDaemonClient() (string, *HTTPClient) {
+	listenAddr := cfg.DefaultListenAddr
+	client := NewHTTPClient(5 * time.Second)
+	if config, err := loadConfiguration(); err == nil {
+		if config.Daemon.ListenAddr != "" {
+			listenAddr = config.Daemon.ListenAddr
+		}
+		client.WithAuthToken(config.Daemon.AuthToken)
+	}
+	return "http://" + probeAddr(listenAddr), client
+}
+
+/**
+ * CONTEXT:   Watch loop for the live dashboard
+ * INPUT:     Context cancelled on Ctrl+C, client, daemon URL and user
+ * OUTPUT:    Redraw every second; refetch on stream events, every minute while
+ *            streaming, or every --interval while polling
+ * BUSINESS:  Countdowns tick locally from the last snapshot, so the daemon is
+ *            only queried when something may have changed
+ * CHANGE:    Initial watch loop
+ * RISK:      Low - Stream reconnects are paced by the polling interval
+ */
+func watchStatus(ctx context.Context, client *HTTPClient, baseURL, userID string) error {
+	var streaming atomic.Bool
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+
+	go func() {
+		for ctx.Err() == nil {
+			client.StreamLiveEvents(ctx, baseURL, userID,
+				func() { streaming.Store(true); notify() },
+				func(reporting.LiveEvent) { notify() })
+			streaming.Store(false)
+			select {
+			case <-ctx.Done():
+			case <-time.After(statusInterval):
+			}
+		}
+	}()
+
+	var status *reporting.LiveStatus
+	var fetchErr error
+	var fetchedAt time.Time
+	fetch := func() {
+		status, fetchErr = client.GetLiveStatus(baseURL, userID)
+		fetchedAt = time.Now()
+	}
+	fetch()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		source := fmt.Sprintf("polling every %s · Ctrl+C to exit", statusInterval)
+		refresh := statusInterval
+		if streaming.Load() {
+			source = "live · Ctrl+C to exit"
+			refresh = statusStreamRefresh
+		}
+
+		var frame bytes.Buffer
+		if fetchErr != nil {
+			reporting.RenderLiveUnavailable(&frame, fetchErr, time.Now(), source)
+		} else {
+			reporting.RenderLiveStatus(&frame, status, time.Now(), source)
+		}
+		fmt.Print(clearScreen + frame.String())
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+			fetch()
+		case <-ticker.C:
+			if time.Since(fetchedAt) >= refresh {
+				fetch()
+			}
+		}
+	}
+}
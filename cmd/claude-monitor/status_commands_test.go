@@ -0,0 +1,118 @@
+/**
+ * CONTEXT:   Test suite for the live status client
+ * INPUT:     Fake daemons served by httptest and canned event streams
+ * OUTPUT:    Validation of snapshot decoding, auth forwarding and SSE parsing
+ * BUSINESS:  `status --watch` must refresh on every activity event and ignore
+ *            heartbeats
+ * CHANGE:    Initial live status client tests
+ * RISK:      Low - Local test servers only
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/claude-monitor/system/internal/reporting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadServerSentEvents(t *testing.T) {
+	stream := ": connected\n\n" +
+		"event: activity\ndata: {\"tool_name\":\"Edit\"}\n\n" +
+		": ping\n\n" +
+		"data: first\ndata: second\n\n" +
+		"event: activity\ndata: {\"tool_name\":\"Bash\"}\n\n" +
+		"event: activity\n"
+
+	var got []string
+	err := readServerSentEvents(strings.NewReader(stream), func(name, data string) {
+		got = append(got, name+"="+data)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`activity={"tool_name":"Edit"}`,
+		"message=first\nsecond",
+		`activity={"tool_name":"Bash"}`,
+	}, got, "comments and an unterminated trailing event are not dispatched")
+}
+
+func TestGetLiveStatus(t *testing.T) {
+	var auth, userID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		userID = r.URL.Query().Get("user_id")
+		w.Write([]byte(`{"user_id":"alice","daemon":{"status":"healthy","uptime_seconds":30},` +
+			`"session":{"id":"s1","start_time":"2026-01-02T10:00:00Z","end_time":"2026-01-02T15:00:00Z"},` +
+			`"recent":[{"activity_type":"command","tool_name":"Bash"}]}`))
+	}))
+	defer server.Close()
+
+	status, err := NewHTTPClient(time.Second).WithAuthToken("s3cret").GetLiveStatus(server.URL+"/", "alice smith")
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer s3cret", auth)
+	assert.Equal(t, "alice smith", userID)
+	assert.Equal(t, "healthy", status.Daemon.Status)
+	require.NotNil(t, status.Session)
+	assert.Equal(t, 2*time.Hour, status.Session.Remaining(time.Date(2026, 1, 2, 13, 0, 0, 0, time.UTC)))
+	assert.Nil(t, status.WorkBlock)
+	require.Len(t, status.Recent, 1)
+	assert.Equal(t, "Bash", status.Recent[0].ToolName)
+}
+
+func TestGetLiveStatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error":"reporting not available"}`))
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPClient(time.Second).GetLiveStatus(server.URL, "alice")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "reporting not available")
+}
+
+func TestStreamLiveEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/live/events" {
+			http.NotFound(w, r)
+			return
+		}
+		assert.Equal(t, "alice", r.URL.Query().Get("user_id"))
+		assert.Equal(t, "Bearer s3cret", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": connected\n\n")
+		fmt.Fprint(w, "event: activity\ndata: {\"tool_name\":\"Edit\",\"project_name\":\"api\"}\n\n")
+		fmt.Fprint(w, ": ping\n\n")
+		fmt.Fprint(w, "event: activity\ndata: not json\n\n")
+	}))
+	defer server.Close()
+
+	connected := false
+	var events []reporting.LiveEvent
+	err := NewHTTPClient(time.Second).WithAuthToken("s3cret").StreamLiveEvents(context.Background(), server.URL, "alice",
+		func() { connected = true },
+		func(event reporting.LiveEvent) { events = append(events, event) })
+	require.NoError(t, err, "the stream ends cleanly when the daemon closes it")
+	assert.True(t, connected)
+	require.Len(t, events, 1, "malformed events are skipped")
+	assert.Equal(t, "api", events[0].ProjectName)
+}
+
+func TestStreamLiveEventsUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	connected := false
+	err := NewHTTPClient(time.Second).StreamLiveEvents(context.Background(), server.URL, "alice",
+		func() { connected = true }, func(reporting.LiveEvent) {})
+	require.Error(t, err, "older daemons without the stream fall back to polling")
+	assert.False(t, connected)
+}
@@ -0,0 +1,363 @@
+/**
+ * CONTEXT:   Tag command group for free-form labels on tracked work
+ * INPUT:     Tag names and a target: one work block, the last block, a time range or a session
+ * OUTPUT:    Tags attached to or removed from work blocks and sessions, tag listings
+ * BUSINESS:  Labels such as "incident-4312" or "code review" make reports answer
+ *            "how long did this take" for work that spans projects
+ * CHANGE:    Initial tag command group with add, remove, list and delete subcommands
+ * RISK:      Low - Writes only to the tag tables
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/reporting"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tagBlockID   string
+	tagLast      bool
+	tagRange     string
+	tagSessionID string
+)
+
+// clockRangePattern matches a same-day clock range such as 14:00..16:30
+var clockRangePattern = regexp.MustCompile(`^(\d{1,2}:\d{2})\.\.(\d{1,2}:\d{2})$`)
+
+/**
+ * CONTEXT:   Tag command group for labelling work
+ * INPUT:     Tag subcommand selection
+ * OUTPUT:    Routed tag subcommand execution
+ * BUSINESS:  Tags complement projects for work that is not tied to one repository
+ * CHANGE:    Initial tag command group
+ * RISK:      Low - Command routing only
+ */
+var tagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Label work blocks and sessions with tags",
+	Long: `Attach free-form tags to tracked work.
+
+Tags are case-insensitive labels such as "incident-4312", "code review"
+or "learning". A tag on a session applies to all of the session's work
+blocks. Reports show a tag breakdown once any work is tagged, and
+today and report compare accept --tag to count only tagged work.
+
+To label work as it happens, set CLAUDE_MONITOR_TAGS in the shell that
+runs Claude Code; the hook attaches those tags to every work block.`,
+	Example: `  claude-monitor tag add incident-4312 --last
+  claude-monitor tag add "code review" --range 14:00..16:30
+  claude-monitor tag add learning --range 2026-10-12..2026-10-14
+  claude-monitor tag add on-call --session sess_3f9a1c2b7d4e5f60
+  claude-monitor tag remove learning --block wb_3f9a1c2b7d4e5f60
+  claude-monitor tag list
+  claude-monitor today --tag incident-4312`,
+}
+
+/**
+ * CONTEXT:   Tag command initialization with subcommands and flags
+ * INPUT:     Cobra command definitions and flag configurations
+ * OUTPUT:    Complete tag command structure
+ * BUSINESS:  Add and remove share target flags so they behave the same
+ * CHANGE:    Initial command setup
+ * RISK:      Low - Command structure initialization with no side effects
+ */
+func init() {
+	tagAddCmd := &cobra.Command{
+		Use:   "add <tag>... (--block <id> | --last | --range <range> | --session <id>)",
+		Short: "Attach tags to work blocks or a session",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  runTagAdd,
+	}
+
+	tagRemoveCmd := &cobra.Command{
+		Use:     "remove <tag>... (--block <id> | --last | --range <range> | --session <id>)",
+		Aliases: []string{"rm"},
+		Short:   "Remove tags from work blocks or a session",
+		Args:    cobra.MinimumNArgs(1),
+		RunE:    runTagRemove,
+	}
+
+	for _, cmd := range []*cobra.Command{tagAddCmd, tagRemoveCmd} {
+		cmd.Flags().StringVar(&tagBlockID, "block", "", "work block ID")
+		cmd.Flags().BoolVar(&tagLast, "last", false, "most recent work block")
+		cmd.Flags().StringVar(&tagRange, "range", "", "work blocks started in HH:MM..HH:MM (today), YYYY-MM-DD, YYYY-Www, YYYY-MM or FROM..TO")
+		cmd.Flags().StringVar(&tagSessionID, "session", "", "session ID; the tags apply to all its work blocks")
+	}
+
+	tagListCmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List tags with usage counts",
+		Args:    cobra.NoArgs,
+		RunE:    runTagList,
+	}
+
+	tagDeleteCmd := &cobra.Command{
+		Use:   "delete <tag>",
+		Short: "Delete a tag from all work blocks and sessions",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runTagDelete,
+	}
+
+	tagCmd.AddCommand(tagAddCmd)
+	tagCmd.AddCommand(tagRemoveCmd)
+	tagCmd.AddCommand(tagListCmd)
+	tagCmd.AddCommand(tagDeleteCmd)
+}
+
+// tagTarget is the set of work blocks or the session a tag command applies to
+type tagTarget struct {
+	SessionID   string   `json:"session_id,omitempty"`
+	WorkBlocks  []string `json:"work_blocks,omitempty"`
+	Description string   `json:"description"`
+}
+
+/**
+ * CONTEXT:   Tag add command handler
+ * INPUT:     Tag names and exactly one target flag
+ * OUTPUT:    Tags attached with a count of new links
+ * BUSINESS:  Labels can be added after the fact for work that was not pre-labelled
+ * CHANGE:    Initial tag add handler
+ * RISK:      Low - Idempotent inserts
+ */
+func runTagAdd(cmd *cobra.Command, args []string) error {
+	return runTagChange(args, true)
+}
+
+/**
+ * CONTEXT:   Tag remove command handler
+ * INPUT:     Tag names and exactly one target flag
+ * OUTPUT:    Tags detached with a count of removed links
+ * BUSINESS:  Mislabelled work must be correctable
+ * CHANGE:    Initial tag remove handler
+ * RISK:      Low - Deletes link rows only; the tag itself is kept
+ */
+func runTagRemove(cmd *cobra.Command, args []string) error {
+	return runTagChange(args, false)
+}
+
+// runTagChange adds or removes tags on the selected target
+func runTagChange(args []string, add bool) error {
+	tags, err := sqlite.ParseTags(args...)
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return fmt.Errorf("specify at least one tag")
+	}
+
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	ctx := context.Background()
+	target, err := resolveTagTarget(ctx)
+	if err != nil {
+		return err
+	}
+
+	tagRepo := sqlite.NewTagRepository(unifiedDB.DB())
+	changed := 0
+	if target.SessionID != "" {
+		if add {
+			changed, err = tagRepo.TagSession(ctx, target.SessionID, tags, sqlite.TagSourceManual)
+		} else {
+			changed, err = tagRepo.UntagSession(ctx, target.SessionID, tags)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, blockID := range target.WorkBlocks {
+		var n int
+		if add {
+			n, err = tagRepo.TagWorkBlock(ctx, blockID, tags, sqlite.TagSourceManual)
+		} else {
+			n, err = tagRepo.UntagWorkBlock(ctx, blockID, tags)
+		}
+		if err != nil {
+			return err
+		}
+		changed += n
+	}
+
+	if outputFormat == "json" {
+		return printJSON(map[string]interface{}{
+			"tags":    tags,
+			"target":  target,
+			"added":   add,
+			"changed": changed,
+		})
+	}
+
+	verb, preposition := "Tagged", "on"
+	if !add {
+		verb, preposition = "Removed", "from"
+	}
+	successColor.Printf("✅ %s %s %s %s\n", verb, strings.Join(tags, ", "), preposition, target.Description)
+	dimColor.Printf("   %d tag link(s) changed\n", changed)
+	return nil
+}
+
+/**
+ * CONTEXT:   Resolve the target flags of tag add and remove
+ * INPUT:     --block, --last, --range and --session flags
+ * OUTPUT:    Work block IDs or session ID with a description for messages
+ * BUSINESS:  Exactly one target keeps bulk tagging deliberate
+ * CHANGE:    Initial target resolution
+ * RISK:      Low - Read-only lookups
+ */
+func resolveTagTarget(ctx context.Context) (*tagTarget, error) {
+	selected := 0
+	for _, set := range []bool{tagBlockID != "", tagLast, tagRange != "", tagSessionID != ""} {
+		if set {
+			selected++
+		}
+	}
+	if selected != 1 {
+		return nil, fmt.Errorf("specify exactly one of --block, --last, --range or --session")
+	}
+
+	workBlockRepo := sqlite.NewWorkBlockRepository(unifiedDB.DB())
+	switch {
+	case tagBlockID != "":
+		block, err := workBlockRepo.GetByID(ctx, tagBlockID)
+		if err != nil {
+			return nil, err
+		}
+		return &tagTarget{WorkBlocks: []string{block.ID}, Description: "work block " + block.ID}, nil
+
+	case tagLast:
+		block, err := workBlockRepo.GetLatestByUser(ctx, getCurrentUserID())
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			return nil, fmt.Errorf("no work blocks recorded yet")
+		}
+		return &tagTarget{
+			WorkBlocks:  []string{block.ID},
+			Description: fmt.Sprintf("the last work block (%s, started %s)", block.ID, block.StartTime.Local().Format("Jan 2 15:04")),
+		}, nil
+
+	case tagRange != "":
+		start, end, err := parseTagRange(tagRange, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		blocks, err := workBlockRepo.GetByUserInRange(ctx, getCurrentUserID(), start, end)
+		if err != nil {
+			return nil, err
+		}
+		if len(blocks) == 0 {
+			return nil, fmt.Errorf("no work blocks started in %s", tagRange)
+		}
+		target := &tagTarget{Description: fmt.Sprintf("%d work block(s) in %s", len(blocks), tagRange)}
+		for _, block := range blocks {
+			target.WorkBlocks = append(target.WorkBlocks, block.ID)
+		}
+		return target, nil
+
+	default:
+		session, err := sqlite.NewSessionRepository(unifiedDB).GetByID(ctx, tagSessionID)
+		if err != nil {
+			return nil, err
+		}
+		return &tagTarget{SessionID: session.ID, Description: "session " + session.ID}, nil
+	}
+}
+
+// parseTagRange accepts HH:MM..HH:MM for today or any report period specification
+func parseTagRange(spec string, now time.Time) (time.Time, time.Time, error) {
+	if m := clockRangePattern.FindStringSubmatch(strings.TrimSpace(spec)); m != nil {
+		day := now.Format("2006-01-02")
+		start, err := time.ParseInLocation("2006-01-02 15:04", day+" "+m[1], now.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid start time %q: %w", m[1], err)
+		}
+		end, err := time.ParseInLocation("2006-01-02 15:04", day+" "+m[2], now.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid end time %q: %w", m[2], err)
+		}
+		if !end.After(start) {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid range %q: end is not after start", spec)
+		}
+		return start, end, nil
+	}
+
+	period, err := reporting.ParseReportPeriod(spec, now.Location())
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return period.Start, period.End, nil
+}
+
+/**
+ * CONTEXT:   Tag list command handler
+ * INPUT:     Global output format
+ * OUTPUT:    Tags with work block and session counts
+ * BUSINESS:  Seeing existing tags avoids near-duplicate labels
+ * CHANGE:    Initial tag list handler
+ * RISK:      Low - Read-only query
+ */
+func runTagList(cmd *cobra.Command, args []string) error {
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	tags, err := sqlite.NewTagRepository(unifiedDB.DB()).List(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == "json" {
+		return printJSON(tags)
+	}
+
+	if len(tags) == 0 {
+		infoColor.Println("No tags yet. Add one with 'claude-monitor tag add <tag> --last'.")
+		return nil
+	}
+
+	headerColor.Printf("%-32s %8s %8s  %s\n", "TAG", "BLOCKS", "SESSIONS", "CREATED")
+	for _, tag := range tags {
+		fmt.Printf("%-32s %8d %8d  %s\n", tag.Name, tag.WorkBlocks, tag.Sessions, tag.CreatedAt.Local().Format("2006-01-02"))
+	}
+	return nil
+}
+
+/**
+ * CONTEXT:   Tag delete command handler
+ * INPUT:     Tag name
+ * OUTPUT:    Tag removed from every work block and session
+ * BUSINESS:  Retired labels can be cleaned up in one step
+ * CHANGE:    Initial tag delete handler
+ * RISK:      Medium - Removes all links of the tag; cannot be undone
+ */
+func runTagDelete(cmd *cobra.Command, args []string) error {
+	tag, err := sqlite.NormalizeTag(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := initializeDefaultReporting(); err != nil {
+		return err
+	}
+	defer closeReporting()
+
+	if err := sqlite.NewTagRepository(unifiedDB.DB()).Delete(context.Background(), tag); err != nil {
+		return err
+	}
+	successColor.Printf("✅ Deleted tag %q\n", tag)
+	return nil
+}
@@ -3,7 +3,7 @@
  * INPUT:     HTTP requests and activity events requiring session and work block management
  * OUTPUT:    Processed activities with complete SQLite persistence for sessions, work blocks, and projects
  * BUSINESS:  Complete work tracking with session management, project auto-creation, and idle detection
- * CHANGE:    Enhanced integration layer adding work block management to session processing
+ * CHANGE:    Structured slog logging carrying the request context
  * RISK:      Medium - Core server functionality integration affecting complete activity processing
  */
 
@@ -13,7 +13,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -67,7 +67,7 @@ func NewServerIntegration(dbPath string) (*ServerIntegration, error) {
 	// Load timezone
 	timezone, err := time.LoadLocation("America/Montevideo")
 	if err != nil {
-		log.Printf("Warning: failed to load timezone America/Montevideo, using UTC: %v", err)
+		slog.Warn("Failed to load timezone, using UTC", "timezone", "America/Montevideo", "error", err)
 		timezone = time.UTC
 	}
 	
@@ -116,8 +116,8 @@ func (si *ServerIntegration) ProcessActivityEvent(ctx context.Context, event *Ac
 	// Convert timestamp to timezone
 	event.Timestamp = event.Timestamp.In(si.timezone)
 	
-	log.Printf("📝 Processing complete activity: user=%s, path=%s, time=%s", 
-		event.UserID, projectPath, event.Timestamp.Format("2006-01-02 15:04:05"))
+	slog.DebugContext(ctx, "Processing activity",
+		"user_id", event.UserID, "project_path", projectPath, "timestamp", event.Timestamp)
 	
 	// STEP 1: Create/ensure user exists in database
 	if err := si.ensureUserExists(ctx, event.UserID); err != nil {
@@ -136,8 +136,9 @@ func (si *ServerIntegration) ProcessActivityEvent(ctx context.Context, event *Ac
 		return fmt.Errorf("failed to process work block activity: %w", err)
 	}
 	
-	log.Printf("✅ Complete activity processed: session=%s, work_block=%s, activities=%d, work_hours=%.2f", 
-		session.ID, workBlock.ID, session.ActivityCount, workBlock.DurationHours)
+	slog.InfoContext(ctx, "Activity processed",
+		"session_id", session.ID, "work_block_id", workBlock.ID,
+		"activity_count", session.ActivityCount, "work_hours", workBlock.DurationHours)
 	
 	return nil
 }
@@ -158,7 +159,7 @@ func (si *ServerIntegration) HandleActivity(w http.ResponseWriter, r *http.Reque
 	
 	var event ActivityEvent
 	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
-		log.Printf("❌ Invalid JSON in activity request: %v", err)
+		slog.WarnContext(r.Context(), "Invalid JSON in activity request", "error", err)
 		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
 		return
 	}
@@ -169,7 +170,7 @@ func (si *ServerIntegration) HandleActivity(w http.ResponseWriter, r *http.Reque
 	
 	// Process activity event
 	if err := si.ProcessActivityEvent(ctx, &event); err != nil {
-		log.Printf("❌ Failed to process activity: %v", err)
+		slog.ErrorContext(r.Context(), "Failed to process activity", "error", err)
 		http.Error(w, "Failed to process activity", http.StatusInternalServerError)
 		return
 	}
@@ -212,7 +213,7 @@ func (si *ServerIntegration) HandleGetActiveSession(w http.ResponseWriter, r *ht
 	// Get active session
 	session, err := si.sessionManager.GetActiveSession(ctx, userID)
 	if err != nil {
-		log.Printf("❌ Failed to get active session: %v", err)
+		slog.ErrorContext(r.Context(), "Failed to get active session", "error", err)
 		http.Error(w, "Failed to get active session", http.StatusInternalServerError)
 		return
 	}
@@ -265,7 +266,7 @@ func (si *ServerIntegration) HandleCleanupExpiredSessions(w http.ResponseWriter,
 	// Mark expired sessions
 	expiredCount, err := si.sessionManager.MarkExpiredSessions(ctx)
 	if err != nil {
-		log.Printf("❌ Failed to mark expired sessions: %v", err)
+		slog.ErrorContext(r.Context(), "Failed to mark expired sessions", "error", err)
 		http.Error(w, "Failed to cleanup expired sessions", http.StatusInternalServerError)
 		return
 	}
@@ -273,7 +274,7 @@ func (si *ServerIntegration) HandleCleanupExpiredSessions(w http.ResponseWriter,
 	// Also mark idle work blocks
 	idleCount, err := si.workBlockManager.MarkIdleWorkBlocks(ctx)
 	if err != nil {
-		log.Printf("Warning: failed to mark idle work blocks: %v", err)
+		slog.WarnContext(r.Context(), "Failed to mark idle work blocks", "error", err)
 	}
 	
 	response := map[string]interface{}{
@@ -287,7 +288,7 @@ func (si *ServerIntegration) HandleCleanupExpiredSessions(w http.ResponseWriter,
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 	
-	log.Printf("🧹 Session cleanup completed: %d sessions marked as expired", expiredCount)
+	slog.InfoContext(r.Context(), "Session cleanup completed", "expired_sessions", expiredCount)
 }
 
 /**
@@ -340,7 +341,7 @@ func (si *ServerIntegration) HandleGetWorkBlockStatus(w http.ResponseWriter, r *
 	// Get active work block
 	workBlock, err := si.workBlockManager.GetActiveWorkBlock(ctx, sessionID, projectPath)
 	if err != nil {
-		log.Printf("❌ Failed to get work block status: %v", err)
+		slog.ErrorContext(r.Context(), "Failed to get work block status", "error", err)
 		http.Error(w, "Failed to get work block status", http.StatusInternalServerError)
 		return
 	}
@@ -404,7 +405,7 @@ func (si *ServerIntegration) HandleGetSessionWorkBlocks(w http.ResponseWriter, r
 	// Get work blocks for session
 	workBlocks, err := si.workBlockManager.GetWorkBlocksBySession(ctx, sessionID, limit)
 	if err != nil {
-		log.Printf("❌ Failed to get session work blocks: %v", err)
+		slog.ErrorContext(r.Context(), "Failed to get session work blocks", "error", err)
 		http.Error(w, "Failed to get session work blocks", http.StatusInternalServerError)
 		return
 	}
@@ -412,7 +413,7 @@ func (si *ServerIntegration) HandleGetSessionWorkBlocks(w http.ResponseWriter, r
 	// Calculate total work time
 	totalWorkTime, err := si.workBlockManager.CalculateSessionWorkTime(ctx, sessionID)
 	if err != nil {
-		log.Printf("Warning: failed to calculate session work time: %v", err)
+		slog.WarnContext(r.Context(), "Failed to calculate session work time", "error", err)
 		totalWorkTime = 0
 	}
 	
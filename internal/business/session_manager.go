@@ -3,7 +3,7 @@
  * INPUT:     User ID and activity timestamps for session determination
  * OUTPUT:    Active sessions through database queries, automatic session creation
  * BUSINESS:  Sessions are active when NOW() BETWEEN start_time AND end_time
- * CHANGE:    Complete refactor from memory-based to database-backed session logic
+ * CHANGE:    Session lifecycle logged through slog with session/user attributes
  * RISK:      Medium - Core session logic replacement affecting all activity processing
  */
 
@@ -12,7 +12,7 @@ package business
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	"github.com/claude-monitor/system/internal/database/sqlite"
@@ -40,7 +40,7 @@ func NewSessionManager(sessionRepo *sqlite.SessionRepository) *SessionManager {
 	// Load America/Montevideo timezone for consistent time handling
 	timezone, err := time.LoadLocation("America/Montevideo")
 	if err != nil {
-		log.Printf("Warning: failed to load timezone America/Montevideo, using UTC: %v", err)
+		slog.Warn("Failed to load timezone, using UTC", "timezone", "America/Montevideo", "error", err)
 		timezone = time.UTC
 	}
 
@@ -103,7 +103,7 @@ func (sm *SessionManager) validateActiveSessions(ctx context.Context, sessions [
 
 	// Handle multiple active sessions (data inconsistency)
 	if len(sessions) > 1 {
-		log.Printf("⚠️  CRITICAL: Found %d active sessions, cleaning up duplicates", len(sessions))
+		slog.WarnContext(ctx, "Multiple active sessions found, cleaning up duplicates", "count", len(sessions))
 		return sm.cleanupDuplicateSessions(ctx, sessions, activityTime)
 	}
 
@@ -112,16 +112,16 @@ func (sm *SessionManager) validateActiveSessions(ctx context.Context, sessions [
 	if sm.isSessionActive(session, activityTime) {
 		sessionAge := activityTime.Sub(session.StartTime)
 		remaining := sm.sessionLength - sessionAge
-		log.Printf("♻️  Using existing session %s (age: %v, remaining: %v)", 
-			session.ID, sessionAge, remaining)
+		slog.DebugContext(ctx, "Using existing session",
+			"session_id", session.ID, "age", sessionAge, "remaining", remaining)
 		return session, nil
 	}
 
 	// Session is expired, mark it and return nil
-	log.Printf("⏰ Session %s expired, will create new session", session.ID)
+	slog.InfoContext(ctx, "Session expired, creating new session", "session_id", session.ID)
 	session.State = "expired"
 	if err := sm.sessionRepo.Update(ctx, session); err != nil {
-		log.Printf("Warning: failed to mark session as expired: %v", err)
+		slog.WarnContext(ctx, "Failed to mark session as expired", "error", err)
 	}
 	
 	return nil, nil
@@ -148,10 +148,10 @@ func (sm *SessionManager) cleanupDuplicateSessions(ctx context.Context, sessions
 	// Mark all others as expired
 	for _, session := range sessions {
 		if session.ID != mostRecent.ID {
-			log.Printf("🔄 Expiring duplicate session %s (started %v)", session.ID, session.StartTime)
+			slog.InfoContext(ctx, "Expiring duplicate session", "session_id", session.ID, "start_time", session.StartTime)
 			session.State = "expired"
 			if err := sm.sessionRepo.Update(ctx, session); err != nil {
-				log.Printf("Warning: failed to expire duplicate session %s: %v", session.ID, err)
+				slog.WarnContext(ctx, "Failed to expire duplicate session", "session_id", session.ID, "error", err)
 			}
 		}
 	}
@@ -162,10 +162,10 @@ func (sm *SessionManager) cleanupDuplicateSessions(ctx context.Context, sessions
 	}
 
 	// Most recent is also expired
-	log.Printf("🔄 Most recent session %s also expired", mostRecent.ID) 
+	slog.InfoContext(ctx, "Most recent session also expired", "session_id", mostRecent.ID) 
 	mostRecent.State = "expired"
 	if err := sm.sessionRepo.Update(ctx, mostRecent); err != nil {
-		log.Printf("Warning: failed to expire most recent session: %v", err)
+		slog.WarnContext(ctx, "Failed to expire most recent session", "error", err)
 	}
 
 	return nil, nil
@@ -238,9 +238,9 @@ func (sm *SessionManager) createNewSession(ctx context.Context, userID string, s
 		return nil, fmt.Errorf("failed to create new session: %w", err)
 	}
 
-	log.Printf("🆕 Created new session %s for user %s at %v (ends: %v)", 
-		session.ID, userID, startTime.Format("2006-01-02 15:04:05"),
-		session.EndTime.Format("2006-01-02 15:04:05"))
+	slog.InfoContext(ctx, "Created new session",
+		"session_id", session.ID, "user_id", userID,
+		"start_time", startTime, "end_time", session.EndTime)
 
 	return session, nil
 }
@@ -268,7 +268,7 @@ func (sm *SessionManager) GetActiveSession(ctx context.Context, userID string) (
 	}
 
 	if len(activeSessions) > 1 {
-		log.Printf("⚠️  Warning: Multiple active sessions found for user %s", userID)
+		slog.WarnContext(ctx, "Multiple active sessions found", "user_id", userID)
 	}
 
 	// Return most recent active session
@@ -297,7 +297,7 @@ func (sm *SessionManager) MarkExpiredSessions(ctx context.Context) (int, error)
 	}
 
 	if expiredCount > 0 {
-		log.Printf("⏰ Marked %d sessions as expired", expiredCount)
+		slog.InfoContext(ctx, "Marked sessions as expired", "count", expiredCount)
 	}
 
 	return expiredCount, nil
@@ -413,6 +413,6 @@ func (sm *SessionManager) CloseSession(ctx context.Context, sessionID string, cl
 		return fmt.Errorf("failed to close session: %w", err)
 	}
 	
-	log.Printf("\u2705 Closed session %s at %v", sessionID, closeTime)
+	slog.InfoContext(ctx, "Closed session", "session_id", sessionID, "close_time", closeTime)
 	return nil
 }
\ No newline at end of file
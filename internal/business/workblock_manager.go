@@ -3,7 +3,7 @@
  * INPUT:     Activity events with session, project, and timing information for work tracking
  * OUTPUT:    Managed work blocks with automatic project creation and activity coordination
  * BUSINESS:  Work blocks track active work periods with direct activity event integration
- * CHANGE:    Enhanced with activity management integration for CHECKPOINT 4
+ * CHANGE:    Replaced emoji log.Printf output with leveled slog records
  * RISK:      Medium - Core work tracking logic affecting time calculations and user reports
  */
 
@@ -12,7 +12,7 @@ package business
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	"github.com/claude-monitor/system/internal/database/sqlite"
@@ -58,7 +58,7 @@ func NewWorkBlockManager(workBlockRepo *sqlite.WorkBlockRepository, projectRepo
 	// Load timezone for consistent time handling
 	timezone, err := time.LoadLocation("America/Montevideo")
 	if err != nil {
-		log.Printf("Warning: failed to load timezone America/Montevideo, using UTC: %v", err)
+		slog.Warn("Failed to load timezone, using UTC", "timezone", "America/Montevideo", "error", err)
 		timezone = time.UTC
 	}
 
@@ -95,8 +95,8 @@ func (wbm *WorkBlockManager) ProcessActivity(ctx context.Context, sessionID, pro
 	// Convert activity time to timezone
 	activityTime = activityTime.In(wbm.timezone)
 
-	log.Printf("🔨 Processing work block activity: session=%s, path=%s, time=%s", 
-		sessionID, projectPath, activityTime.Format("2006-01-02 15:04:05"))
+	slog.DebugContext(ctx, "Processing work block activity",
+		"session_id", sessionID, "project_path", projectPath, "activity_time", activityTime)
 
 	// STEP 1: Get or create project from path
 	project, err := wbm.projectRepo.GetOrCreate(ctx, projectPath)
@@ -118,12 +118,12 @@ func (wbm *WorkBlockManager) ProcessActivity(ctx context.Context, sessionID, pro
 
 	// STEP 4: Check if existing work block is idle (>5 minutes since last activity)
 	if wbm.workBlockRepo.IsWorkBlockIdle(activeWorkBlock, activityTime) {
-		log.Printf("💤 Work block %s is idle (last activity: %v), creating new work block",
-			activeWorkBlock.ID, activeWorkBlock.LastActivityTime.Format("15:04:05"))
+		slog.InfoContext(ctx, "Work block idle, creating new work block",
+			"work_block_id", activeWorkBlock.ID, "last_activity", activeWorkBlock.LastActivityTime)
 		
 		// Finish the idle work block
 		if err := wbm.finishIdleWorkBlock(ctx, activeWorkBlock, activityTime); err != nil {
-			log.Printf("Warning: failed to finish idle work block: %v", err)
+			slog.WarnContext(ctx, "Failed to finish idle work block", "error", err)
 		}
 		
 		// Create new work block
@@ -141,8 +141,8 @@ func (wbm *WorkBlockManager) ProcessActivity(ctx context.Context, sessionID, pro
 		return nil, fmt.Errorf("failed to get updated work block: %w", err)
 	}
 
-	log.Printf("✅ Updated work block: id=%s, activities=%d, duration=%.2f hours",
-		updatedWorkBlock.ID, updatedWorkBlock.ActivityCount, updatedWorkBlock.DurationHours)
+	slog.DebugContext(ctx, "Updated work block",
+		"work_block_id", updatedWorkBlock.ID, "activity_count", updatedWorkBlock.ActivityCount, "duration_hours", updatedWorkBlock.DurationHours)
 
 	return updatedWorkBlock, nil
 }
@@ -177,8 +177,8 @@ func (wbm *WorkBlockManager) createNewWorkBlock(ctx context.Context, sessionID s
 		return nil, fmt.Errorf("failed to create new work block: %w", err)
 	}
 
-	log.Printf("🆕 Created new work block: id=%s, project=%s (%s), session=%s",
-		workBlock.ID, project.Name, project.Path, sessionID)
+	slog.InfoContext(ctx, "Created new work block",
+		"work_block_id", workBlock.ID, "project", project.Name, "project_path", project.Path, "session_id", sessionID)
 
 	return workBlock, nil
 }
@@ -204,8 +204,8 @@ func (wbm *WorkBlockManager) finishIdleWorkBlock(ctx context.Context, workBlock
 		return fmt.Errorf("failed to finish idle work block: %w", err)
 	}
 
-	log.Printf("🏁 Finished idle work block: id=%s, duration=%.2f hours (ended at %s)",
-		workBlock.ID, endTime.Sub(workBlock.StartTime).Hours(), endTime.Format("15:04:05"))
+	slog.InfoContext(ctx, "Finished idle work block",
+		"work_block_id", workBlock.ID, "duration_hours", endTime.Sub(workBlock.StartTime).Hours(), "end_time", endTime)
 
 	return nil
 }
@@ -284,7 +284,7 @@ func (wbm *WorkBlockManager) MarkIdleWorkBlocks(ctx context.Context) (int, error
 	}
 
 	if idleCount > 0 {
-		log.Printf("🧹 Idle detection completed: marked %d work blocks as idle", idleCount)
+		slog.InfoContext(ctx, "Idle detection completed", "idle_work_blocks", idleCount)
 	}
 
 	return idleCount, nil
@@ -317,7 +317,7 @@ func (wbm *WorkBlockManager) FinishWorkBlocksForSession(ctx context.Context, ses
 	for _, workBlock := range workBlocks {
 		if workBlock.EndTime == nil { // Only finish active work blocks
 			if err := wbm.workBlockRepo.FinishWorkBlock(ctx, workBlock.ID, endTime); err != nil {
-				log.Printf("Warning: failed to finish work block %s: %v", workBlock.ID, err)
+				slog.WarnContext(ctx, "Failed to finish work block", "work_block_id", workBlock.ID, "error", err)
 				continue
 			}
 			finishedCount++
@@ -325,7 +325,7 @@ func (wbm *WorkBlockManager) FinishWorkBlocksForSession(ctx context.Context, ses
 	}
 
 	if finishedCount > 0 {
-		log.Printf("🏁 Session cleanup: finished %d work blocks for session %s", finishedCount, sessionID)
+		slog.InfoContext(ctx, "Session cleanup finished work blocks", "count", finishedCount, "session_id", sessionID)
 	}
 
 	return finishedCount, nil
@@ -547,7 +547,7 @@ func (wbm *WorkBlockManager) CloseWorkBlock(ctx context.Context, workBlockID str
 		return fmt.Errorf("failed to close work block: %w", err)
 	}
 	
-	log.Printf("\u2705 Closed work block %s at %v (duration: %.2f hours)", workBlockID, closeTime, duration.Hours())
+	slog.InfoContext(ctx, "Closed work block", "work_block_id", workBlockID, "close_time", closeTime, "duration_hours", duration.Hours())
 	return nil
 }
 
@@ -630,7 +630,7 @@ func (wbm *WorkBlockManager) GetWorkBlockActivities(ctx context.Context, workBlo
 		return nil, fmt.Errorf("failed to get activities for work block: %w", err)
 	}
 
-	log.Printf("📋 Retrieved %d activities for work block %s", len(activities), workBlockID)
+	slog.DebugContext(ctx, "Retrieved work block activities", "count", len(activities), "work_block_id", workBlockID)
 	return activities, nil
 }
 
@@ -656,8 +656,8 @@ func (wbm *WorkBlockManager) ValidateWorkBlockActivityCount(ctx context.Context,
 	isValid := actualCount == expectedCount
 
 	if !isValid {
-		log.Printf("⚠️ Activity count mismatch for work block %s: expected=%d, actual=%d", 
-			workBlock.ID, expectedCount, actualCount)
+		slog.WarnContext(ctx, "Activity count mismatch for work block",
+			"work_block_id", workBlock.ID, "expected", expectedCount, "actual", actualCount)
 	}
 
 	return isValid, nil
@@ -699,7 +699,7 @@ func (wbm *WorkBlockManager) GetWorkBlockSummary(ctx context.Context, workBlockI
 	// Validate activity count
 	isValid, err := wbm.ValidateWorkBlockActivityCount(ctx, workBlock)
 	if err != nil {
-		log.Printf("Warning: failed to validate activity count: %v", err)
+		slog.WarnContext(ctx, "Failed to validate activity count", "error", err)
 		isValid = false
 	}
 
@@ -3,7 +3,7 @@
  * INPUT:     Activity events, session IDs, project paths for work block management
  * OUTPUT:    Active work blocks with proper state transitions and idle detection
  * BUSINESS:  Core work block operations with 5-minute idle timeout and automatic project creation
- * CHANGE:    Split from workblock_manager.go following Single Responsibility Principle
+ * CHANGE:    Leveled slog records; per-activity updates log at debug
  * RISK:      Medium - Core work tracking logic affecting time calculations and user reports
  */
 
@@ -12,7 +12,7 @@ package business
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	"github.com/claude-monitor/system/internal/database/sqlite"
@@ -45,7 +45,7 @@ func NewWorkBlockManagerCore(workBlockRepo *sqlite.WorkBlockRepository, projectR
 	// Load timezone for consistent time handling
 	timezone, err := time.LoadLocation("America/Montevideo")
 	if err != nil {
-		log.Printf("Warning: failed to load timezone America/Montevideo, using UTC: %v", err)
+		slog.Warn("Failed to load timezone, using UTC", "timezone", "America/Montevideo", "error", err)
 		timezone = time.UTC
 	}
 
@@ -82,8 +82,8 @@ func (wbmc *WorkBlockManagerCore) ProcessActivity(ctx context.Context, sessionID
 	// Convert activity time to timezone
 	activityTime = activityTime.In(wbmc.timezone)
 
-	log.Printf("🔨 Processing work block activity: session=%s, path=%s, time=%s", 
-		sessionID, projectPath, activityTime.Format("2006-01-02 15:04:05"))
+	slog.DebugContext(ctx, "Processing work block activity",
+		"session_id", sessionID, "project_path", projectPath, "activity_time", activityTime)
 
 	// STEP 1: Get or create project from path
 	project, err := wbmc.projectRepo.GetOrCreate(ctx, projectPath)
@@ -105,12 +105,12 @@ func (wbmc *WorkBlockManagerCore) ProcessActivity(ctx context.Context, sessionID
 
 	// STEP 4: Check if existing work block is idle (>5 minutes since last activity)
 	if wbmc.workBlockRepo.IsWorkBlockIdle(activeWorkBlock, activityTime) {
-		log.Printf("💤 Work block %s is idle (last activity: %v), creating new work block",
-			activeWorkBlock.ID, activeWorkBlock.LastActivityTime.Format("15:04:05"))
+		slog.InfoContext(ctx, "Work block idle, creating new work block",
+			"work_block_id", activeWorkBlock.ID, "last_activity", activeWorkBlock.LastActivityTime)
 		
 		// Finish the idle work block
 		if err := wbmc.finishIdleWorkBlock(ctx, activeWorkBlock, activityTime); err != nil {
-			log.Printf("Warning: failed to finish idle work block: %v", err)
+			slog.WarnContext(ctx, "Failed to finish idle work block", "error", err)
 		}
 		
 		// Create new work block
@@ -128,8 +128,8 @@ func (wbmc *WorkBlockManagerCore) ProcessActivity(ctx context.Context, sessionID
 		return nil, fmt.Errorf("failed to get updated work block: %w", err)
 	}
 
-	log.Printf("✅ Updated work block: id=%s, activities=%d, duration=%.2f hours",
-		updatedWorkBlock.ID, updatedWorkBlock.ActivityCount, updatedWorkBlock.DurationHours)
+	slog.DebugContext(ctx, "Updated work block",
+		"work_block_id", updatedWorkBlock.ID, "activity_count", updatedWorkBlock.ActivityCount, "duration_hours", updatedWorkBlock.DurationHours)
 
 	return updatedWorkBlock, nil
 }
@@ -164,8 +164,8 @@ func (wbmc *WorkBlockManagerCore) createNewWorkBlock(ctx context.Context, sessio
 		return nil, fmt.Errorf("failed to create new work block: %w", err)
 	}
 
-	log.Printf("🆕 Created new work block: id=%s, project=%s (%s), session=%s",
-		workBlock.ID, project.Name, project.Path, sessionID)
+	slog.InfoContext(ctx, "Created new work block",
+		"work_block_id", workBlock.ID, "project", project.Name, "project_path", project.Path, "session_id", sessionID)
 
 	return workBlock, nil
 }
@@ -191,8 +191,8 @@ func (wbmc *WorkBlockManagerCore) finishIdleWorkBlock(ctx context.Context, workB
 		return fmt.Errorf("failed to finish idle work block: %w", err)
 	}
 
-	log.Printf("🏁 Finished idle work block: id=%s, duration=%.2f hours (ended at %s)",
-		workBlock.ID, endTime.Sub(workBlock.StartTime).Hours(), endTime.Format("15:04:05"))
+	slog.InfoContext(ctx, "Finished idle work block",
+		"work_block_id", workBlock.ID, "duration_hours", endTime.Sub(workBlock.StartTime).Hours(), "end_time", endTime)
 
 	return nil
 }
@@ -271,7 +271,7 @@ func (wbmc *WorkBlockManagerCore) MarkIdleWorkBlocks(ctx context.Context) (int,
 	}
 
 	if idleCount > 0 {
-		log.Printf("🧹 Idle detection completed: marked %d work blocks as idle", idleCount)
+		slog.InfoContext(ctx, "Idle detection completed", "idle_work_blocks", idleCount)
 	}
 
 	return idleCount, nil
@@ -304,7 +304,7 @@ func (wbmc *WorkBlockManagerCore) FinishWorkBlocksForSession(ctx context.Context
 	for _, workBlock := range workBlocks {
 		if workBlock.EndTime == nil { // Only finish active work blocks
 			if err := wbmc.workBlockRepo.FinishWorkBlock(ctx, workBlock.ID, endTime); err != nil {
-				log.Printf("Warning: failed to finish work block %s: %v", workBlock.ID, err)
+				slog.WarnContext(ctx, "Failed to finish work block", "work_block_id", workBlock.ID, "error", err)
 				continue
 			}
 			finishedCount++
@@ -312,7 +312,7 @@ func (wbmc *WorkBlockManagerCore) FinishWorkBlocksForSession(ctx context.Context
 	}
 
 	if finishedCount > 0 {
-		log.Printf("🏁 Session cleanup: finished %d work blocks for session %s", finishedCount, sessionID)
+		slog.InfoContext(ctx, "Session cleanup finished work blocks", "count", finishedCount, "session_id", sessionID)
 	}
 
 	return finishedCount, nil
@@ -3,7 +3,7 @@
  * INPUT:     Work block IDs, project paths, activity data for project-related operations
  * OUTPUT:    Project-integrated work block data, validation results, and activity summaries
  * BUSINESS:  Project integration supporting work block organization and detailed activity analysis
- * CHANGE:    Split from workblock_manager.go following Single Responsibility Principle
+ * CHANGE:    Project sync and conflict logging moved to slog
  * RISK:      Low - Project integration and validation operations with activity repository
  */
 
@@ -12,7 +12,7 @@ package business
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"path/filepath"
 	"strings"
 
@@ -71,7 +71,7 @@ func (wbpi *WorkBlockProjectIntegration) GetWorkBlockActivities(ctx context.Cont
 		return nil, fmt.Errorf("failed to get activities for work block: %w", err)
 	}
 
-	log.Printf("📋 Retrieved %d activities for work block %s", len(activities), workBlockID)
+	slog.DebugContext(ctx, "Retrieved work block activities", "count", len(activities), "work_block_id", workBlockID)
 	return activities, nil
 }
 
@@ -97,8 +97,8 @@ func (wbpi *WorkBlockProjectIntegration) ValidateWorkBlockActivityCount(ctx cont
 	isValid := actualCount == expectedCount
 
 	if !isValid {
-		log.Printf("⚠️ Activity count mismatch for work block %s: expected=%d, actual=%d", 
-			workBlock.ID, expectedCount, actualCount)
+		slog.WarnContext(ctx, "Activity count mismatch for work block",
+			"work_block_id", workBlock.ID, "expected", expectedCount, "actual", actualCount)
 	}
 
 	return isValid, nil
@@ -138,7 +138,7 @@ func (wbpi *WorkBlockProjectIntegration) GetWorkBlockSummary(ctx context.Context
 	// Validate activity count
 	isValid, err := wbpi.ValidateWorkBlockActivityCount(ctx, workBlock)
 	if err != nil {
-		log.Printf("Warning: failed to validate activity count: %v", err)
+		slog.WarnContext(ctx, "Failed to validate activity count", "error", err)
 		isValid = false
 	}
 
@@ -227,7 +227,7 @@ func (wbpi *WorkBlockProjectIntegration) ValidateWorkBlock(ctx context.Context,
 		return fmt.Errorf("work block duration cannot be negative")
 	}
 
-	log.Printf("✅ Work block %s validation successful", workBlock.ID)
+	slog.DebugContext(ctx, "Work block validation successful", "work_block_id", workBlock.ID)
 	return nil
 }
 
@@ -260,7 +260,7 @@ func (wbpi *WorkBlockProjectIntegration) GetProjectFromPath(projectPath string)
 		projectName = projectName[1:] // Remove leading dot
 	}
 	
-	log.Printf("📋 Extracted project info: name='%s', path='%s'", projectName, normalizedPath)
+	slog.Debug("Extracted project info", "project", projectName, "project_path", normalizedPath)
 	
 	return projectName, normalizedPath, nil
 }
@@ -307,8 +307,8 @@ func (wbpi *WorkBlockProjectIntegration) UpdateWorkBlockProject(ctx context.Cont
 		return nil, fmt.Errorf("failed to update work block project: %w", err)
 	}
 
-	log.Printf("🔄 Updated work block %s project: %s -> %s (%s)", 
-		workBlockID, oldProjectID, newProject.ID, newProject.Name)
+	slog.InfoContext(ctx, "Updated work block project",
+		"work_block_id", workBlockID, "old_project_id", oldProjectID, "project_id", newProject.ID, "project", newProject.Name)
 
 	return workBlock, nil
 }
@@ -347,14 +347,14 @@ func (wbpi *WorkBlockProjectIntegration) SyncProjectData(ctx context.Context, pr
 		if wb.ProjectID == projectID {
 			// Work block already references this project - validate consistency
 			if err := wbpi.ValidateWorkBlock(ctx, wb); err != nil {
-				log.Printf("Warning: work block %s validation failed during sync: %v", wb.ID, err)
+				slog.WarnContext(ctx, "Work block validation failed during sync", "work_block_id", wb.ID, "error", err)
 				continue
 			}
 			syncedCount++
 		}
 	}
 
-	log.Printf("🔄 Synchronized %d work blocks for project %s (%s)", syncedCount, project.ID, project.Name)
+	slog.InfoContext(ctx, "Synchronized project work blocks", "count", syncedCount, "project_id", project.ID, "project", project.Name)
 	return syncedCount, nil
 }
 
@@ -389,14 +389,14 @@ func (wbpi *WorkBlockProjectIntegration) ResolveProjectConflicts(ctx context.Con
 		// Check if project exists
 		project, err := wbpi.projectRepo.GetByID(ctx, wb.ProjectID)
 		if err != nil {
-			log.Printf("Error checking project %s: %v", wb.ProjectID, err)
+			slog.ErrorContext(ctx, "Failed to check project", "project_id", wb.ProjectID, "error", err)
 			conflictsFound++
 			continue
 		}
 
 		if project == nil {
 			// Orphaned work block - project doesn't exist
-			log.Printf("Orphaned work block %s references non-existent project %s", wb.ID, wb.ProjectID)
+			slog.WarnContext(ctx, "Orphaned work block references missing project", "work_block_id", wb.ID, "project_id", wb.ProjectID)
 			orphanedCount++
 			conflictsFound++
 			
@@ -413,7 +413,7 @@ func (wbpi *WorkBlockProjectIntegration) ResolveProjectConflicts(ctx context.Con
 
 		// Validate work block
 		if err := wbpi.ValidateWorkBlock(ctx, wb); err != nil {
-			log.Printf("Invalid work block %s: %v", wb.ID, err)
+			slog.WarnContext(ctx, "Invalid work block", "work_block_id", wb.ID, "error", err)
 			conflictsFound++
 			invalidProjects = append(invalidProjects, wb.ProjectID)
 		}
@@ -424,8 +424,8 @@ func (wbpi *WorkBlockProjectIntegration) ResolveProjectConflicts(ctx context.Con
 	results["orphaned_work_blocks"] = orphanedCount
 	results["invalid_projects"] = invalidProjects
 
-	log.Printf("🔧 Project conflict resolution: %d found, %d resolved, %d orphaned", 
-		conflictsFound, conflictsResolved, orphanedCount)
+	slog.InfoContext(ctx, "Project conflict resolution completed",
+		"found", conflictsFound, "resolved", conflictsResolved, "orphaned", orphanedCount)
 
 	return results, nil
 }
@@ -3,7 +3,7 @@
  * INPUT:     Session IDs, timestamps, limits for session-related work block operations
  * OUTPUT:    Session work block management, cleanup results, and health status
  * BUSINESS:  Session-oriented work block operations supporting session lifecycle management
- * CHANGE:    Split from workblock_manager.go following Single Responsibility Principle
+ * CHANGE:    Session-end finalization logged through slog
  * RISK:      Medium - Session state management affecting work block lifecycle
  */
 
@@ -12,7 +12,7 @@ package business
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	"github.com/claude-monitor/system/internal/database/sqlite"
@@ -38,7 +38,7 @@ func NewWorkBlockSessionManager(workBlockRepo *sqlite.WorkBlockRepository, proje
 	// Load timezone for consistent time handling
 	timezone, err := time.LoadLocation("America/Montevideo")
 	if err != nil {
-		log.Printf("Warning: failed to load timezone America/Montevideo, using UTC: %v", err)
+		slog.Warn("Failed to load timezone, using UTC", "timezone", "America/Montevideo", "error", err)
 		timezone = time.UTC
 	}
 
@@ -120,7 +120,7 @@ func (wbsm *WorkBlockSessionManager) CloseWorkBlock(ctx context.Context, workBlo
 		return fmt.Errorf("failed to close work block: %w", err)
 	}
 	
-	log.Printf("✅ Closed work block %s at %v (duration: %.2f hours)", workBlockID, closeTime, duration.Hours())
+	slog.InfoContext(ctx, "Closed work block", "work_block_id", workBlockID, "close_time", closeTime, "duration_hours", duration.Hours())
 	return nil
 }
 
@@ -175,7 +175,7 @@ func (wbsm *WorkBlockSessionManager) HandleSessionEnd(ctx context.Context, sessi
 	for _, workBlock := range workBlocks {
 		if workBlock.EndTime == nil && workBlock.State == "active" {
 			if err := wbsm.workBlockRepo.FinishWorkBlock(ctx, workBlock.ID, endTime); err != nil {
-				log.Printf("Warning: failed to finish work block %s on session end: %v", workBlock.ID, err)
+				slog.WarnContext(ctx, "Failed to finish work block on session end", "work_block_id", workBlock.ID, "error", err)
 				continue
 			}
 			finishedCount++
@@ -183,51 +183,12 @@ func (wbsm *WorkBlockSessionManager) HandleSessionEnd(ctx context.Context, sessi
 	}
 
 	if finishedCount > 0 {
-		log.Printf("🏁 Session end: finalized %d work blocks for session %s", finishedCount, sessionID)
+		slog.InfoContext(ctx, "Session end finalized work blocks", "count", finishedCount, "session_id", sessionID)
 	}
 
 	return finishedCount, nil
 }
 
-/**
- * CONTEXT:   Clean up old work blocks based on retention policy
- * INPUT:     Context and retention duration for cleanup operations
- * OUTPUT:    Number of work blocks cleaned up
- * BUSINESS:  Cleanup operations maintain database performance and enforce retention policies
- * CHANGE:    Initial cleanup implementation with configurable retention
- * RISK:      Medium - Data deletion operation with retention policy enforcement
- */
-func (wbsm *WorkBlockSessionManager) CleanupOldWorkBlocks(ctx context.Context, retentionDays int) (int, error) {
-	if retentionDays <= 0 {
-		retentionDays = 90 // Default 90-day retention
-	}
-
-	cutoffTime := time.Now().In(wbsm.timezone).AddDate(0, 0, -retentionDays)
-	
-	allWorkBlocks, err := wbsm.workBlockRepo.GetAll(ctx)
-	if err != nil {
-		return 0, fmt.Errorf("failed to get work blocks for cleanup: %w", err)
-	}
-
-	cleanedCount := 0
-	for _, workBlock := range allWorkBlocks {
-		// Only clean up finished work blocks older than cutoff
-		if workBlock.EndTime != nil && workBlock.EndTime.Before(cutoffTime) {
-			if err := wbsm.workBlockRepo.Delete(ctx, workBlock.ID); err != nil {
-				log.Printf("Warning: failed to delete old work block %s: %v", workBlock.ID, err)
-				continue
-			}
-			cleanedCount++
-		}
-	}
-
-	if cleanedCount > 0 {
-		log.Printf("🧹 Cleanup completed: removed %d work blocks older than %d days", cleanedCount, retentionDays)
-	}
-
-	return cleanedCount, nil
-}
-
 /**
  * CONTEXT:   Process session work blocks for analysis and reporting
  * INPUT:     Session ID and processing options for work block operations
@@ -272,8 +233,8 @@ func (wbsm *WorkBlockSessionManager) ProcessSessionWorkBlocks(ctx context.Contex
 		"processed_at":       time.Now().In(wbsm.timezone),
 	}
 
-	log.Printf("📊 Processed session %s: %d work blocks, %.2f hours, %d activities", 
-		sessionID, len(workBlocks), totalHours, totalActivities)
+	slog.InfoContext(ctx, "Processed session",
+		"session_id", sessionID, "work_blocks", len(workBlocks), "hours", totalHours, "activities", totalActivities)
 
 	return result, nil
 }
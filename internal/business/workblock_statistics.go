@@ -3,7 +3,7 @@
  * INPUT:     Session IDs, date ranges, work block entities for statistical analysis
  * OUTPUT:    Calculated metrics, counts, and analytics data for reporting
  * BUSINESS:  Statistical operations supporting productivity reporting and system monitoring
- * CHANGE:    Split from workblock_manager.go following Single Responsibility Principle
+ * CHANGE:    Statistics logging moved to slog at debug level
  * RISK:      Low - Read-only statistical calculations with no state modifications
  */
 
@@ -12,7 +12,7 @@ package business
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 
 	"github.com/claude-monitor/system/internal/database/sqlite"
 )
@@ -238,7 +238,7 @@ func (wbs *WorkBlockStatistics) CalculateAverageSessionDuration(ctx context.Cont
 	}
 
 	average := totalDuration / float64(len(sessionDurations))
-	log.Printf("📊 Calculated average session duration: %.2f hours across %d sessions", average, len(sessionDurations))
+	slog.DebugContext(ctx, "Calculated average session duration", "hours", average, "sessions", len(sessionDurations))
 
 	return average, nil
 }
@@ -0,0 +1,82 @@
+/**
+ * CONTEXT:   Daemon configuration for container images
+ * INPUT:     CLAUDE_MONITOR_* environment variables only; no config file
+ * OUTPUT:    Validated daemon configuration with container defaults applied
+ * BUSINESS:  A container reads its settings from the environment, keeps state
+ *            on a mounted volume and logs to stdout for the runtime to collect
+ * CHANGE:    Initial container configuration
+ * RISK:      Medium - Binds all interfaces, so an auth token is mandatory
+ */
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// DefaultContainerDataDir is where the image expects its data volume
+	DefaultContainerDataDir = "/data"
+
+	// DefaultContainerListenAddr binds every interface so published ports reach the daemon
+	DefaultContainerListenAddr = "0.0.0.0:" + DefaultDaemonPort
+)
+
+/**
+ * CONTEXT:   Load container configuration from the environment
+ * INPUT:     CLAUDE_MONITOR_LISTEN_ADDR, CLAUDE_MONITOR_DATA_DIR,
+ *            CLAUDE_MONITOR_AUTH_TOKEN or CLAUDE_MONITOR_AUTH_TOKEN_FILE, and
+ *            every variable LoadFromEnvironment understands
+ * OUTPUT:    Daemon configuration, or an error when no auth token is set
+ * BUSINESS:  The database, backups and prompt status file live under the data
+ *            volume unless overridden; logs are JSON on stdout
+ * CHANGE:    Keep CLAUDE_MONITOR_BACKUP_PATH instead of always using the data volume
+ * RISK:      Medium - Refuses to start an unauthenticated daemon on 0.0.0.0
+ */
+func LoadContainerConfig() (*DaemonConfig, error) {
+	config := LoadFromEnvironment()
+
+	if os.Getenv("CLAUDE_MONITOR_LISTEN_ADDR") == "" {
+		config.Server.ListenAddr = DefaultContainerListenAddr
+		config.Server.Host = "0.0.0.0"
+	}
+
+	dataDir := os.Getenv("CLAUDE_MONITOR_DATA_DIR")
+	if dataDir == "" {
+		dataDir = DefaultContainerDataDir
+	}
+	if os.Getenv("CLAUDE_MONITOR_DB_PATH") == "" {
+		config.Database.Path = filepath.Join(dataDir, "claude_monitor.db")
+	}
+	if os.Getenv("CLAUDE_MONITOR_BACKUP_PATH") == "" {
+		config.Database.BackupPath = filepath.Join(dataDir, "backups")
+	}
+	if os.Getenv("CLAUDE_MONITOR_STATUS_FILE") == "" {
+		config.WorkTracking.StatusFile = filepath.Join(dataDir, "status.json")
+	}
+
+	// The container runtime collects stdout; a log file would fill the volume
+	config.Logging.Format = "json"
+	config.Logging.OutputFile = ""
+
+	if config.Server.AuthToken == "" {
+		if tokenFile := os.Getenv("CLAUDE_MONITOR_AUTH_TOKEN_FILE"); tokenFile != "" {
+			data, err := os.ReadFile(tokenFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read auth token file: %w", err)
+			}
+			config.Server.AuthToken = strings.TrimSpace(string(data))
+		}
+	}
+	if config.Server.AuthToken == "" {
+		return nil, fmt.Errorf("container mode requires CLAUDE_MONITOR_AUTH_TOKEN or CLAUDE_MONITOR_AUTH_TOKEN_FILE")
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid container configuration: %w", err)
+	}
+	return config, nil
+}
@@ -12,8 +12,11 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -43,6 +46,18 @@ type DaemonConfig struct {
 	
 	// Health and monitoring
 	Health HealthConfig `json:"health"`
+	
+	// Calendar feed
+	Calendar CalendarConfig `json:"calendar"`
+	
+	// Project detection rules
+	Projects ProjectsConfig `json:"projects"`
+	
+	// Data retention and purge schedule
+	Retention RetentionConfig `json:"retention"`
+	
+	// Alert delivery channels and work alerts
+	Alerts AlertsConfig `json:"alerts"`
 }
 
 type ServerConfig struct {
@@ -56,6 +71,7 @@ type ServerConfig struct {
 	TLSEnabled      bool          `json:"tls_enabled"`
 	TLSCertFile     string        `json:"tls_cert_file"`
 	TLSKeyFile      string        `json:"tls_key_file"`
+	AuthToken       string        `json:"auth_token,omitempty"`
 }
 
 type DatabaseConfig struct {
@@ -65,6 +81,9 @@ type DatabaseConfig struct {
 	BackupEnabled       bool          `json:"backup_enabled"`
 	BackupInterval      time.Duration `json:"backup_interval"`
 	BackupPath          string        `json:"backup_path"`
+	BackupKeepLast      int           `json:"backup_keep_last"`
+	BackupKeepDaily     int           `json:"backup_keep_daily"`
+	BackupKeepWeekly    int           `json:"backup_keep_weekly"`
 	MaxConnections      int           `json:"max_connections"`
 	MaxIdleConnections  int           `json:"max_idle_connections"`
 	ConnectTimeout      time.Duration `json:"connect_timeout"`
@@ -84,6 +103,7 @@ type WorkTrackingConfig struct {
 	IdleTimeout     time.Duration `json:"idle_timeout"`
 	CleanupInterval time.Duration `json:"cleanup_interval"`
 	AutoFinalize    bool          `json:"auto_finalize"`
+	StatusFile      string        `json:"status_file"`
 }
 
 type PerformanceConfig struct {
@@ -95,6 +115,89 @@ type PerformanceConfig struct {
 	RateLimitRPS          int           `json:"rate_limit_rps"`
 }
 
+// CalendarConfig controls the iCalendar feed; an empty token disables it.
+// The token only grants access to User's work, which defaults to the daemon user.
+type CalendarConfig struct {
+	Token string `json:"token"`
+	User  string `json:"user,omitempty"`
+}
+
+// ProjectsConfig holds the ordered path rules applied when activity is ingested
+type ProjectsConfig struct {
+	Rules       []ProjectRuleConfig `json:"rules"`
+	CustomNames map[string]string   `json:"custom_names"`
+}
+
+// ProjectRuleConfig maps a glob (match) or regex path pattern to project labels, or ignores it
+type ProjectRuleConfig struct {
+	Match  string   `json:"match,omitempty"`
+	Regex  string   `json:"regex,omitempty"`
+	Name   string   `json:"name,omitempty"`
+	Group  string   `json:"group,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+	Ignore bool     `json:"ignore,omitempty"`
+}
+
+// RetentionConfig sets how long raw data is kept; 0 days keeps a table forever
+type RetentionConfig struct {
+	ActivityEventsDays int  `json:"activity_events_days"`
+	WorkBlocksDays     int  `json:"work_blocks_days"`
+	IntervalHours      int  `json:"interval_hours"`
+	BatchSize          int  `json:"batch_size"`
+	Vacuum             bool `json:"vacuum"`
+}
+
+// AlertsConfig routes health and work alerts to delivery channels
+type AlertsConfig struct {
+	Channels             []AlertChannelConfig `json:"channels"`
+	Rules                []AlertRuleConfig    `json:"rules"`
+	CooldownMinutes      int                  `json:"cooldown_minutes"`
+	SessionEndingMinutes int                  `json:"session_ending_minutes"`
+	ContinuousWorkHours  float64              `json:"continuous_work_hours"`
+	BreakMinutes         int                  `json:"break_minutes"`
+	GoalReached          bool                 `json:"goal_reached"`
+}
+
+// AlertChannelConfig is one delivery channel: command, webhook, email or file
+type AlertChannelConfig struct {
+	Name    string            `json:"name"`
+	Type    string            `json:"type"`
+	Command []string          `json:"command,omitempty"`
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Path    string            `json:"path,omitempty"`
+	SMTP    SMTPConfig        `json:"smtp,omitempty"`
+}
+
+// SMTPConfig holds the mail server and envelope for an email channel
+type SMTPConfig struct {
+	Host     string   `json:"host,omitempty"`
+	Port     int      `json:"port,omitempty"`
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+	From     string   `json:"from,omitempty"`
+	To       []string `json:"to,omitempty"`
+}
+
+// AlertRuleConfig sends matching alerts to channels; empty filters match everything
+type AlertRuleConfig struct {
+	Channels        []string `json:"channels"`
+	Sources         []string `json:"sources,omitempty"`
+	Levels          []string `json:"levels,omitempty"`
+	Types           []string `json:"types,omitempty"`
+	CooldownMinutes int      `json:"cooldown_minutes,omitempty"`
+}
+
+// Enabled reports whether any delivery channel is configured
+func (ac AlertsConfig) Enabled() bool {
+	return len(ac.Channels) > 0
+}
+
+// Enabled reports whether any table has a retention limit
+func (rc RetentionConfig) Enabled() bool {
+	return rc.ActivityEventsDays > 0 || rc.WorkBlocksDays > 0
+}
+
 type HealthConfig struct {
 	EnableHealthCheck bool          `json:"enable_health_check"`
 	HealthCheckPath   string        `json:"health_check_path"`
@@ -130,6 +233,9 @@ func NewDefaultConfig() *DaemonConfig {
 			BackupEnabled:      true,
 			BackupInterval:     24 * time.Hour,
 			BackupPath:         "./data/backups",
+			BackupKeepLast:     7,
+			BackupKeepDaily:    7,
+			BackupKeepWeekly:   4,
 			MaxConnections:     25,
 			MaxIdleConnections: 5,
 			ConnectTimeout:     10 * time.Second,
@@ -147,6 +253,7 @@ func NewDefaultConfig() *DaemonConfig {
 			IdleTimeout:     5 * time.Minute, // Work block idle timeout
 			CleanupInterval: 2 * time.Minute, // Cleanup frequency
 			AutoFinalize:    true,
+			StatusFile:      "./data/status.json", // Prompt snapshot; empty disables
 		},
 		Performance: PerformanceConfig{
 			MaxConcurrentRequests: 1000,
@@ -163,6 +270,18 @@ func NewDefaultConfig() *DaemonConfig {
 			MetricsPath:       "/metrics",
 			CheckInterval:     30 * time.Second,
 		},
+		Retention: RetentionConfig{
+			IntervalHours: 24,
+			BatchSize:     500,
+			Vacuum:        true,
+		},
+		Alerts: AlertsConfig{
+			CooldownMinutes:      60,
+			SessionEndingMinutes: 15,
+			ContinuousWorkHours:  5,
+			BreakMinutes:         15,
+			GoalReached:          true,
+		},
 	}
 }
 
@@ -217,21 +336,35 @@ func LoadDaemonConfig(configPath string) (*DaemonConfig, error) {
  * INPUT:     Environment variables with CLAUDE_MONITOR_ prefix
  * OUTPUT:    Daemon configuration with environment overrides applied
  * BUSINESS:  Support container and deployment environments with env var configuration
- * CHANGE:    Initial environment variable configuration support
+ * CHANGE:    Added the backup directory
  * RISK:      Medium - Environment variable parsing with type conversion
  */
 func LoadFromEnvironment() *DaemonConfig {
 	config := NewDefaultConfig()
 	
-	// Server configuration from environment
+	// Server configuration from environment; the server binds Host:Port
 	if addr := os.Getenv("CLAUDE_MONITOR_LISTEN_ADDR"); addr != "" {
 		config.Server.ListenAddr = addr
+		if host, port, err := net.SplitHostPort(addr); err == nil {
+			config.Server.Host = host
+			if p, err := strconv.Atoi(port); err == nil {
+				config.Server.Port = p
+			}
+		}
+	}
+	
+	if token := os.Getenv("CLAUDE_MONITOR_AUTH_TOKEN"); token != "" {
+		config.Server.AuthToken = token
 	}
 	
 	if dbPath := os.Getenv("CLAUDE_MONITOR_DB_PATH"); dbPath != "" {
 		config.Database.Path = dbPath
 	}
 	
+	if backupPath := os.Getenv("CLAUDE_MONITOR_BACKUP_PATH"); backupPath != "" {
+		config.Database.BackupPath = backupPath
+	}
+	
 	if logLevel := os.Getenv("CLAUDE_MONITOR_LOG_LEVEL"); logLevel != "" {
 		config.Logging.Level = logLevel
 	}
@@ -244,6 +377,14 @@ func LoadFromEnvironment() *DaemonConfig {
 		config.Logging.OutputFile = logFile
 	}
 	
+	if calendarToken := os.Getenv("CLAUDE_MONITOR_CALENDAR_TOKEN"); calendarToken != "" {
+		config.Calendar.Token = calendarToken
+	}
+	
+	if statusFile := os.Getenv("CLAUDE_MONITOR_STATUS_FILE"); statusFile != "" {
+		config.WorkTracking.StatusFile = statusFile
+	}
+	
 	// Parse duration environment variables
 	if sessionDuration := os.Getenv("CLAUDE_MONITOR_SESSION_DURATION"); sessionDuration != "" {
 		if dur, err := time.ParseDuration(sessionDuration); err == nil {
@@ -323,6 +464,12 @@ func (dc *DaemonConfig) Validate() error {
 		if dc.Database.BackupInterval <= 0 {
 			return fmt.Errorf("backup interval must be positive when backup enabled, got %v", dc.Database.BackupInterval)
 		}
+		if dc.Database.BackupKeepLast < 0 || dc.Database.BackupKeepDaily < 0 || dc.Database.BackupKeepWeekly < 0 {
+			return fmt.Errorf("backup keep counts cannot be negative")
+		}
+		if dc.Database.BackupKeepLast+dc.Database.BackupKeepDaily+dc.Database.BackupKeepWeekly == 0 {
+			return fmt.Errorf("backup rotation must keep at least one backup")
+		}
 		
 		// Ensure backup directory exists
 		if err := os.MkdirAll(dc.Database.BackupPath, 0755); err != nil {
@@ -383,6 +530,29 @@ func (dc *DaemonConfig) Validate() error {
 		return fmt.Errorf("rate limit RPS must be positive, got %d", dc.Performance.RateLimitRPS)
 	}
 	
+	// Validate retention configuration
+	if dc.Retention.ActivityEventsDays < 0 || dc.Retention.WorkBlocksDays < 0 {
+		return fmt.Errorf("retention days cannot be negative")
+	}
+	
+	if dc.Retention.Enabled() && dc.Retention.IntervalHours <= 0 {
+		return fmt.Errorf("retention interval must be positive when retention is enabled, got %d hours", dc.Retention.IntervalHours)
+	}
+	
+	if dc.Retention.BatchSize <= 0 {
+		return fmt.Errorf("retention batch size must be positive, got %d", dc.Retention.BatchSize)
+	}
+	
+	// Validate alert configuration
+	if err := dc.Alerts.Validate(); err != nil {
+		return fmt.Errorf("invalid alerts configuration: %w", err)
+	}
+	
+	// Validate health configuration
+	if dc.Health.EnableHealthCheck && dc.Health.CheckInterval <= 0 {
+		return fmt.Errorf("health check interval must be positive, got %v", dc.Health.CheckInterval)
+	}
+	
 	return nil
 }
 
@@ -438,4 +608,66 @@ func (dc *DaemonConfig) GetDatabasePath() string {
  */
 func (dc *DaemonConfig) GetServerAddr() string {
 	return dc.Server.ListenAddr
-}
\ No newline at end of file
+}
+
+/**
+ * CONTEXT:   Validate alert channels and routing rules
+ * INPUT:     Alerts configuration
+ * OUTPUT:    Error naming the first invalid channel or rule
+ * BUSINESS:  A typo in a channel should stop the daemon at startup, not
+ *            silently drop alerts later
+ * CHANGE:    Initial alerts validation
+ * RISK:      Low - Validation only
+ */
+func (ac AlertsConfig) Validate() error {
+	if ac.CooldownMinutes < 0 || ac.SessionEndingMinutes < 0 || ac.BreakMinutes < 0 || ac.ContinuousWorkHours < 0 {
+		return fmt.Errorf("alert thresholds cannot be negative")
+	}
+
+	names := make(map[string]bool, len(ac.Channels))
+	for i, channel := range ac.Channels {
+		if channel.Name == "" {
+			return fmt.Errorf("channel %d has no name", i+1)
+		}
+		if names[channel.Name] {
+			return fmt.Errorf("duplicate channel %q", channel.Name)
+		}
+		names[channel.Name] = true
+
+		switch channel.Type {
+		case "command":
+			if len(channel.Command) == 0 {
+				return fmt.Errorf("channel %q: command is required", channel.Name)
+			}
+		case "webhook":
+			if !strings.HasPrefix(channel.URL, "http://") && !strings.HasPrefix(channel.URL, "https://") {
+				return fmt.Errorf("channel %q: url must be http or https", channel.Name)
+			}
+		case "email":
+			if channel.SMTP.Host == "" || channel.SMTP.From == "" || len(channel.SMTP.To) == 0 {
+				return fmt.Errorf("channel %q: smtp host, from and to are required", channel.Name)
+			}
+		case "file":
+			if channel.Path == "" {
+				return fmt.Errorf("channel %q: path is required", channel.Name)
+			}
+		default:
+			return fmt.Errorf("channel %q: unknown type %q (use command, webhook, email or file)", channel.Name, channel.Type)
+		}
+	}
+
+	for i, rule := range ac.Rules {
+		if len(rule.Channels) == 0 {
+			return fmt.Errorf("rule %d has no channels", i+1)
+		}
+		for _, name := range rule.Channels {
+			if !names[name] {
+				return fmt.Errorf("rule %d: unknown channel %q", i+1, name)
+			}
+		}
+		if rule.CooldownMinutes < 0 {
+			return fmt.Errorf("rule %d: cooldown cannot be negative", i+1)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/**
+ * CONTEXT:   Admin token guarding the /api/v1/admin endpoints
+ * INPUT:     Configured auth token, or a random token written next to the database
+ * OUTPUT:    Middleware rejecting admin calls without the token, and the token file
+ * BUSINESS:  Backup and restore replace or copy all tracked data, so they always need
+ *            a token; without a configured auth token the daemon writes a fresh one
+ *            only the database owner can read, and the CLI sends it
+ * CHANGE:    Initial admin token
+ * RISK:      High - A missing check lets any local process restore the database;
+ *            compares in constant time and fails closed if the token cannot be written
+ */
+
+package daemon
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// adminTokenFile is the token file name in the database directory
+const adminTokenFile = "admin.token"
+
+// AdminTokenPath returns the admin token file for the database at databasePath
+func AdminTokenPath(databasePath string) string {
+	return filepath.Join(filepath.Dir(databasePath), adminTokenFile)
+}
+
+// ReadAdminToken returns the admin token written by a daemon using databasePath, or ""
+func ReadAdminToken(databasePath string) string {
+	data, err := os.ReadFile(AdminTokenPath(databasePath))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// writeAdminToken stores a new random token at path, readable by the owner only
+func writeAdminToken(path string) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate admin token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	tmpPath := path + ".tmp"
+	os.Remove(tmpPath)
+	if err := os.WriteFile(tmpPath, []byte(token+"\n"), 0600); err != nil {
+		return "", fmt.Errorf("failed to write admin token: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to write admin token: %w", err)
+	}
+	return token, nil
+}
+
+// setupAdminToken picks the configured auth token, or writes a fresh admin token
+func (o *Orchestrator) setupAdminToken() {
+	if o.config.Server.AuthToken != "" {
+		o.adminToken = o.config.Server.AuthToken
+		return
+	}
+	path := AdminTokenPath(o.config.Database.Path)
+	token, err := writeAdminToken(path)
+	if err != nil {
+		// Fail closed: admin endpoints reject every request
+		o.logger.Warn("Admin endpoints disabled", "path", path, "error", err)
+		return
+	}
+	o.adminToken = token
+}
+
+/**
+ * CONTEXT:   Bearer token check for admin endpoints
+ * INPUT:     Admin HTTP handler
+ * OUTPUT:    Handler answering 401 unless the request carries the admin token
+ * BUSINESS:  Applies even when the daemon runs without global auth on localhost
+ * CHANGE:    Initial admin auth
+ * RISK:      High - An empty admin token rejects every request
+ */
+func (o *Orchestrator) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		scheme, supplied, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+		if o.adminToken == "" || !strings.EqualFold(scheme, "Bearer") ||
+			subtle.ConstantTimeCompare([]byte(strings.TrimSpace(supplied)), []byte(o.adminToken)) != 1 {
+			o.logger.Warn("Rejected admin request",
+				"remote_addr", r.RemoteAddr,
+				"endpoint", r.URL.Path)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="claude-monitor-admin"`)
+			writeAPIError(w, http.StatusUnauthorized, "admin token required")
+			return
+		}
+		next(w, r)
+	}
+}
@@ -0,0 +1,278 @@
+/**
+ * CONTEXT:   Health and work alert delivery for the daemon
+ * INPUT:     Health monitor alert changes, active sessions, work blocks and goals
+ * OUTPUT:    Notifications sent through the configured alert channels
+ * BUSINESS:  "Session window ends in 15 minutes", "5 hours of continuous work"
+ *            and "goal reached" reach the user without opening a report
+ * CHANGE:    Initial alert delivery
+ * RISK:      Medium - Dedup state is in memory, so a daemon restart can repeat a
+ *            goal alert already sent earlier in the period
+ */
+
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/health"
+	"github.com/claude-monitor/system/internal/notify"
+	"github.com/claude-monitor/system/internal/reporting"
+)
+
+// Work alert evaluation schedule
+const (
+	workAlertsStartDelay = time.Minute
+	workAlertsInterval   = time.Minute
+	continuousLookback   = 24 * time.Hour
+)
+
+// Work alert types
+const (
+	alertTypeSessionWindow  = "session_window"
+	alertTypeContinuousWork = "continuous_work"
+	alertTypeGoal           = "goal"
+)
+
+// setupAlerts creates the dispatcher and connects the health monitor to it
+func (o *Orchestrator) setupAlerts() error {
+	if !o.config.Alerts.Enabled() {
+		return nil
+	}
+
+	dispatcher, err := notify.NewDispatcher(o.config.Alerts, o.logger)
+	if err != nil {
+		return fmt.Errorf("failed to configure alert channels: %w", err)
+	}
+	o.notifier = dispatcher
+
+	if o.health != nil {
+		o.health.OnAlert(o.deliverHealthAlert)
+	}
+	return nil
+}
+
+// deliverHealthAlert forwards a raised or resolved health alert
+func (o *Orchestrator) deliverHealthAlert(alert health.Alert) {
+	state := "raised"
+	title := fmt.Sprintf("Health check %s failing", alert.Check)
+	if alert.Resolved {
+		state = "resolved"
+		title = fmt.Sprintf("Health check %s recovered", alert.Check)
+	}
+
+	o.notifier.Send(o.ctx, notify.Notification{
+		Key:       fmt.Sprintf("health:%s:%s:%s", alert.Check, alert.ID, state),
+		Source:    notify.SourceHealth,
+		Level:     string(alert.Level),
+		Type:      string(alert.Type),
+		Title:     title,
+		Message:   alert.Message,
+		Timestamp: alert.Timestamp,
+		Metadata:  alert.Metadata,
+	})
+}
+
+// workAlertsJob evaluates work alerts every minute
+func (o *Orchestrator) workAlertsJob() scheduledJob {
+	return scheduledJob{
+		name:         "work-alerts",
+		initialDelay: workAlertsStartDelay,
+		interval:     workAlertsInterval,
+		run:          o.runWorkAlerts,
+	}
+}
+
+/**
+ * CONTEXT:   Evaluate work alerts for every user with an active session
+ * INPUT:     Active sessions, recent work blocks and goal progress
+ * OUTPUT:    Session window, continuous work and goal notifications
+ * BUSINESS:  Each alert carries a key unique to the session, work stretch or
+ *            goal period, so the cooldown suppresses per-minute repeats; goal
+ *            alerts are also remembered until their period ends
+ * CHANGE:    Initial work alert evaluation
+ * RISK:      Low - Read-only queries once a minute
+ */
+func (o *Orchestrator) runWorkAlerts(ctx context.Context) error {
+	sessions, err := sqlite.NewSessionRepository(o.db).GetActiveSessions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load active sessions: %w", err)
+	}
+
+	now := time.Now()
+	alerts := o.config.Alerts
+	users := make(map[string]bool)
+	for _, session := range sessions {
+		users[session.UserID] = true
+		o.checkSessionWindow(ctx, session, now, time.Duration(alerts.SessionEndingMinutes)*time.Minute)
+	}
+
+	for userID := range users {
+		if alerts.ContinuousWorkHours > 0 {
+			if err := o.checkContinuousWork(ctx, userID, now); err != nil {
+				return err
+			}
+		}
+		if alerts.GoalReached && o.reportingSvc != nil {
+			if err := o.checkGoals(ctx, userID, now); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkSessionWindow warns once when a session's 5-hour window is about to end
+func (o *Orchestrator) checkSessionWindow(ctx context.Context, session *sqlite.Session, now time.Time, window time.Duration) {
+	remaining := session.EndTime.Sub(now)
+	if window <= 0 || remaining <= 0 || remaining > window {
+		return
+	}
+
+	minutes := int(remaining.Round(time.Minute) / time.Minute)
+	o.notifier.Send(ctx, notify.Notification{
+		Key:     "work:session_window:" + session.ID,
+		Source:  notify.SourceWork,
+		Level:   notify.LevelInfo,
+		Type:    alertTypeSessionWindow,
+		Title:   fmt.Sprintf("Session window ends in %d minutes", minutes),
+		Message: fmt.Sprintf("The Claude session started at %s ends at %s.", session.StartTime.Local().Format("15:04"), session.EndTime.Local().Format("15:04")),
+		Metadata: map[string]string{
+			"user_id":    session.UserID,
+			"session_id": session.ID,
+			"end_time":   session.EndTime.UTC().Format(time.RFC3339),
+		},
+	})
+}
+
+// checkContinuousWork warns for each full hour past the threshold in a stretch
+// of work blocks separated by less than the configured break
+func (o *Orchestrator) checkContinuousWork(ctx context.Context, userID string, now time.Time) error {
+	blocks, err := sqlite.NewWorkBlockRepository(o.db.DB()).GetByUserInRange(ctx, userID,
+		o.db.ToDBTime(now.Add(-continuousLookback)), o.db.ToDBTime(now))
+	if err != nil {
+		return fmt.Errorf("failed to load work blocks: %w", err)
+	}
+
+	breakGap := time.Duration(o.config.Alerts.BreakMinutes) * time.Minute
+	stretchStart, stretchEnd, ok := latestWorkStretch(blocks, breakGap)
+	if !ok || now.Sub(stretchEnd) >= breakGap {
+		return nil
+	}
+
+	threshold := time.Duration(o.config.Alerts.ContinuousWorkHours * float64(time.Hour))
+	worked := stretchEnd.Sub(stretchStart)
+	if worked < threshold {
+		return nil
+	}
+
+	hours := int(worked / time.Hour)
+	o.notifier.Send(ctx, notify.Notification{
+		Key:     fmt.Sprintf("work:continuous_work:%s:%d:%d", userID, stretchStart.Unix(), hours),
+		Source:  notify.SourceWork,
+		Level:   notify.LevelWarning,
+		Type:    alertTypeContinuousWork,
+		Title:   fmt.Sprintf("%d hours of continuous work", hours),
+		Message: fmt.Sprintf("You have been working since %s without a %d-minute break.", stretchStart.Local().Format("15:04"), o.config.Alerts.BreakMinutes),
+		Metadata: map[string]string{
+			"user_id":       userID,
+			"stretch_start": stretchStart.UTC().Format(time.RFC3339),
+			"worked":        worked.Round(time.Minute).String(),
+		},
+	})
+	return nil
+}
+
+// latestWorkStretch returns the span of the most recent run of blocks whose
+// gaps are all shorter than breakGap; blocks are ordered by start time
+func latestWorkStretch(blocks []*sqlite.WorkBlock, breakGap time.Duration) (time.Time, time.Time, bool) {
+	if len(blocks) == 0 {
+		return time.Time{}, time.Time{}, false
+	}
+
+	blockEnd := func(block *sqlite.WorkBlock) time.Time {
+		if block.EndTime != nil {
+			return *block.EndTime
+		}
+		return block.LastActivityTime
+	}
+
+	last := blocks[len(blocks)-1]
+	start, end := last.StartTime, blockEnd(last)
+	for i := len(blocks) - 2; i >= 0; i-- {
+		previousEnd := blockEnd(blocks[i])
+		if start.Sub(previousEnd) >= breakGap {
+			break
+		}
+		start = blocks[i].StartTime
+		if previousEnd.After(end) {
+			end = previousEnd
+		}
+	}
+	return start, end, true
+}
+
+/**
+ * CONTEXT:   Goal alerts for one user
+ * INPUT:     User ID and evaluation instant
+ * OUTPUT:    One notification per goal and period when a target is met or a cap exceeded
+ * BUSINESS:  A met goal stays met for the rest of its period; the dispatcher
+ *            cooldown alone would repeat it every cooldown window, so delivered
+ *            goal periods are remembered until the period ends
+ * CHANGE:    Remember delivered goal periods instead of relying on the cooldown
+ * RISK:      Low - Only the work-alerts job touches goalAlerts, so no lock is needed
+ */
+func (o *Orchestrator) checkGoals(ctx context.Context, userID string, now time.Time) error {
+	statuses, err := o.reportingSvc.GetGoalStatus(ctx, userID, now)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate goals: %w", err)
+	}
+
+	if o.goalAlerts == nil {
+		o.goalAlerts = make(map[string]time.Time)
+	}
+	for key, periodEnd := range o.goalAlerts {
+		if !now.Before(periodEnd) {
+			delete(o.goalAlerts, key)
+		}
+	}
+
+	for _, status := range statuses {
+		alertKey := fmt.Sprintf("%s:%d", status.GoalID, status.PeriodStart.Unix())
+		if _, sent := o.goalAlerts[alertKey]; sent {
+			continue
+		}
+
+		var level, title string
+		switch {
+		case status.Kind == sqlite.GoalKindCap && status.State == reporting.GoalStateExceeded:
+			level, title = notify.LevelWarning, fmt.Sprintf("Goal cap exceeded: %s", status.Name)
+		case status.Kind != sqlite.GoalKindCap && status.State == reporting.GoalStateMet:
+			level, title = notify.LevelInfo, fmt.Sprintf("Goal reached: %s", status.Name)
+		default:
+			continue
+		}
+
+		delivered, _ := o.notifier.Send(ctx, notify.Notification{
+			Key:     fmt.Sprintf("work:goal:%s:%s:%d", status.GoalID, status.State, status.PeriodStart.Unix()),
+			Source:  notify.SourceWork,
+			Level:   level,
+			Type:    alertTypeGoal,
+			Title:   title,
+			Message: fmt.Sprintf("%.1f of %.1f hours this %s period (%.0f%%).", status.ActualHours, status.TargetHours, status.Period, status.Percent),
+			Metadata: map[string]string{
+				"user_id": userID,
+				"goal_id": status.GoalID,
+				"period":  status.Period,
+				"state":   status.State,
+			},
+		})
+		// Failed deliveries are retried on the next run
+		if len(delivered) > 0 {
+			o.goalAlerts[alertKey] = status.PeriodEnd
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+/**
+ * CONTEXT:   Tests for work alert delivery
+ * INPUT:     A met daily goal evaluated repeatedly against a recording channel
+ * OUTPUT:    Validation that goal alerts are delivered once per period
+ * BUSINESS:  A met goal stays met all period; repeating it every cooldown is noise
+ * CHANGE:    Initial goal alert dedup test
+ * RISK:      Low - Temp database per test
+ */
+
+package daemon
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/claude-monitor/system/internal/config"
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/notify"
+	"github.com/claude-monitor/system/internal/reporting"
+)
+
+// alertRecorder is an in-memory alert channel
+type alertRecorder struct {
+	mu  sync.Mutex
+	got []notify.Notification
+}
+
+func (r *alertRecorder) Name() string { return "recorder" }
+
+func (r *alertRecorder) Notify(ctx context.Context, n notify.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.got = append(r.got, n)
+	return nil
+}
+
+func TestGoalAlertsOncePerPeriod(t *testing.T) {
+	o := newAPITestOrchestrator(t)
+	ctx := context.Background()
+
+	o.reportingSvc = reporting.NewSQLiteReportingService(
+		sqlite.NewSessionRepository(o.db),
+		sqlite.NewWorkBlockRepository(o.db.DB()),
+		sqlite.NewActivityRepository(o.db.DB()),
+		sqlite.NewProjectRepository(o.db.DB()),
+	)
+	goals := sqlite.NewGoalRepository(o.db.DB())
+	o.reportingSvc.EnableGoalTracking(goals)
+
+	// No cooldown at all: only the goal period dedup keeps this to one alert
+	dispatcher, err := notify.NewDispatcher(cfg.AlertsConfig{CooldownMinutes: 0}, nil)
+	require.NoError(t, err)
+	recorder := &alertRecorder{}
+	dispatcher.Add(recorder)
+	o.notifier = dispatcher
+
+	require.NoError(t, sqlite.NewUserRepository(o.db.DB()).EnsureExists(ctx, "alice"))
+	project, err := sqlite.NewProjectRepository(o.db.DB()).GetOrCreate(ctx, "/work/api")
+	require.NoError(t, err)
+	require.NoError(t, goals.Create(ctx, &sqlite.Goal{
+		UserID: "alice", Name: "focus", Period: sqlite.GoalPeriodDaily, Kind: sqlite.GoalKindTarget,
+		Metric: sqlite.GoalMetricWorkHours, TargetHours: 1,
+	}))
+
+	day := time.Date(2026, 3, 4, 0, 0, 0, 0, time.Local)
+	_, err = sqlite.NewEntryRepository(o.db).Add(ctx, sqlite.ManualEntry{
+		UserID: "alice", ProjectID: project.ID,
+		StartTime: day.Add(9 * time.Hour), EndTime: day.Add(11 * time.Hour), Reason: "focus",
+	})
+	require.NoError(t, err)
+
+	// Across several hours, well past the default 60-minute cooldown
+	for at := day.Add(12 * time.Hour); at.Before(day.Add(16 * time.Hour)); at = at.Add(30 * time.Minute) {
+		require.NoError(t, o.checkGoals(ctx, "alice", at))
+	}
+	require.Len(t, recorder.got, 1)
+	assert.Equal(t, "Goal reached: focus", recorder.got[0].Title)
+
+	// The next day is a new period with nothing tracked yet
+	require.NoError(t, o.checkGoals(ctx, "alice", day.AddDate(0, 0, 1).Add(12*time.Hour)))
+	assert.Len(t, recorder.got, 1)
+	assert.Empty(t, o.goalAlerts, "the finished period is forgotten")
+}
@@ -0,0 +1,501 @@
+/**
+ * CONTEXT:   Versioned JSON API handlers for Claude Monitor daemon
+ * INPUT:     HTTP requests under /api/v1 for reporting data and hook activity
+ * OUTPUT:    JSON responses and iCalendar feed backed by reporting and activity tracking
+ * BUSINESS:  Integrations (status bars, dashboards, calendars) read analytics without the CLI
+ * CHANGE:    Admin endpoints require a token and restore only manifest-listed backups
+ * RISK:      Medium - Reporting reads, validated activity and tag writes, and a
+ *            token-guarded restore endpoint that replaces the database
+ */
+
+package daemon
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/logging"
+	"github.com/claude-monitor/system/internal/reporting"
+	"github.com/claude-monitor/system/internal/tracking"
+)
+
+// maxActivityBodyBytes bounds hook payloads; events carry paths and short commands
+const maxActivityBodyBytes = 64 << 10
+
+/**
+ * CONTEXT:   Register versioned API routes on the daemon router
+ * INPUT:     Initialized router and database connection
+ * OUTPUT:    /api/v1 subrouter with reporting endpoints
+ * BUSINESS:  Versioned prefix lets the API evolve without breaking integrations
+ * CHANGE:    Admin routes always go through the admin token check
+ * RISK:      Low - Route registration only
+ */
+func (o *Orchestrator) setupAPIRoutes() {
+	if o.db != nil && o.reportingSvc == nil {
+		o.reportingSvc = reporting.NewSQLiteReportingService(
+			sqlite.NewSessionRepository(o.db),
+			sqlite.NewWorkBlockRepository(o.db.DB()),
+			sqlite.NewActivityRepository(o.db.DB()),
+			sqlite.NewProjectRepository(o.db.DB()),
+		)
+		o.reportingSvc.EnableGoalTracking(sqlite.NewGoalRepository(o.db.DB()))
+	}
+	if o.db != nil && o.tracker == nil {
+		o.tracker = tracking.NewActivityTracker(o.db)
+		rules, err := tracking.NewProjectRules(o.config.Projects.Rules, o.config.Projects.CustomNames)
+		if err != nil {
+			o.logger.Error("Ignoring invalid project rules", "error", err)
+		} else {
+			o.tracker.SetProjectRules(rules)
+		}
+	}
+	if o.db != nil && o.calendar == nil {
+		o.calendar = reporting.NewCalendarExporter(
+			sqlite.NewSessionRepository(o.db),
+			sqlite.NewWorkBlockRepository(o.db.DB()),
+			sqlite.NewProjectRepository(o.db.DB()),
+		)
+	}
+	if o.db != nil && o.tags == nil {
+		o.tags = sqlite.NewTagRepository(o.db.DB())
+	}
+	if o.events == nil {
+		o.events = newEventBroker()
+	}
+	if o.statusFile == nil && o.config.WorkTracking.StatusFile != "" && o.reportingSvc != nil {
+		o.statusFile = make(chan struct{}, 1)
+	}
+	if o.db != nil && o.backups == nil {
+		o.backups = sqlite.NewBackupManager(o.db, o.config.Database.BackupPath, o.backupPolicy())
+	}
+	if o.adminToken == "" {
+		o.setupAdminToken()
+	}
+
+	api := o.router.PathPrefix("/api/v1").Subrouter()
+	api.HandleFunc("/goals/status", o.handleGoalsStatus).Methods("GET")
+	api.HandleFunc("/activity", o.handleActivity).Methods("POST")
+	api.HandleFunc("/calendar.ics", o.handleCalendar).Methods("GET")
+	api.HandleFunc("/workblocks/{id}/tags", o.handleWorkBlockTags).Methods("GET")
+	api.HandleFunc("/workblocks/{id}/tags", o.handleAddWorkBlockTags).Methods("POST")
+	api.HandleFunc("/workblocks/{id}/tags/{tag}", o.handleRemoveWorkBlockTag).Methods("DELETE")
+	api.HandleFunc("/admin/backup", o.requireAdmin(o.handleAdminBackup)).Methods("POST")
+	api.HandleFunc("/admin/restore", o.requireAdmin(o.handleAdminRestore)).Methods("POST")
+	api.HandleFunc("/live", o.handleLiveStatus).Methods("GET")
+	api.HandleFunc("/live/events", o.handleLiveEvents).Methods("GET")
+}
+
+/**
+ * CONTEXT:   Activity ingestion endpoint for the Claude Code hook
+ * INPUT:     HTTP POST with a JSON activity event
+ * OUTPUT:    JSON with the recorded activity, session and work block IDs, or ignored flag
+ * BUSINESS:  Hook events are the only source of tracked work time
+ * CHANGE:    Reject events for other users unless requests are token-authenticated
+ * RISK:      Medium - Writes on every Claude action; payload size is bounded
+ */
+func (o *Orchestrator) handleActivity(w http.ResponseWriter, r *http.Request) {
+	if o.tracker == nil {
+		writeAPIError(w, http.StatusServiceUnavailable, "activity tracking not available")
+		return
+	}
+
+	var event tracking.ActivityEvent
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxActivityBodyBytes)).Decode(&event); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid activity payload")
+		return
+	}
+	if event.UserID == "" {
+		event.UserID = defaultAPIUserID()
+	}
+	if !o.apiUserAllowed(event.UserID) {
+		writeAPIError(w, http.StatusForbidden, "user_id must match the daemon user unless an auth token is configured")
+		return
+	}
+	if event.ProjectPath == "" {
+		writeAPIError(w, http.StatusBadRequest, "project_path is required")
+		return
+	}
+
+	ctx := logging.With(r.Context(), logging.KeyUser, event.UserID)
+	activity, err := o.tracker.Record(ctx, event)
+	if errors.Is(err, tracking.ErrPathIgnored) {
+		o.logger.DebugContext(ctx, "Activity ignored by project rule", "project_path", event.ProjectPath)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"ignored": true})
+		return
+	}
+	if err != nil {
+		o.logger.ErrorContext(ctx, "Failed to record activity", "tool", event.ToolName, "error", err)
+		writeAPIError(w, http.StatusInternalServerError, "failed to record activity")
+		return
+	}
+	
+	ctx = logging.With(ctx,
+		logging.KeySession, activity.SessionID,
+		logging.KeyWorkBlock, activity.WorkBlockID,
+		logging.KeyProject, activity.ProjectID)
+	o.logger.InfoContext(ctx, "Activity recorded",
+		"activity_id", activity.ID,
+		"activity_type", activity.ActivityType,
+		"tool", activity.ToolName)
+	o.publishActivity(ctx, activity)
+	o.requestStatusFile(event.UserID)
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"activity_id":   activity.ID,
+		"activity_type": activity.ActivityType,
+		"session_id":    activity.SessionID,
+		"work_block_id": activity.WorkBlockID,
+		"project_id":    activity.ProjectID,
+	})
+}
+
+/**
+ * CONTEXT:   Goals status endpoint with current period progress
+ * INPUT:     HTTP GET request with optional user_id query parameter
+ * OUTPUT:    JSON list of goal status for the current periods
+ * BUSINESS:  Status bars and dashboards show goal progress at a glance
+ * CHANGE:    Other users' goals require an auth token, as for activity writes
+ * RISK:      Low - Read-only goal evaluation
+ */
+func (o *Orchestrator) handleGoalsStatus(w http.ResponseWriter, r *http.Request) {
+	if o.reportingSvc == nil {
+		writeAPIError(w, http.StatusServiceUnavailable, "reporting not available")
+		return
+	}
+
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		userID = defaultAPIUserID()
+	}
+	if !o.apiUserAllowed(userID) {
+		writeAPIError(w, http.StatusForbidden, "user_id must match the daemon user unless an auth token is configured")
+		return
+	}
+
+	now := time.Now()
+	goals, err := o.reportingSvc.GetGoalStatus(r.Context(), userID, now)
+	if err != nil {
+		o.logger.Error("Failed to evaluate goals", "user_id", userID, "error", err)
+		writeAPIError(w, http.StatusInternalServerError, "failed to evaluate goals")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"user_id":      userID,
+		"evaluated_at": now.UTC().Format(time.RFC3339),
+		"goals":        goals,
+	})
+}
+
+/**
+ * CONTEXT:   iCalendar feed of work blocks and optional sessions
+ * INPUT:     HTTP GET with token and optional user_id, from, to and sessions parameters
+ * OUTPUT:    text/calendar document, default range the last 30 days
+ * BUSINESS:  Calendar apps subscribe by URL, so the token travels in the query string
+ * CHANGE:    The feed is bound to the token's user; other user_id values are rejected
+ * RISK:      Medium - Exposes work history; disabled unless a token is configured
+ */
+func (o *Orchestrator) handleCalendar(w http.ResponseWriter, r *http.Request) {
+	token := o.config.Calendar.Token
+	if token == "" {
+		writeAPIError(w, http.StatusNotFound, "calendar feed disabled; set daemon calendar_token")
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(r.URL.Query().Get("token")), []byte(token)) != 1 {
+		writeAPIError(w, http.StatusUnauthorized, "invalid calendar token")
+		return
+	}
+	if o.calendar == nil {
+		writeAPIError(w, http.StatusServiceUnavailable, "reporting not available")
+		return
+	}
+
+	query := r.URL.Query()
+	userID := o.config.Calendar.User
+	if userID == "" {
+		userID = defaultAPIUserID()
+	}
+	if requested := query.Get("user_id"); requested != "" && requested != userID {
+		writeAPIError(w, http.StatusForbidden, "calendar token is not valid for this user")
+		return
+	}
+
+	period, err := reporting.CalendarPeriod(query.Get("from"), query.Get("to"), time.Now())
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	options := reporting.CalendarOptions{IncludeSessions: query.Get("sessions") == "true"}
+	events, err := o.calendar.Events(r.Context(), userID, period, options)
+	if err != nil {
+		o.logger.Error("Failed to export calendar", "user_id", userID, "error", err)
+		writeAPIError(w, http.StatusInternalServerError, "failed to export calendar")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="claude-monitor.ics"`)
+	reporting.WriteICalendar(w, events, time.Now())
+}
+
+// workBlockTagsRequest is the body of POST /api/v1/workblocks/{id}/tags
+type workBlockTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+/**
+ * CONTEXT:   Work block tags endpoint
+ * INPUT:     HTTP GET with work block ID in the path
+ * OUTPUT:    JSON with the block's own tags and the tags inherited from its session
+ * BUSINESS:  Editors and dashboards show and edit labels on tracked work
+ * CHANGE:    Initial tag listing endpoint
+ * RISK:      Low - Read-only queries
+ */
+func (o *Orchestrator) handleWorkBlockTags(w http.ResponseWriter, r *http.Request) {
+	block, ok := o.lookupWorkBlock(w, r)
+	if !ok {
+		return
+	}
+	o.writeWorkBlockTags(w, r, block)
+}
+
+/**
+ * CONTEXT:   Add tags to a work block
+ * INPUT:     HTTP POST with work block ID in the path and {"tags": [...]} body
+ * OUTPUT:    JSON with the block's tags after the change
+ * BUSINESS:  Tags added later label work that was not pre-labelled by the hook
+ * CHANGE:    Without an auth token only the daemon user's blocks can be changed
+ * RISK:      Low - Validated, idempotent insert
+ */
+func (o *Orchestrator) handleAddWorkBlockTags(w http.ResponseWriter, r *http.Request) {
+	block, ok := o.lookupWorkBlock(w, r)
+	if !ok {
+		return
+	}
+
+	var body workBlockTagsRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxActivityBodyBytes)).Decode(&body); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid tags payload")
+		return
+	}
+	tags, err := sqlite.ParseTags(body.Tags...)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(tags) == 0 {
+		writeAPIError(w, http.StatusBadRequest, "tags are required")
+		return
+	}
+
+	if _, err := o.tags.TagWorkBlock(r.Context(), block.ID, tags, sqlite.TagSourceAPI); err != nil {
+		o.logger.Error("Failed to tag work block", "work_block_id", block.ID, "error", err)
+		writeAPIError(w, http.StatusInternalServerError, "failed to tag work block")
+		return
+	}
+	o.writeWorkBlockTags(w, r, block)
+}
+
+/**
+ * CONTEXT:   Remove one tag from a work block
+ * INPUT:     HTTP DELETE with work block ID and tag in the path
+ * OUTPUT:    JSON with the block's tags after the change, 404 if the tag was not attached
+ * BUSINESS:  Mislabelled work must be correctable without the CLI
+ * CHANGE:    Without an auth token only the daemon user's blocks can be changed
+ * RISK:      Low - Deletes a single link row
+ */
+func (o *Orchestrator) handleRemoveWorkBlockTag(w http.ResponseWriter, r *http.Request) {
+	block, ok := o.lookupWorkBlock(w, r)
+	if !ok {
+		return
+	}
+
+	tag, err := sqlite.NormalizeTag(mux.Vars(r)["tag"])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	removed, err := o.tags.UntagWorkBlock(r.Context(), block.ID, []string{tag})
+	if err != nil {
+		o.logger.Error("Failed to untag work block", "work_block_id", block.ID, "error", err)
+		writeAPIError(w, http.StatusInternalServerError, "failed to remove tag")
+		return
+	}
+	if removed == 0 {
+		writeAPIError(w, http.StatusNotFound, "tag not attached to work block")
+		return
+	}
+	o.writeWorkBlockTags(w, r, block)
+}
+
+// lookupWorkBlock resolves the {id} path variable, writing an error response on
+// failure; blocks of other users are refused unless an auth token is configured
+func (o *Orchestrator) lookupWorkBlock(w http.ResponseWriter, r *http.Request) (*sqlite.WorkBlock, bool) {
+	if o.db == nil || o.tags == nil {
+		writeAPIError(w, http.StatusServiceUnavailable, "tagging not available")
+		return nil, false
+	}
+
+	block, err := sqlite.NewWorkBlockRepository(o.db.DB()).GetByID(r.Context(), mux.Vars(r)["id"])
+	if errors.Is(err, sqlite.ErrWorkBlockNotFound) {
+		writeAPIError(w, http.StatusNotFound, "work block not found")
+		return nil, false
+	}
+	if err != nil {
+		o.logger.Error("Failed to get work block", "error", err)
+		writeAPIError(w, http.StatusInternalServerError, "failed to get work block")
+		return nil, false
+	}
+
+	session, err := sqlite.NewSessionRepository(o.db).GetByID(r.Context(), block.SessionID)
+	if err != nil {
+		o.logger.Error("Failed to get work block session", "work_block_id", block.ID, "error", err)
+		writeAPIError(w, http.StatusInternalServerError, "failed to get work block")
+		return nil, false
+	}
+	if !o.apiUserAllowed(session.UserID) {
+		writeAPIError(w, http.StatusForbidden, "work block belongs to another user; an auth token is required")
+		return nil, false
+	}
+	return block, true
+}
+
+// writeWorkBlockTags responds with a work block's own and session tags
+func (o *Orchestrator) writeWorkBlockTags(w http.ResponseWriter, r *http.Request, block *sqlite.WorkBlock) {
+	tags, err := o.tags.GetWorkBlockTags(r.Context(), block.ID)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "failed to get tags")
+		return
+	}
+	sessionTags, err := o.tags.GetSessionTags(r.Context(), block.SessionID)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "failed to get tags")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"work_block_id": block.ID,
+		"session_id":    block.SessionID,
+		"tags":          tags,
+		"session_tags":  sessionTags,
+	})
+}
+
+/**
+ * CONTEXT:   Admin endpoint creating a backup through the running daemon
+ * INPUT:     HTTP POST with no body
+ * OUTPUT:    JSON with the new backup and the backups removed by rotation
+ * BUSINESS:  The CLI backs up through the daemon so only one process writes the manifest
+ * CHANGE:    Initial backup endpoint
+ * RISK:      Low - VACUUM INTO reads the database without blocking writers
+ */
+func (o *Orchestrator) handleAdminBackup(w http.ResponseWriter, r *http.Request) {
+	if o.backups == nil {
+		writeAPIError(w, http.StatusServiceUnavailable, "backups not available")
+		return
+	}
+
+	backup, removed, err := o.backups.Create(r.Context(), sqlite.BackupReasonManual)
+	if err != nil {
+		o.logger.Error("Backup failed", "error", err)
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"backup":  backup,
+		"removed": removed,
+	})
+}
+
+/**
+ * CONTEXT:   Admin endpoint restoring a backup into the running daemon's database
+ * INPUT:     HTTP POST with JSON {"path": "..."} naming a backup file
+ * OUTPUT:    JSON with the verified backup and the pre-restore backup
+ * BUSINESS:  Restore works without stopping the daemon; the current data is backed
+ *            up first so a wrong restore can be undone
+ * CHANGE:    Only manifest-listed backups in the backup directory are restored
+ * RISK:      High - Replaces all data; requires the admin token
+ */
+func (o *Orchestrator) handleAdminRestore(w http.ResponseWriter, r *http.Request) {
+	if o.backups == nil {
+		writeAPIError(w, http.StatusServiceUnavailable, "backups not available")
+		return
+	}
+
+	var req struct {
+		Path string `json:"path"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxActivityBodyBytes)).Decode(&req); err != nil || req.Path == "" {
+		writeAPIError(w, http.StatusBadRequest, "path is required")
+		return
+	}
+	path, err := o.backups.Resolve(req.Path)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, err := sqlite.VerifyBackup(r.Context(), path); err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	safety, _, err := o.backups.Create(r.Context(), sqlite.BackupReasonPreRestore)
+	if err != nil {
+		o.logger.Error("Pre-restore backup failed", "error", err)
+		writeAPIError(w, http.StatusInternalServerError, "pre-restore backup failed: "+err.Error())
+		return
+	}
+	check, err := o.db.RestoreFrom(r.Context(), path)
+	if err != nil {
+		o.logger.Error("Restore failed", "path", path, "error", err)
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	o.logger.Info("Database restored", "path", check.Path, "schema_version", check.SchemaVersion, "pre_restore_backup", safety.Path)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"restored":           check,
+		"pre_restore_backup": safety,
+	})
+}
+
+// writeJSON encodes a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeAPIError encodes a JSON error body with the given status code
+func writeAPIError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error":     message,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// apiUserAllowed reports whether a request may act for userID; without an auth
+// token any local process can call the API, so reads and writes stay with the
+// daemon user
+func (o *Orchestrator) apiUserAllowed(userID string) bool {
+	return o.config.Server.AuthToken != "" || userID == defaultAPIUserID()
+}
+
+// defaultAPIUserID mirrors the CLI's user resolution so both see the same data
+func defaultAPIUserID() string {
+	if user := os.Getenv("USER"); user != "" {
+		return user
+	}
+	if user := os.Getenv("USERNAME"); user != "" {
+		return user
+	}
+	return "default"
+}
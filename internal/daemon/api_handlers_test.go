@@ -0,0 +1,91 @@
+/**
+ * CONTEXT:   Tests for the versioned API handlers
+ * INPUT:     Requests against a router backed by a temp database and no auth token
+ * OUTPUT:    Validation that other users' data is refused without a token
+ * BUSINESS:  Without a token any local process can call the API
+ * CHANGE:    Initial work block tag ownership tests
+ * RISK:      Low - Temp database per test
+ */
+
+package daemon
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/claude-monitor/system/internal/config"
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/tracking"
+)
+
+// newAPITestOrchestrator returns an orchestrator on a temp database with alice as the daemon user
+func newAPITestOrchestrator(t *testing.T) *Orchestrator {
+	t.Helper()
+	t.Setenv("USER", "alice")
+
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(t.TempDir(), "api.db")))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return &Orchestrator{
+		config:  cfg.NewDefaultConfig(),
+		logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		db:      db,
+		tracker: tracking.NewActivityTracker(db),
+		tags:    sqlite.NewTagRepository(db.DB()),
+	}
+}
+
+// tagRouter registers only the work block tag routes
+func (o *Orchestrator) tagRouter() *mux.Router {
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/workblocks/{id}/tags", o.handleWorkBlockTags).Methods("GET")
+	router.HandleFunc("/api/v1/workblocks/{id}/tags", o.handleAddWorkBlockTags).Methods("POST")
+	router.HandleFunc("/api/v1/workblocks/{id}/tags/{tag}", o.handleRemoveWorkBlockTag).Methods("DELETE")
+	return router
+}
+
+func TestWorkBlockTagsRejectOtherUsers(t *testing.T) {
+	o := newAPITestOrchestrator(t)
+	require.Empty(t, o.config.Server.AuthToken)
+	ctx := context.Background()
+
+	own, err := o.tracker.Record(ctx, tracking.ActivityEvent{UserID: "alice", ProjectPath: "/work/api"})
+	require.NoError(t, err)
+	foreign, err := o.tracker.Record(ctx, tracking.ActivityEvent{UserID: "bob", ProjectPath: "/work/web", Tags: []string{"review"}})
+	require.NoError(t, err)
+
+	router := o.tagRouter()
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+
+	foreignPath := "/api/v1/workblocks/" + foreign.WorkBlockID + "/tags"
+	assert.Equal(t, http.StatusForbidden, serve("GET", foreignPath, "").Code)
+	assert.Equal(t, http.StatusForbidden, serve("POST", foreignPath, `{"tags":["incident-4312"]}`).Code)
+	assert.Equal(t, http.StatusForbidden, serve("DELETE", foreignPath+"/review", "").Code)
+
+	tags, err := o.tags.GetWorkBlockTags(ctx, foreign.WorkBlockID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"review"}, tags, "foreign block is unchanged")
+
+	ownPath := "/api/v1/workblocks/" + own.WorkBlockID + "/tags"
+	assert.Equal(t, http.StatusOK, serve("POST", ownPath, `{"tags":["incident-4312"]}`).Code)
+	assert.Equal(t, http.StatusOK, serve("DELETE", ownPath+"/incident-4312", "").Code)
+
+	// With a token configured, authenticated callers may label any user's blocks
+	o.config.Server.AuthToken = "secret"
+	assert.Equal(t, http.StatusOK, serve("POST", foreignPath, `{"tags":["incident-4312"]}`).Code)
+}
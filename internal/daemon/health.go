@@ -0,0 +1,189 @@
+/**
+ * CONTEXT:   Liveness, readiness and detailed health endpoints for the daemon
+ * INPUT:     Health configuration, database schema state and scheduler state
+ * OUTPUT:    /health/live, /health/ready and /health/details responses
+ * BUSINESS:  The systemd watchdog, container probes and dashboards depend on
+ *            these endpoints to restart a wedged daemon or hold back traffic
+ * CHANGE:    Initial health monitor integration
+ * RISK:      Medium - A wrong readiness answer keeps hooks away from a working
+ *            daemon or sends them to a broken one
+ */
+
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/health"
+)
+
+// readinessTimeout bounds the on-demand readiness checks of one request
+const readinessTimeout = 2 * time.Second
+
+// setupHealth creates the health monitor and registers the daemon's readiness checks
+func (o *Orchestrator) setupHealth() {
+	if !o.config.Health.EnableHealthCheck {
+		return
+	}
+
+	o.health = health.NewMonitor("claude-monitor", o.config.Health.CheckInterval, o.logger)
+	o.health.Register(health.Check{
+		Name:      "database",
+		Readiness: true,
+		AlertType: health.AlertTypeDatabase,
+		Run:       o.checkDatabaseReady,
+	})
+	o.health.Register(health.Check{
+		Name:      "scheduler",
+		Readiness: true,
+		AlertType: health.AlertTypeScheduler,
+		Run:       o.checkSchedulerRunning,
+	})
+}
+
+// setupHealthRoutes registers the probe endpoints; ReadyCheckPath aliases /health/ready
+func (o *Orchestrator) setupHealthRoutes() {
+	if o.health == nil {
+		return
+	}
+	o.router.HandleFunc("/health/live", o.handleLive).Methods("GET")
+	o.router.HandleFunc("/health/ready", o.handleReady).Methods("GET")
+	o.router.HandleFunc("/health/details", o.handleHealthDetails).Methods("GET")
+	if path := o.config.Health.ReadyCheckPath; path != "" && path != "/health/ready" {
+		o.router.HandleFunc(path, o.handleReady).Methods("GET")
+	}
+}
+
+// healthJob runs the full check round on HealthConfig.CheckInterval
+func (o *Orchestrator) healthJob() scheduledJob {
+	return scheduledJob{
+		name:     "health",
+		interval: o.config.Health.CheckInterval,
+		run: func(ctx context.Context) error {
+			checkCtx, cancel := context.WithTimeout(ctx, o.config.Health.CheckInterval)
+			defer cancel()
+			o.health.RunChecks(checkCtx)
+			return nil
+		},
+	}
+}
+
+// checkDatabaseReady requires a reachable database at the latest schema version
+func (o *Orchestrator) checkDatabaseReady(ctx context.Context) health.CheckDetail {
+	if err := o.db.Ping(ctx); err != nil {
+		return health.CheckDetail{Message: "Database unreachable", Error: err.Error()}
+	}
+	version, err := o.db.SchemaVersion(ctx)
+	if err != nil {
+		return health.CheckDetail{Message: "Database schema unreadable", Error: err.Error()}
+	}
+
+	latest := sqlite.LatestSchemaVersion()
+	detail := health.CheckDetail{
+		Healthy: version == latest,
+		Metadata: map[string]string{
+			"path":           o.config.Database.Path,
+			"schema_version": fmt.Sprintf("%d", version),
+			"latest_version": fmt.Sprintf("%d", latest),
+		},
+	}
+	if detail.Healthy {
+		detail.Message = "Database reachable and migrated"
+	} else {
+		detail.Message = fmt.Sprintf("Database at schema version %d, expected %d", version, latest)
+		detail.Error = "Schema migration incomplete"
+	}
+	return detail
+}
+
+// checkSchedulerRunning requires the maintenance scheduler to be started and not stopping
+func (o *Orchestrator) checkSchedulerRunning(ctx context.Context) health.CheckDetail {
+	if !o.schedulerRunning.Load() {
+		return health.CheckDetail{Message: "Scheduler not running", Error: "Scheduler stopped or not yet started"}
+	}
+	return health.CheckDetail{
+		Healthy:  true,
+		Message:  "Scheduler running",
+		Metadata: map[string]string{"jobs": fmt.Sprintf("%d", o.jobCount)},
+	}
+}
+
+/**
+ * CONTEXT:   Liveness probe
+ * INPUT:     HTTP GET request
+ * OUTPUT:    200 while check rounds keep running, 503 once they stall
+ * BUSINESS:  Lets the systemd watchdog and container runtimes restart a wedged
+ *            daemon; deliberately ignores the database so outages don't cause
+ *            restart loops
+ * CHANGE:    Initial liveness endpoint
+ * RISK:      Low - Reads in-memory state only
+ */
+func (o *Orchestrator) handleLive(w http.ResponseWriter, r *http.Request) {
+	live, lastRun := o.health.Live()
+	response := map[string]interface{}{
+		"status":         "alive",
+		"uptime_seconds": int64(o.GetUptime().Seconds()),
+	}
+	if !lastRun.IsZero() {
+		response["last_check"] = lastRun.UTC().Format(time.RFC3339)
+	}
+
+	status := http.StatusOK
+	if !live {
+		response["status"] = "stalled"
+		status = http.StatusServiceUnavailable
+	}
+	writeHealthJSON(w, status, response)
+}
+
+/**
+ * CONTEXT:   Readiness probe
+ * INPUT:     HTTP GET request
+ * OUTPUT:    200 with per-check results when ready, 503 otherwise
+ * BUSINESS:  Ready means the database is migrated and the scheduler is running.
+ *            There is no spool-drained check: hook events are written to the
+ *            database within the request and nothing is queued for later
+ * CHANGE:    Document why readiness has no spool criterion
+ * RISK:      Low - Runs cheap checks with a short timeout
+ */
+func (o *Orchestrator) handleReady(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	result := o.health.Ready(ctx)
+	response := map[string]interface{}{
+		"status": "ready",
+		"checks": result.Checks,
+	}
+	status := http.StatusOK
+	if !result.IsHealthy {
+		response["status"] = "not_ready"
+		status = http.StatusServiceUnavailable
+	}
+	writeHealthJSON(w, status, response)
+}
+
+/**
+ * CONTEXT:   Detailed health endpoint
+ * INPUT:     HTTP GET request
+ * OUTPUT:    Latest check round, active alerts and request metrics
+ * BUSINESS:  Dashboards show why the daemon is degraded, not just that it is
+ * CHANGE:    Initial details endpoint
+ * RISK:      Low - Serves the cached round; never runs checks itself
+ */
+func (o *Orchestrator) handleHealthDetails(w http.ResponseWriter, r *http.Request) {
+	writeHealthJSON(w, http.StatusOK, o.health.Details())
+}
+
+// writeHealthJSON writes a probe response
+func writeHealthJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,224 @@
+/**
+ * CONTEXT:   Live status endpoints for the terminal dashboard
+ * INPUT:     GET /api/v1/live snapshots and GET /api/v1/live/events streams
+ * OUTPUT:    LiveStatus JSON and a server-sent event per recorded activity
+ * BUSINESS:  `claude-monitor status --watch` refreshes on each hook event
+ *            instead of polling the whole snapshot every few seconds
+ * CHANGE:    Snapshots and streams are scoped to one user; other users need
+ *            an auth token, and a stream only carries its own user's events
+ * RISK:      Medium - Streams hold connections open until the client or the
+ *            server goes away; subscribers are capped
+ */
+
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/reporting"
+)
+
+const (
+	// maxLiveSubscribers bounds concurrently open event streams
+	maxLiveSubscribers = 32
+
+	// liveHeartbeatInterval keeps idle streams alive through proxies
+	liveHeartbeatInterval = 15 * time.Second
+)
+
+// eventBroker fans recorded activities out to open event streams
+type eventBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan reporting.LiveEvent]string // channel to subscribed user ID
+	closed      bool
+}
+
+// newEventBroker creates a broker with no subscribers
+func newEventBroker() *eventBroker {
+	return &eventBroker{subscribers: make(map[chan reporting.LiveEvent]string)}
+}
+
+// subscribe returns a channel of userID's events, or false when closed or at capacity
+func (b *eventBroker) subscribe(userID string) (chan reporting.LiveEvent, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed || len(b.subscribers) >= maxLiveSubscribers {
+		return nil, false
+	}
+	ch := make(chan reporting.LiveEvent, 16)
+	b.subscribers[ch] = userID
+	return ch, true
+}
+
+// unsubscribe removes ch; it is a no-op after close
+func (b *eventBroker) unsubscribe(ch chan reporting.LiveEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subscribers[ch]; ok {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
+
+// publish delivers event to the subscribers of its user, dropping it for slow ones
+func (b *eventBroker) publish(event reporting.LiveEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch, userID := range b.subscribers {
+		if userID != event.UserID {
+			continue
+		}
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// active reports whether any stream is open
+func (b *eventBroker) active() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers) > 0
+}
+
+// close ends every stream; http.Server.Shutdown does not cancel open requests
+func (b *eventBroker) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for ch := range b.subscribers {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
+
+/**
+ * CONTEXT:   Live status snapshot endpoint
+ * INPUT:     HTTP GET request with optional user_id query parameter
+ * OUTPUT:    LiveStatus JSON with daemon health, session window, active block,
+ *            today's totals and recent events
+ * BUSINESS:  One request gives the dashboard everything it renders
+ * CHANGE:    Other users' status requires an auth token, as for activity writes
+ * RISK:      Low - Read-only
+ */
+func (o *Orchestrator) handleLiveStatus(w http.ResponseWriter, r *http.Request) {
+	if o.reportingSvc == nil || o.db == nil {
+		writeAPIError(w, http.StatusServiceUnavailable, "reporting not available")
+		return
+	}
+
+	userID, ok := o.liveUserID(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := o.reportingSvc.GetLiveStatus(r.Context(), userID, o.db.Now(), o.config.WorkTracking.IdleTimeout)
+	if err != nil {
+		o.logger.Error("Failed to build live status", "user_id", userID, "error", err)
+		writeAPIError(w, http.StatusInternalServerError, "failed to build live status")
+		return
+	}
+	status.Daemon = reporting.LiveDaemon{
+		Status:        o.healthStatus,
+		UptimeSeconds: int64(o.GetUptime().Seconds()),
+		Version:       "1.0.0",
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+/**
+ * CONTEXT:   Live event stream
+ * INPUT:     HTTP GET request accepting text/event-stream, optional user_id query parameter
+ * OUTPUT:    "activity" events carrying LiveEvent JSON, heartbeat comments in between
+ * BUSINESS:  Watchers refresh the moment a hook event lands
+ * CHANGE:    Streams carry only the requested user's events
+ * RISK:      Medium - Clears the server write timeout for this response only
+ */
+func (o *Orchestrator) handleLiveEvents(w http.ResponseWriter, r *http.Request) {
+	userID, ok := o.liveUserID(w, r)
+	if !ok {
+		return
+	}
+	events, ok := o.events.subscribe(userID)
+	if !ok {
+		writeAPIError(w, http.StatusServiceUnavailable, "too many live event streams")
+		return
+	}
+	defer o.events.unsubscribe(events)
+
+	rc := http.NewResponseController(w)
+	// The server's WriteTimeout would otherwise cut the stream after seconds
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		o.logger.Debug("Cannot clear write deadline for event stream", "error", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(liveHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, open := <-events:
+			if !open {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: activity\ndata: %s\n\n", data)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// liveUserID resolves the user_id query parameter, writing 403 for a user the caller may not read
+func (o *Orchestrator) liveUserID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		userID = defaultAPIUserID()
+	}
+	if !o.apiUserAllowed(userID) {
+		writeAPIError(w, http.StatusForbidden, "user_id must match the daemon user unless an auth token is configured")
+		return "", false
+	}
+	return userID, true
+}
+
+// publishActivity pushes a recorded activity to its user's open event streams
+func (o *Orchestrator) publishActivity(ctx context.Context, activity *sqlite.Activity) {
+	if o.events == nil || !o.events.active() {
+		return
+	}
+	event := reporting.LiveEvent{
+		UserID:       activity.UserID,
+		Timestamp:    activity.Timestamp,
+		ActivityType: activity.ActivityType,
+		ToolName:     activity.ToolName,
+		GitBranch:    activity.GitBranch,
+	}
+	if project, err := sqlite.NewProjectRepository(o.db.DB()).GetByID(ctx, activity.ProjectID); err == nil {
+		event.ProjectName = project.Name
+	}
+	o.events.publish(event)
+}
@@ -0,0 +1,65 @@
+/**
+ * CONTEXT:   Tests for the live status endpoints
+ * INPUT:     Live snapshot requests and broker subscriptions for two users
+ * OUTPUT:    Validation that snapshots and streams stay with their user
+ * BUSINESS:  Without a token any local process can call the API
+ * CHANGE:    Initial per-user live scoping tests
+ * RISK:      Low - Temp database per test
+ */
+
+package daemon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/reporting"
+)
+
+func TestLiveStatusRejectsOtherUsers(t *testing.T) {
+	o := newAPITestOrchestrator(t)
+	o.reportingSvc = reporting.NewSQLiteReportingService(
+		sqlite.NewSessionRepository(o.db),
+		sqlite.NewWorkBlockRepository(o.db.DB()),
+		sqlite.NewActivityRepository(o.db.DB()),
+		sqlite.NewProjectRepository(o.db.DB()),
+	)
+	o.events = newEventBroker()
+
+	get := func(handler http.HandlerFunc, target string) int {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest("GET", target, nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, get(o.handleLiveStatus, "/api/v1/live"))
+	assert.Equal(t, http.StatusOK, get(o.handleLiveStatus, "/api/v1/live?user_id=alice"))
+	assert.Equal(t, http.StatusForbidden, get(o.handleLiveStatus, "/api/v1/live?user_id=bob"))
+	assert.Equal(t, http.StatusForbidden, get(o.handleLiveEvents, "/api/v1/live/events?user_id=bob"))
+
+	o.config.Server.AuthToken = "secret"
+	assert.Equal(t, http.StatusOK, get(o.handleLiveStatus, "/api/v1/live?user_id=bob"))
+}
+
+func TestEventBrokerDeliversOnlyOwnEvents(t *testing.T) {
+	broker := newEventBroker()
+	alice, ok := broker.subscribe("alice")
+	require.True(t, ok)
+	bob, ok := broker.subscribe("bob")
+	require.True(t, ok)
+
+	broker.publish(reporting.LiveEvent{UserID: "alice", ToolName: "Edit"})
+	broker.publish(reporting.LiveEvent{UserID: "bob", ToolName: "Bash"})
+
+	require.Len(t, alice, 1)
+	assert.Equal(t, "Edit", (<-alice).ToolName)
+	require.Len(t, bob, 1)
+	assert.Equal(t, "Bash", (<-bob).ToolName)
+
+	broker.close()
+}
@@ -3,19 +3,26 @@
  * INPUT:     HTTP requests requiring rate limiting, logging, and metrics collection
  * OUTPUT:    Processed requests with rate limiting, logging, and performance tracking
  * BUSINESS:  Production middleware essential for API reliability and monitoring
- * CHANGE:    CRITICAL FIX - Complete production middleware implementation
+ * CHANGE:    Added bearer token authentication for exposed deployments
  * RISK:      Medium - Middleware affecting all HTTP requests and API performance
  */
 
 package daemon
 
 import (
+	"crypto/subtle"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync/atomic"
 	"time"
+	
+	"github.com/claude-monitor/system/internal/logging"
 )
 
+// maxRequestIDLength bounds caller-supplied request IDs written to the log
+const maxRequestIDLength = 128
+
 /**
  * CONTEXT:   Rate limiting middleware using token bucket algorithm
  * INPUT:     HTTP requests requiring rate limiting based on configuration
@@ -50,28 +57,81 @@ func (o *Orchestrator) rateLimitMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+/**
+ * CONTEXT:   Bearer token authentication middleware
+ * INPUT:     HTTP requests with an Authorization: Bearer <token> header
+ * OUTPUT:    HTTP 401 for missing or wrong tokens, allowed requests passed through
+ * BUSINESS:  A daemon bound to 0.0.0.0 in a container must not accept hook
+ *            events or admin calls from anyone on the network; health probes
+ *            stay open for orchestrators and the calendar feed checks its own token
+ * CHANGE:    Initial auth middleware
+ * RISK:      High - A wrong exemption exposes the API; compares in constant time
+ */
+func (o *Orchestrator) authMiddleware(next http.Handler) http.Handler {
+	token := []byte(o.config.Server.AuthToken)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if o.authExempt(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		
+		scheme, supplied, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+		if !strings.EqualFold(scheme, "Bearer") || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(supplied)), token) != 1 {
+			o.logger.Warn("Rejected unauthenticated request",
+				"remote_addr", r.RemoteAddr,
+				"endpoint", r.URL.Path)
+			
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("WWW-Authenticate", `Bearer realm="claude-monitor"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": "unauthorized", "message": "Missing or invalid bearer token"}`))
+			return
+		}
+		
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authExempt reports whether path is a health probe or the self-authenticating calendar feed
+func (o *Orchestrator) authExempt(path string) bool {
+	switch path {
+	case "/health", "/health/live", "/health/ready", "/api/v1/calendar.ics":
+		return true
+	}
+	return path == o.config.Health.ReadyCheckPath
+}
+
 /**
  * CONTEXT:   HTTP request logging middleware for audit and debugging
  * INPUT:     HTTP requests requiring structured logging
  * OUTPUT:    Structured log entries with request details and response status
  * BUSINESS:  Request logging essential for debugging, monitoring, and security audit
- * CHANGE:    Production request logging with structured slog integration
+ * CHANGE:    Attach a request ID (from X-Request-ID or generated) to the context
+ *            so every log record written for the request carries it
  * RISK:      Low - Logging middleware with minimal performance impact
  */
 func (o *Orchestrator) loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 		
+		// Reuse the caller's request ID so hook and daemon logs correlate
+		requestID := r.Header.Get(logging.RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = logging.NewRequestID()
+		}
+		ctx := logging.With(r.Context(), logging.KeyRequestID, requestID)
+		w.Header().Set(logging.RequestIDHeader, requestID)
+		
 		// Create response wrapper to capture status code
 		wrapped := &responseWrapper{ResponseWriter: w, statusCode: http.StatusOK}
 		
 		// Process request
-		next.ServeHTTP(wrapped, r)
+		next.ServeHTTP(wrapped, r.WithContext(ctx))
 		
 		// Log request with structured data
 		duration := time.Since(start)
 		
-		o.logger.Info("HTTP request",
+		o.logger.InfoContext(ctx, "HTTP request",
 			"method", r.Method,
 			"path", r.URL.Path,
 			"remote_addr", r.RemoteAddr,
@@ -90,9 +150,9 @@ func (o *Orchestrator) loggingMiddleware(next http.Handler) http.Handler {
 /**
  * CONTEXT:   Metrics collection middleware for performance monitoring
  * INPUT:     HTTP requests requiring metrics collection
- * OUTPUT:    Updated request counters and connection tracking
+ * OUTPUT:    Updated request counters, connection tracking and health monitor timings
  * BUSINESS:  Metrics collection enables monitoring, alerting, and performance analysis
- * CHANGE:    Basic metrics collection for production monitoring
+ * CHANGE:    Record response times and server errors in the health monitor
  * RISK:      Low - Minimal overhead metrics collection
  */
 func (o *Orchestrator) metricsMiddleware(next http.Handler) http.Handler {
@@ -104,7 +164,19 @@ func (o *Orchestrator) metricsMiddleware(next http.Handler) http.Handler {
 		atomic.AddInt32(&o.connectionCount, 1)
 		defer atomic.AddInt32(&o.connectionCount, -1)
 		
-		next.ServeHTTP(w, r)
+		if o.health == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		
+		// Feed response times and server errors to the health monitor
+		start := time.Now()
+		wrapped := &responseWrapper{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(wrapped, r)
+		o.health.RecordRequest(time.Since(start))
+		if wrapped.statusCode >= http.StatusInternalServerError {
+			o.health.RecordError()
+		}
 	})
 }
 
@@ -124,4 +196,9 @@ type responseWrapper struct {
 func (rw *responseWrapper) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
+}
+
+// Unwrap lets http.ResponseController flush and set deadlines on the real writer
+func (rw *responseWrapper) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
 }
\ No newline at end of file
@@ -12,11 +12,14 @@ package daemon
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 	"golang.org/x/time/rate"
@@ -24,6 +27,12 @@ import (
 	"github.com/gorilla/mux"
 	cfg "github.com/claude-monitor/system/internal/config"
 	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/health"
+	"github.com/claude-monitor/system/internal/logging"
+	"github.com/claude-monitor/system/internal/notify"
+	"github.com/claude-monitor/system/internal/reporting"
+	"github.com/claude-monitor/system/internal/systemd"
+	"github.com/claude-monitor/system/internal/tracking"
 )
 
 /**
@@ -36,12 +45,21 @@ import (
  */
 type Orchestrator struct {
 	// Configuration
-	config *cfg.DaemonConfig
-	logger *slog.Logger
+	config    *cfg.DaemonConfig
+	logger    *slog.Logger
+	logCloser io.Closer
 	
 	// Infrastructure  
-	db         *sqlite.SQLiteDB
-	httpServer *http.Server
+	db           *sqlite.SQLiteDB
+	httpServer   *http.Server
+	reportingSvc *reporting.SQLiteReportingService
+	tracker      *tracking.ActivityTracker
+	calendar     *reporting.CalendarExporter
+	tags         *sqlite.TagRepository
+	backups      *sqlite.BackupManager
+	adminToken   string
+	events       *eventBroker
+	statusFile   chan struct{}
 	
 	// HTTP Server 
 	router      *mux.Router
@@ -53,12 +71,21 @@ type Orchestrator struct {
 	lastRequestTime  time.Time
 	connectionCount  int32
 	healthStatus     string
+	health           *health.Monitor
+	notifier         *notify.Dispatcher
+	goalAlerts       map[string]time.Time // goal ID + period start -> period end, delivered
+	systemd          *systemd.Notifier
 	
 	// Lifecycle management
 	ctx       context.Context
 	cancel    context.CancelFunc
 	startTime time.Time
 	isRunning bool
+	jobs      sync.WaitGroup
+	jobCount  int
+	
+	// schedulerRunning is reported by the readiness probe
+	schedulerRunning atomic.Bool
 }
 
 // OrchestratorConfig holds configuration for orchestrator initialization
@@ -73,20 +100,11 @@ type OrchestratorConfig struct {
  * INPUT:     Complete daemon configuration with database and server settings
  * OUTPUT:    Production orchestrator with database, rate limiting, and monitoring
  * BUSINESS:  Production deployment requires complete, reliable daemon initialization
- * CHANGE:    CRITICAL FIX - Complete production initialization with all components
+ * CHANGE:    Build the logger from the logging configuration and make it the
+ *            slog default for the sqlite and business packages
  * RISK:      Medium - Complete initialization affecting all daemon functionality
  */
 func NewOrchestrator(config OrchestratorConfig) (*Orchestrator, error) {
-	// Create logger if none provided
-	logger := config.Logger
-	if logger == nil {
-		logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-			Level: slog.LevelInfo,
-		}))
-	}
-	
-	logger.Info("Initializing production Claude Monitor daemon")
-	
 	// Use provided daemon config or create default
 	daemonConfig := config.DaemonConfig
 	if daemonConfig == nil {
@@ -98,6 +116,22 @@ func NewOrchestrator(config OrchestratorConfig) (*Orchestrator, error) {
 		return nil, fmt.Errorf("invalid daemon configuration: %w", err)
 	}
 	
+	// Create logger from configuration if none provided
+	logger := config.Logger
+	var logCloser io.Closer
+	if logger == nil {
+		var err error
+		logger, logCloser, err = logging.New(daemonConfig.Logging, os.Stdout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure logging: %w", err)
+		}
+		slog.SetDefault(logger)
+	}
+	
+	logger.Info("Initializing production Claude Monitor daemon",
+		"log_level", daemonConfig.Logging.Level,
+		"log_file", daemonConfig.Logging.OutputFile)
+	
 	// Create context for lifecycle management
 	ctx, cancel := context.WithCancel(context.Background())
 	
@@ -113,18 +147,23 @@ func NewOrchestrator(config OrchestratorConfig) (*Orchestrator, error) {
 	db, err := sqlite.NewSQLiteDB(dbConfig)
 	if err != nil {
 		cancel()
+		if logCloser != nil {
+			logCloser.Close()
+		}
 		return nil, fmt.Errorf("failed to initialize database: %w", err)
 	}
 	
 	orchestrator := &Orchestrator{
 		config:       daemonConfig,
 		logger:       logger,
+		logCloser:    logCloser,
 		db:           db,
 		rateLimiter:  rateLimiter,
 		ctx:          ctx,
 		cancel:       cancel,
 		startTime:    time.Now(),
 		healthStatus: "initializing",
+		systemd:      systemd.NewNotifier(),
 	}
 	
 	logger.Info("Production daemon initialized successfully",
@@ -138,7 +177,7 @@ func NewOrchestrator(config OrchestratorConfig) (*Orchestrator, error) {
  * INPUT:     System signals for shutdown and runtime management
  * OUTPUT:    Running production daemon with HTTP server, database, and monitoring
  * BUSINESS:  Provide complete production service for HTTP API and monitoring
- * CHANGE:    CRITICAL FIX - Complete production daemon execution
+ * CHANGE:    Start the prompt status file writer with the maintenance jobs
  * RISK:      Medium - Production daemon affecting all system functionality
  */
 func (o *Orchestrator) Run() error {
@@ -160,14 +199,25 @@ func (o *Orchestrator) Run() error {
 		return fmt.Errorf("failed to setup HTTP server: %w", err)
 	}
 	
+	// Bind before serving so a port conflict fails startup instead of racing readiness
+	listener, err := o.listen()
+	if err != nil {
+		return fmt.Errorf("failed to setup HTTP listener: %w", err)
+	}
+	
 	// Start HTTP server
 	serverErrChan := make(chan error, 1)
-	go o.startHTTPServer(serverErrChan)
+	go o.startHTTPServer(listener, serverErrChan)
+	
+	// Start maintenance jobs such as retention purges
+	o.startScheduler(o.scheduledJobs())
+	o.startStatusFile()
 	
 	// Mark as healthy after successful start
 	o.healthStatus = "healthy"
 	o.logger.Info("Production daemon started successfully",
-		"endpoints", []string{"/health", "/status", "/metrics"})
+		"endpoints", []string{"/health", "/health/live", "/health/ready", "/health/details", "/status", "/metrics"})
+	o.notifyReady(listener.Addr().String())
 	
 	// Wait for shutdown signal or server error
 	select {
@@ -186,12 +236,14 @@ func (o *Orchestrator) Run() error {
  * INPUT:     Shutdown context with configurable timeout
  * OUTPUT:    Clean shutdown with database, server, and monitoring cleanup
  * BUSINESS:  Ensure complete cleanup during production shutdown
- * CHANGE:    CRITICAL FIX - Complete production shutdown with monitoring
+ * CHANGE:    Tell systemd that shutdown has started before draining connections
  * RISK:      Low - Essential shutdown handling for production reliability
  */
 func (o *Orchestrator) gracefulShutdown() error {
+	o.notifyStopping()
 	o.isRunning = false
 	o.healthStatus = "shutting_down"
+	o.schedulerRunning.Store(false) // readiness fails while connections drain
 	o.logger.Info("Starting graceful shutdown")
 	
 	// Create shutdown context with configurable timeout
@@ -217,8 +269,9 @@ func (o *Orchestrator) gracefulShutdown() error {
 		}
 	}
 	
-	// Cancel context
+	// Cancel context and wait for running maintenance jobs
 	o.cancel()
+	o.jobs.Wait()
 	
 	// Close database connections
 	if o.db != nil {
@@ -237,6 +290,10 @@ func (o *Orchestrator) gracefulShutdown() error {
 		"total_requests", o.requestCount,
 		"final_status", o.healthStatus)
 	
+	if o.logCloser != nil {
+		o.logCloser.Close()
+	}
+	
 	return nil
 }
 
@@ -269,7 +326,7 @@ func (o *Orchestrator) GetUptime() time.Duration {
  * INPUT:     Complete daemon configuration with performance and monitoring settings
  * OUTPUT:    Production HTTP server with rate limiting, health checks, and metrics
  * BUSINESS:  Production API requires rate limiting, monitoring, and proper error handling
- * CHANGE:    CRITICAL FIX - Complete production HTTP server with rate limiting
+ * CHANGE:    Close live event streams when the server shuts down
  * RISK:      Medium - Production HTTP server affecting all API functionality
  */
 func (o *Orchestrator) setupProductionServer() error {
@@ -280,15 +337,32 @@ func (o *Orchestrator) setupProductionServer() error {
 	o.router.Use(o.loggingMiddleware)
 	o.router.Use(o.metricsMiddleware)
 	
+	// Token auth runs after logging so rejected requests are still recorded
+	if o.config.Server.AuthToken != "" {
+		o.router.Use(o.authMiddleware)
+	}
+	
 	// Health endpoint with database connectivity check
 	o.router.HandleFunc("/health", o.handleHealth).Methods("GET")
 	
+	// Liveness, readiness and detailed health probes
+	o.setupHealth()
+	o.setupHealthRoutes()
+	
+	// Alert delivery for health and work alerts
+	if err := o.setupAlerts(); err != nil {
+		return err
+	}
+	
 	// Status endpoint with comprehensive daemon information
 	o.router.HandleFunc("/status", o.handleStatus).Methods("GET")
 	
 	// Metrics endpoint for monitoring (basic)
 	o.router.HandleFunc("/metrics", o.handleMetrics).Methods("GET")
 	
+	// Versioned reporting API
+	o.setupAPIRoutes()
+	
 	// Create production HTTP server with timeouts
 	listenAddr := fmt.Sprintf("%s:%d", o.config.Server.Host, o.config.Server.Port)
 	
@@ -300,22 +374,25 @@ func (o *Orchestrator) setupProductionServer() error {
 		IdleTimeout:    o.config.Server.IdleTimeout,
 		MaxHeaderBytes: 1 << 20, // 1MB
 	}
+	if o.events != nil {
+		o.httpServer.RegisterOnShutdown(o.events.close)
+	}
 	
 	return nil
 }
 
 /**
  * CONTEXT:   Start HTTP server in background
- * INPUT:     Error channel for server failures
+ * INPUT:     Bound listener and error channel for server failures
  * OUTPUT:    Running HTTP server
  * BUSINESS:  HTTP server required for daemon operation
- * CHANGE:    CHECKPOINT 8 - Basic server startup
+ * CHANGE:    Serve on a pre-bound listener so socket activation can pass one in
  * RISK:      Low - Simple server startup
  */
-func (o *Orchestrator) startHTTPServer(errChan chan<- error) {
-	o.logger.Info("Starting HTTP server", "addr", o.httpServer.Addr)
+func (o *Orchestrator) startHTTPServer(listener net.Listener, errChan chan<- error) {
+	o.logger.Info("Starting HTTP server", "addr", listener.Addr().String())
 	
-	err := o.httpServer.ListenAndServe()
+	err := o.httpServer.Serve(listener)
 	if err != nil && err != http.ErrServerClosed {
 		errChan <- fmt.Errorf("HTTP server failed: %w", err)
 	}
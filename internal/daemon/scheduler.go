@@ -0,0 +1,167 @@
+/**
+ * CONTEXT:   Background job scheduler for daemon maintenance
+ * INPUT:     Jobs with an interval, an initial delay and a run function
+ * OUTPUT:    Jobs run on their interval until the daemon context is cancelled
+ * BUSINESS:  Maintenance such as retention purges runs without user action
+ * CHANGE:    Added the systemd watchdog and status jobs
+ * RISK:      Medium - Jobs share the database with request handlers; shutdown waits
+ *            for a running job before the database is closed
+ */
+
+package daemon
+
+import (
+	"context"
+	"time"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+)
+
+// Start delays give the daemon time to settle before the first run
+const (
+	retentionStartDelay = 2 * time.Minute
+	backupStartDelay    = time.Minute
+)
+
+// scheduledJob is a maintenance task the daemon runs periodically
+type scheduledJob struct {
+	name         string
+	initialDelay time.Duration
+	interval     time.Duration
+	run          func(context.Context) error
+}
+
+/**
+ * CONTEXT:   Start all scheduled jobs in the background
+ * INPUT:     Jobs from scheduledJobs
+ * OUTPUT:    One goroutine per job, tracked for graceful shutdown
+ * BUSINESS:  A failing job is logged and retried on its next interval
+ * CHANGE:    Initial job runner
+ * RISK:      Low - Jobs stop when the orchestrator context is cancelled
+ */
+func (o *Orchestrator) startScheduler(jobs []scheduledJob) {
+	o.jobCount = len(jobs)
+	o.schedulerRunning.Store(true)
+	for _, job := range jobs {
+		o.jobs.Add(1)
+		go func(job scheduledJob) {
+			defer o.jobs.Done()
+
+			timer := time.NewTimer(job.initialDelay)
+			defer timer.Stop()
+			for {
+				select {
+				case <-o.ctx.Done():
+					return
+				case <-timer.C:
+				}
+
+				started := time.Now()
+				if err := job.run(o.ctx); err != nil && o.ctx.Err() == nil {
+					o.logger.Error("Scheduled job failed", "job", job.name, "error", err)
+				} else {
+					o.logger.Debug("Scheduled job finished", "job", job.name, "duration", time.Since(started))
+				}
+				timer.Reset(job.interval)
+			}
+		}(job)
+	}
+}
+
+// scheduledJobs returns the maintenance jobs enabled by the configuration
+func (o *Orchestrator) scheduledJobs() []scheduledJob {
+	jobs := o.systemdJobs()
+	if o.health != nil {
+		jobs = append(jobs, o.healthJob())
+	}
+	if o.notifier != nil {
+		jobs = append(jobs, o.workAlertsJob())
+	}
+	if retention := o.config.Retention; retention.Enabled() {
+		jobs = append(jobs, scheduledJob{
+			name:         "retention",
+			initialDelay: retentionStartDelay,
+			interval:     time.Duration(retention.IntervalHours) * time.Hour,
+			run:          o.runRetention,
+		})
+	}
+	if o.config.Database.BackupEnabled && o.backups != nil {
+		jobs = append(jobs, scheduledJob{
+			name:         "backup",
+			initialDelay: o.backupInitialDelay(),
+			interval:     o.config.Database.BackupInterval,
+			run:          o.runBackup,
+		})
+	}
+	return jobs
+}
+
+// backupPolicy returns the rotation policy from the database configuration
+func (o *Orchestrator) backupPolicy() sqlite.BackupPolicy {
+	return sqlite.BackupPolicy{
+		KeepLast:   o.config.Database.BackupKeepLast,
+		KeepDaily:  o.config.Database.BackupKeepDaily,
+		KeepWeekly: o.config.Database.BackupKeepWeekly,
+	}
+}
+
+// backupInitialDelay schedules the first backup one interval after the newest
+// existing backup, so frequent daemon restarts neither skip nor repeat backups
+func (o *Orchestrator) backupInitialDelay() time.Duration {
+	backups, err := o.backups.List()
+	if err != nil || len(backups) == 0 {
+		return backupStartDelay
+	}
+	if due := time.Until(backups[0].CreatedAt.Add(o.config.Database.BackupInterval)); due > backupStartDelay {
+		return due
+	}
+	return backupStartDelay
+}
+
+// runBackup creates a scheduled backup and rotates old ones
+func (o *Orchestrator) runBackup(ctx context.Context) error {
+	backup, removed, err := o.backups.Create(ctx, sqlite.BackupReasonScheduled)
+	if err != nil {
+		return err
+	}
+	o.logger.Info("Scheduled backup completed", "file", backup.File, "size_bytes", backup.SizeBytes, "rotated", len(removed))
+	return nil
+}
+
+/**
+ * CONTEXT:   Scheduled retention purge
+ * INPUT:     Daemon context, cancelled on shutdown
+ * OUTPUT:    Expired rows purged in batches, followed by an incremental vacuum
+ * BUSINESS:  Keeps the database bounded without a full VACUUM blocking the hook
+ * CHANGE:    Initial retention job
+ * RISK:      High - Deletes data according to the configured retention days
+ */
+func (o *Orchestrator) runRetention(ctx context.Context) error {
+	retention := o.config.Retention
+	repo := sqlite.NewRetentionRepository(o.db)
+	result, err := repo.Purge(ctx, sqlite.RetentionPolicy{
+		ActivityEventsDays: retention.ActivityEventsDays,
+		WorkBlocksDays:     retention.WorkBlocksDays,
+		BatchSize:          retention.BatchSize,
+	}, o.db.Now())
+	if err != nil {
+		return err
+	}
+
+	o.logger.Info("Retention purge completed",
+		"activity_events", result.ActivityEvents,
+		"work_blocks", result.WorkBlocks,
+		"sessions", result.Sessions,
+		"rollup_rows", result.RollupRows,
+		"batches", result.Batches)
+
+	if !retention.Vacuum || result.Total() == 0 {
+		return nil
+	}
+	vacuum, err := repo.Vacuum(ctx, false)
+	if err != nil {
+		return err
+	}
+	o.logger.Info("Retention vacuum completed", "mode", vacuum.Mode, "freed_bytes", vacuum.FreedBytes)
+	return nil
+}
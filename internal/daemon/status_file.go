@@ -0,0 +1,78 @@
+/**
+ * CONTEXT:   Prompt status file maintained by the daemon
+ * INPUT:     User IDs queued after each recorded activity
+ * OUTPUT:    work_tracking.status_file rewritten atomically on each of the daemon
+ *            user's state changes, and marked stopped on shutdown
+ * BUSINESS:  `claude-monitor prompt` reads this file on every shell prompt
+ *            instead of querying SQLite or the API; the file holds one user, so
+ *            other users' activity must not overwrite it
+ * CHANGE:    Only the daemon user's activity rewrites the file
+ * RISK:      Low - Writes are serialized in one goroutine and coalesce under load
+ */
+
+package daemon
+
+import (
+	"time"
+
+	"github.com/claude-monitor/system/internal/reporting"
+)
+
+// requestStatusFile queues a rewrite after userID's activity; a pending rewrite
+// absorbs it, and other users' activity is ignored
+func (o *Orchestrator) requestStatusFile(userID string) {
+	if o.statusFile == nil || userID != defaultAPIUserID() {
+		return
+	}
+	select {
+	case o.statusFile <- struct{}{}:
+	default:
+	}
+}
+
+// startStatusFile runs the status file writer until the daemon context ends
+func (o *Orchestrator) startStatusFile() {
+	if o.statusFile == nil {
+		return
+	}
+	o.jobs.Add(1)
+	go func() {
+		defer o.jobs.Done()
+		var last *reporting.StatusSnapshot
+		userID := defaultAPIUserID()
+		write := func() {
+			status, err := o.reportingSvc.GetLiveStatus(o.ctx, userID, o.db.Now(), o.config.WorkTracking.IdleTimeout)
+			if err != nil {
+				if o.ctx.Err() == nil {
+					o.logger.Warn("Failed to build status snapshot", "user_id", userID, "error", err)
+				}
+				return
+			}
+			snapshot := reporting.NewStatusSnapshot(status, reporting.SnapshotDaemonRunning)
+			if err := reporting.WriteStatusSnapshot(o.config.WorkTracking.StatusFile, snapshot); err != nil {
+				o.logger.Warn("Failed to write status file", "path", o.config.WorkTracking.StatusFile, "error", err)
+				return
+			}
+			last = snapshot
+		}
+
+		write()
+		for {
+			select {
+			case <-o.statusFile:
+				write()
+			case <-o.ctx.Done():
+				// Prompts go quiet instead of showing a frozen session countdown
+				if last == nil {
+					last = &reporting.StatusSnapshot{Version: reporting.StatusSnapshotVersion, UserID: userID}
+				}
+				last.Daemon = reporting.SnapshotDaemonStopped
+				last.UpdatedAt = time.Now()
+				if err := reporting.WriteStatusSnapshot(o.config.WorkTracking.StatusFile, last); err != nil {
+					o.logger.Warn("Failed to write status file", "path", o.config.WorkTracking.StatusFile, "error", err)
+				}
+				return
+			}
+		}
+	}()
+}
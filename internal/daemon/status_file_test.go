@@ -0,0 +1,27 @@
+/**
+ * CONTEXT:   Tests for the prompt status file writer
+ * INPUT:     Rewrite requests after activity of the daemon user and another user
+ * OUTPUT:    Validation that only the daemon user's activity queues a rewrite
+ * BUSINESS:  The file holds one user's snapshot and prompts must not show another's
+ * CHANGE:    Initial status file request test
+ * RISK:      Low - No file is written
+ */
+
+package daemon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusFileIgnoresOtherUsers(t *testing.T) {
+	o := newAPITestOrchestrator(t)
+	o.statusFile = make(chan struct{}, 1)
+
+	o.requestStatusFile("bob")
+	assert.Empty(t, o.statusFile, "another user's activity does not rewrite the daemon user's file")
+
+	o.requestStatusFile("alice")
+	assert.Len(t, o.statusFile, 1)
+}
@@ -0,0 +1,111 @@
+/**
+ * CONTEXT:   systemd integration for the daemon: readiness, status, watchdog
+ *            and socket-activated listeners
+ * INPUT:     Notify socket and LISTEN_FDS passed by systemd, health monitor state
+ * OUTPUT:    READY once the API is bound, periodic WATCHDOG pings while live,
+ *            STOPPING on shutdown
+ * BUSINESS:  Type=notify lets systemd order hooks after a ready daemon and
+ *            restart a hung one; socket activation starts it on first use
+ * CHANGE:    Initial systemd notify and socket activation wiring
+ * RISK:      Medium - Missing watchdog pings make systemd kill the daemon
+ */
+
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/claude-monitor/system/internal/systemd"
+)
+
+// statusUpdateInterval controls how often the request count is reported in STATUS
+const statusUpdateInterval = time.Minute
+
+// listen returns the socket-activated listener when systemd passed one, and
+// binds the configured address otherwise
+func (o *Orchestrator) listen() (net.Listener, error) {
+	inherited, err := systemd.Listeners()
+	if err != nil {
+		return nil, err
+	}
+	if len(inherited) > 0 {
+		for _, extra := range inherited[1:] {
+			o.logger.Warn("Ignoring extra socket-activated listener", "addr", extra.Addr().String())
+			extra.Close()
+		}
+		o.logger.Info("Using socket-activated listener", "addr", inherited[0].Addr().String())
+		return inherited[0], nil
+	}
+
+	listener, err := net.Listen("tcp", o.httpServer.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", o.httpServer.Addr, err)
+	}
+	return listener, nil
+}
+
+// notifyReady tells systemd the API accepts requests; the watchdog and status
+// jobs are only useful under systemd
+func (o *Orchestrator) notifyReady(addr string) {
+	if !o.systemd.Enabled() {
+		return
+	}
+	if err := o.systemd.Ready(fmt.Sprintf("Serving hook events on %s", addr)); err != nil {
+		o.logger.Warn("Failed to notify systemd of readiness", "error", err)
+		return
+	}
+	o.logger.Debug("Notified systemd of readiness", "watchdog", o.systemd.WatchdogInterval())
+}
+
+// notifyStopping tells systemd that shutdown has begun
+func (o *Orchestrator) notifyStopping() {
+	if err := o.systemd.Stopping("Shutting down"); err != nil {
+		o.logger.Warn("Failed to notify systemd of shutdown", "error", err)
+	}
+}
+
+// systemdJobs returns the watchdog and status jobs when running under systemd
+func (o *Orchestrator) systemdJobs() []scheduledJob {
+	if !o.systemd.Enabled() {
+		return nil
+	}
+	jobs := []scheduledJob{{
+		name:         "systemd-status",
+		initialDelay: statusUpdateInterval,
+		interval:     statusUpdateInterval,
+		run: func(ctx context.Context) error {
+			return o.systemd.Status(fmt.Sprintf("Serving hook events; %d requests, status %s", atomic.LoadInt64(&o.requestCount), o.healthStatus))
+		},
+	}}
+	if interval := o.systemd.WatchdogInterval(); interval > 0 {
+		jobs = append(jobs, scheduledJob{
+			name:     "systemd-watchdog",
+			interval: interval / 2,
+			run:      o.pingWatchdog,
+		})
+	}
+	return jobs
+}
+
+/**
+ * CONTEXT:   Watchdog keep-alive
+ * INPUT:     Health monitor liveness
+ * OUTPUT:    WATCHDOG=1 while health check rounds keep running
+ * BUSINESS:  A daemon whose checks have stalled stops pinging, so systemd
+ *            restarts it after WatchdogSec instead of leaving hooks hanging
+ * CHANGE:    Initial watchdog job
+ * RISK:      Medium - Pinging unconditionally would hide a wedged daemon
+ */
+func (o *Orchestrator) pingWatchdog(ctx context.Context) error {
+	if o.health != nil {
+		if live, lastRun := o.health.Live(); !live {
+			o.logger.Warn("Skipping watchdog ping; health checks have stalled", "last_check", lastRun)
+			return nil
+		}
+	}
+	return o.systemd.Watchdog()
+}
@@ -3,7 +3,7 @@
  * INPUT:     Activity events with FK relationships to work blocks
  * OUTPUT:    Database CRUD operations with proper JSON metadata handling
  * BUSINESS:  Activities belong to work blocks and drive idle detection
- * CHANGE:    New repository replacing in-memory activity arrays
+ * CHANGE:    Repository targets activity_events and records hook tool names and git branch
  * RISK:      Low - Standard repository pattern with FK constraints
  */
 
@@ -29,14 +29,22 @@ func NewActivityRepository(db *sql.DB) *ActivityRepository {
 
 // Activity represents an activity event in the database
 type Activity struct {
-	ID           string            `json:"id"`
-	WorkBlockID  string            `json:"work_block_id"`
-	Timestamp    time.Time         `json:"timestamp"`
-	ActivityType string            `json:"activity_type"`
-	Command      string            `json:"command"`
-	Description  string            `json:"description"`
-	Metadata     map[string]string `json:"metadata"`
-	CreatedAt    time.Time         `json:"created_at"`
+	ID             string            `json:"id"`
+	UserID         string            `json:"user_id,omitempty"`
+	SessionID      string            `json:"session_id,omitempty"`
+	WorkBlockID    string            `json:"work_block_id"`
+	ProjectID      string            `json:"project_id,omitempty"`
+	Timestamp      time.Time         `json:"timestamp"`
+	ActivityType   string            `json:"activity_type"`
+	ActivitySource string            `json:"activity_source,omitempty"`
+	ToolName       string            `json:"tool_name,omitempty"`
+	GitBranch      string            `json:"git_branch,omitempty"`
+	GitCommit      string            `json:"git_commit,omitempty"`
+	Tags           []string          `json:"tags,omitempty"`
+	Command        string            `json:"command"`
+	Description    string            `json:"description"`
+	Metadata       map[string]string `json:"metadata"`
+	CreatedAt      time.Time         `json:"created_at"`
 }
 
 // ActivitySummary provides aggregated activity data for reporting
@@ -48,43 +56,43 @@ type ActivitySummary struct {
 	ActivityRate    float64           `json:"activity_rate"` // activities per minute
 }
 
+// activityColumns is the column list read by scanActivities
+const activityColumns = `a.id, a.user_id, COALESCE(a.session_id, ''), COALESCE(a.work_block_id, ''),
+		       COALESCE(a.project_id, ''), a.timestamp, a.activity_type, a.activity_source,
+		       COALESCE(a.tool_name, ''), COALESCE(a.git_branch, ''), COALESCE(a.git_commit, ''),
+		       COALESCE(a.command, ''), COALESCE(a.description, ''),
+		       COALESCE(a.metadata, ''), a.created_at, ` + activityTagsColumn
+
+// insertActivityQuery fills user, session and project from the work block when not given
+const insertActivityQuery = `
+		INSERT INTO activity_events (
+			id, user_id, session_id, work_block_id, project_id, timestamp,
+			activity_type, activity_source, tool_name, git_branch, git_commit,
+			command, description, metadata, created_at
+		) VALUES (
+			?,
+			COALESCE(?, (SELECT s.user_id FROM work_blocks wb JOIN sessions s ON wb.session_id = s.id WHERE wb.id = ?)),
+			COALESCE(?, (SELECT session_id FROM work_blocks WHERE id = ?)),
+			?,
+			COALESCE(?, (SELECT project_id FROM work_blocks WHERE id = ?)),
+			?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+		)`
+
 /**
  * CONTEXT:   Save activity to database with WorkBlock FK relationship
  * INPUT:     Activity struct with all required fields and JSON metadata
  * OUTPUT:    Database insert with FK constraint validation
  * BUSINESS:  Activities must belong to existing work blocks
- * CHANGE:    Initial implementation with JSON metadata support
+ * CHANGE:    Insert into activity_events with user, session, project and tool name
  * RISK:      Medium - JSON serialization and FK constraint validation
  */
 func (r *ActivityRepository) SaveActivity(activity *Activity) error {
-	// Serialize metadata to JSON
-	metadataJSON := "{}"
-	if activity.Metadata != nil {
-		jsonBytes, err := json.Marshal(activity.Metadata)
-		if err != nil {
-			return fmt.Errorf("failed to serialize metadata: %w", err)
-		}
-		metadataJSON = string(jsonBytes)
+	args, err := activityInsertArgs(activity)
+	if err != nil {
+		return err
 	}
 
-	query := `
-		INSERT INTO activities (
-			id, work_block_id, timestamp, activity_type, 
-			command, description, metadata, created_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
-
-	_, err := r.db.Exec(query,
-		activity.ID,
-		activity.WorkBlockID,
-		activity.Timestamp,
-		activity.ActivityType,
-		activity.Command,
-		activity.Description,
-		metadataJSON,
-		activity.CreatedAt,
-	)
-
-	if err != nil {
+	if _, err := r.db.Exec(insertActivityQuery, args...); err != nil {
 		return fmt.Errorf("failed to save activity: %w", err)
 	}
 
@@ -96,16 +104,15 @@ func (r *ActivityRepository) SaveActivity(activity *Activity) error {
  * INPUT:     Work block ID for FK relationship lookup
  * OUTPUT:    Array of activities with deserialized JSON metadata
  * BUSINESS:  Activities are retrieved by work block for reporting
- * CHANGE:    Initial implementation with JSON metadata deserialization
+ * CHANGE:    Query activity_events through the shared activity scanner
  * RISK:      Low - Standard SELECT query with JSON handling
  */
 func (r *ActivityRepository) GetActivitiesByWorkBlock(workBlockID string) ([]*Activity, error) {
 	query := `
-		SELECT id, work_block_id, timestamp, activity_type, 
-		       command, description, metadata, created_at
-		FROM activities 
-		WHERE work_block_id = ? 
-		ORDER BY timestamp ASC`
+		SELECT ` + activityColumns + `
+		FROM activity_events a
+		WHERE a.work_block_id = ?
+		ORDER BY a.timestamp ASC`
 
 	rows, err := r.db.Query(query, workBlockID)
 	if err != nil {
@@ -113,40 +120,7 @@ func (r *ActivityRepository) GetActivitiesByWorkBlock(workBlockID string) ([]*Ac
 	}
 	defer rows.Close()
 
-	var activities []*Activity
-	for rows.Next() {
-		activity := &Activity{}
-		var metadataJSON string
-
-		err := rows.Scan(
-			&activity.ID,
-			&activity.WorkBlockID,
-			&activity.Timestamp,
-			&activity.ActivityType,
-			&activity.Command,
-			&activity.Description,
-			&metadataJSON,
-			&activity.CreatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan activity: %w", err)
-		}
-
-		// Deserialize metadata
-		if metadataJSON != "" && metadataJSON != "{}" {
-			err = json.Unmarshal([]byte(metadataJSON), &activity.Metadata)
-			if err != nil {
-				// Log error but continue - don't fail entire query for bad JSON
-				activity.Metadata = map[string]string{"parse_error": metadataJSON}
-			}
-		} else {
-			activity.Metadata = make(map[string]string)
-		}
-
-		activities = append(activities, activity)
-	}
-
-	return activities, nil
+	return scanActivities(rows)
 }
 
 /**
@@ -154,19 +128,15 @@ func (r *ActivityRepository) GetActivitiesByWorkBlock(workBlockID string) ([]*Ac
  * INPUT:     Work block ID for aggregation calculations
  * OUTPUT:    Activity summary with counts, rates, and time ranges
  * BUSINESS:  Activity summaries drive work block insights and reporting
- * CHANGE:    Initial implementation with comprehensive activity metrics
+ * CHANGE:    Read first and last timestamps as typed columns; MIN/MAX come back as text
  * RISK:      Low - Aggregation query with proper error handling
  */
 func (r *ActivityRepository) GetActivitySummary(workBlockID string) (*ActivitySummary, error) {
 	// Get basic activity statistics
 	query := `
-		SELECT 
-			COUNT(*) as total_activities,
-			MIN(timestamp) as first_activity,
-			MAX(timestamp) as last_activity,
-			activity_type
-		FROM activities 
-		WHERE work_block_id = ? 
+		SELECT activity_type, COUNT(*)
+		FROM activity_events
+		WHERE work_block_id = ?
 		GROUP BY activity_type
 		ORDER BY COUNT(*) DESC`
 
@@ -180,37 +150,40 @@ func (r *ActivityRepository) GetActivitySummary(workBlockID string) (*ActivitySu
 		ActivityCounts: make(map[string]int),
 	}
 
-	var firstActivitySet, lastActivitySet bool
 	for rows.Next() {
 		var count int
 		var activityType string
-		var firstActivity, lastActivity time.Time
-
-		err := rows.Scan(&count, &firstActivity, &lastActivity, &activityType)
-		if err != nil {
+		if err := rows.Scan(&activityType, &count); err != nil {
 			return nil, fmt.Errorf("failed to scan activity summary: %w", err)
 		}
 
 		summary.TotalActivities += count
 		summary.ActivityCounts[activityType] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating activity summary: %w", err)
+	}
+	if summary.TotalActivities == 0 {
+		return summary, nil
+	}
 
-		// Track overall first and last activity times
-		if !firstActivitySet || firstActivity.Before(summary.FirstActivity) {
-			summary.FirstActivity = firstActivity
-			firstActivitySet = true
-		}
-		if !lastActivitySet || lastActivity.After(summary.LastActivity) {
-			summary.LastActivity = lastActivity
-			lastActivitySet = true
-		}
+	// Track overall first and last activity times
+	boundQuery := `
+		SELECT timestamp FROM activity_events
+		WHERE work_block_id = ?
+		ORDER BY julianday(timestamp) %s
+		LIMIT 1`
+	if err := r.db.QueryRow(fmt.Sprintf(boundQuery, "ASC"), workBlockID).Scan(&summary.FirstActivity); err != nil {
+		return nil, fmt.Errorf("failed to query first activity: %w", err)
+	}
+	if err := r.db.QueryRow(fmt.Sprintf(boundQuery, "DESC"), workBlockID).Scan(&summary.LastActivity); err != nil {
+		return nil, fmt.Errorf("failed to query last activity: %w", err)
 	}
 
 	// Calculate activity rate (activities per minute)
-	if firstActivitySet && lastActivitySet && summary.TotalActivities > 0 {
-		duration := summary.LastActivity.Sub(summary.FirstActivity)
-		if duration > 0 {
-			summary.ActivityRate = float64(summary.TotalActivities) / duration.Minutes()
-		}
+	duration := summary.LastActivity.Sub(summary.FirstActivity)
+	if duration > 0 {
+		summary.ActivityRate = float64(summary.TotalActivities) / duration.Minutes()
 	}
 
 	return summary, nil
@@ -221,14 +194,13 @@ func (r *ActivityRepository) GetActivitySummary(workBlockID string) (*ActivitySu
  * INPUT:     Time range for activity filtering across all work blocks
  * OUTPUT:    Activities array sorted by timestamp
  * BUSINESS:  Time-based activity queries support daily/weekly/monthly reports
- * CHANGE:    Initial implementation for reporting system integration
+ * CHANGE:    Query activity_events through the shared activity scanner
  * RISK:      Low - Standard time range query with proper indexing
  */
 func (r *ActivityRepository) GetActivitiesByTimeRange(startTime, endTime time.Time) ([]*Activity, error) {
 	query := `
-		SELECT a.id, a.work_block_id, a.timestamp, a.activity_type, 
-		       a.command, a.description, a.metadata, a.created_at
-		FROM activities a
+		SELECT ` + activityColumns + `
+		FROM activity_events a
 		JOIN work_blocks wb ON a.work_block_id = wb.id
 		WHERE a.timestamp >= ? AND a.timestamp <= ?
 		ORDER BY a.timestamp ASC`
@@ -239,39 +211,50 @@ func (r *ActivityRepository) GetActivitiesByTimeRange(startTime, endTime time.Ti
 	}
 	defer rows.Close()
 
-	var activities []*Activity
-	for rows.Next() {
-		activity := &Activity{}
-		var metadataJSON string
+	return scanActivities(rows)
+}
 
-		err := rows.Scan(
-			&activity.ID,
-			&activity.WorkBlockID,
-			&activity.Timestamp,
-			&activity.ActivityType,
-			&activity.Command,
-			&activity.Description,
-			&metadataJSON,
-			&activity.CreatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan activity: %w", err)
-		}
+/**
+ * CONTEXT:   Get one user's activities within a time range
+ * INPUT:     User ID and time range for activity filtering
+ * OUTPUT:    Activities array sorted by timestamp
+ * BUSINESS:  Per-user reports must not mix in other users' tool usage
+ * CHANGE:    Initial user-scoped range query for tool breakdowns
+ * RISK:      Low - julianday compares instants, so bounds in any timezone match
+ */
+func (r *ActivityRepository) GetUserActivitiesByTimeRange(ctx context.Context, userID string, startTime, endTime time.Time) ([]*Activity, error) {
+	query := `
+		SELECT ` + activityColumns + `
+		FROM activity_events a
+		WHERE a.user_id = ?
+		  AND julianday(a.timestamp) >= julianday(?) AND julianday(a.timestamp) <= julianday(?)
+		ORDER BY julianday(a.timestamp) ASC`
 
-		// Deserialize metadata
-		if metadataJSON != "" && metadataJSON != "{}" {
-			err = json.Unmarshal([]byte(metadataJSON), &activity.Metadata)
-			if err != nil {
-				activity.Metadata = map[string]string{"parse_error": metadataJSON}
-			}
-		} else {
-			activity.Metadata = make(map[string]string)
-		}
+	rows, err := r.db.QueryContext(ctx, query, userID, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user activities by time range: %w", err)
+	}
+	defer rows.Close()
 
-		activities = append(activities, activity)
+	return scanActivities(rows)
+}
+
+// GetRecentByUser returns the user's latest activities, newest first
+func (r *ActivityRepository) GetRecentByUser(ctx context.Context, userID string, limit int) ([]*Activity, error) {
+	query := `
+		SELECT ` + activityColumns + `
+		FROM activity_events a
+		WHERE a.user_id = ?
+		ORDER BY julianday(a.timestamp) DESC, a.rowid DESC
+		LIMIT ?`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recent activities: %w", err)
 	}
+	defer rows.Close()
 
-	return activities, nil
+	return scanActivities(rows)
 }
 
 /**
@@ -279,7 +262,7 @@ func (r *ActivityRepository) GetActivitiesByTimeRange(startTime, endTime time.Ti
  * INPUT:     Array of activities for batch database operations
  * OUTPUT:    Single transaction with all activities inserted
  * BUSINESS:  Bulk operations improve performance for high-frequency activity logging
- * CHANGE:    Initial implementation with transaction support
+ * CHANGE:    Batch insert into activity_events with the single-insert statement
  * RISK:      Medium - Transaction management and bulk insert validation
  */
 func (r *ActivityRepository) SaveActivitiesBatch(activities []*Activity) error {
@@ -293,40 +276,19 @@ func (r *ActivityRepository) SaveActivitiesBatch(activities []*Activity) error {
 	}
 	defer tx.Rollback()
 
-	query := `
-		INSERT INTO activities (
-			id, work_block_id, timestamp, activity_type, 
-			command, description, metadata, created_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
-
-	stmt, err := tx.Prepare(query)
+	stmt, err := tx.Prepare(insertActivityQuery)
 	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %w", err)
 	}
 	defer stmt.Close()
 
-	for _, activity := range activities {
-		// Serialize metadata to JSON
-		metadataJSON := "{}"
-		if activity.Metadata != nil {
-			jsonBytes, err := json.Marshal(activity.Metadata)
-			if err != nil {
-				return fmt.Errorf("failed to serialize metadata for activity %s: %w", activity.ID, err)
-			}
-			metadataJSON = string(jsonBytes)
+	for i, activity := range activities {
+		args, err := activityInsertArgs(activity)
+		if err != nil {
+			return fmt.Errorf("failed to prepare activity %d: %w", i, err)
 		}
 
-		_, err = stmt.Exec(
-			activity.ID,
-			activity.WorkBlockID,
-			activity.Timestamp,
-			activity.ActivityType,
-			activity.Command,
-			activity.Description,
-			metadataJSON,
-			activity.CreatedAt,
-		)
-		if err != nil {
+		if _, err := stmt.Exec(args...); err != nil {
 			return fmt.Errorf("failed to execute statement for activity %s: %w", activity.ID, err)
 		}
 	}
@@ -339,12 +301,12 @@ func (r *ActivityRepository) SaveActivitiesBatch(activities []*Activity) error {
  * INPUT:     Work block ID for cascade deletion
  * OUTPUT:    Cleanup of all associated activities
  * BUSINESS:  Activity cleanup supports work block lifecycle management
- * CHANGE:    Initial implementation for data lifecycle management
+ * CHANGE:    Delete from activity_events
  * RISK:      Medium - Deletion operations require careful validation
  */
 func (r *ActivityRepository) DeleteActivitiesByWorkBlock(workBlockID string) error {
-	query := `DELETE FROM activities WHERE work_block_id = ?`
-	
+	query := `DELETE FROM activity_events WHERE work_block_id = ?`
+
 	result, err := r.db.Exec(query, workBlockID)
 	if err != nil {
 		return fmt.Errorf("failed to delete activities: %w", err)
@@ -366,16 +328,14 @@ func (r *ActivityRepository) DeleteActivitiesByWorkBlock(workBlockID string) err
  * INPUT:     Context for database operations
  * OUTPUT:    All activities in the database, ordered by timestamp
  * BUSINESS:  System-wide activity queries for health monitoring and analytics
- * CHANGE:    Added GetAll method for activity count and system monitoring
+ * CHANGE:    Query activity_events through the shared activity scanner
  * RISK:      Medium - Could return large dataset, consider pagination in future
  */
 func (r *ActivityRepository) GetAll(ctx context.Context) ([]*Activity, error) {
 	query := `
-		SELECT 
-			id, work_block_id, timestamp, activity_type, 
-			command, description, metadata, created_at
-		FROM activities 
-		ORDER BY timestamp DESC`
+		SELECT ` + activityColumns + `
+		FROM activity_events a
+		ORDER BY a.timestamp DESC`
 
 	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
@@ -383,29 +343,89 @@ func (r *ActivityRepository) GetAll(ctx context.Context) ([]*Activity, error) {
 	}
 	defer rows.Close()
 
+	return scanActivities(rows)
+}
+
+// activityInsertArgs builds insertActivityQuery arguments with defaults applied
+func activityInsertArgs(activity *Activity) ([]interface{}, error) {
+	if activity == nil {
+		return nil, fmt.Errorf("activity cannot be nil")
+	}
+
+	metadataJSON := "{}"
+	if activity.Metadata != nil {
+		jsonBytes, err := json.Marshal(activity.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize metadata: %w", err)
+		}
+		metadataJSON = string(jsonBytes)
+	}
+
+	if activity.ActivitySource == "" {
+		activity.ActivitySource = "hook"
+	}
+	if activity.CreatedAt.IsZero() {
+		activity.CreatedAt = time.Now()
+	}
+	// created_at must not precede the event; compare in one location
+	activity.CreatedAt = activity.CreatedAt.In(activity.Timestamp.Location())
+	if activity.CreatedAt.Before(activity.Timestamp) {
+		activity.CreatedAt = activity.Timestamp
+	}
+
+	workBlockID := nullableString(activity.WorkBlockID)
+	return []interface{}{
+		activity.ID,
+		nullableString(activity.UserID), workBlockID,
+		nullableString(activity.SessionID), workBlockID,
+		workBlockID,
+		nullableString(activity.ProjectID), workBlockID,
+		activity.Timestamp,
+		activity.ActivityType,
+		activity.ActivitySource,
+		nullableString(activity.ToolName),
+		nullableString(activity.GitBranch),
+		nullableString(activity.GitCommit),
+		activity.Command,
+		activity.Description,
+		metadataJSON,
+		activity.CreatedAt,
+	}, nil
+}
+
+// scanActivities reads activity rows selected with activityColumns
+func scanActivities(rows *sql.Rows) ([]*Activity, error) {
 	var activities []*Activity
 	for rows.Next() {
 		activity := &Activity{}
-		var metadataJSON string
+		var metadataJSON, tags string
 
 		err := rows.Scan(
 			&activity.ID,
+			&activity.UserID,
+			&activity.SessionID,
 			&activity.WorkBlockID,
+			&activity.ProjectID,
 			&activity.Timestamp,
 			&activity.ActivityType,
+			&activity.ActivitySource,
+			&activity.ToolName,
+			&activity.GitBranch,
+			&activity.GitCommit,
 			&activity.Command,
 			&activity.Description,
 			&metadataJSON,
 			&activity.CreatedAt,
+			&tags,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan activity: %w", err)
 		}
+		activity.Tags = splitTagList(tags)
 
-		// Deserialize metadata
+		// Deserialize metadata; bad JSON is kept rather than failing the query
 		if metadataJSON != "" && metadataJSON != "{}" {
-			err = json.Unmarshal([]byte(metadataJSON), &activity.Metadata)
-			if err != nil {
+			if err := json.Unmarshal([]byte(metadataJSON), &activity.Metadata); err != nil {
 				activity.Metadata = map[string]string{"parse_error": metadataJSON}
 			}
 		} else {
@@ -415,9 +435,9 @@ func (r *ActivityRepository) GetAll(ctx context.Context) ([]*Activity, error) {
 		activities = append(activities, activity)
 	}
 
-	if err = rows.Err(); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating activities: %w", err)
 	}
 
 	return activities, nil
-}
\ No newline at end of file
+}
@@ -3,7 +3,7 @@
  * INPUT:     Test scenarios for CRUD operations, JSON metadata, and FK validation
  * OUTPUT:    Complete test coverage for activity repository functionality
  * BUSINESS:  Activity repository tests ensure data integrity and FK relationships
- * CHANGE:    Initial test implementation for CHECKPOINT 4 activity management
+ * CHANGE:    Ported to the activity_events repository API
  * RISK:      Low - Test code ensuring system reliability and data consistency
  */
 
@@ -12,13 +12,11 @@ package sqlite
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
-	"os"
+	"fmt"
 	"path/filepath"
 	"testing"
 	"time"
 
-	"github.com/claude-monitor/system/internal/database/sqlite"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -28,28 +26,25 @@ import (
  * INPUT:     Test name and database path requirements
  * OUTPUT:    Configured test database with schema and sample data
  * BUSINESS:  Test database provides isolated environment for activity testing
- * CHANGE:    Initial test database setup with FK constraints
+ * CHANGE:    Open through NewSQLiteDB so the real schema and migrations apply
  * RISK:      Low - Test infrastructure for reliable testing
  */
 func setupActivityTestDB(t *testing.T) (*sql.DB, func()) {
-	// Create temporary database file
-	tempDir := t.TempDir()
-	dbPath := filepath.Join(tempDir, "test_activity.db")
-
-	sqliteDB, err := NewSQLiteDB(dbPath)
+	sqliteDB, err := NewSQLiteDB(DefaultConnectionConfig(filepath.Join(t.TempDir(), "test_activity.db")))
 	require.NoError(t, err, "Failed to create test database")
 
 	// Setup test data
 	ctx := context.Background()
-	
+	db := sqliteDB.DB()
+
 	// Insert test user
-	_, err = sqliteDB.DB.ExecContext(ctx, `
+	_, err = db.ExecContext(ctx, `
 		INSERT INTO users (id, username) VALUES ('test_user', 'test_user')
 	`)
 	require.NoError(t, err, "Failed to insert test user")
 
 	// Insert test project
-	_, err = sqliteDB.DB.ExecContext(ctx, `
+	_, err = db.ExecContext(ctx, `
 		INSERT INTO projects (id, name, path) VALUES ('test_project', 'Test Project', '/test/path')
 	`)
 	require.NoError(t, err, "Failed to insert test project")
@@ -57,7 +52,7 @@ func setupActivityTestDB(t *testing.T) (*sql.DB, func()) {
 	// Insert test session
 	sessionStart := time.Now().Add(-1 * time.Hour)
 	sessionEnd := sessionStart.Add(5 * time.Hour)
-	_, err = sqliteDB.DB.ExecContext(ctx, `
+	_, err = db.ExecContext(ctx, `
 		INSERT INTO sessions (id, user_id, start_time, end_time, state, first_activity_time, last_activity_time, activity_count)
 		VALUES ('test_session', 'test_user', ?, ?, 'active', ?, ?, 1)
 	`, sessionStart, sessionEnd, sessionStart, sessionStart)
@@ -65,7 +60,7 @@ func setupActivityTestDB(t *testing.T) (*sql.DB, func()) {
 
 	// Insert test work block
 	workBlockStart := time.Now().Add(-30 * time.Minute)
-	_, err = sqliteDB.DB.ExecContext(ctx, `
+	_, err = db.ExecContext(ctx, `
 		INSERT INTO work_blocks (id, session_id, project_id, start_time, state, last_activity_time, activity_count)
 		VALUES ('test_workblock', 'test_session', 'test_project', ?, 'active', ?, 1)
 	`, workBlockStart, workBlockStart)
@@ -73,10 +68,19 @@ func setupActivityTestDB(t *testing.T) (*sql.DB, func()) {
 
 	cleanup := func() {
 		sqliteDB.Close()
-		os.RemoveAll(tempDir)
 	}
 
-	return sqliteDB.DB, cleanup
+	return db, cleanup
+}
+
+// newTestActivity returns a command activity on the test work block
+func newTestActivity(id string, timestamp time.Time) *Activity {
+	return &Activity{
+		ID:           id,
+		WorkBlockID:  "test_workblock",
+		Timestamp:    timestamp,
+		ActivityType: "command",
+	}
 }
 
 /**
@@ -84,7 +88,7 @@ func setupActivityTestDB(t *testing.T) (*sql.DB, func()) {
  * INPUT:     Activity entity with valid and invalid work block references
  * OUTPUT:    Successful activity creation with FK validation
  * BUSINESS:  Activities must have valid work block associations
- * CHANGE:    Initial FK constraint validation testing
+ * CHANGE:    Check that user, session and project are filled from the work block
  * RISK:      Low - Test ensuring data integrity requirements
  */
 func TestActivityRepository_Save_FKConstraints(t *testing.T) {
@@ -92,80 +96,58 @@ func TestActivityRepository_Save_FKConstraints(t *testing.T) {
 	defer cleanup()
 
 	repo := NewActivityRepository(db)
-	ctx := context.Background()
 
 	t.Run("valid activity with FK references", func(t *testing.T) {
-		// Create valid activity
-		activity, err := entities.NewActivityEvent(entities.ActivityEventConfig{
-			UserID:         "test_user",
-			WorkBlockID:    "test_workblock",
-			ProjectPath:    "/test/path",
-			ActivityType:   entities.ActivityTypeCommand,
-			ActivitySource: entities.ActivitySourceHook,
-			Timestamp:      time.Now(),
-			Command:        "test command",
-			Description:    "test activity",
-			Metadata:       map[string]string{"key": "value"},
-		})
-		require.NoError(t, err)
-
-		// Set required associations
-		err = activity.AssociateWithSession("test_session")
-		require.NoError(t, err)
-		err = activity.AssociateWithWorkBlock("test_workblock")
-		require.NoError(t, err)
+		activity := newTestActivity("activity_valid", time.Now())
+		activity.ToolName = "Bash"
+		activity.Command = "test command"
+		activity.Description = "test activity"
+		activity.Metadata = map[string]string{"key": "value"}
 
 		// Save should succeed
-		err = repo.Save(ctx, activity)
+		err := repo.SaveActivity(activity)
 		assert.NoError(t, err, "Save with valid FK should succeed")
 
-		// Verify activity was saved
-		savedActivity, err := repo.FindByID(ctx, activity.ID())
-		assert.NoError(t, err)
-		assert.Equal(t, activity.ID(), savedActivity.ID())
-		assert.Equal(t, "test command", savedActivity.Command())
-		assert.Equal(t, "test activity", savedActivity.Description())
-		assert.Equal(t, "value", savedActivity.Metadata()["key"])
+		// Verify activity was saved with its associations resolved
+		saved, err := repo.GetActivitiesByWorkBlock("test_workblock")
+		require.NoError(t, err)
+		require.Len(t, saved, 1)
+		assert.Equal(t, activity.ID, saved[0].ID)
+		assert.Equal(t, "test_user", saved[0].UserID)
+		assert.Equal(t, "test_session", saved[0].SessionID)
+		assert.Equal(t, "test_project", saved[0].ProjectID)
+		assert.Equal(t, "hook", saved[0].ActivitySource)
+		assert.Equal(t, "Bash", saved[0].ToolName)
+		assert.Equal(t, "test command", saved[0].Command)
+		assert.Equal(t, "test activity", saved[0].Description)
+		assert.Equal(t, "value", saved[0].Metadata["key"])
 	})
 
-	t.Run("invalid activity with missing work block", func(t *testing.T) {
-		// Create activity without work block
-		activity, err := entities.NewActivityEvent(entities.ActivityEventConfig{
-			UserID:         "test_user",
-			ProjectPath:    "/test/path",
-			ActivityType:   entities.ActivityTypeCommand,
-			ActivitySource: entities.ActivitySourceHook,
-			Timestamp:      time.Now(),
-		})
-		require.NoError(t, err)
+	t.Run("invalid activity without work block or user", func(t *testing.T) {
+		activity := newTestActivity("activity_orphan", time.Now())
+		activity.WorkBlockID = ""
 
-		// Save should fail - no work block association
-		err = repo.Save(ctx, activity)
-		assert.Error(t, err, "Save without work block should fail")
-		assert.Contains(t, err.Error(), "must be associated with a work block")
+		// Save should fail - no user can be resolved
+		err := repo.SaveActivity(activity)
+		assert.Error(t, err, "Save without work block or user should fail")
 	})
 
 	t.Run("invalid activity with non-existent work block", func(t *testing.T) {
-		// Create activity with invalid work block ID
-		activity, err := entities.NewActivityEvent(entities.ActivityEventConfig{
-			UserID:         "test_user",
-			WorkBlockID:    "invalid_workblock",
-			ProjectPath:    "/test/path",
-			ActivityType:   entities.ActivityTypeCommand,
-			ActivitySource: entities.ActivitySourceHook,
-			Timestamp:      time.Now(),
-		})
-		require.NoError(t, err)
-
-		err = activity.AssociateWithSession("test_session")
-		require.NoError(t, err)
-		err = activity.AssociateWithWorkBlock("invalid_workblock")
-		require.NoError(t, err)
+		activity := newTestActivity("activity_invalid_block", time.Now())
+		activity.UserID = "test_user"
+		activity.WorkBlockID = "invalid_workblock"
 
 		// Save should fail due to FK constraint
-		err = repo.Save(ctx, activity)
+		err := repo.SaveActivity(activity)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "work block does not exist")
+	})
+
+	t.Run("invalid activity type", func(t *testing.T) {
+		activity := newTestActivity("activity_bad_type", time.Now())
+		activity.ActivityType = "query"
+
+		err := repo.SaveActivity(activity)
+		assert.Error(t, err, "activity_type is limited by a CHECK constraint")
 	})
 }
 
@@ -174,324 +156,93 @@ func TestActivityRepository_Save_FKConstraints(t *testing.T) {
  * INPUT:     Activity entities with various metadata configurations
  * OUTPUT:    Proper JSON handling with validation and error cases
  * BUSINESS:  Activity metadata provides flexible context storage
- * CHANGE:    Initial JSON metadata handling validation
+ * CHANGE:    Ported to SaveActivity and GetActivitiesByWorkBlock
  * RISK:      Low - Test ensuring metadata integrity and handling
  */
 func TestActivityRepository_JSONMetadataHandling(t *testing.T) {
-	db, cleanup := setupActivityTestDB(t)
-	defer cleanup()
-
-	repo := NewActivityRepository(db)
-	ctx := context.Background()
-
-	t.Run("complex metadata serialization", func(t *testing.T) {
-		complexMetadata := map[string]string{
+	tests := []struct {
+		name     string
+		metadata map[string]string
+	}{
+		{"complex metadata serialization", map[string]string{
 			"file_path":     "/path/to/file.go",
 			"line_number":   "42",
 			"function_name": "processActivity",
 			"complexity":    "high",
 			"duration_ms":   "1500",
-		}
-
-		activity, err := entities.NewActivityEvent(entities.ActivityEventConfig{
-			UserID:         "test_user",
-			ProjectPath:    "/test/path",
-			ActivityType:   entities.ActivityTypeFileEdit,
-			ActivitySource: entities.ActivitySourceCLI,
-			Timestamp:      time.Now(),
-			Command:        "edit file",
-			Description:    "editing source file",
-			Metadata:       complexMetadata,
-		})
-		require.NoError(t, err)
-
-		err = activity.AssociateWithSession("test_session")
-		require.NoError(t, err)
-		err = activity.AssociateWithWorkBlock("test_workblock")
-		require.NoError(t, err)
-
-		// Save activity
-		err = repo.Save(ctx, activity)
-		require.NoError(t, err)
-
-		// Retrieve and validate metadata
-		savedActivity, err := repo.FindByID(ctx, activity.ID())
-		require.NoError(t, err)
-
-		savedMetadata := savedActivity.Metadata()
-		assert.Equal(t, len(complexMetadata), len(savedMetadata))
-		assert.Equal(t, "/path/to/file.go", savedMetadata["file_path"])
-		assert.Equal(t, "42", savedMetadata["line_number"])
-		assert.Equal(t, "processActivity", savedMetadata["function_name"])
-		assert.Equal(t, "high", savedMetadata["complexity"])
-		assert.Equal(t, "1500", savedMetadata["duration_ms"])
-	})
-
-	t.Run("empty metadata handling", func(t *testing.T) {
-		activity, err := entities.NewActivityEvent(entities.ActivityEventConfig{
-			UserID:         "test_user",
-			ProjectPath:    "/test/path",
-			ActivityType:   entities.ActivityTypeCommand,
-			ActivitySource: entities.ActivitySourceHook,
-			Timestamp:      time.Now(),
-			Metadata:       map[string]string{}, // Empty metadata
-		})
-		require.NoError(t, err)
-
-		err = activity.AssociateWithSession("test_session")
-		require.NoError(t, err)
-		err = activity.AssociateWithWorkBlock("test_workblock")
-		require.NoError(t, err)
-
-		// Save activity
-		err = repo.Save(ctx, activity)
-		require.NoError(t, err)
-
-		// Retrieve and validate empty metadata
-		savedActivity, err := repo.FindByID(ctx, activity.ID())
-		require.NoError(t, err)
-
-		savedMetadata := savedActivity.Metadata()
-		assert.NotNil(t, savedMetadata)
-		assert.Equal(t, 0, len(savedMetadata))
-	})
-
-	t.Run("null metadata handling", func(t *testing.T) {
-		activity, err := entities.NewActivityEvent(entities.ActivityEventConfig{
-			UserID:         "test_user",
-			ProjectPath:    "/test/path",
-			ActivityType:   entities.ActivityTypeCommand,
-			ActivitySource: entities.ActivitySourceHook,
-			Timestamp:      time.Now(),
-			Metadata:       nil, // Null metadata
-		})
-		require.NoError(t, err)
-
-		err = activity.AssociateWithSession("test_session")
-		require.NoError(t, err)
-		err = activity.AssociateWithWorkBlock("test_workblock")
-		require.NoError(t, err)
-
-		// Save activity
-		err = repo.Save(ctx, activity)
-		require.NoError(t, err)
-
-		// Retrieve and validate metadata handling
-		savedActivity, err := repo.FindByID(ctx, activity.ID())
-		require.NoError(t, err)
-
-		savedMetadata := savedActivity.Metadata()
-		assert.NotNil(t, savedMetadata)
-		assert.Equal(t, 0, len(savedMetadata))
-	})
-}
-
-/**
- * CONTEXT:   Test Claude processing context serialization and handling
- * INPUT:     Activities with Claude processing context data
- * OUTPUT:    Proper Claude context storage and retrieval
- * BUSINESS:  Claude processing context enables accurate time tracking
- * CHANGE:    Initial Claude context handling testing
- * RISK:      Low - Test ensuring Claude integration data integrity
- */
-func TestActivityRepository_ClaudeProcessingContext(t *testing.T) {
-	db, cleanup := setupActivityTestDB(t)
-	defer cleanup()
-
-	repo := NewActivityRepository(db)
-	ctx := context.Background()
-
-	t.Run("claude processing start context", func(t *testing.T) {
-		claudeContext := &entities.ClaudeProcessingContext{
-			PromptID:         "prompt_123",
-			EstimatedTime:    30 * time.Second,
-			TokensCount:      nil, // Not available at start
-			PromptLength:     150,
-			ComplexityHint:   "code_generation",
-			ClaudeActivity:   entities.ClaudeActivityStart,
-		}
-
-		activity, err := entities.NewActivityEvent(entities.ActivityEventConfig{
-			UserID:         "test_user",
-			ProjectPath:    "/test/path",
-			ActivityType:   entities.ActivityTypeGeneration,
-			ActivitySource: entities.ActivitySourceHook,
-			Timestamp:      time.Now(),
-			Command:        "claude generate",
-			Description:    "starting code generation",
-			ClaudeContext:  claudeContext,
-		})
-		require.NoError(t, err)
-
-		err = activity.AssociateWithSession("test_session")
-		require.NoError(t, err)
-		err = activity.AssociateWithWorkBlock("test_workblock")
-		require.NoError(t, err)
-
-		// Save activity
-		err = repo.Save(ctx, activity)
-		require.NoError(t, err)
-
-		// Retrieve and validate Claude context
-		savedActivity, err := repo.FindByID(ctx, activity.ID())
-		require.NoError(t, err)
+		}},
+		{"empty metadata handling", map[string]string{}},
+		{"null metadata handling", nil},
+	}
 
-		savedContext := savedActivity.ClaudeContext()
-		require.NotNil(t, savedContext)
-		assert.Equal(t, "prompt_123", savedContext.PromptID)
-		assert.Equal(t, 30*time.Second, savedContext.EstimatedTime)
-		assert.Equal(t, 150, savedContext.PromptLength)
-		assert.Equal(t, "code_generation", savedContext.ComplexityHint)
-		assert.Equal(t, entities.ClaudeActivityStart, savedContext.ClaudeActivity)
-		assert.Nil(t, savedContext.TokensCount)
-		assert.Nil(t, savedContext.ActualTime)
-	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, cleanup := setupActivityTestDB(t)
+			defer cleanup()
 
-	t.Run("claude processing end context", func(t *testing.T) {
-		actualTime := 45 * time.Second
-		tokensCount := 1200
-
-		claudeContext := &entities.ClaudeProcessingContext{
-			PromptID:         "prompt_123",
-			EstimatedTime:    30 * time.Second,
-			ActualTime:       &actualTime,
-			TokensCount:      &tokensCount,
-			PromptLength:     150,
-			ComplexityHint:   "code_generation",
-			ClaudeActivity:   entities.ClaudeActivityEnd,
-		}
+			repo := NewActivityRepository(db)
+			activity := newTestActivity("activity_metadata", time.Now())
+			activity.ActivityType = "file_edit"
+			activity.ActivitySource = "cli"
+			activity.Metadata = tt.metadata
+			require.NoError(t, repo.SaveActivity(activity))
 
-		activity, err := entities.NewActivityEvent(entities.ActivityEventConfig{
-			UserID:         "test_user",
-			ProjectPath:    "/test/path",
-			ActivityType:   entities.ActivityTypeGeneration,
-			ActivitySource: entities.ActivitySourceHook,
-			Timestamp:      time.Now(),
-			Command:        "claude complete",
-			Description:    "completed code generation",
-			ClaudeContext:  claudeContext,
+			saved, err := repo.GetActivitiesByWorkBlock("test_workblock")
+			require.NoError(t, err)
+			require.Len(t, saved, 1)
+
+			savedMetadata := saved[0].Metadata
+			assert.NotNil(t, savedMetadata)
+			assert.Equal(t, len(tt.metadata), len(savedMetadata))
+			for key, value := range tt.metadata {
+				assert.Equal(t, value, savedMetadata[key])
+			}
+			assert.Equal(t, "cli", saved[0].ActivitySource)
 		})
-		require.NoError(t, err)
-
-		err = activity.AssociateWithSession("test_session")
-		require.NoError(t, err)
-		err = activity.AssociateWithWorkBlock("test_workblock")
-		require.NoError(t, err)
-
-		// Save activity
-		err = repo.Save(ctx, activity)
-		require.NoError(t, err)
-
-		// Retrieve and validate complete Claude context
-		savedActivity, err := repo.FindByID(ctx, activity.ID())
-		require.NoError(t, err)
-
-		savedContext := savedActivity.ClaudeContext()
-		require.NotNil(t, savedContext)
-		assert.Equal(t, "prompt_123", savedContext.PromptID)
-		assert.Equal(t, 30*time.Second, savedContext.EstimatedTime)
-		assert.Equal(t, 45*time.Second, *savedContext.ActualTime)
-		assert.Equal(t, 1200, *savedContext.TokensCount)
-		assert.Equal(t, 150, savedContext.PromptLength)
-		assert.Equal(t, entities.ClaudeActivityEnd, savedContext.ClaudeActivity)
-	})
+	}
 }
 
 /**
- * CONTEXT:   Test work block activity count updates and validation
- * INPUT:     Activities saved to work blocks with count tracking
- * OUTPUT:    Accurate work block activity count updates
- * BUSINESS:  Work block activity counts drive time tracking metrics
- * CHANGE:    Initial activity count update testing
+ * CONTEXT:   Test activity summary aggregation per work block
+ * INPUT:     Activities of several types saved to one work block
+ * OUTPUT:    Accurate per-type counts, time range and activity rate
+ * BUSINESS:  Work block summaries drive time tracking insights
+ * CHANGE:    Replaces the activity count test; counters are kept by the work block repository
  * RISK:      Low - Test ensuring accurate activity counting
  */
-func TestActivityRepository_WorkBlockActivityUpdates(t *testing.T) {
+func TestActivityRepository_ActivitySummary(t *testing.T) {
 	db, cleanup := setupActivityTestDB(t)
 	defer cleanup()
 
 	repo := NewActivityRepository(db)
-	ctx := context.Background()
-
-	t.Run("single activity updates work block count", func(t *testing.T) {
-		// Check initial work block count
-		var initialCount int
-		err := db.QueryRowContext(ctx, 
-			"SELECT activity_count FROM work_blocks WHERE id = 'test_workblock'").Scan(&initialCount)
-		require.NoError(t, err)
-
-		// Create and save activity
-		activity, err := entities.NewActivityEvent(entities.ActivityEventConfig{
-			UserID:         "test_user",
-			ProjectPath:    "/test/path",
-			ActivityType:   entities.ActivityTypeCommand,
-			ActivitySource: entities.ActivitySourceHook,
-			Timestamp:      time.Now(),
-		})
-		require.NoError(t, err)
-
-		err = activity.AssociateWithSession("test_session")
-		require.NoError(t, err)
-		err = activity.AssociateWithWorkBlock("test_workblock")
-		require.NoError(t, err)
-
-		err = repo.Save(ctx, activity)
-		require.NoError(t, err)
-
-		// Check updated work block count
-		var updatedCount int
-		err = db.QueryRowContext(ctx, 
-			"SELECT activity_count FROM work_blocks WHERE id = 'test_workblock'").Scan(&updatedCount)
-		require.NoError(t, err)
-
-		assert.Equal(t, initialCount+1, updatedCount, "Activity count should increment by 1")
-	})
-
-	t.Run("multiple activities update work block count correctly", func(t *testing.T) {
-		// Get current work block count
-		var currentCount int
-		err := db.QueryRowContext(ctx, 
-			"SELECT activity_count FROM work_blocks WHERE id = 'test_workblock'").Scan(&currentCount)
-		require.NoError(t, err)
+	start := time.Now().Add(-20 * time.Minute)
 
-		// Add multiple activities
-		activitiesCount := 3
-		for i := 0; i < activitiesCount; i++ {
-			activity, err := entities.NewActivityEvent(entities.ActivityEventConfig{
-				UserID:         "test_user",
-				ProjectPath:    "/test/path",
-				ActivityType:   entities.ActivityTypeCommand,
-				ActivitySource: entities.ActivitySourceHook,
-				Timestamp:      time.Now().Add(time.Duration(i) * time.Second),
-				Command:        fmt.Sprintf("command_%d", i),
-			})
-			require.NoError(t, err)
-
-			err = activity.AssociateWithSession("test_session")
-			require.NoError(t, err)
-			err = activity.AssociateWithWorkBlock("test_workblock")
-			require.NoError(t, err)
-
-			err = repo.Save(ctx, activity)
-			require.NoError(t, err)
-		}
-
-		// Check final work block count
-		var finalCount int
-		err = db.QueryRowContext(ctx, 
-			"SELECT activity_count FROM work_blocks WHERE id = 'test_workblock'").Scan(&finalCount)
-		require.NoError(t, err)
+	types := []string{"command", "command", "file_edit", "search"}
+	for i, activityType := range types {
+		activity := newTestActivity(fmt.Sprintf("activity_%d", i), start.Add(time.Duration(i)*5*time.Minute))
+		activity.ActivityType = activityType
+		require.NoError(t, repo.SaveActivity(activity))
+	}
 
-		assert.Equal(t, currentCount+activitiesCount, finalCount, 
-			"Activity count should increment by number of activities added")
-	})
+	summary, err := repo.GetActivitySummary("test_workblock")
+	require.NoError(t, err)
+	assert.Equal(t, 4, summary.TotalActivities)
+	assert.Equal(t, map[string]int{"command": 2, "file_edit": 1, "search": 1}, summary.ActivityCounts)
+	assert.WithinDuration(t, start, summary.FirstActivity, time.Second)
+	assert.WithinDuration(t, start.Add(15*time.Minute), summary.LastActivity, time.Second)
+	assert.InDelta(t, 4.0/15.0, summary.ActivityRate, 0.01)
+
+	empty, err := repo.GetActivitySummary("no_such_block")
+	require.NoError(t, err)
+	assert.Equal(t, 0, empty.TotalActivities)
 }
 
 /**
  * CONTEXT:   Test batch activity operations for performance and consistency
  * INPUT:     Multiple activities for batch insertion and validation
- * OUTPUT:    Efficient batch operations with proper FK and count updates
+ * OUTPUT:    Efficient batch operations with all-or-nothing transaction handling
  * BUSINESS:  Batch operations support high-frequency activity logging
- * CHANGE:    Initial batch operation testing with integrity validation
+ * CHANGE:    Ported to SaveActivitiesBatch
  * RISK:      Medium - Batch operations require careful transaction handling
  */
 func TestActivityRepository_BatchOperations(t *testing.T) {
@@ -501,56 +252,22 @@ func TestActivityRepository_BatchOperations(t *testing.T) {
 	repo := NewActivityRepository(db)
 	ctx := context.Background()
 
-	t.Run("successful batch save with count updates", func(t *testing.T) {
-		// Get initial work block count
-		var initialCount int
-		err := db.QueryRowContext(ctx, 
-			"SELECT activity_count FROM work_blocks WHERE id = 'test_workblock'").Scan(&initialCount)
-		require.NoError(t, err)
-
-		// Create batch of activities
+	t.Run("successful batch save", func(t *testing.T) {
 		batchSize := 5
-		activities := make([]*entities.ActivityEvent, batchSize)
-		
+		activities := make([]*Activity, batchSize)
 		for i := 0; i < batchSize; i++ {
-			activity, err := entities.NewActivityEvent(entities.ActivityEventConfig{
-				UserID:         "test_user",
-				ProjectPath:    "/test/path",
-				ActivityType:   entities.ActivityTypeCommand,
-				ActivitySource: entities.ActivitySourceHook,
-				Timestamp:      time.Now().Add(time.Duration(i) * time.Second),
-				Command:        fmt.Sprintf("batch_command_%d", i),
-				Description:    fmt.Sprintf("batch activity %d", i),
-			})
-			require.NoError(t, err)
-
-			err = activity.AssociateWithSession("test_session")
-			require.NoError(t, err)
-			err = activity.AssociateWithWorkBlock("test_workblock")
-			require.NoError(t, err)
-
-			activities[i] = activity
+			activities[i] = newTestActivity(fmt.Sprintf("batch_%d", i), time.Now().Add(time.Duration(i)*time.Second))
+			activities[i].Command = fmt.Sprintf("batch_command_%d", i)
 		}
 
-		// Save batch
-		err = repo.SaveBatch(ctx, activities)
-		require.NoError(t, err)
-
-		// Verify all activities were saved
-		for _, activity := range activities {
-			savedActivity, err := repo.FindByID(ctx, activity.ID())
-			assert.NoError(t, err)
-			assert.Equal(t, activity.ID(), savedActivity.ID())
-		}
+		require.NoError(t, repo.SaveActivitiesBatch(activities))
 
-		// Verify work block count update
-		var finalCount int
-		err = db.QueryRowContext(ctx, 
-			"SELECT activity_count FROM work_blocks WHERE id = 'test_workblock'").Scan(&finalCount)
+		saved, err := repo.GetActivitiesByWorkBlock("test_workblock")
 		require.NoError(t, err)
-
-		assert.Equal(t, initialCount+batchSize, finalCount, 
-			"Work block count should reflect all batch activities")
+		require.Len(t, saved, batchSize)
+		for i, activity := range saved {
+			assert.Equal(t, fmt.Sprintf("batch_command_%d", i), activity.Command, "ordered by timestamp")
+		}
 	})
 
 	t.Run("batch save with mixed work blocks", func(t *testing.T) {
@@ -558,69 +275,38 @@ func TestActivityRepository_BatchOperations(t *testing.T) {
 		workBlockStart := time.Now().Add(-30 * time.Minute)
 		_, err := db.ExecContext(ctx, `
 			INSERT INTO work_blocks (id, session_id, project_id, start_time, state, last_activity_time, activity_count)
-			VALUES ('test_workblock2', 'test_session', 'test_project', ?, 'active', ?, 0)
+			VALUES ('test_workblock2', 'test_session', 'test_project', ?, 'active', ?, 1)
 		`, workBlockStart, workBlockStart)
 		require.NoError(t, err)
 
-		// Create activities for different work blocks
-		activities := make([]*entities.ActivityEvent, 4)
-		
-		// First two activities for test_workblock
-		for i := 0; i < 2; i++ {
-			activity, err := entities.NewActivityEvent(entities.ActivityEventConfig{
-				UserID:         "test_user",
-				ProjectPath:    "/test/path",
-				ActivityType:   entities.ActivityTypeCommand,
-				ActivitySource: entities.ActivitySourceHook,
-				Timestamp:      time.Now().Add(time.Duration(i) * time.Second),
-				Command:        fmt.Sprintf("wb1_command_%d", i),
-			})
-			require.NoError(t, err)
-
-			err = activity.AssociateWithSession("test_session")
-			require.NoError(t, err)
-			err = activity.AssociateWithWorkBlock("test_workblock")
-			require.NoError(t, err)
-
-			activities[i] = activity
+		activities := make([]*Activity, 4)
+		for i := range activities {
+			activities[i] = newTestActivity(fmt.Sprintf("mixed_%d", i), time.Now().Add(time.Duration(i)*time.Second))
+			if i >= 2 {
+				activities[i].WorkBlockID = "test_workblock2"
+			}
 		}
 
-		// Last two activities for test_workblock2
-		for i := 2; i < 4; i++ {
-			activity, err := entities.NewActivityEvent(entities.ActivityEventConfig{
-				UserID:         "test_user",
-				ProjectPath:    "/test/path",
-				ActivityType:   entities.ActivityTypeCommand,
-				ActivitySource: entities.ActivitySourceHook,
-				Timestamp:      time.Now().Add(time.Duration(i) * time.Second),
-				Command:        fmt.Sprintf("wb2_command_%d", i),
-			})
-			require.NoError(t, err)
-
-			err = activity.AssociateWithSession("test_session")
-			require.NoError(t, err)
-			err = activity.AssociateWithWorkBlock("test_workblock2")
-			require.NoError(t, err)
-
-			activities[i] = activity
-		}
+		require.NoError(t, repo.SaveActivitiesBatch(activities))
 
-		// Save batch
-		err = repo.SaveBatch(ctx, activities)
+		second, err := repo.GetActivitiesByWorkBlock("test_workblock2")
 		require.NoError(t, err)
+		assert.Len(t, second, 2)
+	})
 
-		// Verify work block counts updated correctly
-		var count1, count2 int
-		err = db.QueryRowContext(ctx, 
-			"SELECT activity_count FROM work_blocks WHERE id = 'test_workblock'").Scan(&count1)
-		require.NoError(t, err)
+	t.Run("failed batch saves nothing", func(t *testing.T) {
+		activities := []*Activity{
+			newTestActivity("rollback_0", time.Now()),
+			nil,
+		}
+		err := repo.SaveActivitiesBatch(activities)
+		require.Error(t, err)
 
-		err = db.QueryRowContext(ctx, 
-			"SELECT activity_count FROM work_blocks WHERE id = 'test_workblock2'").Scan(&count2)
+		all, err := repo.GetAll(ctx)
 		require.NoError(t, err)
-
-		// Each work block should have gained 2 activities
-		assert.True(t, count2 >= 2, "test_workblock2 should have at least 2 activities")
+		for _, activity := range all {
+			assert.NotEqual(t, "rollback_0", activity.ID, "batch is one transaction")
+		}
 	})
 }
 
@@ -629,7 +315,7 @@ func TestActivityRepository_BatchOperations(t *testing.T) {
  * INPUT:     Saved activities with various attributes for querying
  * OUTPUT:    Accurate query results with proper filtering and ordering
  * BUSINESS:  Activity queries enable reporting and analytics functionality
- * CHANGE:    Initial activity query testing with multiple filter types
+ * CHANGE:    Ported to the work block, user range and recent activity queries
  * RISK:      Low - Test ensuring query accuracy and performance
  */
 func TestActivityRepository_QueryOperations(t *testing.T) {
@@ -638,98 +324,53 @@ func TestActivityRepository_QueryOperations(t *testing.T) {
 
 	repo := NewActivityRepository(db)
 	ctx := context.Background()
-
-	// Setup test activities
-	setupTestActivities := func() []*entities.ActivityEvent {
-		activities := []*entities.ActivityEvent{}
-		
-		activityConfigs := []entities.ActivityEventConfig{
-			{
-				UserID:         "test_user",
-				ProjectPath:    "/test/path",
-				ActivityType:   entities.ActivityTypeCommand,
-				ActivitySource: entities.ActivitySourceHook,
-				Timestamp:      time.Now().Add(-10 * time.Minute),
-				Command:        "git commit",
-				Description:    "committing changes",
-			},
-			{
-				UserID:         "test_user",
-				ProjectPath:    "/test/path",
-				ActivityType:   entities.ActivityTypeFileEdit,
-				ActivitySource: entities.ActivitySourceCLI,
-				Timestamp:      time.Now().Add(-5 * time.Minute),
-				Command:        "edit main.go",
-				Description:    "editing main file",
-			},
-			{
-				UserID:         "test_user",
-				ProjectPath:    "/test/path",
-				ActivityType:   entities.ActivityTypeGeneration,
-				ActivitySource: entities.ActivitySourceHook,
-				Timestamp:      time.Now(),
-				Command:        "claude generate",
-				Description:    "generating code",
-			},
-		}
-
-		for _, config := range activityConfigs {
-			activity, err := entities.NewActivityEvent(config)
-			require.NoError(t, err)
-
-			err = activity.AssociateWithSession("test_session")
-			require.NoError(t, err)
-			err = activity.AssociateWithWorkBlock("test_workblock")
-			require.NoError(t, err)
-
-			err = repo.Save(ctx, activity)
-			require.NoError(t, err)
-
-			activities = append(activities, activity)
-		}
-
-		return activities
+	now := time.Now()
+
+	configs := []struct {
+		id           string
+		activityType string
+		offset       time.Duration
+		command      string
+	}{
+		{"query_commit", "command", -10 * time.Minute, "git commit"},
+		{"query_edit", "file_edit", -5 * time.Minute, "edit main.go"},
+		{"query_generate", "generation", 0, "claude generate"},
+	}
+	for _, config := range configs {
+		activity := newTestActivity(config.id, now.Add(config.offset))
+		activity.ActivityType = config.activityType
+		activity.Command = config.command
+		require.NoError(t, repo.SaveActivity(activity))
 	}
-
-	testActivities := setupTestActivities()
 
 	t.Run("find by work block ID", func(t *testing.T) {
-		activities, err := repo.FindByWorkBlockID(ctx, "test_workblock")
+		activities, err := repo.GetActivitiesByWorkBlock("test_workblock")
 		require.NoError(t, err)
-		
-		// Should find all test activities plus any from previous tests
-		assert.GreaterOrEqual(t, len(activities), len(testActivities))
-		
-		// Verify all activities belong to the correct work block
-		for _, activity := range activities {
-			assert.Equal(t, "test_workblock", activity.WorkBlockID())
+		require.Len(t, activities, len(configs))
+		for i, activity := range activities {
+			assert.Equal(t, configs[i].id, activity.ID, "ordered oldest first")
+			assert.Equal(t, "test_workblock", activity.WorkBlockID)
 		}
 	})
 
-	t.Run("find by session ID", func(t *testing.T) {
-		activities, err := repo.FindBySessionID(ctx, "test_session")
+	t.Run("find by user and time range", func(t *testing.T) {
+		activities, err := repo.GetUserActivitiesByTimeRange(ctx, "test_user", now.Add(-6*time.Minute), now.Add(time.Minute))
 		require.NoError(t, err)
-		
-		// Should find all test activities plus any from previous tests
-		assert.GreaterOrEqual(t, len(activities), len(testActivities))
-		
-		// Verify all activities belong to the correct session
-		for _, activity := range activities {
-			assert.Equal(t, "test_session", activity.SessionID())
-		}
+		require.Len(t, activities, 2)
+		assert.Equal(t, "query_edit", activities[0].ID)
+		assert.Equal(t, "query_generate", activities[1].ID)
+
+		others, err := repo.GetUserActivitiesByTimeRange(ctx, "other_user", now.Add(-time.Hour), now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Empty(t, others)
 	})
 
-	t.Run("find by user ID", func(t *testing.T) {
-		activities, err := repo.FindByUserID(ctx, "test_user")
+	t.Run("recent by user", func(t *testing.T) {
+		activities, err := repo.GetRecentByUser(ctx, "test_user", 2)
 		require.NoError(t, err)
-		
-		// Should find all test activities plus any from previous tests
-		assert.GreaterOrEqual(t, len(activities), len(testActivities))
-		
-		// Verify all activities belong to the correct user
-		for _, activity := range activities {
-			assert.Equal(t, "test_user", activity.UserID())
-		}
+		require.Len(t, activities, 2)
+		assert.Equal(t, "query_generate", activities[0].ID, "newest first")
+		assert.Equal(t, "query_edit", activities[1].ID)
 	})
 }
 
@@ -738,7 +379,7 @@ func TestActivityRepository_QueryOperations(t *testing.T) {
  * INPUT:     Invalid data and error scenarios for robust error handling
  * OUTPUT:    Proper error handling and graceful failure modes
  * BUSINESS:  Error handling ensures system stability under adverse conditions
- * CHANGE:    Initial error handling validation for activity operations
+ * CHANGE:    Ported to the activity_events repository API
  * RISK:      Low - Test ensuring robust error handling and system stability
  */
 func TestActivityRepository_ErrorHandling(t *testing.T) {
@@ -746,38 +387,38 @@ func TestActivityRepository_ErrorHandling(t *testing.T) {
 	defer cleanup()
 
 	repo := NewActivityRepository(db)
-	ctx := context.Background()
 
 	t.Run("save nil activity", func(t *testing.T) {
-		err := repo.Save(ctx, nil)
+		err := repo.SaveActivity(nil)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "cannot be nil")
 	})
 
-	t.Run("find non-existent activity", func(t *testing.T) {
-		_, err := repo.FindByID(ctx, "non_existent_id")
-		require.Error(t, err)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "not found")
+	t.Run("find activities of non-existent work block", func(t *testing.T) {
+		activities, err := repo.GetActivitiesByWorkBlock("non_existent_id")
+		require.NoError(t, err)
+		assert.Empty(t, activities)
 	})
 
-	t.Run("delete non-existent activity", func(t *testing.T) {
-		err := repo.Delete(ctx, "non_existent_id")
-		require.Error(t, err)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "not found")
+	t.Run("delete activities of a work block", func(t *testing.T) {
+		require.NoError(t, repo.SaveActivity(newTestActivity("to_delete", time.Now())))
+		require.NoError(t, repo.DeleteActivitiesByWorkBlock("test_workblock"))
+
+		activities, err := repo.GetActivitiesByWorkBlock("test_workblock")
+		require.NoError(t, err)
+		assert.Empty(t, activities)
+
+		assert.NoError(t, repo.DeleteActivitiesByWorkBlock("non_existent_id"), "nothing to delete is not an error")
 	})
 
 	t.Run("batch save with nil activity", func(t *testing.T) {
-		activities := []*entities.ActivityEvent{nil}
-		err := repo.SaveBatch(ctx, activities)
+		err := repo.SaveActivitiesBatch([]*Activity{nil})
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "is nil")
+		assert.Contains(t, err.Error(), "cannot be nil")
 	})
 
 	t.Run("batch save empty slice", func(t *testing.T) {
-		err := repo.SaveBatch(ctx, []*entities.ActivityEvent{})
+		err := repo.SaveActivitiesBatch([]*Activity{})
 		assert.NoError(t, err, "Empty batch should succeed without error")
 	})
 }
-
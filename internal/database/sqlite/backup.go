@@ -0,0 +1,583 @@
+/**
+ * CONTEXT:   Point-in-time database backups with rotation and verified restore
+ * INPUT:     Backup directory, keep-last/daily/weekly policy and backup files
+ * OUTPUT:    Timestamped VACUUM INTO copies, a checksum manifest and restored databases
+ * BUSINESS:  Work history survives disk mistakes, bad upgrades and accidental purges
+ * CHANGE:    Restores resolve only manifest-listed backups; backup creation is serialized
+ * RISK:      High - Restore replaces the live database; every restore is verified
+ *            first and preceded by a pre-restore backup
+ */
+
+package sqlite
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+)
+
+// Backup file naming inside the backup directory
+const (
+	backupManifestName = "manifest.json"
+	backupFilePrefix   = "monitor-"
+	backupFileSuffix   = ".db"
+	backupTimeLayout   = "20060102T150405Z"
+)
+
+// Reasons recorded for each backup in the manifest
+const (
+	BackupReasonManual     = "manual"
+	BackupReasonScheduled  = "scheduled"
+	BackupReasonPreRestore = "pre-restore"
+)
+
+/**
+ * CONTEXT:   Rotation policy for timestamped backups
+ * INPUT:     No input - data structure definition
+ * OUTPUT:    How many recent, daily and weekly backups to keep
+ * BUSINESS:  A backup is kept if any rule selects it, so recent history is dense
+ *            and older history thins out to one backup per day, then per week
+ * CHANGE:    Initial rotation policy
+ * RISK:      Low - Data structure only
+ */
+type BackupPolicy struct {
+	KeepLast   int `json:"keep_last"`
+	KeepDaily  int `json:"keep_daily"`
+	KeepWeekly int `json:"keep_weekly"`
+}
+
+// Validate rejects policies that would delete every backup
+func (p BackupPolicy) Validate() error {
+	if p.KeepLast < 0 || p.KeepDaily < 0 || p.KeepWeekly < 0 {
+		return fmt.Errorf("backup keep counts cannot be negative")
+	}
+	if p.KeepLast+p.KeepDaily+p.KeepWeekly == 0 {
+		return fmt.Errorf("backup policy must keep at least one backup")
+	}
+	return nil
+}
+
+// BackupInfo describes one backup file in the manifest
+type BackupInfo struct {
+	File          string    `json:"file"`
+	Path          string    `json:"path,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	SizeBytes     int64     `json:"size_bytes"`
+	SHA256        string    `json:"sha256"`
+	SchemaVersion int       `json:"schema_version"`
+	Reason        string    `json:"reason"`
+}
+
+// backupManifest is the checksum manifest stored next to the backups
+type backupManifest struct {
+	Backups []BackupInfo `json:"backups"`
+}
+
+// BackupCheck is the result of verifying a backup before restore
+type BackupCheck struct {
+	Path             string `json:"path"`
+	SchemaVersion    int    `json:"schema_version"`
+	SHA256           string `json:"sha256"`
+	ChecksumVerified bool   `json:"checksum_verified"`
+}
+
+/**
+ * CONTEXT:   Backup manager for one database and backup directory
+ * INPUT:     Open database, backup directory and rotation policy
+ * OUTPUT:    Backup creation, listing and rotation
+ * BUSINESS:  The daemon and the CLI share the same directory and manifest
+ * CHANGE:    Added a mutex so concurrent backups cannot drop manifest entries
+ * RISK:      Medium - Rotation deletes backup files outside the policy
+ */
+type BackupManager struct {
+	db     *SQLiteDB
+	dir    string
+	policy BackupPolicy
+
+	// mu serializes Create: file naming and the manifest read-modify-write
+	mu sync.Mutex
+}
+
+// NewBackupManager creates a backup manager writing to dir
+func NewBackupManager(db *SQLiteDB, dir string, policy BackupPolicy) *BackupManager {
+	return &BackupManager{db: db, dir: dir, policy: policy}
+}
+
+// Dir returns the backup directory
+func (bm *BackupManager) Dir() string {
+	return bm.dir
+}
+
+/**
+ * CONTEXT:   Create a timestamped backup and rotate old ones
+ * INPUT:     Context and the reason recorded in the manifest
+ * OUTPUT:    The new backup and the backups removed by rotation
+ * BUSINESS:  The copy is written under a temporary name and renamed, so the
+ *            manifest never lists a partial file; scheduled, manual and
+ *            pre-restore backups in one process run one at a time
+ * CHANGE:    Serialized with the manager mutex
+ * RISK:      Medium - Rotation deletes files; the new backup is always kept
+ */
+func (bm *BackupManager) Create(ctx context.Context, reason string) (*BackupInfo, []BackupInfo, error) {
+	if err := bm.policy.Validate(); err != nil {
+		return nil, nil, err
+	}
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	if err := os.MkdirAll(bm.dir, 0755); err != nil {
+		return nil, nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	createdAt := time.Now().UTC()
+	name := bm.nextFileName(createdAt)
+	path := filepath.Join(bm.dir, name)
+	tmpPath := path + ".tmp"
+	os.Remove(tmpPath)
+
+	if err := bm.db.Backup(tmpPath); err != nil {
+		os.Remove(tmpPath)
+		return nil, nil, err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return nil, nil, fmt.Errorf("failed to finalize backup: %w", err)
+	}
+
+	size, sum, err := fileChecksum(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	version, err := schemaVersion(ctx, bm.db.db)
+	if err != nil {
+		return nil, nil, err
+	}
+	info := BackupInfo{
+		File:          name,
+		CreatedAt:     createdAt,
+		SizeBytes:     size,
+		SHA256:        sum,
+		SchemaVersion: version,
+		Reason:        reason,
+	}
+
+	manifest, err := readBackupManifest(bm.dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	manifest.Backups = append(manifest.Backups, info)
+	removed := bm.rotate(manifest)
+	if err := writeBackupManifest(bm.dir, manifest); err != nil {
+		return nil, nil, err
+	}
+
+	info.Path = path
+	return &info, removed, nil
+}
+
+/**
+ * CONTEXT:   List backups recorded in the manifest
+ * INPUT:     No parameters
+ * OUTPUT:    Backups newest first with absolute paths
+ * BUSINESS:  Backups whose files were deleted by hand are left out
+ * CHANGE:    Initial backup listing
+ * RISK:      Low - Read-only
+ */
+func (bm *BackupManager) List() ([]BackupInfo, error) {
+	manifest, err := readBackupManifest(bm.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []BackupInfo
+	for _, info := range manifest.Backups {
+		info.Path = filepath.Join(bm.dir, info.File)
+		if _, err := os.Stat(info.Path); err != nil {
+			continue
+		}
+		backups = append(backups, info)
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+	return backups, nil
+}
+
+/**
+ * CONTEXT:   Resolve a backup reference to a restorable file
+ * INPUT:     Backup file name from the manifest, or a path inside the backup directory
+ * OUTPUT:    Path of the backup file, or an error for anything else
+ * BUSINESS:  Restore replaces all data, so only backups this manager wrote are
+ *            accepted: the file must sit directly in the backup directory, be a
+ *            regular file, be listed in the manifest and match its checksum
+ * CHANGE:    Reject paths outside the backup directory and unlisted or altered files
+ * RISK:      High - This is the only gate between an API caller and RestoreFrom
+ */
+func (bm *BackupManager) Resolve(ref string) (string, error) {
+	dir, err := filepath.Abs(bm.dir)
+	if err != nil {
+		return "", fmt.Errorf("invalid backup directory: %w", err)
+	}
+	name := ref
+	if filepath.Base(ref) != ref {
+		abs, err := filepath.Abs(ref)
+		if err != nil {
+			return "", fmt.Errorf("invalid backup path: %w", err)
+		}
+		if filepath.Dir(abs) != dir {
+			return "", fmt.Errorf("backup %s is not in the backup directory %s", ref, bm.dir)
+		}
+		name = filepath.Base(abs)
+	}
+
+	manifest, err := readBackupManifest(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, info := range manifest.Backups {
+		if info.File != name {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		if fi, err := os.Lstat(path); err != nil || !fi.Mode().IsRegular() {
+			return "", fmt.Errorf("backup %s is missing or not a regular file", name)
+		}
+		_, sum, err := fileChecksum(path)
+		if err != nil {
+			return "", err
+		}
+		if sum != info.SHA256 {
+			return "", fmt.Errorf("backup %s does not match its manifest checksum", name)
+		}
+		return path, nil
+	}
+	return "", fmt.Errorf("backup %s is not listed in the manifest of %s", ref, bm.dir)
+}
+
+// nextFileName returns an unused backup file name for createdAt
+func (bm *BackupManager) nextFileName(createdAt time.Time) string {
+	base := backupFilePrefix + createdAt.Format(backupTimeLayout)
+	name := base + backupFileSuffix
+	for i := 2; ; i++ {
+		if _, err := os.Stat(filepath.Join(bm.dir, name)); os.IsNotExist(err) {
+			return name
+		}
+		name = fmt.Sprintf("%s-%d%s", base, i, backupFileSuffix)
+	}
+}
+
+/**
+ * CONTEXT:   Apply the rotation policy to the manifest
+ * INPUT:     Manifest including the backup just created
+ * OUTPUT:    Manifest trimmed to kept backups; removed backups returned
+ * BUSINESS:  Keep the newest KeepLast backups, the newest backup of each of the
+ *            last KeepDaily days and of each of the last KeepWeekly ISO weeks
+ * CHANGE:    Initial rotation
+ * RISK:      Medium - Deletes backup files; a failed delete keeps the manifest entry
+ */
+func (bm *BackupManager) rotate(manifest *backupManifest) []BackupInfo {
+	backups := manifest.Backups
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+
+	loc := bm.db.Timezone()
+	keep := make(map[string]bool)
+	days := make(map[string]bool)
+	weeks := make(map[string]bool)
+	for i, info := range backups {
+		local := info.CreatedAt.In(loc)
+		if i < bm.policy.KeepLast {
+			keep[info.File] = true
+		}
+		day := local.Format("2006-01-02")
+		if !days[day] && len(days) < bm.policy.KeepDaily {
+			days[day] = true
+			keep[info.File] = true
+		}
+		year, week := local.ISOWeek()
+		weekKey := fmt.Sprintf("%d-W%02d", year, week)
+		if !weeks[weekKey] && len(weeks) < bm.policy.KeepWeekly {
+			weeks[weekKey] = true
+			keep[info.File] = true
+		}
+	}
+
+	var kept, removed []BackupInfo
+	for _, info := range backups {
+		if keep[info.File] {
+			kept = append(kept, info)
+			continue
+		}
+		if err := os.Remove(filepath.Join(bm.dir, info.File)); err != nil && !os.IsNotExist(err) {
+			kept = append(kept, info)
+			continue
+		}
+		removed = append(removed, info)
+	}
+	manifest.Backups = kept
+	return removed
+}
+
+/**
+ * CONTEXT:   Verify a backup file before it replaces the live database
+ * INPUT:     Context and backup file path
+ * OUTPUT:    Schema version and checksum of a restorable backup, or an error
+ * BUSINESS:  Corrupt backups, foreign files and backups from newer releases are
+ *            rejected; older schema versions are upgraded after restore
+ * CHANGE:    Initial backup verification
+ * RISK:      Low - Opens the backup read-only
+ */
+func VerifyBackup(ctx context.Context, path string) (*BackupCheck, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup path: %w", err)
+	}
+	if _, err := os.Stat(abs); err != nil {
+		return nil, fmt.Errorf("backup not found: %w", err)
+	}
+
+	_, sum, err := fileChecksum(abs)
+	if err != nil {
+		return nil, err
+	}
+	check := &BackupCheck{Path: abs, SHA256: sum}
+	if manifest, err := readBackupManifest(filepath.Dir(abs)); err == nil {
+		for _, info := range manifest.Backups {
+			if info.File != filepath.Base(abs) {
+				continue
+			}
+			if info.SHA256 != sum {
+				return nil, fmt.Errorf("backup %s does not match its manifest checksum", info.File)
+			}
+			check.ChecksumVerified = true
+		}
+	}
+
+	src, err := sql.Open("sqlite3", "file:"+abs+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer src.Close()
+
+	var integrity string
+	if err := src.QueryRowContext(ctx, `PRAGMA integrity_check(1)`).Scan(&integrity); err != nil {
+		return nil, fmt.Errorf("failed to check backup integrity: %w", err)
+	}
+	if integrity != "ok" {
+		return nil, fmt.Errorf("backup failed integrity check: %s", integrity)
+	}
+
+	if check.SchemaVersion, err = schemaVersion(ctx, src); err != nil {
+		return nil, fmt.Errorf("not a Claude Monitor database: %w", err)
+	}
+	if check.SchemaVersion > LatestSchemaVersion() {
+		return nil, fmt.Errorf("backup schema version %d is newer than this release supports (%d)",
+			check.SchemaVersion, LatestSchemaVersion())
+	}
+	return check, nil
+}
+
+/**
+ * CONTEXT:   Restore a backup into the open database while it is in use
+ * INPUT:     Context and backup file path
+ * OUTPUT:    Live database replaced page by page, then upgraded to the current schema
+ * BUSINESS:  The daemon restores without a restart; repositories keep their handles
+ * CHANGE:    Initial online restore using the SQLite backup API
+ * RISK:      High - Overwrites all data; callers take a pre-restore backup first
+ */
+func (db *SQLiteDB) RestoreFrom(ctx context.Context, backupPath string) (*BackupCheck, error) {
+	check, err := VerifyBackup(ctx, backupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.copyFrom(ctx, check.Path); err != nil {
+		return nil, err
+	}
+
+	// Older backups pick up schema upgrades exactly like an old database on startup
+	if err := db.Initialize(); err != nil {
+		return nil, fmt.Errorf("failed to upgrade restored database: %w", err)
+	}
+	return check, nil
+}
+
+// copyFrom copies every page of the file at path over the main database
+func (db *SQLiteDB) copyFrom(ctx context.Context, path string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	src, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer src.Close()
+
+	srcConn, err := src.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer srcConn.Close()
+	destConn, err := db.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	defer destConn.Close()
+
+	return destConn.Raw(func(destDriver interface{}) error {
+		return srcConn.Raw(func(srcDriver interface{}) error {
+			dest, ok := destDriver.(*sqlite3.SQLiteConn)
+			source, ok2 := srcDriver.(*sqlite3.SQLiteConn)
+			if !ok || !ok2 {
+				return errors.New("restore requires the sqlite3 driver")
+			}
+
+			backup, err := dest.Backup("main", source, "main")
+			if err != nil {
+				return fmt.Errorf("failed to start restore: %w", err)
+			}
+			if _, err := backup.Step(-1); err != nil {
+				backup.Finish()
+				return fmt.Errorf("failed to restore database: %w", err)
+			}
+			if err := backup.Finish(); err != nil {
+				return fmt.Errorf("failed to finish restore: %w", err)
+			}
+			return nil
+		})
+	})
+}
+
+/**
+ * CONTEXT:   Restore a backup by replacing the database file
+ * INPUT:     Context, backup file path and the path of a closed database
+ * OUTPUT:    Database file atomically replaced by a copy of the backup
+ * BUSINESS:  Used when the daemon is stopped; WAL and shared-memory files of the
+ *            old database are removed so they cannot be replayed over the backup
+ * CHANGE:    Initial offline restore
+ * RISK:      High - Replaces the database file; the database must not be open
+ */
+func RestoreDatabaseFile(ctx context.Context, backupPath, dbPath string) (*BackupCheck, error) {
+	check, err := VerifyBackup(ctx, backupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpPath := dbPath + ".restoring"
+	if err := copyFileSynced(check.Path, tmpPath); err != nil {
+		os.Remove(tmpPath)
+		return nil, err
+	}
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(dbPath + suffix); err != nil && !os.IsNotExist(err) {
+			os.Remove(tmpPath)
+			return nil, fmt.Errorf("failed to remove %s: %w", dbPath+suffix, err)
+		}
+	}
+	if err := os.Rename(tmpPath, dbPath); err != nil {
+		os.Remove(tmpPath)
+		return nil, fmt.Errorf("failed to replace database: %w", err)
+	}
+	syncDir(filepath.Dir(dbPath))
+	return check, nil
+}
+
+// copyFileSynced copies src to dst and flushes dst to disk
+func copyFileSynced(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", dst, err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to copy backup: %w", err)
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to flush %s: %w", dst, err)
+	}
+	return out.Close()
+}
+
+// syncDir flushes a directory entry change such as a rename; errors are ignored
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+}
+
+// fileChecksum returns the size and SHA-256 of a file
+func fileChecksum(path string) (int64, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to checksum %s: %w", path, err)
+	}
+	return size, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// schemaVersion reads the highest applied schema version
+func schemaVersion(ctx context.Context, db *sql.DB) (int, error) {
+	var version int
+	if err := db.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// readBackupManifest loads the manifest in dir; a missing manifest is empty
+func readBackupManifest(dir string) (*backupManifest, error) {
+	manifest := &backupManifest{}
+	data, err := os.ReadFile(filepath.Join(dir, backupManifestName))
+	if os.IsNotExist(err) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup manifest: %w", err)
+	}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse backup manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// writeBackupManifest replaces the manifest in dir atomically
+func writeBackupManifest(dir string, manifest *backupManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode backup manifest: %w", err)
+	}
+	path := filepath.Join(dir, backupManifestName)
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return fmt.Errorf("failed to write backup manifest: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to write backup manifest: %w", err)
+	}
+	return nil
+}
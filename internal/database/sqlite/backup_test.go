@@ -0,0 +1,156 @@
+/**
+ * CONTEXT:   Tests for backups, rotation and restore
+ * INPUT:     Temp database with tracked activity and a backup directory
+ * OUTPUT:    Validation of rotation, verified restore, restore resolution and
+ *            concurrent backup creation
+ * BUSINESS:  A restore must bring back exactly the data that was backed up, and
+ *            only from backups the manager wrote
+ * CHANGE:    Initial backup tests
+ * RISK:      Low - Temp directory per test
+ */
+
+package sqlite_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/tracking"
+)
+
+func TestBackupRotationAndRestore(t *testing.T) {
+	dir := t.TempDir()
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(dir, "monitor.db")))
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	tracker := tracking.NewActivityTracker(db)
+	first, err := tracker.Record(ctx, tracking.ActivityEvent{UserID: "alice", ProjectPath: "/work/api"})
+	require.NoError(t, err)
+
+	backups := sqlite.NewBackupManager(db, filepath.Join(dir, "backups"), sqlite.BackupPolicy{KeepLast: 2, KeepDaily: 1})
+	restorePoint, _, err := backups.Create(ctx, sqlite.BackupReasonManual)
+	require.NoError(t, err)
+	assert.Equal(t, sqlite.LatestSchemaVersion(), restorePoint.SchemaVersion)
+
+	for i := 0; i < 3; i++ {
+		_, _, err := backups.Create(ctx, sqlite.BackupReasonScheduled)
+		require.NoError(t, err)
+	}
+	listed, err := backups.List()
+	require.NoError(t, err)
+	assert.Len(t, listed, 2, "same-day backups rotate down to keep_last")
+	files, err := filepath.Glob(filepath.Join(dir, "backups", "*.db"))
+	require.NoError(t, err)
+	assert.Len(t, files, 2, "rotated backups are deleted")
+
+	restorePoint, _, err = backups.Create(ctx, sqlite.BackupReasonManual)
+	require.NoError(t, err)
+	_, err = tracker.Record(ctx, tracking.ActivityEvent{UserID: "alice", ProjectPath: "/work/web"})
+	require.NoError(t, err)
+
+	check, err := db.RestoreFrom(ctx, restorePoint.Path)
+	require.NoError(t, err)
+	assert.True(t, check.ChecksumVerified)
+	blocks, err := sqlite.NewWorkBlockRepository(db.DB()).GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	assert.Equal(t, first.WorkBlockID, blocks[0].ID)
+
+	// Tampered backups fail the manifest checksum
+	f, err := os.OpenFile(restorePoint.Path, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("x"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	_, err = sqlite.VerifyBackup(ctx, restorePoint.Path)
+	assert.ErrorContains(t, err, "checksum")
+}
+
+func TestBackupResolveOnlyAcceptsManifestBackups(t *testing.T) {
+	dir := t.TempDir()
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(dir, "monitor.db")))
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	backupDir := filepath.Join(dir, "backups")
+	backups := sqlite.NewBackupManager(db, backupDir, sqlite.BackupPolicy{KeepLast: 5})
+	backup, _, err := backups.Create(ctx, sqlite.BackupReasonManual)
+	require.NoError(t, err)
+
+	for _, ref := range []string{backup.File, backup.Path} {
+		path, err := backups.Resolve(ref)
+		require.NoError(t, err, ref)
+		assert.Equal(t, backup.Path, path)
+	}
+
+	// A valid database outside the backup directory is refused
+	outside := filepath.Join(dir, "outside.db")
+	require.NoError(t, db.Backup(outside))
+	_, err = backups.Resolve(outside)
+	assert.ErrorContains(t, err, "not in the backup directory")
+	_, err = backups.Resolve(filepath.Join(backupDir, "..", "outside.db"))
+	assert.Error(t, err)
+
+	// So is one copied into the directory without a manifest entry
+	stray := filepath.Join(backupDir, "stray.db")
+	require.NoError(t, db.Backup(stray))
+	_, err = backups.Resolve("stray.db")
+	assert.ErrorContains(t, err, "not listed in the manifest")
+
+	// And a listed backup replaced by a symlink or altered on disk
+	require.NoError(t, os.Remove(backup.Path))
+	require.NoError(t, os.Symlink(outside, backup.Path))
+	_, err = backups.Resolve(backup.File)
+	assert.ErrorContains(t, err, "not a regular file")
+	require.NoError(t, os.Remove(backup.Path))
+	_, err = sqlite.NewProjectRepository(db.DB()).GetOrCreate(ctx, "/work/api")
+	require.NoError(t, err)
+	require.NoError(t, db.Backup(backup.Path))
+	_, err = backups.Resolve(backup.File)
+	assert.ErrorContains(t, err, "checksum")
+}
+
+func TestConcurrentBackupsKeepEveryManifestEntry(t *testing.T) {
+	dir := t.TempDir()
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(dir, "monitor.db")))
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	backups := sqlite.NewBackupManager(db, filepath.Join(dir, "backups"), sqlite.BackupPolicy{KeepLast: 10})
+
+	const count = 5
+	var wg sync.WaitGroup
+	errs := make(chan error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := backups.Create(ctx, sqlite.BackupReasonScheduled)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	listed, err := backups.List()
+	require.NoError(t, err)
+	require.Len(t, listed, count, "every backup is in the manifest")
+	for _, backup := range listed {
+		_, err := backups.Resolve(backup.File)
+		assert.NoError(t, err, backup.File)
+	}
+}
@@ -3,7 +3,7 @@
  * INPUT:     Database path, connection configuration, and transaction management
  * OUTPUT:    Production-ready SQLite database operations with connection pooling
  * BUSINESS:  Single-source SQLite persistence replacing gob-based system
- * CHANGE:    Complete rewrite from gob to SQLite with proper SQL operations
+ * CHANGE:    Structured slog logging instead of log.Printf
  * RISK:      Low - Standard database/sql package with SQLite, proper error handling
  */
 
@@ -14,7 +14,7 @@ import (
 	"database/sql"
 	"embed"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"sync"
@@ -82,7 +82,7 @@ func NewSQLiteDB(config *ConnectionConfig) (*SQLiteDB, error) {
 	// Load timezone
 	timezone, err := time.LoadLocation(config.Timezone)
 	if err != nil {
-		log.Printf("Warning: failed to load timezone %s, using UTC: %v", config.Timezone, err)
+		slog.Warn("Failed to load timezone, using UTC", "timezone", config.Timezone, "error", err)
 		timezone = time.UTC
 	}
 
@@ -118,8 +118,7 @@ func NewSQLiteDB(config *ConnectionConfig) (*SQLiteDB, error) {
 		return nil, fmt.Errorf("failed to initialize database: %w", err)
 	}
 
-	log.Printf("🗄️  Initialized SQLite database at: %s (timezone: %s)", 
-		config.DBPath, timezone.String())
+	slog.Info("Initialized SQLite database", "path", config.DBPath, "timezone", timezone.String())
 
 	return sqliteDB, nil
 }
@@ -165,6 +164,11 @@ func (db *SQLiteDB) Initialize() error {
 		return fmt.Errorf("failed to commit schema transaction: %w", err)
 	}
 
+	// Apply incremental upgrades on top of the base schema
+	if err := db.applySchemaUpgrades(ctx); err != nil {
+		return err
+	}
+
 	// Verify schema version
 	var version int
 	var description string
@@ -174,7 +178,7 @@ func (db *SQLiteDB) Initialize() error {
 		return fmt.Errorf("failed to verify schema version: %w", err)
 	}
 
-	log.Printf("🗄️  Database schema version %d: %s", version, description)
+	slog.Info("Database schema ready", "version", version, "description", description)
 
 	return nil
 }
@@ -302,7 +306,7 @@ func (db *SQLiteDB) GetStats(ctx context.Context) (map[string]interface{}, error
 		var count int
 		query := fmt.Sprintf("SELECT COUNT(*) FROM %s", table)
 		if err := db.db.QueryRowContext(ctx, query).Scan(&count); err != nil {
-			log.Printf("Warning: failed to count %s table: %v", table, err)
+			slog.WarnContext(ctx, "Failed to count table rows", "table", table, "error", err)
 			stats[table+"_count"] = 0
 		} else {
 			stats[table+"_count"] = count
@@ -314,13 +318,13 @@ func (db *SQLiteDB) GetStats(ctx context.Context) (map[string]interface{}, error
 
 	query := "SELECT COUNT(*) FROM sessions WHERE state = 'active' AND datetime('now') <= end_time"
 	if err := db.db.QueryRowContext(ctx, query).Scan(&activeSessions); err != nil {
-		log.Printf("Warning: failed to count active sessions: %v", err)
+		slog.WarnContext(ctx, "Failed to count active sessions", "error", err)
 	}
 	stats["active_sessions"] = activeSessions
 
 	query = "SELECT COUNT(*) FROM work_blocks WHERE state IN ('active', 'processing')"
 	if err := db.db.QueryRowContext(ctx, query).Scan(&activeWorkBlocks); err != nil {
-		log.Printf("Warning: failed to count active work blocks: %v", err)
+		slog.WarnContext(ctx, "Failed to count active work blocks", "error", err)
 	}
 	stats["active_work_blocks"] = activeWorkBlocks
 
@@ -370,7 +374,7 @@ func (db *SQLiteDB) Backup(backupPath string) error {
 		return fmt.Errorf("failed to create database backup: %w", err)
 	}
 
-	log.Printf("🗄️  Database backed up to: %s", backupPath)
+	slog.Info("Database backed up", "path", backupPath)
 	return nil
 }
 
@@ -394,7 +398,7 @@ func (db *SQLiteDB) Close() error {
 		return fmt.Errorf("failed to close database: %w", err)
 	}
 
-	log.Printf("🗄️  Closed SQLite database connection")
+	slog.Debug("Closed SQLite database connection")
 	db.db = nil
 	return nil
 }
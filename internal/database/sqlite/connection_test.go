@@ -87,11 +87,16 @@ func TestSQLiteDBInitialization(t *testing.T) {
 		
 		var version int
 		var description string
-		query := "SELECT version, description FROM schema_version ORDER BY version DESC LIMIT 1"
+		query := "SELECT version, description FROM schema_version ORDER BY version ASC LIMIT 1"
 		err := db.DB().QueryRowContext(ctx, query).Scan(&version, &description)
 		require.NoError(t, err)
 		assert.Equal(t, 1, version)
 		assert.Contains(t, description, "Initial SQLite schema")
+
+		// Upgrades after the base schema are recorded on top of it
+		err = db.DB().QueryRowContext(ctx, "SELECT MAX(version) FROM schema_version").Scan(&version)
+		require.NoError(t, err)
+		assert.Equal(t, LatestSchemaVersion(), version)
 	})
 
 	t.Run("Foreign key constraints should be enabled", func(t *testing.T) {
@@ -0,0 +1,598 @@
+/**
+ * CONTEXT:   Database consistency checks with transactional repairs
+ * INPUT:     Open database and whether repairs should be applied
+ * OUTPUT:    Structured report of issues per check, with repairs applied on request
+ * BUSINESS:  Counters and time ranges drift through crashes, clock changes and
+ *            older releases; the doctor finds drift in set-based queries and fixes it
+ * CHANGE:    Initial set-based doctor
+ * RISK:      Medium - Repairs rewrite work blocks, sessions and projects; each check
+ *            repairs in one transaction and work block repairs are audited
+ */
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Issue severities in a doctor report
+const (
+	DoctorSeverityError   = "error"
+	DoctorSeverityWarning = "warning"
+)
+
+// doctorAuditReason is recorded on work block repairs in the audit log
+const doctorAuditReason = "db doctor repair"
+
+// DoctorIssue is one problem found by a check
+type DoctorIssue struct {
+	Subject string `json:"subject"`
+	Message string `json:"message"`
+	Fixable bool   `json:"fixable"`
+	Fixed   bool   `json:"fixed"`
+}
+
+// DoctorCheck is the outcome of one check
+type DoctorCheck struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Severity    string        `json:"severity"`
+	Issues      []DoctorIssue `json:"issues"`
+	Fixed       int           `json:"fixed"`
+	Error       string        `json:"error,omitempty"`
+}
+
+// DoctorReport collects all checks of one doctor run
+type DoctorReport struct {
+	Fix    bool          `json:"fix"`
+	Checks []DoctorCheck `json:"checks"`
+}
+
+// Problems counts issues that are still present after the run
+func (r *DoctorReport) Problems() int {
+	count := 0
+	for _, check := range r.Checks {
+		if check.Error != "" {
+			count++
+		}
+		for _, issue := range check.Issues {
+			if !issue.Fixed {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// doctorCheck finds issues and optionally repairs them inside one transaction
+type doctorCheck struct {
+	name        string
+	description string
+	severity    string
+	find        func(ctx context.Context) ([]doctorFinding, error)
+}
+
+// doctorFinding is an issue plus the data its repair needs; apply reports
+// whether the repair changed anything
+type doctorFinding struct {
+	issue DoctorIssue
+	apply func(ctx context.Context, tx *sql.Tx, now time.Time) (bool, error)
+}
+
+/**
+ * CONTEXT:   Database doctor for consistency checks and repairs
+ * INPUT:     Open SQLite database
+ * OUTPUT:    Doctor reports
+ * BUSINESS:  One entry point for `db doctor` and tests
+ * CHANGE:    Initial doctor
+ * RISK:      Low - Holds only the database handle
+ */
+type Doctor struct {
+	db *SQLiteDB
+}
+
+// NewDoctor creates a doctor for db
+func NewDoctor(db *SQLiteDB) *Doctor {
+	return &Doctor{db: db}
+}
+
+/**
+ * CONTEXT:   Run all checks and optionally repair what they find
+ * INPUT:     Context and whether to apply repairs
+ * OUTPUT:    Report with one entry per check
+ * BUSINESS:  Repairs run in dependency order: sessions first, then work block
+ *            ranges, then counters and orphans; nothing is repaired when SQLite
+ *            integrity or foreign keys are broken, because writes could make it worse
+ * CHANGE:    Initial doctor run
+ * RISK:      Medium - Repairs modify data; each check commits or rolls back as a unit
+ */
+func (d *Doctor) Run(ctx context.Context, fix bool) (*DoctorReport, error) {
+	report := &DoctorReport{Fix: fix}
+	structural := []doctorCheck{
+		{
+			name:        "integrity",
+			description: "SQLite integrity check",
+			severity:    DoctorSeverityError,
+			find:        d.findIntegrityProblems,
+		},
+		{
+			name:        "foreign_keys",
+			description: "Rows referencing missing parents",
+			severity:    DoctorSeverityError,
+			find:        d.findForeignKeyViolations,
+		},
+	}
+	repairable := []doctorCheck{
+		{
+			name:        "active_sessions",
+			description: "More than one active session per user",
+			severity:    DoctorSeverityWarning,
+			find:        d.findDuplicateActiveSessions,
+		},
+		{
+			name:        "session_bounds",
+			description: "Work blocks that outlive their session",
+			severity:    DoctorSeverityWarning,
+			find:        d.findBlocksOutsideSession,
+		},
+		{
+			name:        "overlapping_blocks",
+			description: "Overlapping work blocks of the same user",
+			severity:    DoctorSeverityWarning,
+			find:        d.findOverlappingBlocks,
+		},
+		{
+			name:        "counter_drift",
+			description: "Activity counters that disagree with activity events",
+			severity:    DoctorSeverityWarning,
+			find:        d.findCounterDrift,
+		},
+		{
+			name:        "orphaned_projects",
+			description: "Projects nothing refers to",
+			severity:    DoctorSeverityWarning,
+			find:        d.findOrphanedProjects,
+		},
+	}
+
+	healthy := true
+	for _, check := range structural {
+		result := d.runCheck(ctx, check, false)
+		if result.Error != "" || len(result.Issues) > 0 {
+			healthy = false
+		}
+		report.Checks = append(report.Checks, result)
+	}
+	for _, check := range repairable {
+		report.Checks = append(report.Checks, d.runCheck(ctx, check, fix && healthy))
+	}
+	return report, nil
+}
+
+// runCheck finds a check's issues and applies their repairs in one transaction
+func (d *Doctor) runCheck(ctx context.Context, check doctorCheck, fix bool) DoctorCheck {
+	result := DoctorCheck{Name: check.name, Description: check.description, Severity: check.severity, Issues: []DoctorIssue{}}
+	findings, err := check.find(ctx)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	for _, finding := range findings {
+		result.Issues = append(result.Issues, finding.issue)
+	}
+	if !fix || len(findings) == 0 {
+		return result
+	}
+
+	now := d.db.Now()
+	changed := make([]bool, len(findings))
+	err = d.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		for i, finding := range findings {
+			if finding.apply == nil {
+				continue
+			}
+			ok, err := finding.apply(ctx, tx, now)
+			if err != nil {
+				return fmt.Errorf("%s: %w", finding.issue.Subject, err)
+			}
+			changed[i] = ok
+		}
+		return nil
+	})
+	if err != nil {
+		result.Error = fmt.Sprintf("repair rolled back: %v", err)
+		return result
+	}
+	// A repair that matched no row leaves the issue in place for the next run
+	for i := range findings {
+		if changed[i] {
+			result.Issues[i].Fixed = true
+			result.Fixed++
+		}
+	}
+	return result
+}
+
+// findIntegrityProblems runs PRAGMA integrity_check
+func (d *Doctor) findIntegrityProblems(ctx context.Context) ([]doctorFinding, error) {
+	rows, err := d.db.DB().QueryContext(ctx, `PRAGMA integrity_check`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run integrity check: %w", err)
+	}
+	defer rows.Close()
+
+	var findings []doctorFinding
+	for rows.Next() {
+		var message string
+		if err := rows.Scan(&message); err != nil {
+			return nil, fmt.Errorf("failed to read integrity check: %w", err)
+		}
+		if message == "ok" {
+			continue
+		}
+		findings = append(findings, doctorFinding{issue: DoctorIssue{
+			Subject: "database",
+			Message: message + " - restore a backup with 'claude-monitor db restore'",
+		}})
+	}
+	return findings, rows.Err()
+}
+
+// findForeignKeyViolations runs PRAGMA foreign_key_check
+func (d *Doctor) findForeignKeyViolations(ctx context.Context) ([]doctorFinding, error) {
+	rows, err := d.db.DB().QueryContext(ctx, `PRAGMA foreign_key_check`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run foreign key check: %w", err)
+	}
+	defer rows.Close()
+
+	var findings []doctorFinding
+	for rows.Next() {
+		var table, parent string
+		var rowID sql.NullInt64
+		var fkID int
+		if err := rows.Scan(&table, &rowID, &parent, &fkID); err != nil {
+			return nil, fmt.Errorf("failed to read foreign key check: %w", err)
+		}
+		findings = append(findings, doctorFinding{issue: DoctorIssue{
+			Subject: fmt.Sprintf("%s rowid %d", table, rowID.Int64),
+			Message: fmt.Sprintf("references a missing %s row", parent),
+		}})
+	}
+	return findings, rows.Err()
+}
+
+/**
+ * CONTEXT:   Users with more than one open, overlapping active session
+ * INPUT:     Context
+ * OUTPUT:    One finding per extra active session
+ * BUSINESS:  The tracker extends the newest active session; older ones are closed
+ *            as finished. Sessions whose window already passed are expired by the
+ *            tracker on its next event, so only windows still open are compared
+ * CHANGE:    Only flag open windows that overlap a newer active session
+ * RISK:      Low - Only changes session state
+ */
+func (d *Doctor) findDuplicateActiveSessions(ctx context.Context) ([]doctorFinding, error) {
+	rows, err := d.db.DB().QueryContext(ctx, `
+		SELECT s.id, s.user_id, s.start_time, s.end_time
+		FROM sessions s
+		WHERE s.state = 'active' AND julianday(s.end_time) > julianday(?1)
+		  AND EXISTS (
+			SELECT 1 FROM sessions newer
+			WHERE newer.user_id = s.user_id AND newer.state = 'active'
+			  AND julianday(newer.end_time) > julianday(?1)
+			  AND julianday(newer.start_time) < julianday(s.end_time)
+			  AND (julianday(newer.start_time) > julianday(s.start_time)
+			       OR (julianday(newer.start_time) = julianday(s.start_time) AND newer.id > s.id))
+		  )
+		ORDER BY s.user_id, julianday(s.start_time)`, d.db.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find duplicate active sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var findings []doctorFinding
+	for rows.Next() {
+		var id, userID string
+		var start, end time.Time
+		if err := rows.Scan(&id, &userID, &start, &end); err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		findings = append(findings, doctorFinding{
+			issue: DoctorIssue{
+				Subject: id,
+				Message: fmt.Sprintf("user %s has a newer active session; this one started %s", userID, start.Local().Format("2006-01-02 15:04")),
+				Fixable: true,
+			},
+			apply: func(ctx context.Context, tx *sql.Tx, now time.Time) (bool, error) {
+				state := "finished"
+				if now.After(end) {
+					state = "expired"
+				}
+				return execChanged(ctx, tx, `UPDATE sessions SET state = ?, updated_at = ? WHERE id = ? AND state = 'active'`, state, now, id)
+			},
+		})
+	}
+	return findings, rows.Err()
+}
+
+/**
+ * CONTEXT:   Work blocks that end after their session's end time
+ * INPUT:     Context
+ * OUTPUT:    One finding per block, repaired by ending it at the session end
+ * BUSINESS:  Time past the 5-hour session window belongs to another session; the
+ *            tracker splits it there, so the repair trims to the same boundary
+ * CHANGE:    Compare instants with julianday(); idle closes from older releases
+ *            stored offset-less UTC next to local timestamps
+ * RISK:      Medium - Shortens work blocks; the audit log keeps the original range
+ */
+func (d *Doctor) findBlocksOutsideSession(ctx context.Context) ([]doctorFinding, error) {
+	rows, err := d.db.DB().QueryContext(ctx, `
+		SELECT wb.id, s.id, s.end_time
+		FROM work_blocks wb
+		JOIN sessions s ON s.id = wb.session_id
+		WHERE julianday(COALESCE(wb.end_time, wb.last_activity_time)) > julianday(s.end_time)
+		ORDER BY julianday(wb.start_time)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find work blocks outside sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var findings []doctorFinding
+	for rows.Next() {
+		var blockID, sessionID string
+		var sessionEnd time.Time
+		if err := rows.Scan(&blockID, &sessionID, &sessionEnd); err != nil {
+			return nil, fmt.Errorf("failed to scan work block: %w", err)
+		}
+		findings = append(findings, doctorFinding{
+			issue: DoctorIssue{
+				Subject: blockID,
+				Message: fmt.Sprintf("runs past the end of session %s at %s", sessionID, sessionEnd.Local().Format("2006-01-02 15:04")),
+				Fixable: true,
+			},
+			apply: func(ctx context.Context, tx *sql.Tx, now time.Time) (bool, error) {
+				return d.endWorkBlockAt(ctx, tx, blockID, sessionEnd, now)
+			},
+		})
+	}
+	return findings, rows.Err()
+}
+
+/**
+ * CONTEXT:   Overlapping work blocks of the same user
+ * INPUT:     Context
+ * OUTPUT:    One finding per overlapping pair, repaired by ending the earlier
+ *            block where the later one starts
+ * BUSINESS:  Overlaps count the same minutes twice in every report; blocks of two
+ *            different Claude Code instances run in parallel by design and are skipped
+ * CHANGE:    Compare instants with julianday() so idle closes stored as UTC text
+ *            are not mistaken for overlaps
+ * RISK:      Medium - Shortens work blocks; blocks starting at the same instant
+ *            are reported but left for a manual edit
+ */
+func (d *Doctor) findOverlappingBlocks(ctx context.Context) ([]doctorFinding, error) {
+	rows, err := d.db.DB().QueryContext(ctx, `
+		SELECT a.id, b.id, a.start_time, b.start_time
+		FROM work_blocks a
+		JOIN sessions sa ON sa.id = a.session_id
+		JOIN work_blocks b ON julianday(b.start_time) >= julianday(a.start_time) AND b.id != a.id
+		JOIN sessions sb ON sb.id = b.session_id AND sb.user_id = sa.user_id
+		WHERE julianday(b.start_time) < julianday(COALESCE(a.end_time, a.last_activity_time))
+		  AND (julianday(b.start_time) > julianday(a.start_time) OR a.id < b.id)
+		  AND (COALESCE(a.claude_session_id, '') = '' OR COALESCE(b.claude_session_id, '') = ''
+		       OR a.claude_session_id = b.claude_session_id)
+		ORDER BY julianday(a.start_time), julianday(b.start_time)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find overlapping work blocks: %w", err)
+	}
+	defer rows.Close()
+
+	var findings []doctorFinding
+	// trimmed records whether the first repair of an earlier block changed it
+	trimmed := make(map[string]*bool)
+	for rows.Next() {
+		var earlierID, laterID string
+		var earlierStart, laterStart time.Time
+		if err := rows.Scan(&earlierID, &laterID, &earlierStart, &laterStart); err != nil {
+			return nil, fmt.Errorf("failed to scan work blocks: %w", err)
+		}
+
+		finding := doctorFinding{issue: DoctorIssue{
+			Subject: earlierID,
+			Message: fmt.Sprintf("overlaps %s starting %s", laterID, laterStart.Local().Format("2006-01-02 15:04")),
+		}}
+		// Trimming to the first block that starts inside it resolves the later overlaps too
+		switch {
+		case !laterStart.After(earlierStart):
+			finding.issue.Message += " at the same instant - edit one with 'claude-monitor entry edit'"
+		case trimmed[earlierID] != nil:
+			changed := trimmed[earlierID]
+			finding.issue.Fixable = true
+			finding.apply = func(context.Context, *sql.Tx, time.Time) (bool, error) { return *changed, nil }
+		default:
+			changed := new(bool)
+			trimmed[earlierID] = changed
+			finding.issue.Fixable = true
+			finding.apply = func(ctx context.Context, tx *sql.Tx, now time.Time) (bool, error) {
+				var err error
+				*changed, err = d.endWorkBlockAt(ctx, tx, earlierID, laterStart, now)
+				return *changed, err
+			}
+		}
+		findings = append(findings, finding)
+	}
+	return findings, rows.Err()
+}
+
+/**
+ * CONTEXT:   Activity counters that disagree with activity event rows
+ * INPUT:     Context
+ * OUTPUT:    One finding per work block or session whose counter drifted
+ * BUSINESS:  Counters are incremented separately from the event insert; only
+ *            rows newer than the oldest kept event are compared, because the
+ *            retention purge deletes old events but keeps their blocks
+ * CHANGE:    Initial check
+ * RISK:      Low - Rewrites counters only, never below the schema minimum of 1
+ */
+func (d *Doctor) findCounterDrift(ctx context.Context) ([]doctorFinding, error) {
+	var horizon sql.NullString
+	if err := d.db.DB().QueryRowContext(ctx, `SELECT MIN(timestamp) FROM activity_events`).Scan(&horizon); err != nil {
+		return nil, fmt.Errorf("failed to read oldest activity event: %w", err)
+	}
+	if !horizon.Valid {
+		return nil, nil
+	}
+
+	var findings []doctorFinding
+	for _, table := range []struct{ name, column string }{
+		{"work_blocks", "work_block_id"},
+		{"sessions", "session_id"},
+	} {
+		table := table
+		rows, err := d.db.DB().QueryContext(ctx, fmt.Sprintf(`
+			SELECT t.id, t.activity_count, COALESCE(e.events, 0)
+			FROM %[1]s t
+			LEFT JOIN (
+				SELECT %[2]s AS id, COUNT(*) AS events
+				FROM activity_events
+				WHERE %[2]s IS NOT NULL
+				GROUP BY %[2]s
+			) e ON e.id = t.id
+			WHERE t.start_time >= ? AND t.activity_count != MAX(COALESCE(e.events, 0), 1)
+			ORDER BY t.start_time`, table.name, table.column), horizon.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare %s counters: %w", table.name, err)
+		}
+
+		for rows.Next() {
+			var id string
+			var counter, events int64
+			if err := rows.Scan(&id, &counter, &events); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan %s counter: %w", table.name, err)
+			}
+			want := events
+			if want < 1 {
+				want = 1
+			}
+			findings = append(findings, doctorFinding{
+				issue: DoctorIssue{
+					Subject: id,
+					Message: fmt.Sprintf("activity_count is %d but %d activity event(s) exist", counter, events),
+					Fixable: true,
+				},
+				apply: func(ctx context.Context, tx *sql.Tx, now time.Time) (bool, error) {
+					return execChanged(ctx, tx, fmt.Sprintf(`UPDATE %s SET activity_count = ?, updated_at = ? WHERE id = ?`, table.name), want, now, id)
+				},
+			})
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return findings, nil
+}
+
+/**
+ * CONTEXT:   Projects without any data or settings
+ * INPUT:     Context
+ * OUTPUT:    One finding per project, repaired by deleting it
+ * BUSINESS:  Projects created by abandoned detections clutter pickers and reports;
+ *            projects with a parent, children, client, group, tags, goals,
+ *            rollups or an archive date were set up on purpose and are kept
+ * CHANGE:    Initial check
+ * RISK:      Low - Deletes projects that no row refers to
+ */
+func (d *Doctor) findOrphanedProjects(ctx context.Context) ([]doctorFinding, error) {
+	rows, err := d.db.DB().QueryContext(ctx, `
+		SELECT p.id, p.name
+		FROM projects p
+		WHERE NOT EXISTS (SELECT 1 FROM work_blocks wb WHERE wb.project_id = p.id)
+		  AND NOT EXISTS (SELECT 1 FROM activity_events ae WHERE ae.project_id = p.id)
+		  AND NOT EXISTS (SELECT 1 FROM goals g WHERE g.project_id = p.id)
+		  AND NOT EXISTS (SELECT 1 FROM daily_rollups r WHERE r.project_id = p.id)
+		  AND NOT EXISTS (SELECT 1 FROM projects child WHERE child.parent_id = p.id)
+		  AND p.parent_id IS NULL AND p.archived_at IS NULL
+		  AND COALESCE(p.client, '') = '' AND COALESCE(p.group_name, '') = '' AND COALESCE(p.tags, '') = ''
+		ORDER BY p.name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find orphaned projects: %w", err)
+	}
+	defer rows.Close()
+
+	var findings []doctorFinding
+	for rows.Next() {
+		var id, name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+		findings = append(findings, doctorFinding{
+			issue: DoctorIssue{
+				Subject: id,
+				Message: fmt.Sprintf("project %q has no work blocks, activity or settings", name),
+				Fixable: true,
+			},
+			apply: func(ctx context.Context, tx *sql.Tx, now time.Time) (bool, error) {
+				return execChanged(ctx, tx, `DELETE FROM projects WHERE id = ?`, id)
+			},
+		})
+	}
+	return findings, rows.Err()
+}
+
+// endWorkBlockAt finishes a work block at end, clamping its last activity and
+// recording the change in the audit log; it reports false when the block already
+// ended by then
+func (d *Doctor) endWorkBlockAt(ctx context.Context, tx *sql.Tx, blockID string, end, now time.Time) (bool, error) {
+	block, owner, err := loadEntryBlock(ctx, tx, blockID)
+	if err != nil {
+		return false, err
+	}
+	before := snapshotWorkBlock(block)
+
+	end = d.db.ToDBTime(end)
+	if block.EndTime != nil && !block.EndTime.After(end) {
+		return false, nil
+	}
+	lastActivity := block.LastActivityTime
+	if lastActivity.After(end) {
+		lastActivity = end
+	}
+	duration := end.Sub(block.StartTime)
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE work_blocks
+		SET end_time = ?, state = 'finished', last_activity_time = ?,
+		    duration_seconds = ?, duration_hours = ?, edited_at = ?, updated_at = ?
+		WHERE id = ?`,
+		end, lastActivity, int64(duration.Seconds()), duration.Hours(), now, now, blockID)
+	if err != nil {
+		return false, fmt.Errorf("failed to update work block: %w", err)
+	}
+
+	updated, err := scanWorkBlock(tx.QueryRowContext(ctx,
+		`SELECT `+workBlockColumns+` FROM work_blocks wb WHERE wb.id = ?`, blockID))
+	if err != nil {
+		return false, fmt.Errorf("failed to read updated work block: %w", err)
+	}
+	if err := insertAudit(ctx, tx, blockID, owner, AuditActionEdit, before, snapshotWorkBlock(updated), doctorAuditReason, now); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// execChanged runs a repair statement and reports whether it touched a row
+func execChanged(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) (bool, error) {
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
@@ -0,0 +1,138 @@
+/**
+ * CONTEXT:   Tests for database consistency checks and repairs
+ * INPUT:     Temp database with counter drift, overlapping blocks and an orphaned project
+ * OUTPUT:    Validation that each check finds its issue and repairs leave none behind
+ * BUSINESS:  Repairs rewrite tracked time, so they must fix exactly what was reported
+ * CHANGE:    Initial doctor tests
+ * RISK:      Low - Temp database per test
+ */
+
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/tracking"
+)
+
+func TestDoctorFindsAndRepairsDrift(t *testing.T) {
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(t.TempDir(), "doctor.db")))
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	activity, err := tracking.NewActivityTracker(db).Record(ctx, tracking.ActivityEvent{UserID: "alice", ProjectPath: "/work/api"})
+	require.NoError(t, err)
+
+	now := time.Now()
+	entries := sqlite.NewEntryRepository(db)
+	earlier, err := entries.Add(ctx, sqlite.ManualEntry{
+		UserID: "alice", ProjectID: activity.ProjectID,
+		StartTime: now.Add(-4 * time.Hour), EndTime: now.Add(-2 * time.Hour), Reason: "first",
+	})
+	require.NoError(t, err)
+
+	report, err := sqlite.NewDoctor(db).Run(ctx, false)
+	require.NoError(t, err)
+	assert.Zero(t, report.Problems(), "a fresh database is healthy")
+
+	// Simulate drift that the tracker and entry commands never produce
+	later := db.ToDBTime(now.Add(-3 * time.Hour))
+	_, err = db.DB().ExecContext(ctx, `UPDATE work_blocks SET activity_count = 7 WHERE id = ?`, activity.WorkBlockID)
+	require.NoError(t, err)
+	_, err = db.DB().ExecContext(ctx, `
+		INSERT INTO work_blocks (id, session_id, project_id, start_time, end_time, state, last_activity_time,
+			activity_count, duration_seconds, duration_hours, created_at, updated_at)
+		SELECT 'block_overlap', session_id, project_id, ?, end_time, 'finished', end_time, 1, 3600, 1.0, created_at, updated_at
+		FROM work_blocks WHERE id = ?`, later, earlier.ID)
+	require.NoError(t, err)
+	_, err = db.DB().ExecContext(ctx, `INSERT INTO projects (id, name, path, created_at, updated_at) VALUES ('proj_orphan', 'Orphan', '/nowhere', ?, ?)`,
+		db.Now(), db.Now())
+	require.NoError(t, err)
+
+	report, err = sqlite.NewDoctor(db).Run(ctx, false)
+	require.NoError(t, err)
+	issues := make(map[string]int)
+	for _, check := range report.Checks {
+		assert.Empty(t, check.Error, check.Name)
+		issues[check.Name] = len(check.Issues)
+	}
+	assert.Equal(t, 1, issues["counter_drift"])
+	assert.Equal(t, 1, issues["overlapping_blocks"])
+	assert.Equal(t, 1, issues["orphaned_projects"])
+
+	report, err = sqlite.NewDoctor(db).Run(ctx, true)
+	require.NoError(t, err)
+	assert.Zero(t, report.Problems())
+
+	report, err = sqlite.NewDoctor(db).Run(ctx, false)
+	require.NoError(t, err)
+	assert.Zero(t, report.Problems(), "repairs leave nothing behind")
+
+	trimmed, err := sqlite.NewWorkBlockRepository(db.DB()).GetByID(ctx, earlier.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3600), trimmed.DurationSeconds)
+	history, err := entries.History(ctx, earlier.ID, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "db doctor repair", history[0].Reason)
+}
+
+func TestDoctorAcceptsIdleClosesAndPastSessions(t *testing.T) {
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(t.TempDir(), "idle.db")))
+	require.NoError(t, err)
+	defer db.Close()
+	require.NotEqual(t, time.UTC, db.Timezone(), "the bug only shows with an offset")
+
+	ctx := context.Background()
+	tracker := tracking.NewActivityTracker(db)
+	record := func() *sqlite.Activity {
+		activity, err := tracker.Record(ctx, tracking.ActivityEvent{UserID: "alice", ProjectPath: "/work/api", ClaudeSessionID: "claude-a"})
+		require.NoError(t, err)
+		return activity
+	}
+
+	// A block idle for 20 minutes is closed by the next event, which opens a new block
+	first := record()
+	now := db.Now()
+	_, err = db.DB().ExecContext(ctx, `UPDATE sessions SET start_time = ?, end_time = ?, first_activity_time = ? WHERE id = ?`,
+		now.Add(-time.Hour), now.Add(4*time.Hour), now.Add(-time.Hour), first.SessionID)
+	require.NoError(t, err)
+	_, err = db.DB().ExecContext(ctx, `UPDATE work_blocks SET start_time = ?, last_activity_time = ? WHERE id = ?`,
+		now.Add(-40*time.Minute), now.Add(-20*time.Minute), first.WorkBlockID)
+	require.NoError(t, err)
+	next := record()
+	require.NotEqual(t, first.WorkBlockID, next.WorkBlockID)
+
+	// A window that closed yesterday but was never marked expired
+	yesterday := now.AddDate(0, 0, -1)
+	_, err = db.DB().ExecContext(ctx, `
+		INSERT INTO sessions (id, user_id, start_time, end_time, state, first_activity_time,
+			last_activity_time, activity_count, duration_hours, created_at, updated_at)
+		VALUES ('session_past', 'alice', ?, ?, 'active', ?, ?, 1, 5.0, ?, ?)`,
+		yesterday, yesterday.Add(5*time.Hour), yesterday, yesterday, yesterday, yesterday)
+	require.NoError(t, err)
+
+	doctor := sqlite.NewDoctor(db)
+	report, err := doctor.Run(ctx, false)
+	require.NoError(t, err)
+	assert.Zero(t, report.Problems(), "idle closes and past sessions are normal use: %+v", report.Checks)
+
+	// Older releases closed idle blocks with datetime(), storing offset-less UTC text
+	_, err = db.DB().ExecContext(ctx, `UPDATE work_blocks SET end_time = datetime(?) WHERE id = ?`,
+		now.Add(-15*time.Minute).UTC().Format("2006-01-02 15:04:05"), first.WorkBlockID)
+	require.NoError(t, err)
+
+	report, err = doctor.Run(ctx, true)
+	require.NoError(t, err)
+	assert.Zero(t, report.Problems(), "UTC text end times are compared as instants: %+v", report.Checks)
+	for _, check := range report.Checks {
+		assert.Zero(t, check.Fixed, "%s repaired nothing", check.Name)
+	}
+}
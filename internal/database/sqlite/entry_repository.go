@@ -0,0 +1,583 @@
+/**
+ * CONTEXT:   Manual time entries and audited work block corrections
+ * INPUT:     Hand-entered work periods and start, end or project changes to work blocks
+ * OUTPUT:    Work blocks that satisfy the schema constraints plus an audit row per change
+ * BUSINESS:  Users fix blocks left open overnight and add work done on other machines
+ * CHANGE:    Initial entry repository backed by schema version 9
+ * RISK:      Medium - Rewrites tracked time; every change is audited in the same transaction
+ */
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Audit actions recorded in work_block_audit
+const (
+	AuditActionCreate = "create"
+	AuditActionEdit   = "edit"
+	AuditActionDelete = "delete"
+)
+
+// manualSessionDuration matches the tracker's 5-hour session window
+const manualSessionDuration = 5 * time.Hour
+
+/**
+ * CONTEXT:   Hand-entered work period
+ * INPUT:     No input - data structure definition
+ * OUTPUT:    Everything needed to create a finished manual work block
+ * BUSINESS:  Reason is mandatory so corrected reports stay explainable
+ * CHANGE:    Initial manual entry definition
+ * RISK:      Low - Data structure only
+ */
+type ManualEntry struct {
+	UserID      string
+	ProjectID   string
+	StartTime   time.Time
+	EndTime     time.Time
+	Description string
+	Reason      string
+}
+
+// WorkBlockChange lists the work block fields an edit replaces; nil and empty keep the current value
+type WorkBlockChange struct {
+	StartTime *time.Time
+	EndTime   *time.Time
+	ProjectID string
+}
+
+// IsEmpty reports whether the change leaves the work block untouched
+func (c WorkBlockChange) IsEmpty() bool {
+	return c.StartTime == nil && c.EndTime == nil && c.ProjectID == ""
+}
+
+// WorkBlockSnapshot is the audited state of a work block before or after a change
+type WorkBlockSnapshot struct {
+	SessionID       string     `json:"session_id"`
+	ProjectID       string     `json:"project_id"`
+	StartTime       time.Time  `json:"start_time"`
+	EndTime         *time.Time `json:"end_time,omitempty"`
+	State           string     `json:"state"`
+	DurationSeconds int64      `json:"duration_seconds"`
+	Source          string     `json:"source"`
+}
+
+// WorkBlockAudit is one recorded create, edit or delete of a work block
+type WorkBlockAudit struct {
+	ID          string             `json:"id"`
+	WorkBlockID string             `json:"work_block_id"`
+	UserID      string             `json:"user_id"`
+	Action      string             `json:"action"`
+	Before      *WorkBlockSnapshot `json:"before,omitempty"`
+	After       *WorkBlockSnapshot `json:"after,omitempty"`
+	Reason      string             `json:"reason"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+// EntryRepository handles manual work blocks and their audit trail
+type EntryRepository struct {
+	db *SQLiteDB
+}
+
+// NewEntryRepository creates a new entry repository; times are stored in the database timezone
+func NewEntryRepository(db *SQLiteDB) *EntryRepository {
+	return &EntryRepository{db: db}
+}
+
+// snapshotWorkBlock captures the audited fields of a work block
+func snapshotWorkBlock(wb *WorkBlock) *WorkBlockSnapshot {
+	return &WorkBlockSnapshot{
+		SessionID:       wb.SessionID,
+		ProjectID:       wb.ProjectID,
+		StartTime:       wb.StartTime,
+		EndTime:         wb.EndTime,
+		State:           wb.State,
+		DurationSeconds: wb.DurationSeconds,
+		Source:          wb.Source,
+	}
+}
+
+/**
+ * CONTEXT:   Add a finished work block entered by hand
+ * INPUT:     Manual entry with user, project, time range and reason
+ * OUTPUT:    Created work block with source "manual"
+ * BUSINESS:  The block joins the user's session covering its start, or a new 5-hour
+ *            session, and gets one manual activity so activity counts stay positive
+ * CHANGE:    Count the manual activity on an existing session it joins
+ * RISK:      Medium - Overlapping time is rejected to avoid double counting in reports
+ */
+func (er *EntryRepository) Add(ctx context.Context, entry ManualEntry) (*WorkBlock, error) {
+	now := er.db.Now()
+	if entry.UserID == "" || entry.ProjectID == "" {
+		return nil, fmt.Errorf("user and project are required")
+	}
+	// Store times in the database timezone like tracked activity
+	entry.StartTime = er.db.ToDBTime(entry.StartTime)
+	entry.EndTime = er.db.ToDBTime(entry.EndTime)
+	if err := validateEntryChange(entry.StartTime, &entry.EndTime, entry.Reason, now); err != nil {
+		return nil, err
+	}
+
+	tx, err := er.db.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin entry transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := requireProject(ctx, tx, entry.ProjectID); err != nil {
+		return nil, err
+	}
+	if err := checkEntryOverlap(ctx, tx, entry.UserID, "", "", entry.StartTime, entry.EndTime); err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT OR IGNORE INTO users (id, username, created_at, updated_at) VALUES (?, ?, ?, ?)`,
+		entry.UserID, entry.UserID, now, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register user %s: %w", entry.UserID, err)
+	}
+
+	sessionID, opened, err := er.sessionForEntry(ctx, tx, entry.UserID, entry.StartTime, now)
+	if err != nil {
+		return nil, err
+	}
+	// An opened session already counts the manual activity; an existing one must too
+	if !opened {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE sessions
+			SET activity_count = activity_count + 1,
+			    first_activity_time = MIN(first_activity_time, ?),
+			    last_activity_time = MAX(last_activity_time, ?),
+			    updated_at = ?
+			WHERE id = ?`,
+			entry.StartTime, entry.StartTime, now, sessionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update session %s: %w", sessionID, err)
+		}
+	}
+
+	duration := entry.EndTime.Sub(entry.StartTime)
+	blockID := NewEntityID("block")
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO work_blocks (
+			id, session_id, project_id, start_time, end_time, state,
+			last_activity_time, activity_count, duration_seconds, duration_hours,
+			source, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, 'finished', ?, 1, ?, ?, ?, ?, ?)`,
+		blockID, sessionID, entry.ProjectID, entry.StartTime, entry.EndTime,
+		entry.EndTime, int64(duration.Seconds()), duration.Hours(),
+		WorkBlockSourceManual, now, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create manual work block: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO activity_events (
+			id, user_id, session_id, work_block_id, project_id, timestamp,
+			activity_type, activity_source, description, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, 'other', 'manual', ?, ?)`,
+		NewEntityID("activity"), entry.UserID, sessionID, blockID, entry.ProjectID,
+		entry.StartTime, nullableString(entry.Description), now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record manual activity: %w", err)
+	}
+
+	block, err := scanWorkBlock(tx.QueryRowContext(ctx,
+		`SELECT `+workBlockColumns+` FROM work_blocks wb WHERE wb.id = ?`, blockID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manual work block: %w", err)
+	}
+	if err := insertAudit(ctx, tx, block.ID, entry.UserID, AuditActionCreate, nil, snapshotWorkBlock(block), entry.Reason, now); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit manual entry: %w", err)
+	}
+	return block, nil
+}
+
+/**
+ * CONTEXT:   Correct the start, end or project of a work block
+ * INPUT:     Work block ID, requested change, acting user and reason
+ * OUTPUT:    Updated work block marked as edited
+ * BUSINESS:  Setting an end on an open block finishes it, e.g. a block left open
+ *            overnight; a block moved outside its session joins the covering session
+ * CHANGE:    Edited blocks only clash with blocks of their own Claude Code instance
+ * RISK:      Medium - Last activity is clamped into the new range to keep CHECK constraints
+ */
+func (er *EntryRepository) Edit(ctx context.Context, workBlockID string, change WorkBlockChange, userID, reason string) (*WorkBlock, error) {
+	if change.IsEmpty() {
+		return nil, fmt.Errorf("nothing to change - set a start, end or project")
+	}
+	now := er.db.Now()
+
+	tx, err := er.db.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin entry transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	block, owner, err := loadEntryBlock(ctx, tx, workBlockID)
+	if err != nil {
+		return nil, err
+	}
+	before := snapshotWorkBlock(block)
+
+	start := block.StartTime
+	if change.StartTime != nil {
+		start = er.db.ToDBTime(*change.StartTime)
+	}
+	end := block.EndTime
+	if change.EndTime != nil {
+		converted := er.db.ToDBTime(*change.EndTime)
+		end = &converted
+	}
+	if err := validateEntryChange(start, end, reason, now); err != nil {
+		return nil, err
+	}
+
+	lastActivity := block.LastActivityTime
+	if lastActivity.Before(start) {
+		if end == nil {
+			return nil, fmt.Errorf("start %s is after the block's last activity - set an end as well",
+				start.Local().Format("2006-01-02 15:04"))
+		}
+		lastActivity = start
+	}
+	state := block.State
+	var durationSeconds int64
+	var durationHours float64
+	if end != nil {
+		if lastActivity.After(*end) {
+			lastActivity = *end
+		}
+		state = "finished"
+		durationSeconds = int64(end.Sub(start).Seconds())
+		durationHours = end.Sub(start).Hours()
+	}
+
+	projectID := block.ProjectID
+	if change.ProjectID != "" {
+		if err := requireProject(ctx, tx, change.ProjectID); err != nil {
+			return nil, err
+		}
+		projectID = change.ProjectID
+	}
+
+	overlapEnd := lastActivity
+	if end != nil {
+		overlapEnd = *end
+	}
+	if err := checkEntryOverlap(ctx, tx, owner, block.ID, block.ClaudeSessionID, start, overlapEnd); err != nil {
+		return nil, err
+	}
+
+	sessionID := block.SessionID
+	if !start.Equal(block.StartTime) {
+		var sessionStart, sessionEnd time.Time
+		err := tx.QueryRowContext(ctx, `SELECT start_time, end_time FROM sessions WHERE id = ?`, block.SessionID).
+			Scan(&sessionStart, &sessionEnd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read session %s: %w", block.SessionID, err)
+		}
+		if start.Before(sessionStart) || !start.Before(sessionEnd) {
+			if sessionID, _, err = er.sessionForEntry(ctx, tx, owner, start, now); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE work_blocks
+		SET session_id = ?, project_id = ?, start_time = ?, end_time = ?, state = ?,
+		    last_activity_time = ?, duration_seconds = ?, duration_hours = ?,
+		    edited_at = ?, updated_at = ?
+		WHERE id = ?`,
+		sessionID, projectID, start, nullableTime(end), state,
+		lastActivity, durationSeconds, durationHours, now, now, block.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update work block: %w", err)
+	}
+
+	updated, err := scanWorkBlock(tx.QueryRowContext(ctx,
+		`SELECT `+workBlockColumns+` FROM work_blocks wb WHERE wb.id = ?`, block.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read updated work block: %w", err)
+	}
+	if err := insertAudit(ctx, tx, block.ID, auditUser(userID, owner), AuditActionEdit, before, snapshotWorkBlock(updated), reason, now); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit work block edit: %w", err)
+	}
+	return updated, nil
+}
+
+/**
+ * CONTEXT:   Delete a work block with an audit record
+ * INPUT:     Work block ID, acting user and reason
+ * OUTPUT:    The deleted work block as it was before deletion
+ * BUSINESS:  Removes time that should never have been tracked; the audit keeps it recoverable
+ * CHANGE:    Initial audited work block deletion
+ * RISK:      Medium - Activities keep their rows with the work block reference cleared
+ */
+func (er *EntryRepository) Delete(ctx context.Context, workBlockID, userID, reason string) (*WorkBlock, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, fmt.Errorf("a reason is required")
+	}
+	now := er.db.Now()
+
+	tx, err := er.db.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin entry transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	block, owner, err := loadEntryBlock(ctx, tx, workBlockID)
+	if err != nil {
+		return nil, err
+	}
+	if err := insertAudit(ctx, tx, block.ID, auditUser(userID, owner), AuditActionDelete, snapshotWorkBlock(block), nil, reason, now); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM work_blocks WHERE id = ?`, block.ID); err != nil {
+		return nil, fmt.Errorf("failed to delete work block: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit work block deletion: %w", err)
+	}
+	return block, nil
+}
+
+/**
+ * CONTEXT:   Read the audit trail
+ * INPUT:     Optional work block ID and maximum number of rows (0 for all)
+ * OUTPUT:    Audit records, newest first
+ * BUSINESS:  Shows who changed tracked time, when, and why
+ * CHANGE:    Initial audit history query
+ * RISK:      Low - Read-only query
+ */
+func (er *EntryRepository) History(ctx context.Context, workBlockID string, limit int) ([]*WorkBlockAudit, error) {
+	query := `
+		SELECT id, work_block_id, user_id, action, COALESCE(before_state, ''),
+		       COALESCE(after_state, ''), reason, created_at
+		FROM work_block_audit`
+	var args []interface{}
+	if workBlockID != "" {
+		query += ` WHERE work_block_id = ?`
+		args = append(args, workBlockID)
+	}
+	query += ` ORDER BY created_at DESC, rowid DESC`
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+
+	rows, err := er.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit history: %w", err)
+	}
+	defer rows.Close()
+
+	records := make([]*WorkBlockAudit, 0)
+	for rows.Next() {
+		record := &WorkBlockAudit{}
+		var before, after string
+		err := rows.Scan(&record.ID, &record.WorkBlockID, &record.UserID, &record.Action,
+			&before, &after, &record.Reason, &record.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit record: %w", err)
+		}
+		if record.Before, err = decodeSnapshot(before); err != nil {
+			return nil, err
+		}
+		if record.After, err = decodeSnapshot(after); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// validateEntryChange checks a time range and reason against the work block constraints
+func validateEntryChange(start time.Time, end *time.Time, reason string, now time.Time) error {
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("a reason is required")
+	}
+	if start.IsZero() {
+		return fmt.Errorf("start time is required")
+	}
+	if start.After(now) {
+		return fmt.Errorf("start %s is in the future", start.Local().Format("2006-01-02 15:04"))
+	}
+	if end != nil {
+		if !end.After(start) {
+			return fmt.Errorf("end must be after start")
+		}
+		if end.After(now) {
+			return fmt.Errorf("end %s is in the future", end.Local().Format("2006-01-02 15:04"))
+		}
+	}
+	return nil
+}
+
+// requireProject fails with a readable error for unknown project IDs
+func requireProject(ctx context.Context, tx *sql.Tx, projectID string) error {
+	var exists int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM projects WHERE id = ?`, projectID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to look up project %s: %w", projectID, err)
+	}
+	if exists == 0 {
+		return fmt.Errorf("project %s not found", projectID)
+	}
+	return nil
+}
+
+// loadEntryBlock reads a work block and the user owning its session
+func loadEntryBlock(ctx context.Context, tx *sql.Tx, workBlockID string) (*WorkBlock, string, error) {
+	block, err := scanWorkBlock(tx.QueryRowContext(ctx,
+		`SELECT `+workBlockColumns+` FROM work_blocks wb WHERE wb.id = ?`, workBlockID))
+	if err == sql.ErrNoRows {
+		return nil, "", fmt.Errorf("%w: %s", ErrWorkBlockNotFound, workBlockID)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get work block: %w", err)
+	}
+
+	var owner string
+	if err := tx.QueryRowContext(ctx, `SELECT user_id FROM sessions WHERE id = ?`, block.SessionID).Scan(&owner); err != nil {
+		return nil, "", fmt.Errorf("failed to read session %s: %w", block.SessionID, err)
+	}
+	return block, owner, nil
+}
+
+// checkEntryOverlap rejects ranges that overlap another work block of the user; a block of one
+// Claude Code instance may run alongside blocks of other instances but not its own or unkeyed ones.
+// Times are compared with julianday() because older idle closes stored offset-less UTC text
+func checkEntryOverlap(ctx context.Context, tx *sql.Tx, userID, excludeID, claudeSessionID string, start, end time.Time) error {
+	var blockID string
+	var blockStart time.Time
+	err := tx.QueryRowContext(ctx, `
+		SELECT wb.id, wb.start_time
+		FROM work_blocks wb
+		JOIN sessions s ON s.id = wb.session_id
+		WHERE s.user_id = ? AND wb.id != ?
+		  AND julianday(wb.start_time) < julianday(?)
+		  AND julianday(COALESCE(wb.end_time, wb.last_activity_time)) > julianday(?)
+		  AND (? = '' OR COALESCE(wb.claude_session_id, '') IN ('', ?))
+		ORDER BY wb.start_time
+		LIMIT 1`,
+		userID, excludeID, end, start, claudeSessionID, claudeSessionID).Scan(&blockID, &blockStart)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check overlapping work blocks: %w", err)
+	}
+	return fmt.Errorf("overlaps work block %s starting %s - edit or delete it first",
+		blockID, blockStart.Local().Format("2006-01-02 15:04"))
+}
+
+// sessionForEntry returns the user's session covering start, opening a 5-hour one if none does,
+// and whether it opened one; opened sessions are finished so the tracker never extends them
+// with live activity
+func (er *EntryRepository) sessionForEntry(ctx context.Context, tx *sql.Tx, userID string, start, now time.Time) (string, bool, error) {
+	var sessionID string
+	err := tx.QueryRowContext(ctx, `
+		SELECT id FROM sessions
+		WHERE user_id = ? AND julianday(start_time) <= julianday(?) AND julianday(end_time) > julianday(?)
+		ORDER BY start_time DESC
+		LIMIT 1`,
+		userID, start, start).Scan(&sessionID)
+	if err == nil {
+		return sessionID, false, nil
+	}
+	if err != sql.ErrNoRows {
+		return "", false, fmt.Errorf("failed to find session for %s: %w", start.Local().Format("2006-01-02 15:04"), err)
+	}
+
+	end := start.Add(manualSessionDuration)
+	sessionID = NewEntityID("session")
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO sessions (
+			id, user_id, start_time, end_time, state, first_activity_time,
+			last_activity_time, activity_count, duration_hours, created_at, updated_at
+		) VALUES (?, ?, ?, ?, 'finished', ?, ?, 1, ?, ?, ?)`,
+		sessionID, userID, start, end, start, start,
+		manualSessionDuration.Hours(), now, now)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create session for manual entry: %w", err)
+	}
+	return sessionID, true, nil
+}
+
+// insertAudit records one work block change with JSON before and after snapshots
+func insertAudit(ctx context.Context, tx *sql.Tx, workBlockID, userID, action string, before, after *WorkBlockSnapshot, reason string, now time.Time) error {
+	beforeJSON, err := encodeSnapshot(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := encodeSnapshot(after)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO work_block_audit (id, work_block_id, user_id, action, before_state, after_state, reason, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		NewEntityID("audit"), workBlockID, userID, action, beforeJSON, afterJSON,
+		strings.TrimSpace(reason), now)
+	if err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return nil
+}
+
+// auditUser prefers the acting user and falls back to the block's owner
+func auditUser(userID, owner string) string {
+	if userID != "" {
+		return userID
+	}
+	return owner
+}
+
+// encodeSnapshot serializes a snapshot, mapping nil to SQL NULL
+func encodeSnapshot(snapshot *WorkBlockSnapshot) (interface{}, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit snapshot: %w", err)
+	}
+	return string(data), nil
+}
+
+// decodeSnapshot parses a stored snapshot; empty text means none
+func decodeSnapshot(data string) (*WorkBlockSnapshot, error) {
+	if data == "" {
+		return nil, nil
+	}
+	snapshot := &WorkBlockSnapshot{}
+	if err := json.Unmarshal([]byte(data), snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode audit snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// nullableTime maps a nil time to SQL NULL
+func nullableTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
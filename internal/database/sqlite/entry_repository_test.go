@@ -0,0 +1,170 @@
+/**
+ * CONTEXT:   Tests for manual time entries and audited corrections
+ * INPUT:     Tracked and hand-entered work blocks in a temp database
+ * OUTPUT:    Validation of entry rules, edits, deletes, audit history and session counters
+ * BUSINESS:  Corrections must keep reports and session counters consistent
+ * CHANGE:    Initial entry repository tests
+ * RISK:      Low - Temp database per test
+ */
+
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/tracking"
+)
+
+func TestManualEntriesAndCorrections(t *testing.T) {
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(t.TempDir(), "entries.db")))
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	activity, err := tracking.NewActivityTracker(db).Record(ctx, tracking.ActivityEvent{UserID: "alice", ProjectPath: "/work/api"})
+	require.NoError(t, err)
+
+	entries := sqlite.NewEntryRepository(db)
+	now := time.Now()
+	entry := sqlite.ManualEntry{
+		UserID:    "alice",
+		ProjectID: activity.ProjectID,
+		StartTime: now.Add(-10 * time.Hour),
+		EndTime:   now.Add(-8 * time.Hour),
+		Reason:    "worked on laptop",
+	}
+
+	missingReason := entry
+	missingReason.Reason = " "
+	_, err = entries.Add(ctx, missingReason)
+	assert.Error(t, err, "reason is required")
+	future := entry
+	future.EndTime = now.Add(time.Hour)
+	_, err = entries.Add(ctx, future)
+	assert.Error(t, err, "entries cannot end in the future")
+
+	manual, err := entries.Add(ctx, entry)
+	require.NoError(t, err)
+	assert.Equal(t, sqlite.WorkBlockSourceManual, manual.Source)
+	assert.Equal(t, "finished", manual.State)
+	assert.Equal(t, int64(7200), manual.DurationSeconds)
+	assert.Nil(t, manual.EditedAt)
+
+	overlapping := entry
+	overlapping.StartTime = now.Add(-9 * time.Hour)
+	_, err = entries.Add(ctx, overlapping)
+	assert.ErrorContains(t, err, manual.ID)
+
+	// Close the hook's open block an hour before it started
+	start, end := now.Add(-time.Hour), now.Add(-30*time.Minute)
+	edited, err := entries.Edit(ctx, activity.WorkBlockID, sqlite.WorkBlockChange{StartTime: &start, EndTime: &end}, "alice", "left open")
+	require.NoError(t, err)
+	assert.Equal(t, "finished", edited.State)
+	assert.Equal(t, int64(1800), edited.DurationSeconds)
+	assert.Equal(t, sqlite.WorkBlockSourceHook, edited.Source)
+	assert.NotNil(t, edited.EditedAt)
+	assert.NotEqual(t, activity.SessionID, edited.SessionID, "block moved before its session joins a covering session")
+
+	_, err = entries.Edit(ctx, activity.WorkBlockID, sqlite.WorkBlockChange{}, "alice", "noop")
+	assert.Error(t, err)
+
+	_, err = entries.Delete(ctx, manual.ID, "alice", "duplicate")
+	require.NoError(t, err)
+	_, err = sqlite.NewWorkBlockRepository(db.DB()).GetByID(ctx, manual.ID)
+	assert.ErrorIs(t, err, sqlite.ErrWorkBlockNotFound)
+
+	history, err := entries.History(ctx, "", 0)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, sqlite.AuditActionDelete, history[0].Action)
+	assert.Nil(t, history[0].After)
+	assert.True(t, history[0].Before.StartTime.Equal(entry.StartTime))
+	assert.Equal(t, sqlite.AuditActionEdit, history[1].Action)
+	assert.Nil(t, history[1].Before.EndTime)
+	assert.True(t, history[1].After.EndTime.Equal(end))
+	assert.Equal(t, sqlite.AuditActionCreate, history[2].Action)
+	assert.Equal(t, "worked on laptop", history[2].Reason)
+
+	blockHistory, err := entries.History(ctx, activity.WorkBlockID, 0)
+	require.NoError(t, err)
+	assert.Len(t, blockHistory, 1)
+}
+
+func TestManualEntryUpdatesSessionCounters(t *testing.T) {
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(t.TempDir(), "counters.db")))
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	project, err := sqlite.NewProjectRepository(db.DB()).GetOrCreate(ctx, "/work/api")
+	require.NoError(t, err)
+
+	entries := sqlite.NewEntryRepository(db)
+	sessions := sqlite.NewSessionRepository(db)
+	now := time.Now()
+	add := func(start, end time.Time) *sqlite.WorkBlock {
+		block, err := entries.Add(ctx, sqlite.ManualEntry{
+			UserID: "alice", ProjectID: project.ID, StartTime: start, EndTime: end, Reason: "offline work",
+		})
+		require.NoError(t, err)
+		return block
+	}
+
+	first := add(now.Add(-10*time.Hour), now.Add(-9*time.Hour))
+	opened, err := sessions.GetByID(ctx, first.SessionID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), opened.ActivityCount)
+
+	second := add(now.Add(-7*time.Hour), now.Add(-6*time.Hour))
+	require.Equal(t, first.SessionID, second.SessionID, "entry joins the session covering its start")
+
+	joined, err := sessions.GetByID(ctx, first.SessionID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), joined.ActivityCount)
+	assert.True(t, joined.LastActivityTime.Equal(db.ToDBTime(now.Add(-7*time.Hour))), "last activity is %s", joined.LastActivityTime)
+	assert.True(t, joined.FirstActivityTime.Equal(opened.FirstActivityTime))
+
+	report, err := sqlite.NewDoctor(db).Run(ctx, false)
+	require.NoError(t, err)
+	assert.Zero(t, report.Problems(), "manual entries leave no counter drift")
+}
+
+func TestManualEntryAfterLegacyIdleClose(t *testing.T) {
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(t.TempDir(), "legacy.db")))
+	require.NoError(t, err)
+	defer db.Close()
+	require.NotEqual(t, time.UTC, db.Timezone(), "the bug only shows with an offset")
+
+	ctx := context.Background()
+	activity, err := tracking.NewActivityTracker(db).Record(ctx, tracking.ActivityEvent{UserID: "alice", ProjectPath: "/work/api"})
+	require.NoError(t, err)
+
+	// Older releases closed idle blocks with datetime(), storing offset-less UTC text
+	now := time.Now()
+	_, err = db.DB().ExecContext(ctx, `
+		UPDATE work_blocks SET start_time = ?, last_activity_time = ?,
+		       end_time = datetime(?, '+5 minutes'), state = 'idle'
+		WHERE id = ?`,
+		db.ToDBTime(now.Add(-3*time.Hour)), db.ToDBTime(now.Add(-120*time.Minute)),
+		now.Add(-120*time.Minute).UTC().Format("2006-01-02 15:04:05"), activity.WorkBlockID)
+	require.NoError(t, err)
+
+	entries := sqlite.NewEntryRepository(db)
+	entry := sqlite.ManualEntry{
+		UserID: "alice", ProjectID: activity.ProjectID,
+		StartTime: now.Add(-60 * time.Minute), EndTime: now.Add(-30 * time.Minute), Reason: "pairing",
+	}
+	_, err = entries.Add(ctx, entry)
+	require.NoError(t, err, "a block closed 115 minutes ago does not overlap the last hour")
+
+	entry.StartTime, entry.EndTime = now.Add(-150*time.Minute), now.Add(-125*time.Minute)
+	_, err = entries.Add(ctx, entry)
+	assert.ErrorContains(t, err, activity.WorkBlockID, "real overlaps are still refused")
+}
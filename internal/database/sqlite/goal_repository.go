@@ -0,0 +1,270 @@
+/**
+ * CONTEXT:   Goal repository for SQLite database operations
+ * INPUT:     Goal CRUD operations for targets and caps per period
+ * OUTPUT:    Database persistence for goals and budgets
+ * BUSINESS:  Goals express weekly targets ("30 focused hours") and budgets ("at most 10h on X")
+ * CHANGE:    Initial goal repository backed by schema version 2
+ * RISK:      Low - Simple repository with CHECK constraints enforcing valid goals
+ */
+
+package sqlite
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Goal periods, kinds and metrics accepted by the goals table
+const (
+	GoalPeriodDaily   = "daily"
+	GoalPeriodWeekly  = "weekly"
+	GoalPeriodMonthly = "monthly"
+
+	GoalKindTarget = "target"
+	GoalKindCap    = "cap"
+
+	GoalMetricWorkHours     = "work_hours"
+	GoalMetricDeepWorkHours = "deep_work_hours"
+)
+
+// Goal represents a target or cap on tracked hours for a period
+type Goal struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	Name        string    `json:"name"`
+	Period      string    `json:"period"`
+	Kind        string    `json:"kind"`
+	Metric      string    `json:"metric"`
+	ProjectID   string    `json:"project_id,omitempty"`
+	TargetHours float64   `json:"target_hours"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// GoalRepository handles database operations for goals
+type GoalRepository struct {
+	db *sql.DB
+}
+
+// NewGoalRepository creates a new goal repository
+func NewGoalRepository(db *sql.DB) *GoalRepository {
+	return &GoalRepository{db: db}
+}
+
+/**
+ * CONTEXT:   Create new goal in database
+ * INPUT:     Goal entity with period, kind, metric and optional project scope
+ * OUTPUT:    Persisted goal with generated ID and timestamps
+ * BUSINESS:  Goals are validated before insert so CLI errors are readable
+ * CHANGE:    Initial goal creation
+ * RISK:      Low - Standard insertion guarded by CHECK constraints
+ */
+func (gr *GoalRepository) Create(ctx context.Context, goal *Goal) error {
+	if goal == nil {
+		return fmt.Errorf("goal cannot be nil")
+	}
+	if goal.Metric == "" {
+		goal.Metric = GoalMetricWorkHours
+	}
+	if err := ValidateGoal(goal); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if goal.ID == "" {
+		goal.ID = NewEntityID("goal")
+	}
+	if goal.CreatedAt.IsZero() {
+		goal.CreatedAt = now
+	}
+	goal.UpdatedAt = now
+
+	query := `
+		INSERT INTO goals (id, user_id, name, period, kind, metric, project_id, target_hours, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := gr.db.ExecContext(ctx, query,
+		goal.ID, goal.UserID, goal.Name, goal.Period, goal.Kind, goal.Metric,
+		nullableString(goal.ProjectID), goal.TargetHours, goal.CreatedAt, goal.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create goal: %w", err)
+	}
+
+	return nil
+}
+
+/**
+ * CONTEXT:   Get goal by ID
+ * INPUT:     Goal ID for lookup
+ * OUTPUT:    Goal entity or error if not found
+ * BUSINESS:  Goal lookup for progress display and removal
+ * CHANGE:    Initial goal retrieval
+ * RISK:      Low - Standard database query
+ */
+func (gr *GoalRepository) GetByID(ctx context.Context, id string) (*Goal, error) {
+	if id == "" {
+		return nil, fmt.Errorf("goal ID cannot be empty")
+	}
+
+	query := `
+		SELECT id, user_id, name, period, kind, metric, project_id, target_hours, created_at, updated_at
+		FROM goals
+		WHERE id = ?
+	`
+
+	goal, err := scanGoal(gr.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("goal %s not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get goal: %w", err)
+	}
+
+	return goal, nil
+}
+
+/**
+ * CONTEXT:   List goals for a user
+ * INPUT:     User ID and optional period filter (empty for all periods)
+ * OUTPUT:    Goals ordered by period and creation time
+ * BUSINESS:  Goal listing drives CLI output and progress evaluation
+ * CHANGE:    Initial goal listing
+ * RISK:      Low - Indexed query by user
+ */
+func (gr *GoalRepository) ListByUser(ctx context.Context, userID, period string) ([]*Goal, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+
+	query := `
+		SELECT id, user_id, name, period, kind, metric, project_id, target_hours, created_at, updated_at
+		FROM goals
+		WHERE user_id = ? AND (? = '' OR period = ?)
+		ORDER BY CASE period WHEN 'daily' THEN 1 WHEN 'weekly' THEN 2 ELSE 3 END, created_at
+	`
+
+	rows, err := gr.db.QueryContext(ctx, query, userID, period, period)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list goals: %w", err)
+	}
+	defer rows.Close()
+
+	goals := make([]*Goal, 0)
+	for rows.Next() {
+		goal, err := scanGoal(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan goal: %w", err)
+		}
+		goals = append(goals, goal)
+	}
+
+	return goals, rows.Err()
+}
+
+/**
+ * CONTEXT:   Delete goal owned by a user
+ * INPUT:     User ID and goal ID
+ * OUTPUT:    Removed goal or not-found error
+ * BUSINESS:  Users can only remove their own goals
+ * CHANGE:    Initial goal deletion
+ * RISK:      Low - Single-row deletion scoped by user
+ */
+func (gr *GoalRepository) Delete(ctx context.Context, userID, id string) error {
+	if id == "" {
+		return fmt.Errorf("goal ID cannot be empty")
+	}
+
+	result, err := gr.db.ExecContext(ctx, `DELETE FROM goals WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete goal: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("goal %s not found", id)
+	}
+
+	return nil
+}
+
+/**
+ * CONTEXT:   Validate goal fields before persistence
+ * INPUT:     Goal entity
+ * OUTPUT:    Descriptive error for invalid period, kind, metric or hours
+ * BUSINESS:  Readable validation errors instead of raw CHECK constraint failures
+ * CHANGE:    Initial goal validation mirroring schema constraints
+ * RISK:      Low - Pure validation
+ */
+func ValidateGoal(goal *Goal) error {
+	if goal.UserID == "" {
+		return fmt.Errorf("goal user ID cannot be empty")
+	}
+	if goal.Name == "" {
+		return fmt.Errorf("goal name cannot be empty")
+	}
+	switch goal.Period {
+	case GoalPeriodDaily, GoalPeriodWeekly, GoalPeriodMonthly:
+	default:
+		return fmt.Errorf("invalid goal period %q (use daily, weekly or monthly)", goal.Period)
+	}
+	switch goal.Kind {
+	case GoalKindTarget, GoalKindCap:
+	default:
+		return fmt.Errorf("invalid goal kind %q (use target or cap)", goal.Kind)
+	}
+	switch goal.Metric {
+	case GoalMetricWorkHours, GoalMetricDeepWorkHours:
+	default:
+		return fmt.Errorf("invalid goal metric %q (use work_hours or deep_work_hours)", goal.Metric)
+	}
+	if goal.TargetHours <= 0 {
+		return fmt.Errorf("goal hours must be greater than zero")
+	}
+	return nil
+}
+
+// rowScanner abstracts *sql.Row and *sql.Rows for shared scan helpers
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanGoal reads a goal row in the column order used by goal queries
+func scanGoal(row rowScanner) (*Goal, error) {
+	var goal Goal
+	var projectID sql.NullString
+	err := row.Scan(
+		&goal.ID, &goal.UserID, &goal.Name, &goal.Period, &goal.Kind, &goal.Metric,
+		&projectID, &goal.TargetHours, &goal.CreatedAt, &goal.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	goal.ProjectID = projectID.String
+	return &goal, nil
+}
+
+// nullableString maps empty strings to SQL NULL for optional foreign keys
+func nullableString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// NewEntityID creates a random prefixed identifier such as goal_3f9a1c2b7d4e5f60
+func NewEntityID(prefix string) string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%s_%d", prefix, time.Now().UnixNano())
+	}
+	return fmt.Sprintf("%s_%s", prefix, hex.EncodeToString(buf))
+}
@@ -3,7 +3,7 @@
  * INPUT:     Gob backup file path and target SQLite database
  * OUTPUT:    Complete data migration with data integrity validation
  * BUSINESS:  Preserve all historical work tracking data during system upgrade
- * CHANGE:    One-time migration from gob binary format to SQLite relational storage
+ * CHANGE:    Structured slog progress logging
  * RISK:      Medium - Critical data migration must preserve all data without loss
  */
 
@@ -14,7 +14,7 @@ import (
 	"database/sql"
 	"encoding/gob"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"time"
 )
@@ -125,8 +125,8 @@ func LoadLegacyData(gobFilePath string) (*LegacyDatabaseData, error) {
 		data.Activities = make([]*LegacyActivityEvent, 0)
 	}
 
-	log.Printf("📦 Loaded legacy data: %d sessions, %d work blocks, %d activities", 
-		len(data.Sessions), len(data.WorkBlocks), len(data.Activities))
+	slog.Info("Loaded legacy data",
+		"sessions", len(data.Sessions), "work_blocks", len(data.WorkBlocks), "activities", len(data.Activities))
 
 	return &data, nil
 }
@@ -145,7 +145,7 @@ func MigrateFromGobBackup(db *SQLiteDB, gobFilePath string) (*MigrationResult, e
 		Errors: make([]string, 0),
 	}
 
-	log.Printf("🔄 Starting migration from gob backup: %s", gobFilePath)
+	slog.Info("Starting migration from gob backup", "path", gobFilePath)
 
 	// Load legacy data
 	legacyData, err := LoadLegacyData(gobFilePath)
@@ -169,7 +169,7 @@ func MigrateFromGobBackup(db *SQLiteDB, gobFilePath string) (*MigrationResult, e
 			}
 		}
 		result.UsersCreated = len(users)
-		log.Printf("✅ Created %d users", result.UsersCreated)
+		slog.Info("Migrated users", "count", result.UsersCreated)
 
 		// Step 2: Extract and create projects
 		projects, err := extractProjects(legacyData)
@@ -184,7 +184,7 @@ func MigrateFromGobBackup(db *SQLiteDB, gobFilePath string) (*MigrationResult, e
 			}
 		}
 		result.ProjectsCreated = len(projects)
-		log.Printf("✅ Created %d projects", result.ProjectsCreated)
+		slog.Info("Migrated projects", "count", result.ProjectsCreated)
 
 		// Step 3: Migrate sessions with proper end_time calculation
 		for _, legacySession := range legacyData.Sessions {
@@ -195,7 +195,7 @@ func MigrateFromGobBackup(db *SQLiteDB, gobFilePath string) (*MigrationResult, e
 			}
 			result.SessionsMigrated++
 		}
-		log.Printf("✅ Migrated %d sessions", result.SessionsMigrated)
+		slog.Info("Migrated sessions", "count", result.SessionsMigrated)
 
 		// Step 4: Migrate work blocks with project references
 		projectLookup := createProjectLookup(projects)
@@ -207,7 +207,7 @@ func MigrateFromGobBackup(db *SQLiteDB, gobFilePath string) (*MigrationResult, e
 			}
 			result.WorkBlocksMigrated++
 		}
-		log.Printf("✅ Migrated %d work blocks", result.WorkBlocksMigrated)
+		slog.Info("Migrated work blocks", "count", result.WorkBlocksMigrated)
 
 		// Step 5: Migrate activity events (if needed for full history)
 		for _, legacyActivity := range legacyData.Activities {
@@ -218,7 +218,7 @@ func MigrateFromGobBackup(db *SQLiteDB, gobFilePath string) (*MigrationResult, e
 			}
 			result.ActivitiesMigrated++
 		}
-		log.Printf("✅ Migrated %d activity events", result.ActivitiesMigrated)
+		slog.Info("Migrated activity events", "count", result.ActivitiesMigrated)
 
 		return nil
 	})
@@ -231,7 +231,7 @@ func MigrateFromGobBackup(db *SQLiteDB, gobFilePath string) (*MigrationResult, e
 	result.MigrationDuration = time.Since(startTime)
 
 	// Step 6: Validate data integrity
-	log.Printf("🔍 Validating data integrity...")
+	slog.Info("Validating migrated data integrity")
 	if err := validateMigrationIntegrity(db, legacyData, result); err != nil {
 		result.DataIntegrityValid = false
 		result.Errors = append(result.Errors, fmt.Sprintf("Data integrity validation failed: %v", err))
@@ -239,10 +239,13 @@ func MigrateFromGobBackup(db *SQLiteDB, gobFilePath string) (*MigrationResult, e
 	}
 
 	result.DataIntegrityValid = true
-	log.Printf("✅ Migration completed successfully in %v", result.MigrationDuration)
-	log.Printf("📊 Migration Summary: %d users, %d projects, %d sessions, %d work blocks, %d activities", 
-		result.UsersCreated, result.ProjectsCreated, result.SessionsMigrated, 
-		result.WorkBlocksMigrated, result.ActivitiesMigrated)
+	slog.Info("Migration completed",
+		"duration", result.MigrationDuration,
+		"users", result.UsersCreated,
+		"projects", result.ProjectsCreated,
+		"sessions", result.SessionsMigrated,
+		"work_blocks", result.WorkBlocksMigrated,
+		"activities", result.ActivitiesMigrated)
 
 	return result, nil
 }
@@ -440,7 +443,7 @@ func validateMigrationIntegrity(db *SQLiteDB, legacyData *LegacyDatabaseData, re
 		return fmt.Errorf("found %d orphaned work blocks", orphanedWorkBlocks)
 	}
 
-	log.Printf("✅ Data integrity validation passed")
+	slog.Info("Data integrity validation passed")
 	return nil
 }
 
@@ -454,12 +457,17 @@ type User struct {
 }
 
 type Project struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Path        string    `json:"path"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Path        string     `json:"path"`
+	Description string     `json:"description"`
+	Group       string     `json:"group,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	ParentID    string     `json:"parent_id,omitempty"`
+	Client      string     `json:"client,omitempty"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
 }
 
 type Session struct {
@@ -492,6 +500,11 @@ type WorkBlock struct {
 	EstimatedEndTime        *time.Time `json:"estimated_end_time"`
 	LastClaudeActivity      *time.Time `json:"last_claude_activity"`
 	ActivePromptID          string     `json:"active_prompt_id"`
+	GitBranch               string     `json:"git_branch,omitempty"`
+	ClaudeSessionID         string     `json:"claude_session_id,omitempty"`
+	Tags                    []string   `json:"tags,omitempty"`
+	Source                  string     `json:"source"`
+	EditedAt                *time.Time `json:"edited_at,omitempty"`
 	CreatedAt               time.Time  `json:"created_at"`
 	UpdatedAt               time.Time  `json:"updated_at"`
 }
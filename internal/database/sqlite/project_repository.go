@@ -11,6 +11,7 @@ package sqlite
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
 	"fmt"
 	"path/filepath"
@@ -30,6 +31,42 @@ func NewProjectRepository(db *sql.DB) *ProjectRepository {
 
 // Project type is already defined in migration.go
 
+// projectColumns lists project columns in scanProject order
+const projectColumns = `p.id, p.name, p.path, COALESCE(p.description, ''), COALESCE(p.group_name, ''),
+	COALESCE(p.tags, ''), COALESCE(p.parent_id, ''), COALESCE(p.client, ''),
+	p.archived_at, p.created_at, p.updated_at`
+
+// scanProject reads one project row selected with projectColumns
+func scanProject(row rowScanner) (*Project, error) {
+	var project Project
+	var tags string
+	var archivedAt sql.NullTime
+	if err := row.Scan(
+		&project.ID, &project.Name, &project.Path, &project.Description, &project.Group,
+		&tags, &project.ParentID, &project.Client, &archivedAt, &project.CreatedAt, &project.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	project.Tags = splitProjectTags(tags)
+	if archivedAt.Valid {
+		project.ArchivedAt = &archivedAt.Time
+	}
+	return &project, nil
+}
+
+// joinProjectTags stores tags as a comma-separated column
+func joinProjectTags(tags []string) string {
+	return strings.Join(tags, ",")
+}
+
+// splitProjectTags reverses joinProjectTags
+func splitProjectTags(tags string) []string {
+	if tags == "" {
+		return nil
+	}
+	return strings.Split(tags, ",")
+}
+
 /**
  * CONTEXT:   Create new project in database
  * INPUT:     Project entity with name and path
@@ -44,12 +81,14 @@ func (pr *ProjectRepository) Create(ctx context.Context, project *Project) error
 	}
 
 	query := `
-		INSERT INTO projects (id, name, path, description, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO projects (id, name, path, description, group_name, tags, parent_id, client, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	_, err := pr.db.ExecContext(ctx, query,
 		project.ID, project.Name, project.Path, project.Description,
+		nullableString(project.Group), nullableString(joinProjectTags(project.Tags)),
+		nullableString(project.ParentID), nullableString(project.Client),
 		project.CreatedAt, project.UpdatedAt,
 	)
 
@@ -73,18 +112,9 @@ func (pr *ProjectRepository) GetByID(ctx context.Context, id string) (*Project,
 		return nil, fmt.Errorf("project ID cannot be empty")
 	}
 
-	query := `
-		SELECT id, name, path, description, created_at, updated_at
-		FROM projects
-		WHERE id = ?
-	`
-
-	var project Project
-	err := pr.db.QueryRowContext(ctx, query, id).Scan(
-		&project.ID, &project.Name, &project.Path, &project.Description,
-		&project.CreatedAt, &project.UpdatedAt,
-	)
+	query := `SELECT ` + projectColumns + ` FROM projects p WHERE p.id = ?`
 
+	project, err := scanProject(pr.db.QueryRowContext(ctx, query, id))
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("project %s not found", id)
 	}
@@ -92,15 +122,15 @@ func (pr *ProjectRepository) GetByID(ctx context.Context, id string) (*Project,
 		return nil, fmt.Errorf("failed to get project: %w", err)
 	}
 
-	return &project, nil
+	return project, nil
 }
 
 /**
  * CONTEXT:   Get project by path with normalized path matching
  * INPUT:     Project path for lookup
  * OUTPUT:    Project entity or nil if not found
- * BUSINESS:  Project identification by working directory path
- * CHANGE:    CHECKPOINT 8 - Path-based project lookup
+ * BUSINESS:  Project identification by working directory path or alias
+ * CHANGE:    Resolve project aliases so extra checkouts map to one project
  * RISK:      Low - Query with path normalization
  */
 func (pr *ProjectRepository) GetByPath(ctx context.Context, path string) (*Project, error) {
@@ -111,18 +141,17 @@ func (pr *ProjectRepository) GetByPath(ctx context.Context, path string) (*Proje
 	// Normalize path for consistent matching
 	normalizedPath := normalizePath(path)
 
+	// A project's own path wins over an alias pointing elsewhere
 	query := `
-		SELECT id, name, path, description, created_at, updated_at
-		FROM projects
-		WHERE path = ?
+		SELECT ` + projectColumns + `
+		FROM projects p
+		WHERE p.path = ?
+		   OR p.id IN (SELECT project_id FROM project_aliases WHERE path = ?)
+		ORDER BY p.path = ? DESC
+		LIMIT 1
 	`
 
-	var project Project
-	err := pr.db.QueryRowContext(ctx, query, normalizedPath).Scan(
-		&project.ID, &project.Name, &project.Path, &project.Description,
-		&project.CreatedAt, &project.UpdatedAt,
-	)
-
+	project, err := scanProject(pr.db.QueryRowContext(ctx, query, normalizedPath, normalizedPath, normalizedPath))
 	if err == sql.ErrNoRows {
 		return nil, nil // Project not found
 	}
@@ -130,7 +159,7 @@ func (pr *ProjectRepository) GetByPath(ctx context.Context, path string) (*Proje
 		return nil, fmt.Errorf("failed to get project by path: %w", err)
 	}
 
-	return &project, nil
+	return project, nil
 }
 
 /**
@@ -138,10 +167,22 @@ func (pr *ProjectRepository) GetByPath(ctx context.Context, path string) (*Proje
  * INPUT:     Project path for identification or creation
  * OUTPUT:    Existing or newly created project entity
  * BUSINESS:  Automatic project creation ensures work blocks have valid projects
- * CHANGE:    CHECKPOINT 8 - Auto-creation for seamless project management
+ * CHANGE:    Delegates to GetOrCreateNamed with a generated name
  * RISK:      Low - Upsert operation with path normalization
  */
 func (pr *ProjectRepository) GetOrCreate(ctx context.Context, projectPath string) (*Project, error) {
+	return pr.GetOrCreateNamed(ctx, projectPath, "")
+}
+
+/**
+ * CONTEXT:   Get or create project by path with an optional name for new projects
+ * INPUT:     Project path and name; empty name falls back to the directory name
+ * OUTPUT:    Existing or newly created project entity
+ * BUSINESS:  Path rules name projects on creation; later renames are kept
+ * CHANGE:    Extracted from GetOrCreate for rule-based naming
+ * RISK:      Low - Upsert operation with path normalization
+ */
+func (pr *ProjectRepository) GetOrCreateNamed(ctx context.Context, projectPath, name string) (*Project, error) {
 	if projectPath == "" {
 		return nil, fmt.Errorf("project path cannot be empty")
 	}
@@ -158,8 +199,12 @@ func (pr *ProjectRepository) GetOrCreate(ctx context.Context, projectPath string
 
 	// Create new project
 	normalizedPath := normalizePath(projectPath)
-	projectName := generateProjectName(normalizedPath)
-	projectID := generateProjectID(projectName, normalizedPath)
+	projectName := name
+	if projectName == "" {
+		projectName = generateProjectName(normalizedPath)
+	}
+	// IDs derive from the unique path; name-based IDs collide once rules share names
+	projectID := projectIDForPath(normalizedPath)
 
 	newProject := &Project{
 		ID:          projectID,
@@ -178,6 +223,27 @@ func (pr *ProjectRepository) GetOrCreate(ctx context.Context, projectPath string
 	return newProject, nil
 }
 
+/**
+ * CONTEXT:   Set rule-assigned group and tags on a project
+ * INPUT:     Project ID, group and tags
+ * OUTPUT:    Project labels replaced
+ * BUSINESS:  Path rules own project labels, so ingestion keeps them in sync with config
+ * CHANGE:    Initial label update
+ * RISK:      Low - Single-row update
+ */
+func (pr *ProjectRepository) SetLabels(ctx context.Context, id, group string, tags []string) error {
+	result, err := pr.db.ExecContext(ctx,
+		`UPDATE projects SET group_name = ?, tags = ?, updated_at = ? WHERE id = ?`,
+		nullableString(group), nullableString(joinProjectTags(tags)), time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to set project labels: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("project %s not found", id)
+	}
+	return nil
+}
+
 /**
  * CONTEXT:   Get all projects for system monitoring
  * INPUT:     Context for database operations
@@ -187,11 +253,7 @@ func (pr *ProjectRepository) GetOrCreate(ctx context.Context, projectPath string
  * RISK:      Low - System-wide query for monitoring
  */
 func (pr *ProjectRepository) GetAll(ctx context.Context) ([]*Project, error) {
-	query := `
-		SELECT id, name, path, description, created_at, updated_at
-		FROM projects
-		ORDER BY created_at DESC
-	`
+	query := `SELECT ` + projectColumns + ` FROM projects p ORDER BY p.created_at DESC`
 
 	rows, err := pr.db.QueryContext(ctx, query)
 	if err != nil {
@@ -201,15 +263,11 @@ func (pr *ProjectRepository) GetAll(ctx context.Context) ([]*Project, error) {
 
 	var projects []*Project
 	for rows.Next() {
-		var project Project
-		err := rows.Scan(
-			&project.ID, &project.Name, &project.Path, &project.Description,
-			&project.CreatedAt, &project.UpdatedAt,
-		)
+		project, err := scanProject(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan project: %w", err)
 		}
-		projects = append(projects, &project)
+		projects = append(projects, project)
 	}
 
 	return projects, nil
@@ -286,8 +344,409 @@ func (pr *ProjectRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+/**
+ * CONTEXT:   Archive or restore a project
+ * INPUT:     Project ID, archive flag and timestamp
+ * OUTPUT:    Project with archived_at set or cleared
+ * BUSINESS:  Archived projects drop out of listings but keep their history
+ * CHANGE:    Initial project archiving
+ * RISK:      Low - Single-row update
+ */
+func (pr *ProjectRepository) SetArchived(ctx context.Context, id string, archived bool, at time.Time) error {
+	var archivedAt interface{}
+	if archived {
+		archivedAt = at
+	}
+
+	result, err := pr.db.ExecContext(ctx,
+		`UPDATE projects SET archived_at = ?, updated_at = ? WHERE id = ?`,
+		archivedAt, at, id)
+	if err != nil {
+		return fmt.Errorf("failed to archive project: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("project %s not found", id)
+	}
+	return nil
+}
+
+// maxProjectDepth bounds ancestor walks so a corrupted hierarchy cannot loop forever
+const maxProjectDepth = 32
+
+/**
+ * CONTEXT:   Place a project under a parent project
+ * INPUT:     Project ID and parent project ID (empty to make it top-level)
+ * OUTPUT:    Project with parent_id set or cleared
+ * BUSINESS:  Client -> product -> repository trees roll up in reports
+ * CHANGE:    Initial project hierarchy
+ * RISK:      Low - Rejects unknown parents and cycles
+ */
+func (pr *ProjectRepository) SetParent(ctx context.Context, id, parentID string) error {
+	if id == "" {
+		return fmt.Errorf("project ID cannot be empty")
+	}
+
+	if parentID != "" {
+		if parentID == id {
+			return fmt.Errorf("project cannot be its own parent")
+		}
+		ancestors, err := pr.GetAncestors(ctx, parentID)
+		if err != nil {
+			return err
+		}
+		for _, ancestor := range ancestors {
+			if ancestor.ID == id {
+				return fmt.Errorf("project %s is an ancestor of %s; moving it there would create a cycle", id, parentID)
+			}
+		}
+	}
+
+	result, err := pr.db.ExecContext(ctx,
+		`UPDATE projects SET parent_id = ?, updated_at = ? WHERE id = ?`,
+		nullableString(parentID), time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to set project parent: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("project %s not found", id)
+	}
+	return nil
+}
+
+// SetClient sets or clears the client a project is billed to
+func (pr *ProjectRepository) SetClient(ctx context.Context, id, client string) error {
+	result, err := pr.db.ExecContext(ctx,
+		`UPDATE projects SET client = ?, updated_at = ? WHERE id = ?`,
+		nullableString(client), time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to set project client: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("project %s not found", id)
+	}
+	return nil
+}
+
+/**
+ * CONTEXT:   Load a project and its ancestors
+ * INPUT:     Project ID
+ * OUTPUT:    Projects from the given one up to its top-level ancestor
+ * BUSINESS:  Client inheritance and cycle checks walk the hierarchy upwards
+ * CHANGE:    Initial ancestor lookup
+ * RISK:      Low - Bounded by maxProjectDepth
+ */
+func (pr *ProjectRepository) GetAncestors(ctx context.Context, id string) ([]*Project, error) {
+	var lineage []*Project
+	for current := id; current != "" && len(lineage) < maxProjectDepth; {
+		project, err := pr.GetByID(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		lineage = append(lineage, project)
+		current = project.ParentID
+	}
+	return lineage, nil
+}
+
+// GetChildren returns the direct children of a project ordered by name
+func (pr *ProjectRepository) GetChildren(ctx context.Context, id string) ([]*Project, error) {
+	rows, err := pr.db.QueryContext(ctx,
+		`SELECT `+projectColumns+` FROM projects p WHERE p.parent_id = ? ORDER BY p.name`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get child projects: %w", err)
+	}
+	defer rows.Close()
+
+	var children []*Project
+	for rows.Next() {
+		child, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+		children = append(children, child)
+	}
+	return children, rows.Err()
+}
+
+// GetSubtreeIDs returns a project ID followed by the IDs of all its descendants
+func (pr *ProjectRepository) GetSubtreeIDs(ctx context.Context, id string) ([]string, error) {
+	rows, err := pr.db.QueryContext(ctx, `
+		WITH RECURSIVE subtree(id, depth) AS (
+			SELECT id, 0 FROM projects WHERE id = ?
+			UNION
+			SELECT p.id, s.depth + 1 FROM projects p JOIN subtree s ON p.parent_id = s.id
+			WHERE s.depth < ?
+		)
+		SELECT id FROM subtree ORDER BY depth`, id, maxProjectDepth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project subtree: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var projectID string
+		if err := rows.Scan(&projectID); err != nil {
+			return nil, fmt.Errorf("failed to scan project subtree: %w", err)
+		}
+		ids = append(ids, projectID)
+	}
+	return ids, rows.Err()
+}
+
+/**
+ * CONTEXT:   Map an extra path to an existing project
+ * INPUT:     Project ID and alias path
+ * OUTPUT:    Alias row so GetByPath and GetOrCreate resolve the path to the project
+ * BUSINESS:  Several checkouts of one repository count as one project
+ * CHANGE:    Initial project aliases
+ * RISK:      Low - Rejects paths that already belong to another project
+ */
+func (pr *ProjectRepository) AddAlias(ctx context.Context, projectID, path string) error {
+	if projectID == "" || path == "" {
+		return fmt.Errorf("project ID and alias path are required")
+	}
+	normalizedPath := normalizePath(path)
+
+	existing, err := pr.GetByPath(ctx, normalizedPath)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.Path == normalizedPath {
+		return fmt.Errorf("path %s is project %s (%s) - merge it instead", normalizedPath, existing.ID, existing.Name)
+	}
+
+	_, err = pr.db.ExecContext(ctx, `
+		INSERT INTO project_aliases (path, project_id) VALUES (?, ?)
+		ON CONFLICT(path) DO UPDATE SET project_id = excluded.project_id
+	`, normalizedPath, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to add project alias: %w", err)
+	}
+	return nil
+}
+
+// RemoveAlias deletes a path alias; later activity at the path creates a new project
+func (pr *ProjectRepository) RemoveAlias(ctx context.Context, path string) error {
+	result, err := pr.db.ExecContext(ctx, `DELETE FROM project_aliases WHERE path = ?`, normalizePath(path))
+	if err != nil {
+		return fmt.Errorf("failed to remove project alias: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("alias %s not found", normalizePath(path))
+	}
+	return nil
+}
+
+// GetAliases returns the alias paths of a project in path order
+func (pr *ProjectRepository) GetAliases(ctx context.Context, projectID string) ([]string, error) {
+	rows, err := pr.db.QueryContext(ctx,
+		`SELECT path FROM project_aliases WHERE project_id = ? ORDER BY path`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project aliases: %w", err)
+	}
+	defer rows.Close()
+
+	aliases := make([]string, 0)
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, fmt.Errorf("failed to scan project alias: %w", err)
+		}
+		aliases = append(aliases, path)
+	}
+	return aliases, rows.Err()
+}
+
+/**
+ * CONTEXT:   Result of merging one project into another
+ * INPUT:     No input - data structure definition
+ * OUTPUT:    Number of rows moved to the target project
+ * BUSINESS:  Users see what a merge changed
+ * CHANGE:    Count merged daily rollup rows
+ * RISK:      Low - Data structure only
+ */
+type ProjectMergeResult struct {
+	SourceID   string `json:"source_id"`
+	TargetID   string `json:"target_id"`
+	WorkBlocks int64  `json:"work_blocks"`
+	Activities int64  `json:"activities"`
+	Goals      int64  `json:"goals"`
+	Aliases    int64  `json:"aliases"`
+	Children   int64  `json:"children"`
+	Rollups    int64  `json:"rollups"`
+}
+
+/**
+ * CONTEXT:   Merge a duplicate project into a canonical one
+ * INPUT:     Source and target project IDs
+ * OUTPUT:    Source work blocks, activities, goals, aliases, child projects and daily
+ *            rollups moved to the target; source path kept as an alias and the source
+ *            project deleted
+ * BUSINESS:  Cleans up duplicates and "Unknown" projects without losing tracked time
+ * CHANGE:    Sum purged-history rollups into the target; reject merging into a descendant
+ * RISK:      High - Rewrites history; runs in one transaction so it applies fully or not at all
+ */
+func (pr *ProjectRepository) Merge(ctx context.Context, sourceID, targetID string) (*ProjectMergeResult, error) {
+	if sourceID == "" || targetID == "" {
+		return nil, fmt.Errorf("source and target project IDs are required")
+	}
+	if sourceID == targetID {
+		return nil, fmt.Errorf("cannot merge project %s into itself", sourceID)
+	}
+
+	source, err := pr.GetByID(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	targetLineage, err := pr.GetAncestors(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ancestor := range targetLineage[1:] {
+		if ancestor.ID == sourceID {
+			return nil, fmt.Errorf("cannot merge project %s into its sub-project %s; move %s out first", sourceID, targetID, targetID)
+		}
+	}
+
+	tx, err := pr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin merge: %w", err)
+	}
+	defer tx.Rollback()
+
+	result := &ProjectMergeResult{SourceID: sourceID, TargetID: targetID}
+	moves := []struct {
+		query string
+		count *int64
+	}{
+		{`UPDATE work_blocks SET project_id = ? WHERE project_id = ?`, &result.WorkBlocks},
+		{`UPDATE activity_events SET project_id = ? WHERE project_id = ?`, &result.Activities},
+		{`UPDATE goals SET project_id = ? WHERE project_id = ?`, &result.Goals},
+		{`UPDATE project_aliases SET project_id = ? WHERE project_id = ?`, &result.Aliases},
+	}
+	for _, move := range moves {
+		res, err := tx.ExecContext(ctx, move.query, targetID, sourceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge project: %w", err)
+		}
+		*move.count, _ = res.RowsAffected()
+	}
+
+	// Rollups of purged days share a primary key per project, so add them up
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO daily_rollups (user_id, day, project_id, work_blocks, work_seconds, activity_count, updated_at)
+		SELECT user_id, day, ?, work_blocks, work_seconds, activity_count, ?
+		FROM daily_rollups WHERE project_id = ?
+		ON CONFLICT (user_id, day, project_id) DO UPDATE SET
+			work_blocks = work_blocks + excluded.work_blocks,
+			work_seconds = work_seconds + excluded.work_seconds,
+			activity_count = activity_count + excluded.activity_count,
+			updated_at = excluded.updated_at
+	`, targetID, time.Now(), sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge daily rollups: %w", err)
+	}
+	result.Rollups, _ = res.RowsAffected()
+	if _, err := tx.ExecContext(ctx, `DELETE FROM daily_rollups WHERE project_id = ?`, sourceID); err != nil {
+		return nil, fmt.Errorf("failed to merge daily rollups: %w", err)
+	}
+
+	// Children of the source move under the target
+	res, err = tx.ExecContext(ctx, `UPDATE projects SET parent_id = ? WHERE parent_id = ?`, targetID, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge project hierarchy: %w", err)
+	}
+	result.Children, _ = res.RowsAffected()
+
+	// Keep resolving the source path to the merged project
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO project_aliases (path, project_id) VALUES (?, ?)
+		ON CONFLICT(path) DO UPDATE SET project_id = excluded.project_id
+	`, source.Path, targetID); err != nil {
+		return nil, fmt.Errorf("failed to alias merged project path: %w", err)
+	}
+	result.Aliases++
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM projects WHERE id = ?`, sourceID); err != nil {
+		return nil, fmt.Errorf("failed to delete merged project: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE projects SET updated_at = ? WHERE id = ?`, time.Now(), targetID); err != nil {
+		return nil, fmt.Errorf("failed to update merged project: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit merge: %w", err)
+	}
+	return result, nil
+}
+
+/**
+ * CONTEXT:   Tracked work totals for one project
+ * INPUT:     No input - data structure definition
+ * OUTPUT:    Work block and activity counts with tracked hours and time span
+ * BUSINESS:  `project show` and `project list` summarize history per project
+ * CHANGE:    Initial project summary
+ * RISK:      Low - Data structure only
+ */
+type ProjectSummary struct {
+	WorkBlocks    int64      `json:"work_blocks"`
+	Activities    int64      `json:"activities"`
+	TotalHours    float64    `json:"total_hours"`
+	FirstActivity *time.Time `json:"first_activity,omitempty"`
+	LastActivity  *time.Time `json:"last_activity,omitempty"`
+}
+
+/**
+ * CONTEXT:   Summarize tracked work for a project
+ * INPUT:     Project ID
+ * OUTPUT:    Project summary over all work blocks
+ * BUSINESS:  Helps decide which duplicate to keep before a merge
+ * CHANGE:    Initial project summary query
+ * RISK:      Low - Indexed aggregate queries
+ */
+func (pr *ProjectRepository) GetSummary(ctx context.Context, projectID string) (*ProjectSummary, error) {
+	summary := &ProjectSummary{}
+	err := pr.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(activity_count), 0), COALESCE(SUM(duration_hours), 0)
+		FROM work_blocks WHERE project_id = ?
+	`, projectID).Scan(&summary.WorkBlocks, &summary.Activities, &summary.TotalHours)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize project: %w", err)
+	}
+	if summary.WorkBlocks == 0 {
+		return summary, nil
+	}
+
+	// Aggregates lose the DATETIME column type, so read the bounds as rows
+	var first, last sql.NullTime
+	if err := pr.db.QueryRowContext(ctx,
+		`SELECT start_time FROM work_blocks WHERE project_id = ? ORDER BY start_time ASC LIMIT 1`,
+		projectID).Scan(&first); err != nil {
+		return nil, fmt.Errorf("failed to get first project activity: %w", err)
+	}
+	if err := pr.db.QueryRowContext(ctx,
+		`SELECT last_activity_time FROM work_blocks WHERE project_id = ? ORDER BY last_activity_time DESC LIMIT 1`,
+		projectID).Scan(&last); err != nil {
+		return nil, fmt.Errorf("failed to get last project activity: %w", err)
+	}
+	if first.Valid {
+		summary.FirstActivity = &first.Time
+	}
+	if last.Valid {
+		summary.LastActivity = &last.Time
+	}
+	return summary, nil
+}
+
 // Helper functions for project path and name normalization
 
+// projectIDForPath derives a stable project ID from a normalized path
+func projectIDForPath(path string) string {
+	sum := sha256.Sum256([]byte(path))
+	return fmt.Sprintf("proj_%x", sum[:8])
+}
+
 /**
  * CONTEXT:   Normalize project path for consistent database storage
  * INPUT:     Raw project path from working directory
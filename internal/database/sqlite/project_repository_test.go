@@ -0,0 +1,133 @@
+/**
+ * CONTEXT:   Tests for project merging
+ * INPUT:     Temp database with duplicate projects, manual work blocks, goals and rollups
+ * OUTPUT:    Validation that a merge moves all history in one transaction
+ * BUSINESS:  A merge must never lose tracked time or leave rows pointing at a deleted project
+ * CHANGE:    Initial project merge tests
+ * RISK:      Low - Temp database per test
+ */
+
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectRepositoryMerge(t *testing.T) {
+	db := createTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	projects := NewProjectRepository(db.DB())
+	entries := NewEntryRepository(db)
+	goals := NewGoalRepository(db.DB())
+
+	canonical, err := projects.GetOrCreateNamed(ctx, "/work/api", "api")
+	require.NoError(t, err)
+	duplicate, err := projects.GetOrCreateNamed(ctx, "/checkouts/api-2", "api-2")
+	require.NoError(t, err)
+	require.NoError(t, projects.AddAlias(ctx, duplicate.ID, "/tmp/api-worktree"))
+
+	day := time.Date(2026, 3, 4, 9, 0, 0, 0, time.Local)
+	for i, projectID := range []string{canonical.ID, duplicate.ID, duplicate.ID} {
+		start := day.Add(time.Duration(i) * time.Hour)
+		_, err := entries.Add(ctx, ManualEntry{
+			UserID: "alice", ProjectID: projectID,
+			StartTime: start, EndTime: start.Add(30 * time.Minute), Reason: "test",
+		})
+		require.NoError(t, err)
+	}
+
+	goal := &Goal{UserID: "alice", Name: "api", Period: GoalPeriodWeekly, Kind: GoalKindTarget, ProjectID: duplicate.ID, TargetHours: 10}
+	require.NoError(t, goals.Create(ctx, goal))
+
+	// Rollups of purged days: one day collides with the target, one does not
+	insertRollup := func(day, projectID string, blocks, seconds, activities int) {
+		_, err := db.DB().ExecContext(ctx, `
+			INSERT INTO daily_rollups (user_id, day, project_id, work_blocks, work_seconds, activity_count)
+			VALUES ('alice', ?, ?, ?, ?, ?)`, day, projectID, blocks, seconds, activities)
+		require.NoError(t, err)
+	}
+	insertRollup("2025-01-10", canonical.ID, 2, 3600, 20)
+	insertRollup("2025-01-10", duplicate.ID, 1, 1800, 5)
+	insertRollup("2025-01-11", duplicate.ID, 3, 7200, 30)
+
+	result, err := projects.Merge(ctx, duplicate.ID, canonical.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, result.WorkBlocks)
+	assert.EqualValues(t, 2, result.Activities)
+	assert.EqualValues(t, 1, result.Goals)
+	assert.EqualValues(t, 2, result.Rollups)
+	assert.EqualValues(t, 2, result.Aliases, "existing alias moved plus the source path")
+
+	// Source project is gone and its paths resolve to the target
+	_, err = projects.GetByID(ctx, duplicate.ID)
+	assert.Error(t, err)
+	for _, path := range []string{"/checkouts/api-2", "/tmp/api-worktree"} {
+		resolved, err := projects.GetOrCreate(ctx, path)
+		require.NoError(t, err)
+		assert.Equal(t, canonical.ID, resolved.ID, path)
+	}
+
+	countRows := func(query string, args ...interface{}) int {
+		var count int
+		require.NoError(t, db.DB().QueryRowContext(ctx, query, args...).Scan(&count))
+		return count
+	}
+	assert.Equal(t, 3, countRows(`SELECT COUNT(*) FROM work_blocks WHERE project_id = ?`, canonical.ID))
+	assert.Equal(t, 3, countRows(`SELECT COUNT(*) FROM activity_events WHERE project_id = ?`, canonical.ID))
+	assert.Zero(t, countRows(`SELECT COUNT(*) FROM work_blocks WHERE project_id = ?`, duplicate.ID))
+
+	moved, err := goals.GetByID(ctx, goal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, canonical.ID, moved.ProjectID)
+
+	assert.Zero(t, countRows(`SELECT COUNT(*) FROM daily_rollups WHERE project_id = ?`, duplicate.ID))
+	var rows, blockSum, seconds, activities int
+	require.NoError(t, db.DB().QueryRowContext(ctx, `
+		SELECT COUNT(*), SUM(work_blocks), SUM(work_seconds), SUM(activity_count)
+		FROM daily_rollups WHERE project_id = ?`, canonical.ID).Scan(&rows, &blockSum, &seconds, &activities))
+	assert.Equal(t, 2, rows, "colliding days are summed into one row")
+	assert.Equal(t, 6, blockSum)
+	assert.Equal(t, 12600, seconds)
+	assert.Equal(t, 55, activities)
+	require.NoError(t, db.DB().QueryRowContext(ctx, `
+		SELECT work_seconds FROM daily_rollups WHERE project_id = ? AND day = '2025-01-10'`, canonical.ID).Scan(&seconds))
+	assert.Equal(t, 5400, seconds)
+}
+
+func TestProjectRepositoryMergeRejectsDescendantAndSelf(t *testing.T) {
+	db := createTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	projects := NewProjectRepository(db.DB())
+
+	client, err := projects.GetOrCreateNamed(ctx, "/work/acme", "acme")
+	require.NoError(t, err)
+	api, err := projects.GetOrCreateNamed(ctx, "/work/acme/api", "acme-api")
+	require.NoError(t, err)
+	require.NoError(t, projects.SetParent(ctx, api.ID, client.ID))
+
+	_, err = projects.Merge(ctx, client.ID, api.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sub-project")
+
+	_, err = projects.Merge(ctx, api.ID, api.ID)
+	assert.Error(t, err)
+
+	// A rejected merge leaves both projects in place
+	for _, id := range []string{client.ID, api.ID} {
+		_, err := projects.GetByID(ctx, id)
+		assert.NoError(t, err)
+	}
+
+	// Merging the child into its parent is fine
+	_, err = projects.Merge(ctx, api.ID, client.ID)
+	require.NoError(t, err)
+}
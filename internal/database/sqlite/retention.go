@@ -0,0 +1,360 @@
+/**
+ * CONTEXT:   Data retention for raw activity events and work blocks
+ * INPUT:     Retention policy in days per table and batch limits
+ * OUTPUT:    Set-based purges in short transactions, daily rollups, vacuum results
+ * BUSINESS:  Raw events and work blocks are kept for a configurable time; daily
+ *            rollups of purged work blocks are kept forever and read back by reports
+ * CHANGE:    Added the DailyRollup row read by the report generators
+ * RISK:      High - Deletes data; every batch is a bounded transaction so hook writes
+ *            are never blocked for long, and open work blocks are never purged
+ */
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Purge batch defaults used when a policy leaves them unset
+const (
+	defaultPurgeBatchSize  = 500
+	defaultPurgeBatchPause = 50 * time.Millisecond
+)
+
+// Vacuum modes reported by Vacuum
+const (
+	VacuumModeFull        = "full"
+	VacuumModeIncremental = "incremental"
+	VacuumModeSkipped     = "skipped"
+)
+
+// autoVacuumIncremental is the PRAGMA auto_vacuum value for incremental mode
+const autoVacuumIncremental = 2
+
+// purgeWorkBlocksBatch selects the oldest finished work blocks past the cutoff;
+// julianday() compares instants because idle closes from older releases stored
+// offset-less UTC text next to local timestamps
+const purgeWorkBlocksBatch = `SELECT id FROM work_blocks
+	WHERE end_time IS NOT NULL AND julianday(end_time) < julianday(?)
+	ORDER BY julianday(end_time), id LIMIT ?`
+
+/**
+ * CONTEXT:   Retention settings for one purge run
+ * INPUT:     No input - data structure definition
+ * OUTPUT:    Days to keep per table and batch limits
+ * BUSINESS:  Zero days keeps a table forever so retention is opt-in per table
+ * CHANGE:    Initial retention policy
+ * RISK:      Low - Data structure only
+ */
+type RetentionPolicy struct {
+	ActivityEventsDays int
+	WorkBlocksDays     int
+	BatchSize          int
+	BatchPause         time.Duration
+}
+
+// IsEmpty reports whether the policy keeps every table forever
+func (p RetentionPolicy) IsEmpty() bool {
+	return p.ActivityEventsDays <= 0 && p.WorkBlocksDays <= 0
+}
+
+// Validate rejects negative retention days and batch sizes
+func (p RetentionPolicy) Validate() error {
+	if p.ActivityEventsDays < 0 || p.WorkBlocksDays < 0 {
+		return fmt.Errorf("retention days cannot be negative")
+	}
+	if p.BatchSize < 0 {
+		return fmt.Errorf("purge batch size cannot be negative")
+	}
+	return nil
+}
+
+// retentionCutoff returns the time before which rows are purged, nil to keep the table
+func retentionCutoff(days int, now time.Time) *time.Time {
+	if days <= 0 {
+		return nil
+	}
+	cutoff := now.AddDate(0, 0, -days)
+	return &cutoff
+}
+
+// PurgeResult counts the rows a purge removed, or would remove in a preview
+type PurgeResult struct {
+	DryRun               bool       `json:"dry_run"`
+	ActivityEventsCutoff *time.Time `json:"activity_events_cutoff,omitempty"`
+	WorkBlocksCutoff     *time.Time `json:"work_blocks_cutoff,omitempty"`
+	ActivityEvents       int64      `json:"activity_events"`
+	WorkBlocks           int64      `json:"work_blocks"`
+	Sessions             int64      `json:"sessions"`
+	RollupRows           int64      `json:"rollup_rows"`
+	Batches              int        `json:"batches"`
+}
+
+// Total returns the number of purged rows across tables
+func (r *PurgeResult) Total() int64 {
+	return r.ActivityEvents + r.WorkBlocks + r.Sessions
+}
+
+// DailyRollup is the per-project summary of a day's purged work blocks
+type DailyRollup struct {
+	Day           string `json:"day"`
+	ProjectID     string `json:"project_id"`
+	WorkBlocks    int64  `json:"work_blocks"`
+	WorkSeconds   int64  `json:"work_seconds"`
+	ActivityCount int64  `json:"activity_count"`
+}
+
+// VacuumResult reports how free pages were reclaimed
+type VacuumResult struct {
+	Mode       string `json:"mode"`
+	FreedBytes int64  `json:"freed_bytes"`
+}
+
+// RetentionRepository purges expired rows and reclaims space
+type RetentionRepository struct {
+	db *SQLiteDB
+}
+
+// NewRetentionRepository creates a new retention repository
+func NewRetentionRepository(db *SQLiteDB) *RetentionRepository {
+	return &RetentionRepository{db: db}
+}
+
+/**
+ * CONTEXT:   Preview a purge without deleting anything
+ * INPUT:     Retention policy and reference time
+ * OUTPUT:    Row counts that Purge would remove and rollup rows it would write
+ * BUSINESS:  Users check the effect of a retention policy before enabling it
+ * CHANGE:    Compare cutoffs with julianday() so mixed timestamp formats count
+ * RISK:      Low - Read-only aggregate queries
+ */
+func (rr *RetentionRepository) Preview(ctx context.Context, policy RetentionPolicy, now time.Time) (*PurgeResult, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	result := rr.newResult(policy, now)
+	result.DryRun = true
+	conn := rr.db.DB()
+
+	if cutoff := result.ActivityEventsCutoff; cutoff != nil {
+		err := conn.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM activity_events WHERE julianday(timestamp) < julianday(?)`, rr.db.ToDBTime(*cutoff)).
+			Scan(&result.ActivityEvents)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count expired activity events: %w", err)
+		}
+	}
+
+	if cutoff := result.WorkBlocksCutoff; cutoff != nil {
+		dbCutoff := rr.db.ToDBTime(*cutoff)
+		err := conn.QueryRowContext(ctx, `
+			SELECT COUNT(*), COUNT(DISTINCT s.user_id || '|' || substr(wb.start_time, 1, 10) || '|' || wb.project_id)
+			FROM work_blocks wb
+			JOIN sessions s ON s.id = wb.session_id
+			WHERE wb.end_time IS NOT NULL AND julianday(wb.end_time) < julianday(?)`, dbCutoff).
+			Scan(&result.WorkBlocks, &result.RollupRows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count expired work blocks: %w", err)
+		}
+
+		err = conn.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM sessions s
+			WHERE julianday(s.end_time) < julianday(?) AND NOT EXISTS (
+				SELECT 1 FROM work_blocks wb
+				WHERE wb.session_id = s.id AND NOT (wb.end_time IS NOT NULL AND julianday(wb.end_time) < julianday(?)))`,
+			dbCutoff, dbCutoff).Scan(&result.Sessions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count expired sessions: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+/**
+ * CONTEXT:   Purge expired rows in bounded batches
+ * INPUT:     Retention policy, reference time and cancellable context
+ * OUTPUT:    Counts of removed rows and written rollup rows
+ * BUSINESS:  Work blocks are rolled up per user, day and project before deletion;
+ *            sessions left without work blocks go with them
+ * CHANGE:    Compare cutoffs with julianday() so mixed timestamp formats purge
+ * RISK:      High - Deletes data; each batch commits on its own so a cancelled purge
+ *            leaves a consistent, partially purged database
+ */
+func (rr *RetentionRepository) Purge(ctx context.Context, policy RetentionPolicy, now time.Time) (*PurgeResult, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	result := rr.newResult(policy, now)
+	batchSize := policy.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultPurgeBatchSize
+	}
+	pause := policy.BatchPause
+	if pause <= 0 {
+		pause = defaultPurgeBatchPause
+	}
+
+	if cutoff := result.WorkBlocksCutoff; cutoff != nil {
+		dbCutoff := rr.db.ToDBTime(*cutoff)
+		err := rr.purgeInBatches(ctx, result, batchSize, pause, &result.WorkBlocks, func(tx *sql.Tx) (int64, error) {
+			rollup, err := tx.ExecContext(ctx, `
+				INSERT INTO daily_rollups (user_id, day, project_id, work_blocks, work_seconds, activity_count, updated_at)
+				SELECT s.user_id, substr(wb.start_time, 1, 10), wb.project_id,
+				       COUNT(*), SUM(wb.duration_seconds), SUM(wb.activity_count), ?
+				FROM work_blocks wb
+				JOIN sessions s ON s.id = wb.session_id
+				WHERE wb.id IN (`+purgeWorkBlocksBatch+`)
+				GROUP BY s.user_id, substr(wb.start_time, 1, 10), wb.project_id
+				ON CONFLICT (user_id, day, project_id) DO UPDATE SET
+					work_blocks = work_blocks + excluded.work_blocks,
+					work_seconds = work_seconds + excluded.work_seconds,
+					activity_count = activity_count + excluded.activity_count,
+					updated_at = excluded.updated_at`,
+				rr.db.ToDBTime(now), dbCutoff, batchSize)
+			if err != nil {
+				return 0, fmt.Errorf("failed to roll up work blocks: %w", err)
+			}
+			rows, _ := rollup.RowsAffected()
+			result.RollupRows += rows
+
+			return execAffected(ctx, tx, `DELETE FROM work_blocks WHERE id IN (`+purgeWorkBlocksBatch+`)`, dbCutoff, batchSize)
+		})
+		if err != nil {
+			return result, err
+		}
+
+		err = rr.purgeInBatches(ctx, result, batchSize, pause, &result.Sessions, func(tx *sql.Tx) (int64, error) {
+			return execAffected(ctx, tx, `
+				DELETE FROM sessions WHERE id IN (
+					SELECT s.id FROM sessions s
+					WHERE julianday(s.end_time) < julianday(?) AND NOT EXISTS (SELECT 1 FROM work_blocks wb WHERE wb.session_id = s.id)
+					ORDER BY julianday(s.end_time) LIMIT ?)`, dbCutoff, batchSize)
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+
+	if cutoff := result.ActivityEventsCutoff; cutoff != nil {
+		dbCutoff := rr.db.ToDBTime(*cutoff)
+		err := rr.purgeInBatches(ctx, result, batchSize, pause, &result.ActivityEvents, func(tx *sql.Tx) (int64, error) {
+			return execAffected(ctx, tx, `
+				DELETE FROM activity_events WHERE id IN (
+					SELECT id FROM activity_events WHERE julianday(timestamp) < julianday(?) ORDER BY julianday(timestamp) LIMIT ?)`,
+				dbCutoff, batchSize)
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+/**
+ * CONTEXT:   Reclaim free pages after a purge
+ * INPUT:     Whether a full VACUUM may run
+ * OUTPUT:    Vacuum mode used and bytes returned to the file system
+ * BUSINESS:  A full VACUUM also switches the database to incremental auto-vacuum so
+ *            later scheduled purges can reclaim space without rewriting the file
+ * CHANGE:    Initial vacuum step
+ * RISK:      Medium - Full VACUUM rewrites the database and blocks writers while it runs
+ */
+func (rr *RetentionRepository) Vacuum(ctx context.Context, full bool) (*VacuumResult, error) {
+	conn, err := rr.db.DB().Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+	defer conn.Close()
+
+	var pageSize, freeBefore, freeAfter int64
+	var autoVacuum int
+	if err := conn.QueryRowContext(ctx, `PRAGMA page_size`).Scan(&pageSize); err != nil {
+		return nil, fmt.Errorf("failed to read page size: %w", err)
+	}
+	if err := conn.QueryRowContext(ctx, `PRAGMA freelist_count`).Scan(&freeBefore); err != nil {
+		return nil, fmt.Errorf("failed to read free page count: %w", err)
+	}
+	if err := conn.QueryRowContext(ctx, `PRAGMA auto_vacuum`).Scan(&autoVacuum); err != nil {
+		return nil, fmt.Errorf("failed to read auto_vacuum mode: %w", err)
+	}
+
+	result := &VacuumResult{Mode: VacuumModeSkipped}
+	switch {
+	case full:
+		if autoVacuum != autoVacuumIncremental {
+			if _, err := conn.ExecContext(ctx, `PRAGMA auto_vacuum = INCREMENTAL`); err != nil {
+				return nil, fmt.Errorf("failed to enable incremental auto_vacuum: %w", err)
+			}
+		}
+		if _, err := conn.ExecContext(ctx, `VACUUM`); err != nil {
+			return nil, fmt.Errorf("failed to vacuum database: %w", err)
+		}
+		result.Mode = VacuumModeFull
+	case autoVacuum == autoVacuumIncremental:
+		if _, err := conn.ExecContext(ctx, `PRAGMA incremental_vacuum`); err != nil {
+			return nil, fmt.Errorf("failed to run incremental vacuum: %w", err)
+		}
+		result.Mode = VacuumModeIncremental
+	default:
+		return result, nil
+	}
+
+	if err := conn.QueryRowContext(ctx, `PRAGMA freelist_count`).Scan(&freeAfter); err != nil {
+		return nil, fmt.Errorf("failed to read free page count: %w", err)
+	}
+	if freeBefore > freeAfter {
+		result.FreedBytes = (freeBefore - freeAfter) * pageSize
+	}
+	return result, nil
+}
+
+// newResult fills the cutoffs of a purge result from the policy
+func (rr *RetentionRepository) newResult(policy RetentionPolicy, now time.Time) *PurgeResult {
+	return &PurgeResult{
+		ActivityEventsCutoff: retentionCutoff(policy.ActivityEventsDays, now),
+		WorkBlocksCutoff:     retentionCutoff(policy.WorkBlocksDays, now),
+	}
+}
+
+// purgeInBatches runs batch in its own transaction until it removes fewer rows than a full batch
+func (rr *RetentionRepository) purgeInBatches(ctx context.Context, result *PurgeResult, batchSize int, pause time.Duration, counter *int64, batch func(*sql.Tx) (int64, error)) error {
+	for {
+		var removed int64
+		err := rr.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+			var err error
+			removed, err = batch(tx)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if removed > 0 {
+			result.Batches++
+			*counter += removed
+		}
+		if removed < int64(batchSize) {
+			return nil
+		}
+
+		// Let the hook's writes in between batches
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pause):
+		}
+	}
+}
+
+// execAffected runs a statement and returns the number of affected rows
+func execAffected(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) (int64, error) {
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge rows: %w", err)
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,117 @@
+/**
+ * CONTEXT:   Tests for retention purges and daily rollups
+ * INPUT:     Recent hook activity and an old manual entry in a temp database
+ * OUTPUT:    Validation of preview counts, purged rows, rollups and idempotent reruns
+ * BUSINESS:  Retention may drop raw rows but never the hours they recorded
+ * CHANGE:    Initial retention tests
+ * RISK:      Low - Temp database per test
+ */
+
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/tracking"
+)
+
+func TestRetentionPurge(t *testing.T) {
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(t.TempDir(), "retention.db")))
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	activity, err := tracking.NewActivityTracker(db).Record(ctx, tracking.ActivityEvent{UserID: "alice", ProjectPath: "/work/api"})
+	require.NoError(t, err)
+
+	now := time.Now()
+	old, err := sqlite.NewEntryRepository(db).Add(ctx, sqlite.ManualEntry{
+		UserID:    "alice",
+		ProjectID: activity.ProjectID,
+		StartTime: now.AddDate(0, 0, -40),
+		EndTime:   now.AddDate(0, 0, -40).Add(2 * time.Hour),
+		Reason:    "old work",
+	})
+	require.NoError(t, err)
+
+	retention := sqlite.NewRetentionRepository(db)
+	policy := sqlite.RetentionPolicy{ActivityEventsDays: 30, WorkBlocksDays: 30, BatchSize: 1}
+	preview, err := retention.Preview(ctx, policy, db.Now())
+	require.NoError(t, err)
+	assert.True(t, preview.DryRun)
+	assert.Equal(t, int64(1), preview.ActivityEvents)
+	assert.Equal(t, int64(1), preview.WorkBlocks)
+	assert.Equal(t, int64(1), preview.Sessions)
+
+	result, err := retention.Purge(ctx, policy, db.Now())
+	require.NoError(t, err)
+	assert.Equal(t, preview.ActivityEvents, result.ActivityEvents)
+	assert.Equal(t, preview.WorkBlocks, result.WorkBlocks)
+	assert.Equal(t, preview.Sessions, result.Sessions)
+	assert.Equal(t, int64(1), result.RollupRows)
+
+	blocks := sqlite.NewWorkBlockRepository(db.DB())
+	_, err = blocks.GetByID(ctx, old.ID)
+	assert.ErrorIs(t, err, sqlite.ErrWorkBlockNotFound)
+	_, err = blocks.GetByID(ctx, activity.WorkBlockID)
+	assert.NoError(t, err, "open blocks are never purged")
+
+	var seconds int64
+	require.NoError(t, db.DB().QueryRowContext(ctx,
+		`SELECT work_seconds FROM daily_rollups WHERE user_id = ? AND project_id = ?`,
+		"alice", activity.ProjectID).Scan(&seconds))
+	assert.Equal(t, int64(7200), seconds)
+
+	rollups, err := blocks.GetDailyRollups(ctx, "alice", db.ToDBTime(old.StartTime).Format("2006-01-02"))
+	require.NoError(t, err)
+	require.Len(t, rollups, 1)
+	assert.Equal(t, activity.ProjectID, rollups[0].ProjectID)
+	assert.Equal(t, int64(1), rollups[0].WorkBlocks)
+	assert.Equal(t, int64(7200), rollups[0].WorkSeconds)
+
+	again, err := retention.Purge(ctx, policy, db.Now())
+	require.NoError(t, err)
+	assert.Zero(t, again.Total())
+
+	_, err = retention.Vacuum(ctx, true)
+	assert.NoError(t, err)
+}
+
+func TestRetentionPurgeLegacyIdleClose(t *testing.T) {
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(t.TempDir(), "legacy.db")))
+	require.NoError(t, err)
+	defer db.Close()
+	require.NotEqual(t, time.UTC, db.Timezone(), "the bug only shows with an offset")
+
+	ctx := context.Background()
+	project, err := sqlite.NewProjectRepository(db.DB()).GetOrCreate(ctx, "/work/api")
+	require.NoError(t, err)
+
+	// An hour past the cutoff, closed by an older release that stored offset-less UTC
+	now := db.Now()
+	end := now.AddDate(0, 0, -30).Add(-time.Hour)
+	block, err := sqlite.NewEntryRepository(db).Add(ctx, sqlite.ManualEntry{
+		UserID: "alice", ProjectID: project.ID, StartTime: end.Add(-time.Hour), EndTime: end, Reason: "old work",
+	})
+	require.NoError(t, err)
+	_, err = db.DB().ExecContext(ctx, `UPDATE work_blocks SET end_time = ? WHERE id = ?`,
+		end.UTC().Format("2006-01-02 15:04:05"), block.ID)
+	require.NoError(t, err)
+
+	retention := sqlite.NewRetentionRepository(db)
+	policy := sqlite.RetentionPolicy{WorkBlocksDays: 30}
+	preview, err := retention.Preview(ctx, policy, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), preview.WorkBlocks)
+
+	result, err := retention.Purge(ctx, policy, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.WorkBlocks)
+}
@@ -0,0 +1,256 @@
+/**
+ * CONTEXT:   Incremental schema upgrades applied on top of the embedded base schema
+ * INPUT:     Ordered list of versioned upgrade statements and current schema_version rows
+ * OUTPUT:    Database upgraded to the latest schema version with version rows recorded
+ * BUSINESS:  New features add tables and columns without breaking existing databases
+ * CHANGE:    Initial upgrade runner; schema.sql remains version 1
+ * RISK:      Medium - Upgrades alter persistent schema and must stay append-only
+ */
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// schemaUpgrade describes a single versioned schema change
+type schemaUpgrade struct {
+	version     int
+	description string
+	statements  []string
+}
+
+/**
+ * CONTEXT:   Ordered list of schema upgrades after the base schema
+ * INPUT:     No input - static upgrade definitions
+ * OUTPUT:    Upgrade statements keyed by schema version
+ * BUSINESS:  Append-only list keeps upgrade history reproducible for every install
+ * CHANGE:    Version 2 adds goals and budgets, version 3 hook tool names, version 4 project
+ *            archive and aliases, version 5 rule-assigned project group and tags,
+ *            version 6 git branch and commit on activities and work blocks, version 7
+ *            project hierarchy and client, version 8 free-form work block and session tags,
+ *            version 9 manual work blocks and the work block audit trail, version 10
+ *            daily rollups kept when retention purges work blocks, version 11 the
+ *            Claude Code session ID that keys parallel work blocks
+ * RISK:      Medium - Never edit or reorder released upgrades, only append
+ */
+var schemaUpgrades = []schemaUpgrade{
+	{
+		version:     2,
+		description: "Goals and budgets with daily, weekly and monthly periods",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS goals (
+				id TEXT PRIMARY KEY,
+				user_id TEXT NOT NULL,
+				name TEXT NOT NULL,
+				period TEXT NOT NULL CHECK (period IN ('daily', 'weekly', 'monthly')),
+				kind TEXT NOT NULL CHECK (kind IN ('target', 'cap')),
+				metric TEXT NOT NULL DEFAULT 'work_hours' CHECK (metric IN ('work_hours', 'deep_work_hours')),
+				project_id TEXT,
+				target_hours REAL NOT NULL CHECK (target_hours > 0),
+				created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_goals_user_id ON goals(user_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_goals_project_id ON goals(project_id)`,
+		},
+	},
+	{
+		version:     3,
+		description: "Tool name reported by the Claude Code hook on activity events",
+		statements: []string{
+			`ALTER TABLE activity_events ADD COLUMN tool_name TEXT`,
+			`CREATE INDEX IF NOT EXISTS idx_activity_events_tool_name ON activity_events(tool_name)`,
+		},
+	},
+	{
+		version:     4,
+		description: "Project archiving and path aliases",
+		statements: []string{
+			`ALTER TABLE projects ADD COLUMN archived_at DATETIME`,
+			`CREATE TABLE IF NOT EXISTS project_aliases (
+				path TEXT PRIMARY KEY,
+				project_id TEXT NOT NULL,
+				created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_project_aliases_project_id ON project_aliases(project_id)`,
+		},
+	},
+	{
+		version:     5,
+		description: "Project group and tags assigned by path rules",
+		statements: []string{
+			`ALTER TABLE projects ADD COLUMN group_name TEXT`,
+			`ALTER TABLE projects ADD COLUMN tags TEXT`,
+		},
+	},
+	{
+		version:     6,
+		description: "Git branch and commit captured by the hook",
+		statements: []string{
+			`ALTER TABLE activity_events ADD COLUMN git_branch TEXT`,
+			`ALTER TABLE activity_events ADD COLUMN git_commit TEXT`,
+			`ALTER TABLE work_blocks ADD COLUMN git_branch TEXT`,
+			`CREATE INDEX IF NOT EXISTS idx_work_blocks_git_branch ON work_blocks(git_branch)`,
+		},
+	},
+	{
+		version:     7,
+		description: "Project parent and client for hierarchical reports",
+		statements: []string{
+			`ALTER TABLE projects ADD COLUMN parent_id TEXT REFERENCES projects(id) ON DELETE SET NULL`,
+			`ALTER TABLE projects ADD COLUMN client TEXT`,
+			`CREATE INDEX IF NOT EXISTS idx_projects_parent_id ON projects(parent_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_projects_client ON projects(client)`,
+		},
+	},
+	{
+		version:     8,
+		description: "Free-form tags on work blocks and sessions",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS tags (
+				id TEXT PRIMARY KEY,
+				name TEXT NOT NULL UNIQUE,
+				created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+			)`,
+			`CREATE TABLE IF NOT EXISTS work_block_tags (
+				work_block_id TEXT NOT NULL,
+				tag_id TEXT NOT NULL,
+				source TEXT NOT NULL DEFAULT 'manual',
+				created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (work_block_id, tag_id),
+				FOREIGN KEY (work_block_id) REFERENCES work_blocks(id) ON DELETE CASCADE,
+				FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_work_block_tags_tag_id ON work_block_tags(tag_id)`,
+			`CREATE TABLE IF NOT EXISTS session_tags (
+				session_id TEXT NOT NULL,
+				tag_id TEXT NOT NULL,
+				source TEXT NOT NULL DEFAULT 'manual',
+				created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (session_id, tag_id),
+				FOREIGN KEY (session_id) REFERENCES sessions(id) ON DELETE CASCADE,
+				FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_session_tags_tag_id ON session_tags(tag_id)`,
+		},
+	},
+	{
+		version:     9,
+		description: "Manual work blocks and audited corrections",
+		statements: []string{
+			`ALTER TABLE work_blocks ADD COLUMN source TEXT NOT NULL DEFAULT 'hook' CHECK (source IN ('hook', 'manual'))`,
+			`ALTER TABLE work_blocks ADD COLUMN edited_at DATETIME`,
+			`CREATE TABLE IF NOT EXISTS work_block_audit (
+				id TEXT PRIMARY KEY,
+				work_block_id TEXT NOT NULL,
+				user_id TEXT NOT NULL,
+				action TEXT NOT NULL CHECK (action IN ('create', 'edit', 'delete')),
+				before_state TEXT,
+				after_state TEXT,
+				reason TEXT NOT NULL CHECK (length(trim(reason)) > 0),
+				created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				CHECK (action = 'create' OR before_state IS NOT NULL),
+				CHECK (action = 'delete' OR after_state IS NOT NULL)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_work_block_audit_block ON work_block_audit(work_block_id, created_at)`,
+			`CREATE INDEX IF NOT EXISTS idx_work_block_audit_created ON work_block_audit(created_at)`,
+		},
+	},
+	{
+		version:     10,
+		description: "Daily rollups of purged work blocks",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS daily_rollups (
+				user_id TEXT NOT NULL,
+				day TEXT NOT NULL,
+				project_id TEXT NOT NULL,
+				work_blocks INTEGER NOT NULL DEFAULT 0 CHECK (work_blocks >= 0),
+				work_seconds INTEGER NOT NULL DEFAULT 0 CHECK (work_seconds >= 0),
+				activity_count INTEGER NOT NULL DEFAULT 0 CHECK (activity_count >= 0),
+				updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (user_id, day, project_id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_work_blocks_end_time ON work_blocks(end_time)`,
+		},
+	},
+	{
+		version:     11,
+		description: "Claude Code session ID on work blocks",
+		statements: []string{
+			`ALTER TABLE work_blocks ADD COLUMN claude_session_id TEXT`,
+			`CREATE INDEX IF NOT EXISTS idx_work_blocks_claude_session ON work_blocks(session_id, project_id, claude_session_id)`,
+		},
+	},
+}
+
+/**
+ * CONTEXT:   Apply pending schema upgrades in version order
+ * INPUT:     Context for timeout and cancellation
+ * OUTPUT:    Each pending upgrade applied in its own transaction with version row
+ * BUSINESS:  Existing databases pick up new features on next start
+ * CHANGE:    Initial upgrade runner
+ * RISK:      Medium - A failing upgrade aborts initialization to avoid partial schemas
+ */
+func (db *SQLiteDB) applySchemaUpgrades(ctx context.Context) error {
+	var current int
+	if err := db.db.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&current); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	for _, upgrade := range schemaUpgrades {
+		if upgrade.version <= current {
+			continue
+		}
+
+		err := db.withTx(ctx, func(tx *sql.Tx) error {
+			for _, statement := range upgrade.statements {
+				if _, err := tx.ExecContext(ctx, statement); err != nil {
+					return err
+				}
+			}
+			_, err := tx.ExecContext(ctx,
+				"INSERT INTO schema_version (version, description) VALUES (?, ?)",
+				upgrade.version, upgrade.description)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply schema upgrade %d (%s): %w", upgrade.version, upgrade.description, err)
+		}
+	}
+
+	return nil
+}
+
+// LatestSchemaVersion returns the schema version this binary upgrades databases to
+func LatestSchemaVersion() int {
+	if len(schemaUpgrades) == 0 {
+		return 1
+	}
+	return schemaUpgrades[len(schemaUpgrades)-1].version
+}
+
+// SchemaVersion returns the highest schema version applied to the database
+func (db *SQLiteDB) SchemaVersion(ctx context.Context) (int, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return schemaVersion(ctx, db.db)
+}
+
+// withTx runs fn in a transaction without taking the connection mutex
+func (db *SQLiteDB) withTx(ctx context.Context, fn func(*sql.Tx) error) error {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -3,7 +3,7 @@
  * INPUT:     Session entities and query parameters for CRUD operations
  * OUTPUT:    Session data with proper error handling and transaction support
  * BUSINESS:  Session management following 5-hour window business rules
- * CHANGE:    Initial SQLite repository replacing gob-based session storage
+ * CHANGE:    Structured slog logging; per-row session writes log at debug
  * RISK:      Low - Standard repository pattern with prepared statements and error handling
  */
 
@@ -13,7 +13,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
 	"strings"
 	"time"
 )
@@ -66,10 +66,11 @@ func (r *SessionRepository) Create(ctx context.Context, session *Session) error
 		return fmt.Errorf("failed to create session: %w", err)
 	}
 
-	log.Printf("✅ Created session: %s (user: %s, period: %s - %s)",
-		session.ID, session.UserID, 
-		session.StartTime.Format("2006-01-02 15:04:05"),
-		session.EndTime.Format("2006-01-02 15:04:05"))
+	slog.DebugContext(ctx, "Created session",
+		"session_id", session.ID,
+		"user_id", session.UserID,
+		"start_time", session.StartTime,
+		"end_time", session.EndTime)
 
 	return nil
 }
@@ -185,7 +186,7 @@ func (r *SessionRepository) FindByUserAndTimeRange(ctx context.Context, userID s
  * INPUT:     User ID for filtering active sessions
  * OUTPUT:    List of currently active sessions
  * BUSINESS:  Active sessions are within their 5-hour window
- * CHANGE:    Initial active session querying with time-based logic
+ * CHANGE:    Compare instants with julianday() rather than as text
  * RISK:      Low - Time-based filtering with current timestamp
  */
 func (r *SessionRepository) GetActiveSessionsByUser(ctx context.Context, userID string) ([]*Session, error) {
@@ -199,7 +200,7 @@ func (r *SessionRepository) GetActiveSessionsByUser(ctx context.Context, userID
 		SELECT id, user_id, start_time, end_time, state, first_activity_time,
 			   last_activity_time, activity_count, duration_hours, created_at, updated_at
 		FROM sessions
-		WHERE user_id = ? AND state = 'active' AND ? <= end_time
+		WHERE user_id = ? AND state = 'active' AND julianday(?) <= julianday(end_time)
 		ORDER BY start_time DESC
 	`
 
@@ -292,8 +293,8 @@ func (r *SessionRepository) Update(ctx context.Context, session *Session) error
 		return fmt.Errorf("session not found for update: %s", session.ID)
 	}
 
-	log.Printf("✅ Updated session: %s (activities: %d, state: %s)",
-		session.ID, session.ActivityCount, session.State)
+	slog.DebugContext(ctx, "Updated session",
+		"session_id", session.ID, "activity_count", session.ActivityCount, "state", session.State)
 
 	return nil
 }
@@ -328,7 +329,7 @@ func (r *SessionRepository) Delete(ctx context.Context, sessionID string) error
 			return fmt.Errorf("session not found for deletion: %s", sessionID)
 		}
 
-		log.Printf("🗑️  Deleted session: %s (with cascading deletes)", sessionID)
+		slog.InfoContext(ctx, "Deleted session with cascading deletes", "session_id", sessionID)
 		return nil
 	})
 }
@@ -338,7 +339,7 @@ func (r *SessionRepository) Delete(ctx context.Context, sessionID string) error
  * INPUT:     Context for query timeout
  * OUTPUT:    Number of sessions marked as expired
  * BUSINESS:  Sessions expire when current time > end_time
- * CHANGE:    Initial session expiration batch processing
+ * CHANGE:    Compare instants with julianday() rather than as text
  * RISK:      Low - Bulk update with time-based filtering
  */
 func (r *SessionRepository) MarkExpiredSessions(ctx context.Context) (int, error) {
@@ -347,7 +348,7 @@ func (r *SessionRepository) MarkExpiredSessions(ctx context.Context) (int, error
 	query := `
 		UPDATE sessions 
 		SET state = 'expired', updated_at = ?
-		WHERE state = 'active' AND ? > end_time
+		WHERE state = 'active' AND julianday(?) > julianday(end_time)
 	`
 
 	result, err := r.db.DB().ExecContext(ctx, query, currentTime, currentTime)
@@ -361,7 +362,7 @@ func (r *SessionRepository) MarkExpiredSessions(ctx context.Context) (int, error
 	}
 
 	if rowsAffected > 0 {
-		log.Printf("⏰ Marked %d sessions as expired", rowsAffected)
+		slog.InfoContext(ctx, "Marked sessions as expired", "count", rowsAffected)
 	}
 
 	return int(rowsAffected), nil
@@ -409,7 +410,7 @@ func (r *SessionRepository) GetStats(ctx context.Context, userID string) (map[st
 		
 		var count int
 		if err := r.db.DB().QueryRowContext(ctx, query, stateArgs...).Scan(&count); err != nil {
-			log.Printf("Warning: failed to count %s sessions: %v", state, err)
+			slog.WarnContext(ctx, "Failed to count sessions", "state", state, "error", err)
 			stats[state+"_sessions"] = 0
 		} else {
 			stats[state+"_sessions"] = count
@@ -420,7 +421,7 @@ func (r *SessionRepository) GetStats(ctx context.Context, userID string) (map[st
 	query = fmt.Sprintf("SELECT AVG(activity_count) FROM sessions %s", whereClause)
 	var avgActivityCount sql.NullFloat64
 	if err := r.db.DB().QueryRowContext(ctx, query, args...).Scan(&avgActivityCount); err != nil {
-		log.Printf("Warning: failed to calculate average activity count: %v", err)
+		slog.WarnContext(ctx, "Failed to calculate average activity count", "error", err)
 		stats["avg_activity_count"] = 0.0
 	} else if avgActivityCount.Valid {
 		stats["avg_activity_count"] = avgActivityCount.Float64
@@ -432,7 +433,7 @@ func (r *SessionRepository) GetStats(ctx context.Context, userID string) (map[st
 	query = fmt.Sprintf("SELECT MAX(start_time) FROM sessions %s", whereClause)
 	var mostRecentSession sql.NullTime
 	if err := r.db.DB().QueryRowContext(ctx, query, args...).Scan(&mostRecentSession); err != nil {
-		log.Printf("Warning: failed to find most recent session: %v", err)
+		slog.WarnContext(ctx, "Failed to find most recent session", "error", err)
 	} else if mostRecentSession.Valid {
 		stats["most_recent_session"] = r.db.FromDBTime(mostRecentSession.Time)
 	}
@@ -0,0 +1,293 @@
+/**
+ * CONTEXT:   Tag repository for SQLite database operations
+ * INPUT:     Free-form tag names attached to work blocks and sessions
+ * OUTPUT:    Database persistence for tags and their many-to-many links
+ * BUSINESS:  Tags label stretches of work such as "incident-4312" or "code review"
+ * CHANGE:    Initial tag repository backed by schema version 8
+ * RISK:      Low - Idempotent link inserts; deleting a tag cascades to its links
+ */
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Tag sources recorded on work block and session links
+const (
+	TagSourceManual = "manual"
+	TagSourceHook   = "hook"
+	TagSourceAPI    = "api"
+)
+
+// maxTagLength bounds tag names in runes
+const maxTagLength = 64
+
+// workBlockTagsColumn selects a work block's own and session tags as a comma-separated list
+const workBlockTagsColumn = `COALESCE((SELECT group_concat(t.name, ',') FROM tags t WHERE t.id IN (
+		           SELECT tag_id FROM work_block_tags WHERE work_block_id = wb.id
+		           UNION SELECT tag_id FROM session_tags WHERE session_id = wb.session_id)), '')`
+
+// activityTagsColumn selects the tags of an activity's work block and session
+const activityTagsColumn = `COALESCE((SELECT group_concat(t.name, ',') FROM tags t WHERE t.id IN (
+		           SELECT tag_id FROM work_block_tags WHERE work_block_id = a.work_block_id
+		           UNION SELECT tag_id FROM session_tags WHERE session_id = a.session_id)), '')`
+
+// TagUsage is a tag with the number of work blocks and sessions carrying it
+type TagUsage struct {
+	Name       string    `json:"name"`
+	WorkBlocks int       `json:"work_blocks"`
+	Sessions   int       `json:"sessions"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TagRepository handles database operations for tags
+type TagRepository struct {
+	db *sql.DB
+}
+
+// NewTagRepository creates a new tag repository
+func NewTagRepository(db *sql.DB) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+/**
+ * CONTEXT:   Normalize a user-supplied tag name
+ * INPUT:     Raw tag text from the CLI, API or hook environment
+ * OUTPUT:    Lower-case tag with single spaces, or an error
+ * BUSINESS:  "Code Review" and "code  review" must be the same tag
+ * CHANGE:    Initial tag normalization
+ * RISK:      Low - Commas are rejected because they separate tag lists
+ */
+func NormalizeTag(name string) (string, error) {
+	tag := strings.ToLower(strings.Join(strings.Fields(name), " "))
+	switch {
+	case tag == "":
+		return "", fmt.Errorf("tag cannot be empty")
+	case strings.Contains(tag, ","):
+		return "", fmt.Errorf("tag %q cannot contain a comma", name)
+	case utf8.RuneCountInString(tag) > maxTagLength:
+		return "", fmt.Errorf("tag %q is longer than %d characters", name, maxTagLength)
+	}
+	return tag, nil
+}
+
+// ParseTags normalizes and de-duplicates tags, splitting each value on commas
+func ParseTags(values ...string) ([]string, error) {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if strings.TrimSpace(part) == "" {
+				continue
+			}
+			tag, err := NormalizeTag(part)
+			if err != nil {
+				return nil, err
+			}
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags, nil
+}
+
+// splitTagList turns a group_concat tag column into a sorted slice
+func splitTagList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	tags := strings.Split(value, ",")
+	sort.Strings(tags)
+	return tags
+}
+
+/**
+ * CONTEXT:   Attach tags to a work block
+ * INPUT:     Work block ID, normalized tag names and link source
+ * OUTPUT:    Number of tags newly attached
+ * BUSINESS:  Re-tagging is harmless so hooks can send the same tags on every event
+ * CHANGE:    Initial work block tagging
+ * RISK:      Low - Single transaction; unknown work blocks are rejected
+ */
+func (tr *TagRepository) TagWorkBlock(ctx context.Context, workBlockID string, tags []string, source string) (int, error) {
+	return tr.link(ctx, "work_blocks", "work_block_tags", "work_block_id", workBlockID, tags, source)
+}
+
+/**
+ * CONTEXT:   Attach tags to a session
+ * INPUT:     Session ID, normalized tag names and link source
+ * OUTPUT:    Number of tags newly attached
+ * BUSINESS:  Session tags apply to every work block of the session in reports
+ * CHANGE:    Initial session tagging
+ * RISK:      Low - Single transaction; unknown sessions are rejected
+ */
+func (tr *TagRepository) TagSession(ctx context.Context, sessionID string, tags []string, source string) (int, error) {
+	return tr.link(ctx, "sessions", "session_tags", "session_id", sessionID, tags, source)
+}
+
+// UntagWorkBlock removes tags from a work block and returns how many were attached
+func (tr *TagRepository) UntagWorkBlock(ctx context.Context, workBlockID string, tags []string) (int, error) {
+	return tr.unlink(ctx, "work_block_tags", "work_block_id", workBlockID, tags)
+}
+
+// UntagSession removes tags from a session and returns how many were attached
+func (tr *TagRepository) UntagSession(ctx context.Context, sessionID string, tags []string) (int, error) {
+	return tr.unlink(ctx, "session_tags", "session_id", sessionID, tags)
+}
+
+// GetWorkBlockTags returns the tags attached directly to a work block
+func (tr *TagRepository) GetWorkBlockTags(ctx context.Context, workBlockID string) ([]string, error) {
+	return tr.linked(ctx, "work_block_tags", "work_block_id", workBlockID)
+}
+
+// GetSessionTags returns the tags attached to a session
+func (tr *TagRepository) GetSessionTags(ctx context.Context, sessionID string) ([]string, error) {
+	return tr.linked(ctx, "session_tags", "session_id", sessionID)
+}
+
+/**
+ * CONTEXT:   List all tags with usage counts
+ * INPUT:     Context for database operations
+ * OUTPUT:    Tags sorted by name with work block and session counts
+ * BUSINESS:  Shows the tag vocabulary so labels stay consistent
+ * CHANGE:    Initial tag listing
+ * RISK:      Low - Read-only aggregate query
+ */
+func (tr *TagRepository) List(ctx context.Context) ([]*TagUsage, error) {
+	query := `
+		SELECT t.name, t.created_at,
+		       (SELECT COUNT(*) FROM work_block_tags WHERE tag_id = t.id),
+		       (SELECT COUNT(*) FROM session_tags WHERE tag_id = t.id)
+		FROM tags t
+		ORDER BY t.name
+	`
+
+	rows, err := tr.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make([]*TagUsage, 0)
+	for rows.Next() {
+		usage := &TagUsage{}
+		if err := rows.Scan(&usage.Name, &usage.CreatedAt, &usage.WorkBlocks, &usage.Sessions); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, usage)
+	}
+	return tags, rows.Err()
+}
+
+// Delete removes a tag and all its links
+func (tr *TagRepository) Delete(ctx context.Context, name string) error {
+	result, err := tr.db.ExecContext(ctx, `DELETE FROM tags WHERE name = ?`, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return fmt.Errorf("tag %q not found", name)
+	}
+	return nil
+}
+
+// link attaches tags to one row of ownerTable through linkTable
+func (tr *TagRepository) link(ctx context.Context, ownerTable, linkTable, ownerColumn, ownerID string, tags []string, source string) (int, error) {
+	if ownerID == "" {
+		return 0, fmt.Errorf("%s ID cannot be empty", ownerColumn)
+	}
+	if source == "" {
+		source = TagSourceManual
+	}
+
+	tx, err := tr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin tag transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM `+ownerTable+` WHERE id = ?`, ownerID).Scan(&exists)
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up %s: %w", ownerID, err)
+	}
+	if exists == 0 {
+		return 0, fmt.Errorf("%s not found", ownerID)
+	}
+
+	now := time.Now()
+	added := 0
+	for _, tag := range tags {
+		_, err := tx.ExecContext(ctx,
+			`INSERT OR IGNORE INTO tags (id, name, created_at) VALUES (?, ?, ?)`,
+			NewEntityID("tag"), tag, now)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create tag %q: %w", tag, err)
+		}
+
+		result, err := tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO `+linkTable+` (`+ownerColumn+`, tag_id, source, created_at)
+			SELECT ?, id, ?, ? FROM tags WHERE name = ?`,
+			ownerID, source, now, tag)
+		if err != nil {
+			return 0, fmt.Errorf("failed to attach tag %q: %w", tag, err)
+		}
+		if affected, _ := result.RowsAffected(); affected > 0 {
+			added++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit tags: %w", err)
+	}
+	return added, nil
+}
+
+// unlink detaches tags from one owner row
+func (tr *TagRepository) unlink(ctx context.Context, linkTable, ownerColumn, ownerID string, tags []string) (int, error) {
+	removed := 0
+	for _, tag := range tags {
+		result, err := tr.db.ExecContext(ctx, `
+			DELETE FROM `+linkTable+`
+			WHERE `+ownerColumn+` = ? AND tag_id = (SELECT id FROM tags WHERE name = ?)`,
+			ownerID, tag)
+		if err != nil {
+			return 0, fmt.Errorf("failed to remove tag %q: %w", tag, err)
+		}
+		affected, _ := result.RowsAffected()
+		removed += int(affected)
+	}
+	return removed, nil
+}
+
+// linked returns the sorted tag names attached to one owner row
+func (tr *TagRepository) linked(ctx context.Context, linkTable, ownerColumn, ownerID string) ([]string, error) {
+	rows, err := tr.db.QueryContext(ctx, `
+		SELECT t.name FROM tags t
+		JOIN `+linkTable+` l ON l.tag_id = t.id
+		WHERE l.`+ownerColumn+` = ?
+		ORDER BY t.name`, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, name)
+	}
+	return tags, rows.Err()
+}
@@ -0,0 +1,80 @@
+/**
+ * CONTEXT:   Tests for work block and session tags
+ * INPUT:     Hook events with tags and tag commands against a temp database
+ * OUTPUT:    Validation of tag normalization, inheritance, removal and usage counts
+ * BUSINESS:  Tag reports are only useful when labels are normalized consistently
+ * CHANGE:    Initial tag repository tests
+ * RISK:      Low - Temp database per test
+ */
+
+// External test package: the tracker imports sqlite, so internal tests cannot use it
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/tracking"
+)
+
+func TestTagRepository(t *testing.T) {
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(t.TempDir(), "tags.db")))
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	tracker := tracking.NewActivityTracker(db)
+
+	_, err = tracker.Record(ctx, tracking.ActivityEvent{UserID: "alice", ProjectPath: "/work/api", Tags: []string{"a,b"}})
+	require.NoError(t, err, "comma-separated tags are split")
+	_, err = tracker.Record(ctx, tracking.ActivityEvent{UserID: "alice", ProjectPath: "/work/api", Tags: []string{strings.Repeat("x", 65)}})
+	assert.Error(t, err, "overlong tag is rejected")
+
+	activity, err := tracker.Record(ctx, tracking.ActivityEvent{
+		UserID: "alice", ProjectPath: "/work/api", ToolName: "Edit", Tags: []string{" Incident-4312 ", "incident-4312"},
+	})
+	require.NoError(t, err)
+
+	tagRepo := sqlite.NewTagRepository(db.DB())
+	own, err := tagRepo.GetWorkBlockTags(ctx, activity.WorkBlockID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "incident-4312"}, own)
+
+	added, err := tagRepo.TagSession(ctx, activity.SessionID, []string{"on call"}, sqlite.TagSourceManual)
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+	added, err = tagRepo.TagSession(ctx, activity.SessionID, []string{"on call"}, sqlite.TagSourceManual)
+	require.NoError(t, err)
+	assert.Zero(t, added, "tagging twice is a no-op")
+
+	block, err := sqlite.NewWorkBlockRepository(db.DB()).GetByID(ctx, activity.WorkBlockID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "incident-4312", "on call"}, block.Tags, "work blocks inherit session tags")
+
+	activities, err := sqlite.NewActivityRepository(db.DB()).GetActivitiesByWorkBlock(activity.WorkBlockID)
+	require.NoError(t, err)
+	require.NotEmpty(t, activities)
+	assert.Equal(t, block.Tags, activities[0].Tags)
+
+	removed, err := tagRepo.UntagWorkBlock(ctx, activity.WorkBlockID, []string{"a", "missing"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	_, err = tagRepo.TagWorkBlock(ctx, "wb_missing", []string{"a"}, sqlite.TagSourceManual)
+	assert.Error(t, err)
+	_, err = sqlite.NewWorkBlockRepository(db.DB()).GetByID(ctx, "wb_missing")
+	assert.ErrorIs(t, err, sqlite.ErrWorkBlockNotFound)
+
+	usage, err := tagRepo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, usage, 4)
+	assert.Equal(t, "a", usage[0].Name)
+	assert.Zero(t, usage[0].WorkBlocks)
+	assert.Equal(t, 1, usage[3].Sessions)
+}
@@ -0,0 +1,668 @@
+/**
+ * CONTEXT:   Portable export and streaming import of the whole tracking database
+ * INPUT:     JSON Lines streams or standalone SQLite files from another machine
+ * OUTPUT:    Export files and merged rows with remapped IDs and skipped duplicates
+ * BUSINESS:  History moves between machines and a laptop's database merges into a
+ *            workstation's without duplicating anything imported before
+ * CHANGE:    Initial export and import
+ * RISK:      Medium - Import writes every table; rows are applied in bounded
+ *            batches and matched on natural keys so re-running an import is safe
+ */
+
+package sqlite
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Export format identifiers
+const (
+	TransferFormatJSONL      = "jsonl"
+	TransferFormatSQLiteDump = "sqlite-dump"
+)
+
+// Transfer limits
+const (
+	transferFormatName    = "claude-monitor-export"
+	transferFormatVersion = 1
+	importBatchSize       = 500
+	maxImportLineBytes    = 16 << 20
+)
+
+// transferTable describes how one table is exported and merged on import
+type transferTable struct {
+	name     string
+	orderBy  string
+	idPrefix string            // primary key prefix; empty for tables without an id column
+	refs     map[string]string // foreign key column -> referenced table
+	natural  []string          // columns identifying the same record on another machine
+}
+
+// transferTables lists tables in dependency order, parents first
+var transferTables = []transferTable{
+	{name: "users", orderBy: "created_at, id", idPrefix: "user", natural: []string{"username"}},
+	{name: "projects", orderBy: "parent_id IS NOT NULL, created_at, id", idPrefix: "proj",
+		refs: map[string]string{"parent_id": "projects"}, natural: []string{"path"}},
+	{name: "project_aliases", orderBy: "path", refs: map[string]string{"project_id": "projects"}},
+	{name: "tags", orderBy: "name", idPrefix: "tag", natural: []string{"name"}},
+	{name: "goals", orderBy: "created_at, id", idPrefix: "goal",
+		refs: map[string]string{"user_id": "users", "project_id": "projects"}},
+	{name: "sessions", orderBy: "start_time, id", idPrefix: "session",
+		refs: map[string]string{"user_id": "users"}, natural: []string{"user_id", "start_time"}},
+	{name: "work_blocks", orderBy: "start_time, id", idPrefix: "block",
+		refs:    map[string]string{"session_id": "sessions", "project_id": "projects"},
+		natural: []string{"session_id", "start_time"}},
+	{name: "activity_events", orderBy: "timestamp, id", idPrefix: "activity",
+		refs:    map[string]string{"user_id": "users", "session_id": "sessions", "work_block_id": "work_blocks", "project_id": "projects"},
+		natural: []string{"user_id", "timestamp"}},
+	{name: "work_block_tags", orderBy: "work_block_id, tag_id",
+		refs: map[string]string{"work_block_id": "work_blocks", "tag_id": "tags"}},
+	{name: "session_tags", orderBy: "session_id, tag_id",
+		refs: map[string]string{"session_id": "sessions", "tag_id": "tags"}},
+	{name: "work_block_audit", orderBy: "created_at, id", idPrefix: "audit",
+		refs: map[string]string{"work_block_id": "work_blocks", "user_id": "users"}},
+	{name: "daily_rollups", orderBy: "day, user_id, project_id",
+		refs: map[string]string{"user_id": "users", "project_id": "projects"}},
+}
+
+// transferHeader is the first line of a JSON Lines export
+type transferHeader struct {
+	Format        string    `json:"format"`
+	Version       int       `json:"version"`
+	SchemaVersion int       `json:"schema_version"`
+	ExportedAt    time.Time `json:"exported_at"`
+}
+
+// transferRecord is one row in a JSON Lines export
+type transferRecord struct {
+	Table string                 `json:"table"`
+	Row   map[string]interface{} `json:"row"`
+}
+
+// TransferTableStats counts rows per table for one export or import
+type TransferTableStats struct {
+	Table      string `json:"table"`
+	Rows       int64  `json:"rows"`
+	Imported   int64  `json:"imported,omitempty"`
+	Duplicates int64  `json:"duplicates,omitempty"`
+	Remapped   int64  `json:"remapped,omitempty"`
+	Skipped    int64  `json:"skipped,omitempty"`
+}
+
+// TransferStats summarizes an export or import
+type TransferStats struct {
+	Format        string                `json:"format"`
+	SchemaVersion int                   `json:"schema_version"`
+	Tables        []*TransferTableStats `json:"tables"`
+}
+
+// table returns the stats entry for name, creating it in first-seen order
+func (s *TransferStats) table(name string) *TransferTableStats {
+	for _, t := range s.Tables {
+		if t.Table == name {
+			return t
+		}
+	}
+	t := &TransferTableStats{Table: name}
+	s.Tables = append(s.Tables, t)
+	return t
+}
+
+/**
+ * CONTEXT:   Export every table as JSON Lines
+ * INPUT:     Context and destination writer
+ * OUTPUT:    Header line followed by one line per row, parents before children
+ * BUSINESS:  A text format survives schema changes and can be inspected or filtered
+ *            with standard tools before import
+ * CHANGE:    Initial JSON Lines export
+ * RISK:      Low - Read-only; rows are streamed without loading a table into memory
+ */
+func (db *SQLiteDB) ExportJSONL(ctx context.Context, w io.Writer) (*TransferStats, error) {
+	version, err := schemaVersion(ctx, db.db)
+	if err != nil {
+		return nil, err
+	}
+	stats := &TransferStats{Format: TransferFormatJSONL, SchemaVersion: version}
+
+	buffered := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buffered)
+	header := transferHeader{Format: transferFormatName, Version: transferFormatVersion, SchemaVersion: version, ExportedAt: time.Now().UTC()}
+	if err := encoder.Encode(header); err != nil {
+		return nil, fmt.Errorf("failed to write export header: %w", err)
+	}
+
+	for _, table := range transferTables {
+		tableStats := stats.table(table.name)
+		err := streamTable(ctx, db.db, table, func(row map[string]interface{}) error {
+			tableStats.Rows++
+			return encoder.Encode(transferRecord{Table: table.name, Row: row})
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := buffered.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to write export: %w", err)
+	}
+	return stats, nil
+}
+
+/**
+ * CONTEXT:   Export the database as a standalone SQLite file
+ * INPUT:     Context and destination path, which must not exist
+ * OUTPUT:    Compacted copy of the database readable with the sqlite3 shell
+ * BUSINESS:  The fastest way to move a full history; import merges it like JSON Lines
+ * CHANGE:    Initial SQLite export
+ * RISK:      Low - VACUUM INTO reads without blocking writers
+ */
+func (db *SQLiteDB) ExportSQLite(ctx context.Context, path string) (*TransferStats, error) {
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("%s already exists", path)
+	}
+	if err := db.Backup(path); err != nil {
+		return nil, err
+	}
+
+	out, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open export: %w", err)
+	}
+	defer out.Close()
+
+	version, err := schemaVersion(ctx, out)
+	if err != nil {
+		return nil, err
+	}
+	stats := &TransferStats{Format: TransferFormatSQLiteDump, SchemaVersion: version}
+	for _, table := range transferTables {
+		if ok, err := tableExists(ctx, out, table.name); err != nil || !ok {
+			continue
+		}
+		if err := out.QueryRowContext(ctx, `SELECT COUNT(*) FROM "`+table.name+`"`).Scan(&stats.table(table.name).Rows); err != nil {
+			return nil, fmt.Errorf("failed to count %s: %w", table.name, err)
+		}
+	}
+	return stats, nil
+}
+
+// streamTable calls fn for every row of table in export order
+func streamTable(ctx context.Context, src *sql.DB, table transferTable, fn func(map[string]interface{}) error) error {
+	if ok, err := tableExists(ctx, src, table.name); err != nil || !ok {
+		return err
+	}
+
+	rows, err := src.QueryContext(ctx, fmt.Sprintf(`SELECT * FROM "%s" ORDER BY %s`, table.name, table.orderBy))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", table.name, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("failed to read %s columns: %w", table.name, err)
+	}
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return fmt.Errorf("failed to scan %s: %w", table.name, err)
+		}
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			if b, ok := values[i].([]byte); ok {
+				row[column] = string(b)
+			} else {
+				row[column] = values[i]
+			}
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// tableExists reports whether db has a table called name
+func tableExists(ctx context.Context, db *sql.DB, name string) (bool, error) {
+	var count int
+	err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, name).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to inspect tables: %w", err)
+	}
+	return count > 0, nil
+}
+
+/**
+ * CONTEXT:   Streaming importer merging another database into this one
+ * INPUT:     Rows in dependency order from a JSON Lines stream or SQLite file
+ * OUTPUT:    Inserted rows with foreign keys remapped, and per-table statistics
+ * BUSINESS:  Rows already present (same natural key) are mapped to the existing row;
+ *            rows whose ID is taken by a different row get a new ID
+ * CHANGE:    Initial importer
+ * RISK:      Medium - Writes in batches of importBatchSize rows; a failed batch
+ *            rolls back only itself and re-running the import resumes safely
+ */
+type Importer struct {
+	db      *SQLiteDB
+	stats   *TransferStats
+	ids     map[string]map[string]string // table -> source id -> destination id
+	columns map[string]map[string]string // table -> column -> declared type
+	parents map[string]string            // destination project id -> source parent id
+	tx      *sql.Tx
+	pending int
+}
+
+// NewImporter creates an importer writing into db
+func NewImporter(db *SQLiteDB) *Importer {
+	return &Importer{
+		db:      db,
+		ids:     make(map[string]map[string]string),
+		columns: make(map[string]map[string]string),
+		parents: make(map[string]string),
+	}
+}
+
+/**
+ * CONTEXT:   Import a JSON Lines export
+ * INPUT:     Context and reader positioned at the header line
+ * OUTPUT:    Import statistics
+ * BUSINESS:  Exports from newer releases are rejected; older ones import with
+ *            missing columns left at their defaults
+ * CHANGE:    Initial JSON Lines import
+ * RISK:      Medium - Reads one line at a time so memory stays flat
+ */
+func (im *Importer) ImportJSONL(ctx context.Context, r io.Reader) (*TransferStats, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), maxImportLineBytes)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read export: %w", err)
+		}
+		return nil, fmt.Errorf("export is empty")
+	}
+	var header transferHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil || header.Format != transferFormatName {
+		return nil, fmt.Errorf("not a Claude Monitor export")
+	}
+	if err := im.begin(TransferFormatJSONL, header.SchemaVersion); err != nil {
+		return nil, err
+	}
+
+	tables := make(map[string]transferTable, len(transferTables))
+	for _, table := range transferTables {
+		tables[table.name] = table
+	}
+
+	line := 1
+	for scanner.Scan() {
+		line++
+		decoder := json.NewDecoder(strings.NewReader(scanner.Text()))
+		decoder.UseNumber()
+		var record transferRecord
+		if err := decoder.Decode(&record); err != nil {
+			im.rollback()
+			return nil, fmt.Errorf("line %d: invalid record: %w", line, err)
+		}
+		table, ok := tables[record.Table]
+		if !ok {
+			im.stats.table(record.Table).Skipped++
+			continue
+		}
+		if err := im.importRow(ctx, table, record.Row); err != nil {
+			im.rollback()
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		im.rollback()
+		return nil, fmt.Errorf("failed to read export: %w", err)
+	}
+	return im.finish(ctx)
+}
+
+/**
+ * CONTEXT:   Import a standalone SQLite database
+ * INPUT:     Context and path of an sqlite-dump export or another monitor.db
+ * OUTPUT:    Import statistics
+ * BUSINESS:  Merges a copied database file directly, table by table
+ * CHANGE:    Initial SQLite import
+ * RISK:      Medium - Opens the source read-only and streams each table
+ */
+func (im *Importer) ImportSQLite(ctx context.Context, path string) (*TransferStats, error) {
+	src, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer src.Close()
+
+	version, err := schemaVersion(ctx, src)
+	if err != nil {
+		return nil, fmt.Errorf("not a Claude Monitor database: %w", err)
+	}
+	if err := im.begin(TransferFormatSQLiteDump, version); err != nil {
+		return nil, err
+	}
+
+	for _, table := range transferTables {
+		err := streamTable(ctx, src, table, func(row map[string]interface{}) error {
+			return im.importRow(ctx, table, row)
+		})
+		if err != nil {
+			im.rollback()
+			return nil, err
+		}
+	}
+	return im.finish(ctx)
+}
+
+// begin checks the source schema version and resets statistics
+func (im *Importer) begin(format string, version int) error {
+	if version > LatestSchemaVersion() {
+		return fmt.Errorf("export schema version %d is newer than this release supports (%d)", version, LatestSchemaVersion())
+	}
+	im.stats = &TransferStats{Format: format, SchemaVersion: version}
+	return nil
+}
+
+/**
+ * CONTEXT:   Merge one source row
+ * INPUT:     Table description and the row's column values
+ * OUTPUT:    Row inserted, mapped to an existing row, or skipped
+ * BUSINESS:  Foreign keys are rewritten through the ID maps of earlier tables;
+ *            rows whose required parent was not imported are skipped
+ * CHANGE:    Initial row merge
+ * RISK:      Medium - Inserts into the destination inside the current batch
+ */
+func (im *Importer) importRow(ctx context.Context, table transferTable, row map[string]interface{}) error {
+	stats := im.stats.table(table.name)
+	stats.Rows++
+
+	columns, err := im.tableColumns(ctx, table.name)
+	if err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		stats.Skipped++
+		return nil
+	}
+	if err := im.ensureTx(ctx); err != nil {
+		return err
+	}
+
+	values := make(map[string]interface{}, len(row))
+	for column, value := range row {
+		declared, ok := columns[column]
+		if !ok {
+			continue
+		}
+		converted, err := im.convertValue(declared, value)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", table.name, column, err)
+		}
+		values[column] = converted
+	}
+
+	// Rewrite foreign keys; a missing required parent skips the row
+	var parentRef string
+	for column, refTable := range table.refs {
+		old, ok := values[column].(string)
+		if !ok || old == "" {
+			continue
+		}
+		if mapped, ok := im.ids[refTable][old]; ok {
+			values[column] = mapped
+			continue
+		}
+		if table.name == "projects" && column == "parent_id" {
+			parentRef = old
+			values[column] = nil
+			continue
+		}
+		if im.nullable(table.name, column) {
+			values[column] = nil
+			continue
+		}
+		stats.Skipped++
+		return nil
+	}
+
+	sourceID, _ := values["id"].(string)
+	if len(table.natural) > 0 {
+		existing, err := im.findNatural(ctx, table, values)
+		if err != nil {
+			return err
+		}
+		if existing != "" {
+			im.mapID(table.name, sourceID, existing)
+			stats.Duplicates++
+			return nil
+		}
+	}
+
+	if table.idPrefix != "" {
+		var taken int
+		if err := im.tx.QueryRowContext(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM "%s" WHERE id = ?`, table.name), sourceID).Scan(&taken); err != nil {
+			return fmt.Errorf("failed to check %s id: %w", table.name, err)
+		}
+		if taken > 0 {
+			if len(table.natural) == 0 {
+				// Without a natural key, an existing ID means this row was imported before
+				im.mapID(table.name, sourceID, sourceID)
+				stats.Duplicates++
+				return nil
+			}
+			values["id"] = NewEntityID(table.idPrefix)
+			stats.Remapped++
+		}
+		im.mapID(table.name, sourceID, values["id"].(string))
+	}
+
+	inserted, err := im.insert(ctx, table, values)
+	if err != nil {
+		return err
+	}
+	if !inserted {
+		stats.Duplicates++
+		return nil
+	}
+	stats.Imported++
+	if parentRef != "" {
+		im.parents[values["id"].(string)] = parentRef
+	}
+
+	im.pending++
+	if im.pending >= importBatchSize {
+		return im.commit()
+	}
+	return nil
+}
+
+// findNatural returns the destination id of a row with the same natural key
+func (im *Importer) findNatural(ctx context.Context, table transferTable, values map[string]interface{}) (string, error) {
+	conditions := make([]string, 0, len(table.natural))
+	args := make([]interface{}, 0, len(table.natural))
+	for _, column := range table.natural {
+		conditions = append(conditions, fmt.Sprintf(`"%s" = ?`, column))
+		args = append(args, values[column])
+	}
+
+	var id string
+	err := im.tx.QueryRowContext(ctx,
+		fmt.Sprintf(`SELECT id FROM "%s" WHERE %s LIMIT 1`, table.name, strings.Join(conditions, " AND ")),
+		args...).Scan(&id)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to match %s: %w", table.name, err)
+	}
+	return id, nil
+}
+
+// insert adds a row, ignoring rows whose primary key already exists in link tables
+func (im *Importer) insert(ctx context.Context, table transferTable, values map[string]interface{}) (bool, error) {
+	columns := make([]string, 0, len(values))
+	placeholders := make([]string, 0, len(values))
+	args := make([]interface{}, 0, len(values))
+	for column, value := range values {
+		columns = append(columns, `"`+column+`"`)
+		placeholders = append(placeholders, "?")
+		args = append(args, value)
+	}
+
+	verb := "INSERT"
+	if table.idPrefix == "" {
+		verb = "INSERT OR IGNORE"
+	}
+	result, err := im.tx.ExecContext(ctx, fmt.Sprintf(`%s INTO "%s" (%s) VALUES (%s)`,
+		verb, table.name, strings.Join(columns, ", "), strings.Join(placeholders, ", ")), args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to import %s row: %w", table.name, err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to import %s row: %w", table.name, err)
+	}
+	return affected > 0, nil
+}
+
+// convertValue turns an exported value into one the destination column accepts
+func (im *Importer) convertValue(declared string, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case time.Time:
+		return im.db.ToDBTime(v), nil
+	case json.Number:
+		if strings.Contains(declared, "INT") {
+			return v.Int64()
+		}
+		return v.Float64()
+	case string:
+		if declared == "DATETIME" {
+			t, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid time %q", v)
+			}
+			return im.db.ToDBTime(t), nil
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+// tableColumns returns the destination table's columns and declared types
+func (im *Importer) tableColumns(ctx context.Context, table string) (map[string]string, error) {
+	if columns, ok := im.columns[table]; ok {
+		return columns, nil
+	}
+
+	rows, err := im.db.db.QueryContext(ctx, fmt.Sprintf(`PRAGMA table_info("%s")`, table))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s columns: %w", table, err)
+	}
+	defer rows.Close()
+
+	columns := make(map[string]string)
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, declared string
+		var defaultValue sql.NullString
+		if err := rows.Scan(&cid, &name, &declared, &notNull, &defaultValue, &pk); err != nil {
+			return nil, fmt.Errorf("failed to read %s columns: %w", table, err)
+		}
+		columns[name] = strings.ToUpper(declared)
+		if notNull == 0 && pk == 0 {
+			columns[name+"\x00nullable"] = ""
+		}
+	}
+	im.columns[table] = columns
+	return columns, rows.Err()
+}
+
+// nullable reports whether a destination column accepts NULL
+func (im *Importer) nullable(table, column string) bool {
+	_, ok := im.columns[table][column+"\x00nullable"]
+	return ok
+}
+
+// mapID records the destination id for a source id
+func (im *Importer) mapID(table, sourceID, destID string) {
+	if sourceID == "" {
+		return
+	}
+	if im.ids[table] == nil {
+		im.ids[table] = make(map[string]string)
+	}
+	im.ids[table][sourceID] = destID
+}
+
+// ensureTx opens a batch transaction if none is open
+func (im *Importer) ensureTx(ctx context.Context) error {
+	if im.tx != nil {
+		return nil
+	}
+	tx, err := im.db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin import batch: %w", err)
+	}
+	im.tx = tx
+	return nil
+}
+
+// commit ends the current batch
+func (im *Importer) commit() error {
+	if im.tx == nil {
+		return nil
+	}
+	err := im.tx.Commit()
+	im.tx = nil
+	im.pending = 0
+	if err != nil {
+		return fmt.Errorf("failed to commit import batch: %w", err)
+	}
+	return nil
+}
+
+// rollback discards the current batch
+func (im *Importer) rollback() {
+	if im.tx != nil {
+		im.tx.Rollback()
+		im.tx = nil
+		im.pending = 0
+	}
+}
+
+// finish links project parents that appeared after their children and commits
+func (im *Importer) finish(ctx context.Context) (*TransferStats, error) {
+	if err := im.ensureTx(ctx); err != nil {
+		return nil, err
+	}
+	for projectID, sourceParent := range im.parents {
+		parentID, ok := im.ids["projects"][sourceParent]
+		if !ok {
+			continue
+		}
+		if _, err := im.tx.ExecContext(ctx, `UPDATE projects SET parent_id = ? WHERE id = ?`, parentID, projectID); err != nil {
+			im.rollback()
+			return nil, fmt.Errorf("failed to link project %s to its parent: %w", projectID, err)
+		}
+	}
+	if err := im.commit(); err != nil {
+		return nil, err
+	}
+	return im.stats, nil
+}
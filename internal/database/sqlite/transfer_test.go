@@ -0,0 +1,90 @@
+/**
+ * CONTEXT:   Tests for database export and import
+ * INPUT:     Two temp databases with colliding project IDs
+ * OUTPUT:    Validation of JSON Lines and SQLite round trips, ID remapping and
+ *            duplicate detection on re-import
+ * BUSINESS:  Merging a laptop into a workstation must never duplicate history
+ * CHANGE:    Initial export and import tests
+ * RISK:      Low - Temp directory per test
+ */
+
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/tracking"
+)
+
+func TestExportImportRemapsAndSkipsDuplicates(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	laptop, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(dir, "laptop.db")))
+	require.NoError(t, err)
+	defer laptop.Close()
+	activity, err := tracking.NewActivityTracker(laptop).Record(ctx, tracking.ActivityEvent{UserID: "alice", ProjectPath: "/work/api"})
+	require.NoError(t, err)
+
+	workstation, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(dir, "workstation.db")))
+	require.NoError(t, err)
+	defer workstation.Close()
+	_, err = tracking.NewActivityTracker(workstation).Record(ctx, tracking.ActivityEvent{UserID: "bob", ProjectPath: "/work/web"})
+	require.NoError(t, err)
+	// A different project that happens to use the laptop project's ID
+	_, err = workstation.DB().ExecContext(ctx, `INSERT INTO projects (id, name, path, created_at, updated_at) VALUES (?, 'Other', '/elsewhere', ?, ?)`,
+		activity.ProjectID, workstation.Now(), workstation.Now())
+	require.NoError(t, err)
+
+	var export strings.Builder
+	exported, err := laptop.ExportJSONL(ctx, &export)
+	require.NoError(t, err)
+	counts := make(map[string]int64)
+	for _, table := range exported.Tables {
+		counts[table.Table] = table.Rows
+	}
+	assert.Equal(t, int64(1), counts["work_blocks"])
+	assert.Equal(t, int64(1), counts["activity_events"])
+
+	stats, err := sqlite.NewImporter(workstation).ImportJSONL(ctx, strings.NewReader(export.String()))
+	require.NoError(t, err)
+	for _, table := range stats.Tables {
+		assert.Zero(t, table.Duplicates, table.Table)
+		assert.Zero(t, table.Skipped, table.Table)
+		if table.Table == "projects" {
+			assert.Equal(t, int64(1), table.Remapped)
+		}
+	}
+
+	var projectPath string
+	err = workstation.DB().QueryRowContext(ctx, `
+		SELECT p.path FROM work_blocks wb JOIN projects p ON p.id = wb.project_id WHERE wb.id = ?`, activity.WorkBlockID).Scan(&projectPath)
+	require.NoError(t, err)
+	assert.Equal(t, "/work/api", projectPath, "imported work block follows the remapped project")
+
+	// Importing again, in either format, finds everything already present
+	stats, err = sqlite.NewImporter(workstation).ImportJSONL(ctx, strings.NewReader(export.String()))
+	require.NoError(t, err)
+	for _, table := range stats.Tables {
+		assert.Zero(t, table.Imported, table.Table)
+		assert.Equal(t, table.Rows, table.Duplicates, table.Table)
+	}
+
+	dump := filepath.Join(dir, "laptop-export.db")
+	_, err = laptop.ExportSQLite(ctx, dump)
+	require.NoError(t, err)
+	_, err = laptop.ExportSQLite(ctx, dump)
+	assert.Error(t, err, "sqlite-dump never overwrites")
+	stats, err = sqlite.NewImporter(workstation).ImportSQLite(ctx, dump)
+	require.NoError(t, err)
+	for _, table := range stats.Tables {
+		assert.Zero(t, table.Imported, table.Table)
+	}
+}
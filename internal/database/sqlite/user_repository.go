@@ -0,0 +1,53 @@
+/**
+ * CONTEXT:   User repository for SQLite database operations
+ * INPUT:     User identifiers resolved by the CLI, daemon and hook
+ * OUTPUT:    User rows that sessions and activity events reference
+ * BUSINESS:  Users are implicit; the first tracked activity registers them
+ * CHANGE:    Initial user repository with idempotent registration
+ * RISK:      Low - Insert-or-ignore on a small table
+ */
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// UserRepository handles database operations for users
+type UserRepository struct {
+	db *sql.DB
+}
+
+// NewUserRepository creates a new user repository
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+/**
+ * CONTEXT:   Make sure a user row exists before referencing it
+ * INPUT:     User ID, also used as username
+ * OUTPUT:    Existing or newly inserted user row
+ * BUSINESS:  Sessions and activity events have a foreign key on users
+ * CHANGE:    Initial idempotent user registration
+ * RISK:      Low - INSERT OR IGNORE keeps existing rows untouched
+ */
+func (r *UserRepository) EnsureExists(ctx context.Context, userID string) error {
+	if userID == "" {
+		return fmt.Errorf("user ID cannot be empty")
+	}
+
+	now := time.Now()
+	query := `
+		INSERT OR IGNORE INTO users (id, username, created_at, updated_at)
+		VALUES (?, ?, ?, ?)
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, userID, now, now); err != nil {
+		return fmt.Errorf("failed to register user %s: %w", userID, err)
+	}
+
+	return nil
+}
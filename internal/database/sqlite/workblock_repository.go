@@ -12,10 +12,20 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 )
 
+// ErrWorkBlockNotFound is returned by GetByID for unknown work block IDs
+var ErrWorkBlockNotFound = errors.New("work block not found")
+
+// Work block sources: recorded by the hook or entered by hand
+const (
+	WorkBlockSourceHook   = "hook"
+	WorkBlockSourceManual = "manual"
+)
+
 // WorkBlockRepository handles database operations for work blocks
 type WorkBlockRepository struct {
 	db *sql.DB
@@ -28,12 +38,46 @@ func NewWorkBlockRepository(db *sql.DB) *WorkBlockRepository {
 
 // WorkBlock type is already defined in migration.go
 
+// workBlockColumns is the column list read by scanWorkBlock
+const workBlockColumns = `wb.id, wb.session_id, wb.project_id, wb.start_time, wb.end_time,
+		       wb.state, wb.last_activity_time, wb.activity_count,
+		       wb.duration_seconds, wb.duration_hours, COALESCE(wb.git_branch, ''),
+		       wb.source, wb.edited_at, wb.created_at, wb.updated_at, ` + workBlockTagsColumn + `,
+		       COALESCE(wb.claude_session_id, '')`
+
+// scanWorkBlock reads one work block row selected with workBlockColumns
+func scanWorkBlock(row rowScanner) (*WorkBlock, error) {
+	var wb WorkBlock
+	var endTime, editedAt sql.NullTime
+	var tags string
+
+	err := row.Scan(
+		&wb.ID, &wb.SessionID, &wb.ProjectID, &wb.StartTime, &endTime,
+		&wb.State, &wb.LastActivityTime, &wb.ActivityCount,
+		&wb.DurationSeconds, &wb.DurationHours, &wb.GitBranch,
+		&wb.Source, &editedAt, &wb.CreatedAt, &wb.UpdatedAt, &tags,
+		&wb.ClaudeSessionID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	wb.Tags = splitTagList(tags)
+
+	if endTime.Valid {
+		wb.EndTime = &endTime.Time
+	}
+	if editedAt.Valid {
+		wb.EditedAt = &editedAt.Time
+	}
+	return &wb, nil
+}
+
 /**
  * CONTEXT:   Create new work block in database
  * INPUT:     Work block entity with session and project associations
  * OUTPUT:    Persisted work block with database constraints validated
  * BUSINESS:  Work blocks must belong to sessions and projects
- * CHANGE:    CHECKPOINT 8 - Basic work block creation
+ * CHANGE:    Store the Claude Code session ID that owns the block
  * RISK:      Low - Standard database insertion with FK constraints
  */
 func (wr *WorkBlockRepository) Create(ctx context.Context, workBlock *WorkBlock) error {
@@ -45,8 +89,8 @@ func (wr *WorkBlockRepository) Create(ctx context.Context, workBlock *WorkBlock)
 		INSERT INTO work_blocks (
 			id, session_id, project_id, start_time, end_time, state,
 			last_activity_time, activity_count, duration_seconds, 
-			duration_hours, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			duration_hours, git_branch, claude_session_id, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	_, err := wr.db.ExecContext(ctx, query,
@@ -54,6 +98,7 @@ func (wr *WorkBlockRepository) Create(ctx context.Context, workBlock *WorkBlock)
 		workBlock.StartTime, workBlock.EndTime, workBlock.State,
 		workBlock.LastActivityTime, workBlock.ActivityCount,
 		workBlock.DurationSeconds, workBlock.DurationHours,
+		nullableString(workBlock.GitBranch), nullableString(workBlock.ClaudeSessionID),
 		workBlock.CreatedAt, workBlock.UpdatedAt,
 	)
 
@@ -78,34 +123,20 @@ func (wr *WorkBlockRepository) GetByID(ctx context.Context, id string) (*WorkBlo
 	}
 
 	query := `
-		SELECT wb.id, wb.session_id, wb.project_id, wb.start_time, wb.end_time,
-		       wb.state, wb.last_activity_time, wb.activity_count,
-		       wb.duration_seconds, wb.duration_hours, wb.created_at, wb.updated_at
+		SELECT ` + workBlockColumns + `
 		FROM work_blocks wb
 		WHERE wb.id = ?
 	`
 
-	var wb WorkBlock
-	var endTime sql.NullTime
-
-	err := wr.db.QueryRowContext(ctx, query, id).Scan(
-		&wb.ID, &wb.SessionID, &wb.ProjectID, &wb.StartTime, &endTime,
-		&wb.State, &wb.LastActivityTime, &wb.ActivityCount,
-		&wb.DurationSeconds, &wb.DurationHours, &wb.CreatedAt, &wb.UpdatedAt,
-	)
-
+	wb, err := scanWorkBlock(wr.db.QueryRowContext(ctx, query, id))
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("work block %s not found", id)
+		return nil, fmt.Errorf("%w: %s", ErrWorkBlockNotFound, id)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get work block: %w", err)
 	}
 
-	if endTime.Valid {
-		wb.EndTime = &endTime.Time
-	}
-
-	return &wb, nil
+	return wb, nil
 }
 
 /**
@@ -122,32 +153,58 @@ func (wr *WorkBlockRepository) GetActiveBySessionAndProject(ctx context.Context,
 	}
 
 	query := `
-		SELECT wb.id, wb.session_id, wb.project_id, wb.start_time, wb.end_time,
-		       wb.state, wb.last_activity_time, wb.activity_count,
-		       wb.duration_seconds, wb.duration_hours, wb.created_at, wb.updated_at
+		SELECT ` + workBlockColumns + `
 		FROM work_blocks wb
 		WHERE wb.session_id = ? AND wb.project_id = ? AND wb.end_time IS NULL
 		ORDER BY wb.last_activity_time DESC
 		LIMIT 1
 	`
 
-	var wb WorkBlock
-	var endTime sql.NullTime
+	wb, err := scanWorkBlock(wr.db.QueryRowContext(ctx, query, sessionID, projectID))
+	if err == sql.ErrNoRows {
+		return nil, nil // No active work block found
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active work block: %w", err)
+	}
 
-	err := wr.db.QueryRowContext(ctx, query, sessionID, projectID).Scan(
-		&wb.ID, &wb.SessionID, &wb.ProjectID, &wb.StartTime, &endTime,
-		&wb.State, &wb.LastActivityTime, &wb.ActivityCount,
-		&wb.DurationSeconds, &wb.DurationHours, &wb.CreatedAt, &wb.UpdatedAt,
-	)
+	return wb, nil
+}
 
+/**
+ * CONTEXT:   Get the active work block of one Claude Code instance
+ * INPUT:     Session ID, project ID and Claude Code session ID (may be empty)
+ * OUTPUT:    Active work block or nil if the instance has none open
+ * BUSINESS:  Instances running side by side in the same project keep parallel
+ *            blocks. The instance's own block wins, then a block not yet claimed
+ *            by any instance; events without a Claude session ID take any block
+ * CHANGE:    Initial per-instance lookup
+ * RISK:      Low - Indexed query on session, project and Claude session ID
+ */
+func (wr *WorkBlockRepository) GetActiveByInstance(ctx context.Context, sessionID, projectID, claudeSessionID string) (*WorkBlock, error) {
+	if sessionID == "" || projectID == "" {
+		return nil, fmt.Errorf("session ID and project ID cannot be empty")
+	}
+
+	query := `
+		SELECT ` + workBlockColumns + `
+		FROM work_blocks wb
+		WHERE wb.session_id = ? AND wb.project_id = ? AND wb.end_time IS NULL
+		  AND (? = '' OR COALESCE(wb.claude_session_id, '') IN ('', ?))
+		ORDER BY COALESCE(wb.claude_session_id, '') = ? DESC, wb.last_activity_time DESC
+		LIMIT 1
+	`
+
+	wb, err := scanWorkBlock(wr.db.QueryRowContext(ctx, query, sessionID, projectID,
+		claudeSessionID, claudeSessionID, claudeSessionID))
 	if err == sql.ErrNoRows {
-		return nil, nil // No active work block found
+		return nil, nil
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get active work block: %w", err)
 	}
 
-	return &wb, nil
+	return wb, nil
 }
 
 /**
@@ -250,6 +307,28 @@ func (wr *WorkBlockRepository) FinishWorkBlock(ctx context.Context, workBlockID
 	return nil
 }
 
+// SetGitBranch tags a work block with the git branch its activity happened on
+func (wr *WorkBlockRepository) SetGitBranch(ctx context.Context, workBlockID, branch string) error {
+	_, err := wr.db.ExecContext(ctx,
+		`UPDATE work_blocks SET git_branch = ?, updated_at = ? WHERE id = ?`,
+		nullableString(branch), time.Now(), workBlockID)
+	if err != nil {
+		return fmt.Errorf("failed to set work block git branch: %w", err)
+	}
+	return nil
+}
+
+// SetClaudeSessionID claims a work block for the Claude Code instance extending it
+func (wr *WorkBlockRepository) SetClaudeSessionID(ctx context.Context, workBlockID, claudeSessionID string) error {
+	_, err := wr.db.ExecContext(ctx,
+		`UPDATE work_blocks SET claude_session_id = ?, updated_at = ? WHERE id = ?`,
+		nullableString(claudeSessionID), time.Now(), workBlockID)
+	if err != nil {
+		return fmt.Errorf("failed to set work block Claude session: %w", err)
+	}
+	return nil
+}
+
 /**
  * CONTEXT:   Get work blocks by session for reporting
  * INPUT:     Session ID and optional limit
@@ -264,9 +343,7 @@ func (wr *WorkBlockRepository) GetBySession(ctx context.Context, sessionID strin
 	}
 
 	query := `
-		SELECT wb.id, wb.session_id, wb.project_id, wb.start_time, wb.end_time,
-		       wb.state, wb.last_activity_time, wb.activity_count,
-		       wb.duration_seconds, wb.duration_hours, wb.created_at, wb.updated_at
+		SELECT ` + workBlockColumns + `
 		FROM work_blocks wb
 		WHERE wb.session_id = ?
 		ORDER BY wb.start_time ASC
@@ -284,26 +361,137 @@ func (wr *WorkBlockRepository) GetBySession(ctx context.Context, sessionID strin
 
 	var workBlocks []*WorkBlock
 	for rows.Next() {
-		var wb WorkBlock
-		var endTime sql.NullTime
-
-		err := rows.Scan(
-			&wb.ID, &wb.SessionID, &wb.ProjectID, &wb.StartTime, &endTime,
-			&wb.State, &wb.LastActivityTime, &wb.ActivityCount,
-			&wb.DurationSeconds, &wb.DurationHours, &wb.CreatedAt, &wb.UpdatedAt,
-		)
+		wb, err := scanWorkBlock(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan work block: %w", err)
+		}
+
+		workBlocks = append(workBlocks, wb)
+	}
+
+	return workBlocks, nil
+}
+
+/**
+ * CONTEXT:   Get a user's work blocks started within a time range
+ * INPUT:     User ID and half-open range [start, end)
+ * OUTPUT:    Work blocks ordered by start time
+ * BUSINESS:  Range tagging labels every block of an afternoon or a week at once
+ * CHANGE:    Initial user range query for tag commands
+ * RISK:      Low - Indexed join on sessions
+ */
+func (wr *WorkBlockRepository) GetByUserInRange(ctx context.Context, userID string, start, end time.Time) ([]*WorkBlock, error) {
+	query := `
+		SELECT ` + workBlockColumns + `
+		FROM work_blocks wb
+		JOIN sessions s ON s.id = wb.session_id
+		WHERE s.user_id = ? AND wb.start_time >= ? AND wb.start_time < ?
+		ORDER BY wb.start_time ASC
+	`
+
+	rows, err := wr.db.QueryContext(ctx, query, userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work blocks in range: %w", err)
+	}
+	defer rows.Close()
+
+	var workBlocks []*WorkBlock
+	for rows.Next() {
+		wb, err := scanWorkBlock(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan work block: %w", err)
 		}
+		workBlocks = append(workBlocks, wb)
+	}
+	return workBlocks, rows.Err()
+}
+
+/**
+ * CONTEXT:   Get the rollups of a user's purged work blocks for one day
+ * INPUT:     User ID and day as YYYY-MM-DD
+ * OUTPUT:    One rollup per project, ordered by project ID
+ * BUSINESS:  Retention deletes old work blocks; reports add these rollups back
+ *            so purged history still counts toward daily, weekly and monthly totals
+ * CHANGE:    Initial rollup read for reports
+ * RISK:      Low - Primary key lookup on daily_rollups
+ */
+func (wr *WorkBlockRepository) GetDailyRollups(ctx context.Context, userID, day string) ([]*DailyRollup, error) {
+	rows, err := wr.db.QueryContext(ctx, `
+		SELECT day, project_id, work_blocks, work_seconds, activity_count
+		FROM daily_rollups
+		WHERE user_id = ? AND day = ?
+		ORDER BY project_id
+	`, userID, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily rollups: %w", err)
+	}
+	defer rows.Close()
 
-		if endTime.Valid {
-			wb.EndTime = &endTime.Time
+	var rollups []*DailyRollup
+	for rows.Next() {
+		var rollup DailyRollup
+		if err := rows.Scan(&rollup.Day, &rollup.ProjectID, &rollup.WorkBlocks, &rollup.WorkSeconds, &rollup.ActivityCount); err != nil {
+			return nil, fmt.Errorf("failed to scan daily rollup: %w", err)
 		}
+		rollups = append(rollups, &rollup)
+	}
+	return rollups, rows.Err()
+}
 
-		workBlocks = append(workBlocks, &wb)
+// GetLatestByUser returns the user's most recently started work block, or nil if there is none
+func (wr *WorkBlockRepository) GetLatestByUser(ctx context.Context, userID string) (*WorkBlock, error) {
+	query := `
+		SELECT ` + workBlockColumns + `
+		FROM work_blocks wb
+		JOIN sessions s ON s.id = wb.session_id
+		WHERE s.user_id = ?
+		ORDER BY wb.start_time DESC
+		LIMIT 1
+	`
+
+	wb, err := scanWorkBlock(wr.db.QueryRowContext(ctx, query, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest work block: %w", err)
 	}
+	return wb, nil
+}
 
-	return workBlocks, nil
+/**
+ * CONTEXT:   Get a user's open work blocks
+ * INPUT:     User ID
+ * OUTPUT:    Work blocks without an end time, most recently active first
+ * BUSINESS:  Parallel Claude Code instances each keep an open block; the live
+ *            view shows all of them instead of whichever wrote last
+ * CHANGE:    Initial open block query for live status
+ * RISK:      Low - Indexed join on sessions
+ */
+func (wr *WorkBlockRepository) GetOpenByUser(ctx context.Context, userID string) ([]*WorkBlock, error) {
+	query := `
+		SELECT ` + workBlockColumns + `
+		FROM work_blocks wb
+		JOIN sessions s ON s.id = wb.session_id
+		WHERE s.user_id = ? AND wb.end_time IS NULL
+		ORDER BY wb.last_activity_time DESC
+	`
+
+	rows, err := wr.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open work blocks: %w", err)
+	}
+	defer rows.Close()
+
+	var workBlocks []*WorkBlock
+	for rows.Next() {
+		wb, err := scanWorkBlock(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan work block: %w", err)
+		}
+		workBlocks = append(workBlocks, wb)
+	}
+	return workBlocks, rows.Err()
 }
 
 /**
@@ -316,9 +504,7 @@ func (wr *WorkBlockRepository) GetBySession(ctx context.Context, sessionID strin
  */
 func (wr *WorkBlockRepository) GetAll(ctx context.Context) ([]*WorkBlock, error) {
 	query := `
-		SELECT wb.id, wb.session_id, wb.project_id, wb.start_time, wb.end_time,
-		       wb.state, wb.last_activity_time, wb.activity_count,
-		       wb.duration_seconds, wb.duration_hours, wb.created_at, wb.updated_at
+		SELECT ` + workBlockColumns + `
 		FROM work_blocks wb
 		ORDER BY wb.created_at DESC
 	`
@@ -331,23 +517,12 @@ func (wr *WorkBlockRepository) GetAll(ctx context.Context) ([]*WorkBlock, error)
 
 	var workBlocks []*WorkBlock
 	for rows.Next() {
-		var wb WorkBlock
-		var endTime sql.NullTime
-
-		err := rows.Scan(
-			&wb.ID, &wb.SessionID, &wb.ProjectID, &wb.StartTime, &endTime,
-			&wb.State, &wb.LastActivityTime, &wb.ActivityCount,
-			&wb.DurationSeconds, &wb.DurationHours, &wb.CreatedAt, &wb.UpdatedAt,
-		)
+		wb, err := scanWorkBlock(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan work block: %w", err)
 		}
 
-		if endTime.Valid {
-			wb.EndTime = &endTime.Time
-		}
-
-		workBlocks = append(workBlocks, &wb)
+		workBlocks = append(workBlocks, wb)
 	}
 
 	return workBlocks, nil
@@ -370,14 +545,14 @@ func (wr *WorkBlockRepository) Update(ctx context.Context, workBlock *WorkBlock)
 		UPDATE work_blocks 
 		SET session_id = ?, project_id = ?, start_time = ?, end_time = ?, 
 		    state = ?, last_activity_time = ?, activity_count = ?,
-		    duration_seconds = ?, duration_hours = ?, updated_at = ?
+		    duration_seconds = ?, duration_hours = ?, git_branch = ?, updated_at = ?
 		WHERE id = ?
 	`
 
 	result, err := wr.db.ExecContext(ctx, query,
 		workBlock.SessionID, workBlock.ProjectID, workBlock.StartTime, workBlock.EndTime,
 		workBlock.State, workBlock.LastActivityTime, workBlock.ActivityCount,
-		workBlock.DurationSeconds, workBlock.DurationHours, workBlock.UpdatedAt,
+		workBlock.DurationSeconds, workBlock.DurationHours, nullableString(workBlock.GitBranch), workBlock.UpdatedAt,
 		workBlock.ID,
 	)
 	if err != nil {
@@ -398,35 +573,76 @@ func (wr *WorkBlockRepository) Update(ctx context.Context, workBlock *WorkBlock)
 
 /**
  * CONTEXT:   Mark idle work blocks as finished
- * INPUT:     Current timestamp for idle detection
+ * INPUT:     Current timestamp in the database timezone (SQLiteDB.Now)
  * OUTPUT:    Count of work blocks marked as idle
- * BUSINESS:  Cleanup operation for idle work blocks
- * CHANGE:    CHECKPOINT 8 - Idle work block cleanup
+ * BUSINESS:  A block ends 5 minutes after its last activity, but never after
+ *            its session window closes
+ * CHANGE:    Compute end times in Go in the database timezone instead of with
+ *            datetime(), which wrote offset-less UTC next to local timestamps
  * RISK:      Medium - Bulk update operation affecting multiple work blocks
  */
 func (wr *WorkBlockRepository) MarkIdleWorkBlocks(ctx context.Context, currentTime time.Time) (int, error) {
-	idleThreshold := currentTime.Add(-5 * time.Minute)
-	
+	const idleTimeout = 5 * time.Minute
+	idleThreshold := currentTime.Add(-idleTimeout)
+
 	query := `
-		UPDATE work_blocks 
-		SET end_time = datetime(last_activity_time, '+5 minutes'),
-		    duration_seconds = CAST((julianday(datetime(last_activity_time, '+5 minutes')) - julianday(start_time)) * 86400 AS INTEGER),
-		    duration_hours = (julianday(datetime(last_activity_time, '+5 minutes')) - julianday(start_time)) * 24,
-		    state = 'idle',
-		    updated_at = ?
-		WHERE end_time IS NULL 
-		  AND last_activity_time < ?
+		SELECT wb.id, wb.start_time, wb.last_activity_time, s.end_time
+		FROM work_blocks wb
+		JOIN sessions s ON s.id = wb.session_id
+		WHERE wb.end_time IS NULL
+		  AND julianday(wb.last_activity_time) < julianday(?)
 	`
 
-	result, err := wr.db.ExecContext(ctx, query, currentTime, idleThreshold)
+	rows, err := wr.db.QueryContext(ctx, query, idleThreshold)
 	if err != nil {
-		return 0, fmt.Errorf("failed to mark idle work blocks: %w", err)
+		return 0, fmt.Errorf("failed to find idle work blocks: %w", err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	type idleBlock struct {
+		id                   string
+		start, last, session time.Time
+	}
+	var idle []idleBlock
+	for rows.Next() {
+		var block idleBlock
+		if err := rows.Scan(&block.id, &block.start, &block.last, &block.session); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan idle work block: %w", err)
+		}
+		idle = append(idle, block)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to find idle work blocks: %w", err)
+	}
+
+	update := `
+		UPDATE work_blocks
+		SET end_time = ?, duration_seconds = ?, duration_hours = ?,
+		    state = 'idle', updated_at = ?
+		WHERE id = ? AND end_time IS NULL
+	`
+
+	marked := 0
+	for _, block := range idle {
+		endTime := block.last.Add(idleTimeout)
+		if block.session.Before(endTime) {
+			endTime = block.session
+		}
+		if endTime.Before(block.start) {
+			endTime = block.start
+		}
+		endTime = endTime.In(currentTime.Location())
+		duration := endTime.Sub(block.start)
+
+		result, err := wr.db.ExecContext(ctx, update, endTime, int(duration.Seconds()), duration.Hours(), currentTime, block.id)
+		if err != nil {
+			return marked, fmt.Errorf("failed to mark idle work block %s: %w", block.id, err)
+		}
+		if n, err := result.RowsAffected(); err == nil {
+			marked += int(n)
+		}
 	}
 
-	return int(rowsAffected), nil
+	return marked, nil
 }
\ No newline at end of file
@@ -0,0 +1,479 @@
+/**
+ * CONTEXT:   Daemon health monitoring with periodic checks, readiness and alerts
+ * INPUT:     Registered checks, request timings and the check interval
+ * OUTPUT:    Latest check results, readiness and liveness state, active alerts
+ * BUSINESS:  The systemd watchdog, container probes and dashboards depend on an
+ *            accurate picture of whether the daemon is alive and able to serve
+ * CHANGE:    Hand raised and resolved alerts to a delivery callback
+ * RISK:      Medium - Readiness decides whether supervisors route work to the daemon
+ */
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Thresholds for the built-in checks
+const (
+	maxMemoryMB        = 500
+	maxResponseTime    = 100 * time.Millisecond
+	maxAlerts          = 100
+	livenessMultiplier = 3
+)
+
+// AlertLevel is the severity of a health alert
+type AlertLevel string
+
+const (
+	AlertLevelInfo     AlertLevel = "info"
+	AlertLevelWarning  AlertLevel = "warning"
+	AlertLevelError    AlertLevel = "error"
+	AlertLevelCritical AlertLevel = "critical"
+)
+
+// AlertType groups alerts by the resource they concern
+type AlertType string
+
+const (
+	AlertTypeMemory       AlertType = "memory"
+	AlertTypeCPU          AlertType = "cpu"
+	AlertTypeDatabase     AlertType = "database"
+	AlertTypeConnectivity AlertType = "connectivity"
+	AlertTypePerformance  AlertType = "performance"
+	AlertTypeScheduler    AlertType = "scheduler"
+)
+
+// Alert records a failing check; it resolves when the check passes again
+type Alert struct {
+	ID         string            `json:"id"`
+	Check      string            `json:"check"`
+	Level      AlertLevel        `json:"level"`
+	Type       AlertType         `json:"type"`
+	Message    string            `json:"message"`
+	Timestamp  time.Time         `json:"timestamp"`
+	Resolved   bool              `json:"resolved"`
+	ResolvedAt *time.Time        `json:"resolved_at,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+}
+
+// CheckDetail is the outcome of one check
+type CheckDetail struct {
+	Name      string            `json:"name"`
+	Healthy   bool              `json:"healthy"`
+	Readiness bool              `json:"readiness"`
+	Message   string            `json:"message"`
+	Duration  time.Duration     `json:"duration"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	Error     string            `json:"error,omitempty"`
+}
+
+// Result is the outcome of a full round of checks
+type Result struct {
+	ServiceName string                 `json:"service_name"`
+	Timestamp   time.Time              `json:"timestamp"`
+	Uptime      time.Duration          `json:"uptime"`
+	IsHealthy   bool                   `json:"is_healthy"`
+	Checks      map[string]CheckDetail `json:"checks"`
+}
+
+// Metrics are request statistics collected by the HTTP middleware
+type Metrics struct {
+	RequestCount     int64         `json:"request_count"`
+	ErrorCount       int64         `json:"error_count"`
+	AvgResponseTime  time.Duration `json:"avg_response_time"`
+	MemoryUsage      int64         `json:"memory_usage"`
+	LastRequestTime  time.Time     `json:"last_request_time"`
+	HealthCheckCount int64         `json:"health_check_count"`
+}
+
+// Details is the full health picture served to dashboards
+type Details struct {
+	Result       *Result  `json:"result,omitempty"`
+	LastCheck    string   `json:"last_check,omitempty"`
+	Live         bool     `json:"live"`
+	ActiveAlerts []Alert  `json:"active_alerts"`
+	Metrics      Metrics  `json:"metrics"`
+	Failing      []string `json:"failing,omitempty"`
+}
+
+/**
+ * CONTEXT:   A named health check registered with the monitor
+ * INPUT:     Run returns Healthy, Message, Metadata and Error; the monitor sets
+ *            Name, Readiness and Duration
+ * OUTPUT:    One CheckDetail per round
+ * BUSINESS:  Readiness checks gate /health/ready; the others only raise alerts
+ * CHANGE:    Initial pluggable check definition
+ * RISK:      Low - Checks run with the caller's context deadline
+ */
+type Check struct {
+	Name      string
+	Readiness bool
+	AlertType AlertType
+	Run       func(ctx context.Context) CheckDetail
+}
+
+/**
+ * CONTEXT:   Health monitor owned by the daemon orchestrator
+ * INPUT:     Checks registered at startup and request timings from middleware
+ * OUTPUT:    Periodic check results, alerts and liveness state
+ * BUSINESS:  One place answering "is the daemon alive, ready and healthy"
+ * CHANGE:    Moved from ServiceHealthMonitor; the orchestrator schedules RunChecks
+ * RISK:      Medium - Shared by request handlers and the scheduler; guarded by mu
+ */
+type Monitor struct {
+	mu          sync.RWMutex
+	serviceName string
+	interval    time.Duration
+	logger      *slog.Logger
+	startTime   time.Time
+	checks      []Check
+	last        *Result
+	lastRun     time.Time
+	alerts      []Alert
+	metrics     Metrics
+	onAlert     func(Alert)
+}
+
+// NewMonitor creates a monitor with the built-in memory, system and
+// responsiveness checks; interval is how often the owner calls RunChecks
+func NewMonitor(serviceName string, interval time.Duration, logger *slog.Logger) *Monitor {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	m := &Monitor{
+		serviceName: serviceName,
+		interval:    interval,
+		logger:      logger,
+		startTime:   time.Now(),
+	}
+	m.Register(Check{Name: "memory", AlertType: AlertTypeMemory, Run: m.checkMemoryUsage})
+	m.Register(Check{Name: "system", AlertType: AlertTypeCPU, Run: checkSystemResources})
+	m.Register(Check{Name: "responsiveness", AlertType: AlertTypePerformance, Run: m.checkResponsiveness})
+	return m
+}
+
+// OnAlert sets a callback for raised and resolved alerts; it runs after the
+// check round, outside the monitor's lock
+func (m *Monitor) OnAlert(fn func(Alert)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onAlert = fn
+}
+
+// Register adds a check; checks registered later replace ones with the same name
+func (m *Monitor) Register(check Check) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.checks {
+		if m.checks[i].Name == check.Name {
+			m.checks[i] = check
+			return
+		}
+	}
+	m.checks = append(m.checks, check)
+}
+
+/**
+ * CONTEXT:   Run every registered check and update alerts
+ * INPUT:     Context bounding the whole round
+ * OUTPUT:    Stored Result; alerts raised for new failures and resolved on recovery
+ * BUSINESS:  Called on HealthConfig.CheckInterval by the daemon scheduler; raised
+ *            and resolved alerts are handed to the OnAlert callback for delivery
+ * CHANGE:    Deliver alert changes through OnAlert
+ * RISK:      Low - A slow check delays only the round, never request handling
+ */
+func (m *Monitor) RunChecks(ctx context.Context) *Result {
+	started := time.Now()
+	result := &Result{
+		ServiceName: m.serviceName,
+		Timestamp:   started,
+		Uptime:      time.Since(m.startTime),
+		IsHealthy:   true,
+		Checks:      m.run(ctx, m.registered(false)),
+	}
+	for _, detail := range result.Checks {
+		if !detail.Healthy {
+			result.IsHealthy = false
+		}
+	}
+
+	var changed []Alert
+	m.mu.Lock()
+	m.last = result
+	m.lastRun = time.Now()
+	m.metrics.HealthCheckCount++
+	for _, check := range m.checks {
+		if detail, ok := result.Checks[check.Name]; ok {
+			if alert, ok := m.updateAlert(check, detail); ok {
+				changed = append(changed, alert)
+			}
+		}
+	}
+	onAlert := m.onAlert
+	m.mu.Unlock()
+
+	if onAlert != nil {
+		for _, alert := range changed {
+			onAlert(alert)
+		}
+	}
+
+	if result.IsHealthy {
+		m.logger.Debug("Health check passed", "duration", time.Since(started), "checks", len(result.Checks))
+	} else {
+		m.logger.Warn("Health check failed", "duration", time.Since(started), "failed_checks", strings.Join(failing(result), ","))
+	}
+	return result
+}
+
+/**
+ * CONTEXT:   Evaluate readiness on demand
+ * INPUT:     Request context
+ * OUTPUT:    Result covering only readiness checks, run now rather than cached
+ * BUSINESS:  Supervisors must not see "ready" from a stale round after a
+ *            database restore or scheduler stop
+ * CHANGE:    Initial readiness evaluation
+ * RISK:      Low - Readiness checks are expected to be cheap
+ */
+func (m *Monitor) Ready(ctx context.Context) *Result {
+	result := &Result{
+		ServiceName: m.serviceName,
+		Timestamp:   time.Now(),
+		Uptime:      time.Since(m.startTime),
+		IsHealthy:   true,
+		Checks:      m.run(ctx, m.registered(true)),
+	}
+	for _, detail := range result.Checks {
+		if !detail.Healthy {
+			result.IsHealthy = false
+		}
+	}
+	return result
+}
+
+// Live reports whether check rounds are still happening; a round missing for
+// several intervals means the daemon is wedged and should be restarted
+func (m *Monitor) Live() (bool, time.Time) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	reference := m.lastRun
+	if reference.IsZero() {
+		reference = m.startTime
+	}
+	return time.Since(reference) < livenessMultiplier*m.interval, m.lastRun
+}
+
+// Details returns the latest round, active alerts and request metrics
+func (m *Monitor) Details() Details {
+	live, lastRun := m.Live()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	details := Details{
+		Result:       m.last,
+		Live:         live,
+		ActiveAlerts: make([]Alert, 0),
+		Metrics:      m.metrics,
+	}
+	if !lastRun.IsZero() {
+		details.LastCheck = lastRun.UTC().Format(time.RFC3339)
+	}
+	if m.last != nil {
+		details.Failing = failing(m.last)
+	}
+	for _, alert := range m.alerts {
+		if !alert.Resolved {
+			details.ActiveAlerts = append(details.ActiveAlerts, alert)
+		}
+	}
+	return details
+}
+
+// Alerts returns recent alerts, resolved ones included, newest last
+func (m *Monitor) Alerts() []Alert {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]Alert(nil), m.alerts...)
+}
+
+// RecordRequest updates request statistics from the HTTP middleware
+func (m *Monitor) RecordRequest(responseTime time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.metrics.RequestCount++
+	m.metrics.LastRequestTime = time.Now()
+	if m.metrics.RequestCount == 1 {
+		m.metrics.AvgResponseTime = responseTime
+	} else {
+		// Exponential moving average weighted towards recent requests
+		m.metrics.AvgResponseTime = (m.metrics.AvgResponseTime + responseTime) / 2
+	}
+}
+
+// RecordError counts a request that failed with a server error
+func (m *Monitor) RecordError() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metrics.ErrorCount++
+}
+
+// registered returns a snapshot of the checks, optionally readiness checks only
+func (m *Monitor) registered(readinessOnly bool) []Check {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	checks := make([]Check, 0, len(m.checks))
+	for _, check := range m.checks {
+		if !readinessOnly || check.Readiness {
+			checks = append(checks, check)
+		}
+	}
+	return checks
+}
+
+// run executes checks in registration order
+func (m *Monitor) run(ctx context.Context, checks []Check) map[string]CheckDetail {
+	results := make(map[string]CheckDetail, len(checks))
+	for _, check := range checks {
+		started := time.Now()
+		detail := check.Run(ctx)
+		detail.Name = check.Name
+		detail.Readiness = check.Readiness
+		detail.Duration = time.Since(started)
+		results[check.Name] = detail
+	}
+	return results
+}
+
+// updateAlert raises an alert for a newly failing check or resolves the
+// active one once the check passes, returning the alert if either happened;
+// callers hold mu
+func (m *Monitor) updateAlert(check Check, detail CheckDetail) (Alert, bool) {
+	active := -1
+	for i := range m.alerts {
+		if m.alerts[i].Check == check.Name && !m.alerts[i].Resolved {
+			active = i
+		}
+	}
+
+	if detail.Healthy {
+		if active >= 0 {
+			now := time.Now()
+			m.alerts[active].Resolved = true
+			m.alerts[active].ResolvedAt = &now
+			m.logger.Info("Health alert resolved", "check", check.Name, "alert_id", m.alerts[active].ID)
+			return m.alerts[active], true
+		}
+		return Alert{}, false
+	}
+	if active >= 0 {
+		m.alerts[active].Message = detail.Message
+		m.alerts[active].Metadata = detail.Metadata
+		return Alert{}, false
+	}
+
+	level := AlertLevelWarning
+	if check.Readiness {
+		level = AlertLevelError
+	}
+	alert := Alert{
+		ID:        fmt.Sprintf("alert_%d", time.Now().UnixNano()),
+		Check:     check.Name,
+		Level:     level,
+		Type:      check.AlertType,
+		Message:   detail.Message,
+		Timestamp: time.Now(),
+		Metadata:  detail.Metadata,
+	}
+	m.alerts = append(m.alerts, alert)
+	if len(m.alerts) > maxAlerts {
+		m.alerts = m.alerts[len(m.alerts)-maxAlerts:]
+	}
+	m.logger.Warn("Health alert raised", "check", check.Name, "level", level, "message", detail.Message)
+	return alert, true
+}
+
+// failing lists the names of unhealthy checks in a result
+func failing(result *Result) []string {
+	var names []string
+	for name, detail := range result.Checks {
+		if !detail.Healthy {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkMemoryUsage flags heap usage above maxMemoryMB
+func (m *Monitor) checkMemoryUsage(ctx context.Context) CheckDetail {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	m.mu.Lock()
+	m.metrics.MemoryUsage = int64(stats.Alloc)
+	m.mu.Unlock()
+
+	memoryMB := float64(stats.Alloc) / (1024 * 1024)
+	detail := CheckDetail{
+		Healthy: memoryMB < maxMemoryMB,
+		Metadata: map[string]string{
+			"alloc_mb":   fmt.Sprintf("%.2f", memoryMB),
+			"sys_mb":     fmt.Sprintf("%.2f", float64(stats.Sys)/(1024*1024)),
+			"gc_count":   fmt.Sprintf("%d", stats.NumGC),
+			"goroutines": fmt.Sprintf("%d", runtime.NumGoroutine()),
+		},
+	}
+	if detail.Healthy {
+		detail.Message = fmt.Sprintf("Memory usage normal: %.2f MB", memoryMB)
+	} else {
+		detail.Message = fmt.Sprintf("Memory usage high: %.2f MB (limit: %d MB)", memoryMB, maxMemoryMB)
+		detail.Error = "Memory usage exceeds threshold"
+	}
+	return detail
+}
+
+// checkSystemResources reports the runtime platform
+func checkSystemResources(ctx context.Context) CheckDetail {
+	return CheckDetail{
+		Healthy: true,
+		Message: "System resources normal",
+		Metadata: map[string]string{
+			"platform": runtime.GOOS,
+			"arch":     runtime.GOARCH,
+			"version":  runtime.Version(),
+			"cpus":     fmt.Sprintf("%d", runtime.NumCPU()),
+		},
+	}
+}
+
+// checkResponsiveness flags a moving average response time above maxResponseTime
+func (m *Monitor) checkResponsiveness(ctx context.Context) CheckDetail {
+	m.mu.RLock()
+	metrics := m.metrics
+	m.mu.RUnlock()
+
+	detail := CheckDetail{
+		Healthy: metrics.AvgResponseTime < maxResponseTime,
+		Metadata: map[string]string{
+			"avg_response_ms": fmt.Sprintf("%.2f", float64(metrics.AvgResponseTime)/float64(time.Millisecond)),
+			"request_count":   fmt.Sprintf("%d", metrics.RequestCount),
+			"error_count":     fmt.Sprintf("%d", metrics.ErrorCount),
+		},
+	}
+	if detail.Healthy {
+		detail.Message = "Service responsiveness normal"
+	} else {
+		detail.Message = fmt.Sprintf("Service response time high: %v", metrics.AvgResponseTime)
+		detail.Error = "Response time exceeds threshold"
+	}
+	return detail
+}
@@ -0,0 +1,77 @@
+/**
+ * CONTEXT:   Test suite for the daemon health monitor
+ * INPUT:     Monitors with stub checks
+ * OUTPUT:    Validation of readiness, liveness and alert lifecycle
+ * BUSINESS:  Probes and dashboards rely on these answers being accurate
+ * CHANGE:    Initial health monitor tests
+ * RISK:      Low - In-memory tests only
+ */
+
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonitorReadinessAndAlerts(t *testing.T) {
+	monitor := NewMonitor("test", time.Minute, nil)
+
+	dbHealthy := false
+	monitor.Register(Check{
+		Name:      "database",
+		Readiness: true,
+		AlertType: AlertTypeDatabase,
+		Run: func(ctx context.Context) CheckDetail {
+			if !dbHealthy {
+				return CheckDetail{Message: "Database unreachable", Error: "down"}
+			}
+			return CheckDetail{Healthy: true, Message: "Database reachable"}
+		},
+	})
+
+	ready := monitor.Ready(context.Background())
+	assert.False(t, ready.IsHealthy)
+	assert.Len(t, ready.Checks, 1, "readiness runs only readiness checks")
+
+	result := monitor.RunChecks(context.Background())
+	assert.False(t, result.IsHealthy)
+	details := monitor.Details()
+	require.Len(t, details.ActiveAlerts, 1)
+	assert.Equal(t, "database", details.ActiveAlerts[0].Check)
+	assert.Equal(t, AlertLevelError, details.ActiveAlerts[0].Level)
+	assert.Equal(t, []string{"database"}, details.Failing)
+
+	// A check that keeps failing does not raise a second alert
+	monitor.RunChecks(context.Background())
+	assert.Len(t, monitor.Alerts(), 1)
+
+	dbHealthy = true
+	assert.True(t, monitor.Ready(context.Background()).IsHealthy)
+	assert.True(t, monitor.RunChecks(context.Background()).IsHealthy)
+	assert.Empty(t, monitor.Details().ActiveAlerts)
+	alerts := monitor.Alerts()
+	require.Len(t, alerts, 1)
+	assert.True(t, alerts[0].Resolved)
+	assert.NotNil(t, alerts[0].ResolvedAt)
+}
+
+func TestMonitorLiveness(t *testing.T) {
+	monitor := NewMonitor("test", 10*time.Millisecond, nil)
+	live, lastRun := monitor.Live()
+	assert.True(t, live, "a new monitor is live until rounds are overdue")
+	assert.True(t, lastRun.IsZero())
+
+	time.Sleep(40 * time.Millisecond)
+	live, _ = monitor.Live()
+	assert.False(t, live, "missing rounds mean the daemon is stalled")
+
+	monitor.RunChecks(context.Background())
+	live, lastRun = monitor.Live()
+	assert.True(t, live)
+	assert.False(t, lastRun.IsZero())
+}
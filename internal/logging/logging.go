@@ -0,0 +1,208 @@
+/**
+ * CONTEXT:   Single structured logging subsystem for the daemon and its packages
+ * INPUT:     LoggingConfig (level, json/text format, output file and rotation)
+ * OUTPUT:    slog loggers writing to stdout and/or a rotating log file, with
+ *            request-scoped attributes carried through context
+ * BUSINESS:  One consistent, machine-readable log replaces emoji log.Printf
+ *            lines, so `service logs` and log shippers can filter by request,
+ *            user, session and work block
+ * CHANGE:    Initial slog handler factory
+ * RISK:      Medium - A failing log file must never stop activity ingestion;
+ *            write errors are dropped by slog
+ */
+
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	cfg "github.com/claude-monitor/system/internal/config"
+)
+
+// Attribute keys shared by every package that logs an ingestion
+const (
+	KeyRequestID = "request_id"
+	KeyUser      = "user_id"
+	KeySession   = "session_id"
+	KeyWorkBlock = "work_block_id"
+	KeyProject   = "project_id"
+)
+
+// RequestIDHeader carries a caller-supplied request ID through the daemon
+const RequestIDHeader = "X-Request-ID"
+
+// nopCloser is returned when no log file is opened
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
+
+/**
+ * CONTEXT:   Build the daemon logger from configuration
+ * INPUT:     Logging configuration and console writer (normally stdout)
+ * OUTPUT:    Logger, closer for the log file, or an error for bad settings
+ * BUSINESS:  With an output file the log is written there and rotated; the
+ *            console copy keeps `claude-monitor daemon` readable in a terminal
+ *            and feeds journald under systemd
+ * CHANGE:    Initial factory
+ * RISK:      Low - Fails fast on an unknown level/format or unwritable file
+ */
+func New(config cfg.LoggingConfig, console io.Writer) (*slog.Logger, io.Closer, error) {
+	level, err := ParseLevel(config.Level)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var writers []io.Writer
+	var closer io.Closer = nopCloser{}
+	if console != nil {
+		writers = append(writers, console)
+	}
+	if config.OutputFile != "" {
+		file, err := OpenRotatingFile(config.OutputFile, config.MaxSizeMB, config.MaxBackups, config.MaxAgeDays)
+		if err != nil {
+			return nil, nil, err
+		}
+		writers = append(writers, file)
+		closer = file
+	}
+	if len(writers) == 0 {
+		writers = append(writers, io.Discard)
+	}
+
+	handler, err := NewHandler(io.MultiWriter(writers...), config.Format, level)
+	if err != nil {
+		closer.Close()
+		return nil, nil, err
+	}
+	return slog.New(handler), closer, nil
+}
+
+// NewHandler creates a json or text handler that adds context attributes
+func NewHandler(w io.Writer, format string, level slog.Leveler) (slog.Handler, error) {
+	options := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch strings.ToLower(format) {
+	case "", "json":
+		handler = slog.NewJSONHandler(w, options)
+	case "text":
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return nil, fmt.Errorf("invalid log format %q, must be json or text", format)
+	}
+	return &contextHandler{Handler: handler}, nil
+}
+
+// NewCLILogger returns the logger used by short-lived CLI commands: text on
+// stderr, warnings and errors only, so command output stays clean
+func NewCLILogger() *slog.Logger {
+	handler, _ := NewHandler(os.Stderr, "text", slog.LevelWarn)
+	return slog.New(handler)
+}
+
+// ParseLevel converts a configured level name into an slog level
+func ParseLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelInfo, fmt.Errorf("invalid log level %q, must be debug, info, warn or error", level)
+	}
+}
+
+// contextKey stores request-scoped attributes in a context
+type contextKey struct{}
+
+// With returns a context whose log records carry the given key/value pairs in
+// addition to those already attached; later values for a key win
+func With(ctx context.Context, args ...any) context.Context {
+	record := slog.Record{}
+	record.Add(args...)
+
+	existing := Attrs(ctx)
+	attrs := make([]slog.Attr, 0, len(existing)+record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+		return true
+	})
+	for _, attr := range existing {
+		if !hasKey(attrs, attr.Key) {
+			attrs = append(attrs, attr)
+		}
+	}
+	return context.WithValue(ctx, contextKey{}, attrs)
+}
+
+// Attrs returns the request-scoped attributes attached to ctx
+func Attrs(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	attrs, _ := ctx.Value(contextKey{}).([]slog.Attr)
+	return attrs
+}
+
+// RequestID returns the request ID attached to ctx, if any
+func RequestID(ctx context.Context) string {
+	for _, attr := range Attrs(ctx) {
+		if attr.Key == KeyRequestID {
+			return attr.Value.String()
+		}
+	}
+	return ""
+}
+
+// NewRequestID generates a short random request ID
+func NewRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "req-unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// hasKey reports whether attrs already contains key
+func hasKey(attrs []slog.Attr, key string) bool {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// contextHandler adds attributes stored with With to every record
+type contextHandler struct {
+	slog.Handler
+}
+
+// Handle appends request-scoped attributes before delegating
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if attrs := Attrs(ctx); len(attrs) > 0 {
+		record = record.Clone()
+		record.AddAttrs(attrs...)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs keeps the context handler in the chain
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup keeps the context handler in the chain
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,151 @@
+/**
+ * CONTEXT:   Test suite for the logging factory and rotating log file
+ * INPUT:     Logging configurations writing into temporary directories
+ * OUTPUT:    Validation of formats, levels, context attributes and rotation limits
+ * BUSINESS:  Every ingestion log line must carry its request attributes, and
+ *            the log must stay within its size, backup and age limits
+ * CHANGE:    Initial logging tests
+ * RISK:      Low - Temp files only
+ */
+
+package logging
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/claude-monitor/system/internal/config"
+)
+
+func TestNewWritesJSONWithContextAttributes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "daemon.log")
+	var console bytes.Buffer
+	logger, closer, err := New(cfg.LoggingConfig{Level: "info", Format: "json", OutputFile: path}, &console)
+	require.NoError(t, err)
+
+	ctx := With(context.Background(), KeyRequestID, "req-1", KeyUser, "alice")
+	ctx = With(ctx, KeySession, "session-1", KeyWorkBlock, "block-1")
+	logger.DebugContext(ctx, "dropped below level")
+	logger.InfoContext(ctx, "Activity recorded", "tool", "Edit")
+	require.NoError(t, closer.Close())
+
+	assert.Equal(t, "req-1", RequestID(ctx))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, console.String(), string(data), "console and file receive the same records")
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 1)
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	assert.Equal(t, "Activity recorded", record["msg"])
+	assert.Equal(t, "req-1", record[KeyRequestID])
+	assert.Equal(t, "alice", record[KeyUser])
+	assert.Equal(t, "session-1", record[KeySession])
+	assert.Equal(t, "block-1", record[KeyWorkBlock])
+	assert.Equal(t, "Edit", record["tool"])
+}
+
+func TestNewRejectsInvalidSettings(t *testing.T) {
+	_, _, err := New(cfg.LoggingConfig{Level: "verbose", Format: "json"}, nil)
+	assert.Error(t, err)
+	_, _, err = New(cfg.LoggingConfig{Level: "info", Format: "xml"}, nil)
+	assert.Error(t, err)
+
+	var out bytes.Buffer
+	logger, _, err := New(cfg.LoggingConfig{Level: "warn", Format: "text"}, &out)
+	require.NoError(t, err)
+	logger.Info("hidden")
+	logger.Warn("shown", "key", "value")
+	assert.NotContains(t, out.String(), "hidden")
+	assert.Contains(t, out.String(), "msg=shown key=value")
+}
+
+func TestRotatingFileLimitsSizeAndBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.log")
+	file, err := OpenRotatingFile(path, 1, 2, 0)
+	require.NoError(t, err)
+	defer file.Close()
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	file.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	line := bytes.Repeat([]byte("x"), 400*1024)
+	for i := 0; i < 10; i++ {
+		_, err := file.Write(line)
+		require.NoError(t, err)
+	}
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, info.Size(), int64(megabyte))
+
+	backups, err := BackupFiles(path)
+	require.NoError(t, err)
+	require.Len(t, backups, 2, "only MaxBackups rotated files are kept")
+	assert.Less(t, backups[0], backups[1], "backups sort oldest first")
+	for _, backup := range backups {
+		assert.True(t, strings.HasPrefix(filepath.Base(backup), "daemon-20261018T"))
+	}
+
+	require.NoError(t, file.Close())
+	_, err = file.Write(line)
+	assert.ErrorIs(t, err, os.ErrClosed)
+}
+
+func TestRotatingFilePrunesOldBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "daemon.log")
+	stale := filepath.Join(dir, "daemon-20260101T000000.000.log")
+	recent := filepath.Join(dir, "daemon-20261017T000000.000.log")
+	unrelated := filepath.Join(dir, "daemon-notes.log")
+	for _, name := range []string{stale, recent, unrelated} {
+		require.NoError(t, os.WriteFile(name, []byte("old\n"), 0644))
+	}
+	now := time.Now()
+	require.NoError(t, os.Chtimes(stale, now.AddDate(0, 0, -40), now.AddDate(0, 0, -40)))
+
+	file, err := OpenRotatingFile(path, 1, 0, 30)
+	require.NoError(t, err)
+	_, err = file.Write([]byte("current\n"))
+	require.NoError(t, err)
+	require.NoError(t, file.Rotate())
+	require.NoError(t, file.Close())
+
+	backups, err := BackupFiles(path)
+	require.NoError(t, err)
+	assert.Len(t, backups, 2)
+	assert.NotContains(t, backups, stale, "backups older than MaxAgeDays are removed")
+	assert.Contains(t, backups, recent)
+	assert.FileExists(t, unrelated, "files without a rotation timestamp are left alone")
+
+	// The rotated backup holds the previous content and the live file restarts empty
+	current := backups[0]
+	if current == recent {
+		current = backups[1]
+	}
+	rotated, err := os.Open(current)
+	require.NoError(t, err)
+	defer rotated.Close()
+	scanner := bufio.NewScanner(rotated)
+	require.True(t, scanner.Scan())
+	assert.Equal(t, "current", scanner.Text())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+}
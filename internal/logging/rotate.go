@@ -0,0 +1,199 @@
+/**
+ * CONTEXT:   Size-rotated log file with backup count and age limits
+ * INPUT:     Log file path, maximum size, backups to keep and maximum age
+ * OUTPUT:    io.WriteCloser appending to the live file and rotating it
+ * BUSINESS:  The daemon runs for months; its log must not fill the disk
+ * CHANGE:    Initial rotation, replacing ServiceLogger's separate logic
+ * RISK:      Medium - Rotation renames files that `service logs` may be
+ *            following; rotated names sort chronologically for that reason
+ */
+
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is embedded in rotated file names; it sorts chronologically
+const backupTimeFormat = "20060102T150405.000"
+
+// Default rotation limits applied when the configuration leaves them at zero
+const (
+	defaultMaxSizeMB = 100
+	megabyte         = 1024 * 1024
+)
+
+/**
+ * CONTEXT:   Rotating log file writer
+ * INPUT:     Log records from slog handlers
+ * OUTPUT:    Live file plus rotated backups named <base>-<timestamp><ext>
+ * BUSINESS:  Backups beyond MaxBackups or older than MaxAgeDays are deleted
+ * CHANGE:    Initial rotating writer
+ * RISK:      Low - Serialized by a mutex; a failed rotation keeps writing to
+ *            the current file
+ */
+type RotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	maxAge     time.Duration
+	file       *os.File
+	size       int64
+	closed     bool
+	now        func() time.Time
+}
+
+// OpenRotatingFile opens (or creates) path for appending; zero maxBackups or
+// maxAgeDays keeps backups without that limit
+func OpenRotatingFile(path string, maxSizeMB, maxBackups, maxAgeDays int) (*RotatingFile, error) {
+	if maxSizeMB <= 0 {
+		maxSizeMB = defaultMaxSizeMB
+	}
+	r := &RotatingFile{
+		path:       path,
+		maxSize:    int64(maxSizeMB) * megabyte,
+		maxBackups: maxBackups,
+		maxAge:     time.Duration(maxAgeDays) * 24 * time.Hour,
+		now:        time.Now,
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Write appends p, rotating first when it would exceed the size limit
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return 0, os.ErrClosed
+	}
+	if r.file == nil {
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			fmt.Fprintf(os.Stderr, "claude-monitor: log rotation failed: %v\n", err)
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Rotate forces a rotation, e.g. on SIGHUP or from tests
+func (r *RotatingFile) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rotate()
+}
+
+// Close closes the live file; later writes fail with os.ErrClosed
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// open opens the live file for appending and records its size
+func (r *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+// rotate renames the live file to a timestamped backup and opens a new one;
+// callers hold mu
+func (r *RotatingFile) rotate() error {
+	if r.file != nil {
+		r.file.Close()
+		r.file = nil
+	}
+
+	ext := filepath.Ext(r.path)
+	base := strings.TrimSuffix(r.path, ext)
+	backup := fmt.Sprintf("%s-%s%s", base, r.now().UTC().Format(backupTimeFormat), ext)
+	if err := os.Rename(r.path, backup); err != nil && !os.IsNotExist(err) {
+		r.open()
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	if err := r.open(); err != nil {
+		return err
+	}
+	return r.prune()
+}
+
+// prune deletes backups beyond the count and age limits
+func (r *RotatingFile) prune() error {
+	backups, err := BackupFiles(r.path)
+	if err != nil {
+		return err
+	}
+
+	now := r.now()
+	for i, backup := range backups {
+		tooMany := r.maxBackups > 0 && i < len(backups)-r.maxBackups
+		tooOld := false
+		if r.maxAge > 0 {
+			if info, err := os.Stat(backup); err == nil {
+				tooOld = now.Sub(info.ModTime()) > r.maxAge
+			}
+		}
+		if tooMany || tooOld {
+			if err := os.Remove(backup); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove old log %s: %w", backup, err)
+			}
+		}
+	}
+	return nil
+}
+
+// BackupFiles lists rotated backups of path, oldest first
+func BackupFiles(path string) ([]string, error) {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	matches, err := filepath.Glob(base + "-*" + ext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list log backups: %w", err)
+	}
+
+	backups := matches[:0]
+	for _, match := range matches {
+		stamp := strings.TrimSuffix(strings.TrimPrefix(match, base+"-"), ext)
+		if _, err := time.Parse(backupTimeFormat, stamp); err == nil {
+			backups = append(backups, match)
+		}
+	}
+	sort.Strings(backups)
+	return backups, nil
+}
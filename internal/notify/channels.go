@@ -0,0 +1,254 @@
+/**
+ * CONTEXT:   Alert delivery channels
+ * INPUT:     Channel configuration and notifications
+ * OUTPUT:    Desktop notification command, webhook POST, email or file line
+ * BUSINESS:  Each team member picks the channel they actually watch
+ * CHANGE:    Initial command, webhook, email and file channels
+ * RISK:      Medium - Channels reach outside the process; every delivery is
+ *            bounded by the dispatcher's timeout
+ */
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	cfg "github.com/claude-monitor/system/internal/config"
+)
+
+// NewChannel creates the notifier for one configured channel
+func NewChannel(config cfg.AlertChannelConfig) (Notifier, error) {
+	switch config.Type {
+	case "command":
+		return &CommandNotifier{name: config.Name, command: config.Command}, nil
+	case "webhook":
+		return &WebhookNotifier{name: config.Name, url: config.URL, headers: config.Headers, client: &http.Client{}}, nil
+	case "email":
+		return &EmailNotifier{name: config.Name, smtp: config.SMTP}, nil
+	case "file":
+		return &FileNotifier{name: config.Name, path: config.Path}, nil
+	default:
+		return nil, fmt.Errorf("channel %q: unknown type %q", config.Name, config.Type)
+	}
+}
+
+/**
+ * CONTEXT:   Command channel for local desktop notifications
+ * INPUT:     Command and arguments with {title}, {message}, {level}, {type}
+ *            and {source} placeholders
+ * OUTPUT:    Command run once per notification
+ * BUSINESS:  Works with notify-send, osascript, terminal-notifier or any script
+ * CHANGE:    Initial command channel
+ * RISK:      Low - Runs without a shell, so alert text cannot inject commands
+ */
+type CommandNotifier struct {
+	name    string
+	command []string
+}
+
+// Name returns the channel name
+func (c *CommandNotifier) Name() string { return c.name }
+
+// Notify runs the command with placeholders expanded and the alert in the environment
+func (c *CommandNotifier) Notify(ctx context.Context, n Notification) error {
+	replacer := strings.NewReplacer(
+		"{title}", n.Title,
+		"{message}", n.Message,
+		"{level}", n.Level,
+		"{type}", n.Type,
+		"{source}", n.Source,
+	)
+	args := make([]string, len(c.command))
+	for i, arg := range c.command {
+		args[i] = replacer.Replace(arg)
+	}
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Env = append(os.Environ(),
+		"CLAUDE_MONITOR_ALERT_TITLE="+n.Title,
+		"CLAUDE_MONITOR_ALERT_MESSAGE="+n.Message,
+		"CLAUDE_MONITOR_ALERT_LEVEL="+n.Level,
+		"CLAUDE_MONITOR_ALERT_TYPE="+n.Type,
+		"CLAUDE_MONITOR_ALERT_SOURCE="+n.Source,
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", args[0], err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+/**
+ * CONTEXT:   Webhook channel
+ * INPUT:     URL, optional headers and notifications
+ * OUTPUT:    JSON POST of the notification
+ * BUSINESS:  Feeds chat integrations and incident tools
+ * CHANGE:    Initial webhook channel
+ * RISK:      Low - Non-2xx responses are reported as delivery failures
+ */
+type WebhookNotifier struct {
+	name    string
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// Name returns the channel name
+func (w *WebhookNotifier) Name() string { return w.name }
+
+// Notify posts the notification as JSON
+func (w *WebhookNotifier) Notify(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "claude-monitor")
+	for key, value := range w.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+/**
+ * CONTEXT:   Email channel over SMTP
+ * INPUT:     SMTP server, credentials, envelope and notifications
+ * OUTPUT:    Plain-text email per notification
+ * BUSINESS:  Reaches people who are away from the machine
+ * CHANGE:    Initial email channel
+ * RISK:      Medium - STARTTLS is used when offered; PLAIN auth is only sent
+ *            over TLS or to localhost, as enforced by net/smtp
+ */
+type EmailNotifier struct {
+	name string
+	smtp cfg.SMTPConfig
+}
+
+// Name returns the channel name
+func (e *EmailNotifier) Name() string { return e.name }
+
+// Notify sends the notification as an email
+func (e *EmailNotifier) Notify(ctx context.Context, n Notification) error {
+	port := e.smtp.Port
+	if port == 0 {
+		port = 587
+	}
+	addr := net.JoinHostPort(e.smtp.Host, strconv.Itoa(port))
+
+	var auth smtp.Auth
+	if e.smtp.Username != "" {
+		auth = smtp.PlainAuth("", e.smtp.Username, e.smtp.Password, e.smtp.Host)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(addr, auth, e.smtp.From, e.smtp.To, e.message(n))
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("smtp delivery failed: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("smtp delivery timed out: %w", ctx.Err())
+	}
+}
+
+// message renders the notification as an RFC 5322 message
+func (e *EmailNotifier) message(n Notification) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", e.smtp.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(e.smtp.To, ", "))
+	fmt.Fprintf(&b, "Subject: [claude-monitor] %s\r\n", sanitizeHeader(n.Title))
+	fmt.Fprintf(&b, "Date: %s\r\n", n.Timestamp.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&b, "%s\r\n\r\n", n.Message)
+	fmt.Fprintf(&b, "Level:  %s\r\nType:   %s\r\nSource: %s\r\nTime:   %s\r\n",
+		n.Level, n.Type, n.Source, n.Timestamp.Local().Format("2006-01-02 15:04:05"))
+
+	keys := make([]string, 0, len(n.Metadata))
+	for key := range n.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%s: %s\r\n", key, n.Metadata[key])
+	}
+	return []byte(b.String())
+}
+
+// sanitizeHeader keeps alert text from breaking out of a header line
+func sanitizeHeader(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
+
+/**
+ * CONTEXT:   File channel
+ * INPUT:     File path and notifications
+ * OUTPUT:    One JSON line appended per notification
+ * BUSINESS:  Simple audit trail and input for custom tooling
+ * CHANGE:    Initial file channel
+ * RISK:      Low - Appends with owner-only permissions
+ */
+type FileNotifier struct {
+	mu   sync.Mutex
+	name string
+	path string
+}
+
+// Name returns the channel name
+func (f *FileNotifier) Name() string { return f.name }
+
+// Notify appends the notification as a JSON line
+func (f *FileNotifier) Notify(ctx context.Context, n Notification) error {
+	line, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return fmt.Errorf("failed to create alert directory: %w", err)
+	}
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open alert file: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write alert file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+/**
+ * CONTEXT:   Alert delivery with routing rules and cooldown windows
+ * INPUT:     Notifications from the health monitor and the work alert job
+ * OUTPUT:    Notifications delivered to the matching channels
+ * BUSINESS:  Users hear about a failing daemon or an ending session window
+ *            where they already look: desktop, chat webhook, email or a file
+ * CHANGE:    Initial notifier subsystem
+ * RISK:      Medium - A misconfigured rule can flood a channel; cooldowns are
+ *            kept per channel and dedup key
+ */
+
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	cfg "github.com/claude-monitor/system/internal/config"
+)
+
+// Notification sources
+const (
+	SourceHealth = "health"
+	SourceWork   = "work"
+)
+
+// Notification levels, matching the health alert levels
+const (
+	LevelInfo     = "info"
+	LevelWarning  = "warning"
+	LevelError    = "error"
+	LevelCritical = "critical"
+)
+
+// deliveryTimeout bounds one delivery to one channel
+const deliveryTimeout = 10 * time.Second
+
+// Notification is one alert to deliver
+type Notification struct {
+	Key       string            `json:"key"`
+	Source    string            `json:"source"`
+	Level     string            `json:"level"`
+	Type      string            `json:"type"`
+	Title     string            `json:"title"`
+	Message   string            `json:"message"`
+	Timestamp time.Time         `json:"timestamp"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+// Notifier delivers notifications to one channel
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, n Notification) error
+}
+
+// rule routes notifications matching its filters to channels
+type rule struct {
+	channels []string
+	sources  map[string]bool
+	levels   map[string]bool
+	types    map[string]bool
+	cooldown time.Duration
+}
+
+// matches reports whether every non-empty filter accepts the notification
+func (r rule) matches(n Notification) bool {
+	return matchSet(r.sources, n.Source) && matchSet(r.levels, n.Level) && matchSet(r.types, n.Type)
+}
+
+// matchSet treats an empty set as matching everything
+func matchSet(set map[string]bool, value string) bool {
+	return len(set) == 0 || set[value]
+}
+
+/**
+ * CONTEXT:   Dispatcher routing notifications to channels
+ * INPUT:     Channels and rules from AlertsConfig
+ * OUTPUT:    Deliveries respecting per-channel cooldowns
+ * BUSINESS:  Without rules every notification goes to every channel
+ * CHANGE:    Initial dispatcher
+ * RISK:      Medium - Deliveries are synchronous; each is bounded by deliveryTimeout
+ */
+type Dispatcher struct {
+	mu       sync.Mutex
+	channels map[string]Notifier
+	order    []string
+	rules    []rule
+	cooldown time.Duration
+	sent     map[string]time.Time // channel + key -> last delivery
+	logger   *slog.Logger
+	now      func() time.Time
+}
+
+// NewDispatcher builds channels and rules from configuration
+func NewDispatcher(config cfg.AlertsConfig, logger *slog.Logger) (*Dispatcher, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	d := &Dispatcher{
+		channels: make(map[string]Notifier),
+		cooldown: time.Duration(config.CooldownMinutes) * time.Minute,
+		sent:     make(map[string]time.Time),
+		logger:   logger,
+		now:      time.Now,
+	}
+	for _, channel := range config.Channels {
+		notifier, err := NewChannel(channel)
+		if err != nil {
+			return nil, err
+		}
+		d.Add(notifier)
+	}
+	for _, r := range config.Rules {
+		d.rules = append(d.rules, rule{
+			channels: r.Channels,
+			sources:  toSet(r.Sources),
+			levels:   toSet(r.Levels),
+			types:    toSet(r.Types),
+			cooldown: time.Duration(r.CooldownMinutes) * time.Minute,
+		})
+	}
+	return d, nil
+}
+
+// Add registers a channel; without rules it receives every notification
+func (d *Dispatcher) Add(notifier Notifier) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, exists := d.channels[notifier.Name()]; !exists {
+		d.order = append(d.order, notifier.Name())
+	}
+	d.channels[notifier.Name()] = notifier
+}
+
+/**
+ * CONTEXT:   Deliver a notification to every matching channel
+ * INPUT:     Notification with a dedup key
+ * OUTPUT:    Names of channels delivered to; joined errors of failed deliveries
+ * BUSINESS:  A key delivered to a channel within its cooldown is skipped, so a
+ *            flapping check or a repeated work alert notifies once per window
+ * CHANGE:    Initial send
+ * RISK:      Low - A failed delivery is not recorded, so it is retried next time
+ */
+func (d *Dispatcher) Send(ctx context.Context, n Notification) ([]string, error) {
+	if n.Timestamp.IsZero() {
+		n.Timestamp = d.now()
+	}
+	if n.Key == "" {
+		n.Key = n.Source + ":" + n.Type + ":" + n.Title
+	}
+
+	targets := d.route(n)
+	var delivered []string
+	var errs []error
+	for _, target := range targets {
+		deliveryCtx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+		err := target.notifier.Notify(deliveryCtx, n)
+		cancel()
+		if err != nil {
+			d.release(target.notifier.Name(), n.Key, target.previous)
+			d.logger.Warn("Alert delivery failed", "channel", target.notifier.Name(), "key", n.Key, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", target.notifier.Name(), err))
+			continue
+		}
+		delivered = append(delivered, target.notifier.Name())
+	}
+	if len(delivered) > 0 {
+		d.logger.Info("Alert delivered", "key", n.Key, "level", n.Level, "channels", delivered)
+	}
+	return delivered, errors.Join(errs...)
+}
+
+// target is a channel selected for one notification
+type target struct {
+	notifier Notifier
+	previous time.Time
+}
+
+// route selects channels outside their cooldown and reserves them
+func (d *Dispatcher) route(n Notification) []target {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cooldowns := make(map[string]time.Duration)
+	if len(d.rules) == 0 {
+		for _, name := range d.order {
+			cooldowns[name] = d.cooldown
+		}
+	}
+	for _, r := range d.rules {
+		if !r.matches(n) {
+			continue
+		}
+		cooldown := d.cooldown
+		if r.cooldown > 0 {
+			cooldown = r.cooldown
+		}
+		for _, name := range r.channels {
+			if existing, ok := cooldowns[name]; !ok || cooldown < existing {
+				cooldowns[name] = cooldown
+			}
+		}
+	}
+
+	now := d.now()
+	var targets []target
+	for _, name := range d.order {
+		cooldown, ok := cooldowns[name]
+		if !ok {
+			continue
+		}
+		key := name + "\x00" + n.Key
+		previous := d.sent[key]
+		if !previous.IsZero() && now.Sub(previous) < cooldown {
+			continue
+		}
+		d.sent[key] = now
+		targets = append(targets, target{notifier: d.channels[name], previous: previous})
+	}
+	d.prune(now)
+	return targets
+}
+
+// release undoes a reservation after a failed delivery
+func (d *Dispatcher) release(channel, key string, previous time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if previous.IsZero() {
+		delete(d.sent, channel+"\x00"+key)
+	} else {
+		d.sent[channel+"\x00"+key] = previous
+	}
+}
+
+// prune forgets deliveries older than a day so the map stays bounded
+func (d *Dispatcher) prune(now time.Time) {
+	horizon := 24 * time.Hour
+	if d.cooldown > horizon {
+		horizon = d.cooldown
+	}
+	for _, r := range d.rules {
+		if r.cooldown > horizon {
+			horizon = r.cooldown
+		}
+	}
+	for key, sent := range d.sent {
+		if now.Sub(sent) > horizon {
+			delete(d.sent, key)
+		}
+	}
+}
+
+// toSet converts a filter list into a lookup set
+func toSet(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
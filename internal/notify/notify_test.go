@@ -0,0 +1,217 @@
+/**
+ * CONTEXT:   Test suite for alert routing and delivery channels
+ * INPUT:     Dispatchers with recording, webhook, file, command and SMTP channels
+ * OUTPUT:    Validation of routing rules, cooldowns and channel payloads
+ * BUSINESS:  Alerts must reach the right channel exactly once per window
+ * CHANGE:    Initial notifier tests with a local fake SMTP server
+ * RISK:      Low - Local servers and temp files only
+ */
+
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/claude-monitor/system/internal/config"
+)
+
+// recorder is an in-memory channel that can be made to fail
+type recorder struct {
+	name  string
+	mu    sync.Mutex
+	got   []Notification
+	fails bool
+}
+
+func (r *recorder) Name() string { return r.name }
+
+func (r *recorder) Notify(ctx context.Context, n Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fails {
+		return errors.New("unavailable")
+	}
+	r.got = append(r.got, n)
+	return nil
+}
+
+func TestDispatcherRoutingAndCooldown(t *testing.T) {
+	config := cfg.AlertsConfig{
+		CooldownMinutes: 60,
+		Channels: []cfg.AlertChannelConfig{
+			{Name: "desktop", Type: "file", Path: filepath.Join(t.TempDir(), "unused")},
+			{Name: "pager", Type: "file", Path: filepath.Join(t.TempDir(), "unused")},
+		},
+		Rules: []cfg.AlertRuleConfig{
+			{Channels: []string{"desktop"}, Sources: []string{SourceWork}},
+			{Channels: []string{"pager"}, Sources: []string{SourceHealth}, Levels: []string{LevelError, LevelCritical}, CooldownMinutes: 5},
+		},
+	}
+	dispatcher, err := NewDispatcher(config, nil)
+	require.NoError(t, err)
+	desktop, pager := &recorder{name: "desktop"}, &recorder{name: "pager"}
+	dispatcher.Add(desktop)
+	dispatcher.Add(pager)
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	dispatcher.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	sent, err := dispatcher.Send(ctx, Notification{Key: "window", Source: SourceWork, Level: LevelInfo, Title: "Session window ends"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"desktop"}, sent)
+
+	sent, _ = dispatcher.Send(ctx, Notification{Key: "memory", Source: SourceHealth, Level: LevelWarning})
+	assert.Empty(t, sent, "warnings are not routed to the pager")
+
+	sent, _ = dispatcher.Send(ctx, Notification{Key: "database", Source: SourceHealth, Level: LevelError})
+	assert.Equal(t, []string{"pager"}, sent)
+
+	// Same keys inside their cooldown windows are suppressed
+	now = now.Add(10 * time.Minute)
+	sent, _ = dispatcher.Send(ctx, Notification{Key: "window", Source: SourceWork, Level: LevelInfo})
+	assert.Empty(t, sent)
+	sent, _ = dispatcher.Send(ctx, Notification{Key: "database", Source: SourceHealth, Level: LevelError})
+	assert.Equal(t, []string{"pager"}, sent, "the pager rule has a 5 minute cooldown")
+
+	// A failed delivery does not start a cooldown
+	desktop.fails = true
+	_, err = dispatcher.Send(ctx, Notification{Key: "goal", Source: SourceWork, Level: LevelInfo})
+	assert.Error(t, err)
+	desktop.fails = false
+	sent, err = dispatcher.Send(ctx, Notification{Key: "goal", Source: SourceWork, Level: LevelInfo})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"desktop"}, sent)
+	assert.Len(t, desktop.got, 2)
+}
+
+func TestWebhookFileAndCommandChannels(t *testing.T) {
+	dir := t.TempDir()
+	var received Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	config := cfg.AlertsConfig{Channels: []cfg.AlertChannelConfig{
+		{Name: "hook", Type: "webhook", URL: server.URL, Headers: map[string]string{"Authorization": "Bearer secret"}},
+		{Name: "log", Type: "file", Path: filepath.Join(dir, "alerts", "alerts.jsonl")},
+		{Name: "desktop", Type: "command", Command: []string{"sh", "-c", `printf '%s|%s' "$1" "$CLAUDE_MONITOR_ALERT_LEVEL" > "$2"`, "sh", "{title}", filepath.Join(dir, "command.out")}},
+	}}
+	dispatcher, err := NewDispatcher(config, nil)
+	require.NoError(t, err)
+
+	n := Notification{Key: "goal", Source: SourceWork, Level: LevelInfo, Type: "goal", Title: "Goal reached: Deep work", Message: "4.0 of 4.0 hours"}
+	sent, err := dispatcher.Send(context.Background(), n)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hook", "log", "desktop"}, sent)
+
+	assert.Equal(t, n.Title, received.Title)
+	assert.Equal(t, SourceWork, received.Source)
+
+	data, err := os.ReadFile(filepath.Join(dir, "alerts", "alerts.jsonl"))
+	require.NoError(t, err)
+	var logged Notification
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(string(data))), &logged))
+	assert.Equal(t, "goal", logged.Key)
+
+	out, err := os.ReadFile(filepath.Join(dir, "command.out"))
+	require.NoError(t, err)
+	assert.Equal(t, "Goal reached: Deep work|info", string(out))
+}
+
+func TestEmailChannelWithFakeSMTP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	messages := make(chan string, 1)
+	go serveFakeSMTP(listener, messages)
+
+	host, portText, _ := net.SplitHostPort(listener.Addr().String())
+	port, _ := strconv.Atoi(portText)
+	notifier, err := NewChannel(cfg.AlertChannelConfig{
+		Name: "mail", Type: "email",
+		SMTP: cfg.SMTPConfig{Host: host, Port: port, From: "monitor@example.com", To: []string{"dev@example.com"}},
+	})
+	require.NoError(t, err)
+
+	err = notifier.Notify(context.Background(), Notification{
+		Source: SourceHealth, Level: LevelError, Type: "database",
+		Title: "Health check database failing\r\nBcc: evil@example.com", Message: "Database unreachable",
+		Timestamp: time.Now(), Metadata: map[string]string{"path": "/tmp/monitor.db"},
+	})
+	require.NoError(t, err)
+
+	select {
+	case message := <-messages:
+		assert.Contains(t, message, "Subject: [claude-monitor] Health check database failing  Bcc: evil@example.com")
+		assert.NotContains(t, message, "\r\nBcc:")
+		assert.Contains(t, message, "Database unreachable")
+		assert.Contains(t, message, "path: /tmp/monitor.db")
+	case <-time.After(5 * time.Second):
+		t.Fatal("fake SMTP server received no message")
+	}
+}
+
+// serveFakeSMTP accepts one connection and records the DATA section
+func serveFakeSMTP(listener net.Listener, messages chan<- string) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+	reply("220 fake ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 fake")
+		case strings.HasPrefix(command, "DATA"):
+			reply("354 go ahead")
+			var body strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				body.WriteString(dataLine)
+			}
+			messages <- body.String()
+			reply("250 queued")
+		case strings.HasPrefix(command, "QUIT"):
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
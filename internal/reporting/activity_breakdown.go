@@ -0,0 +1,146 @@
+/**
+ * CONTEXT:   Tool and activity-type breakdown for daily, weekly and monthly reports
+ * INPUT:     Activity events with hook tool names and activity types
+ * OUTPUT:    Breakdown rows with counts, attributed time, share and average gap
+ * BUSINESS:  Shows where Claude time went: editing files, running commands, searching
+ * CHANGE:    Initial breakdown computation from activity_events
+ * RISK:      Low - Read-only aggregation over one query per report
+ */
+
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+)
+
+// activityIdleGap caps the time attributed to one event, matching the work block idle timeout
+const activityIdleGap = 5 * time.Minute
+
+// noToolName labels events without a tool, such as submitted prompts
+const noToolName = "(no tool)"
+
+// breakdownTotals accumulates one breakdown row before percentages are known
+type breakdownTotals struct {
+	count    int
+	duration time.Duration
+	gapSum   time.Duration
+	gapCount int
+}
+
+/**
+ * CONTEXT:   Load and break down a user's activities for a report period
+ * INPUT:     Activity repository, user ID and inclusive period bounds
+ * OUTPUT:    Tool and activity-type breakdown rows
+ * BUSINESS:  Every report period uses the same attribution rules
+ * CHANGE:    Initial shared loader for report generators
+ * RISK:      Low - Single range query per report
+ */
+func loadActivityBreakdowns(ctx context.Context, activityRepo *sqlite.ActivityRepository, userID string, start, end time.Time, filter ReportFilter) ([]ActivityBreakdown, []ActivityBreakdown, error) {
+	if activityRepo == nil {
+		return []ActivityBreakdown{}, []ActivityBreakdown{}, nil
+	}
+
+	activities, err := activityRepo.GetUserActivitiesByTimeRange(ctx, userID, start, end)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get activities for breakdown: %w", err)
+	}
+
+	tools, types := BuildActivityBreakdowns(filter.filterActivities(activities), earliest(end, time.Now()))
+	return tools, types, nil
+}
+
+/**
+ * CONTEXT:   Attribute time to tools and activity types from event timestamps
+ * INPUT:     Activities sorted by timestamp and the end of the observed period
+ * OUTPUT:    Tool and activity-type rows sorted by attributed time
+ * BUSINESS:  Each event owns the time until the next event, capped at the idle
+ *            timeout, so a long edit followed by a break is not counted as editing
+ * CHANGE:    Initial attribution rules
+ * RISK:      Low - Pure computation; unsorted input only skews gaps
+ */
+func BuildActivityBreakdowns(activities []*sqlite.Activity, until time.Time) ([]ActivityBreakdown, []ActivityBreakdown) {
+	toolTotals := make(map[string]*breakdownTotals)
+	typeTotals := make(map[string]*breakdownTotals)
+	var total time.Duration
+
+	for i, activity := range activities {
+		next := until
+		hasNext := i+1 < len(activities)
+		if hasNext {
+			next = activities[i+1].Timestamp
+		}
+
+		gap := next.Sub(activity.Timestamp)
+		if gap < 0 {
+			gap = 0
+		}
+		attributed := gap
+		if attributed > activityIdleGap {
+			attributed = activityIdleGap
+		}
+		total += attributed
+
+		toolName := activity.ToolName
+		if toolName == "" {
+			toolName = noToolName
+		}
+		for _, totals := range []*breakdownTotals{
+			breakdownEntry(toolTotals, toolName),
+			breakdownEntry(typeTotals, activity.ActivityType),
+		} {
+			totals.count++
+			totals.duration += attributed
+			if hasNext && gap <= activityIdleGap {
+				totals.gapSum += gap
+				totals.gapCount++
+			}
+		}
+	}
+
+	return finalizeBreakdown(toolTotals, total), finalizeBreakdown(typeTotals, total)
+}
+
+// breakdownEntry returns the totals for a key, creating them on first use
+func breakdownEntry(totals map[string]*breakdownTotals, key string) *breakdownTotals {
+	entry, ok := totals[key]
+	if !ok {
+		entry = &breakdownTotals{}
+		totals[key] = entry
+	}
+	return entry
+}
+
+// finalizeBreakdown converts totals into rows sorted by time, then count, then name
+func finalizeBreakdown(totals map[string]*breakdownTotals, total time.Duration) []ActivityBreakdown {
+	rows := make([]ActivityBreakdown, 0, len(totals))
+	for name, entry := range totals {
+		row := ActivityBreakdown{
+			Name:  name,
+			Count: entry.count,
+			Hours: entry.duration.Hours(),
+		}
+		if total > 0 {
+			row.Percentage = float64(entry.duration) / float64(total) * 100
+		}
+		if entry.gapCount > 0 {
+			row.AverageGapSeconds = (entry.gapSum / time.Duration(entry.gapCount)).Seconds()
+		}
+		rows = append(rows, row)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Hours != rows[j].Hours {
+			return rows[i].Hours > rows[j].Hours
+		}
+		if rows[i].Count != rows[j].Count {
+			return rows[i].Count > rows[j].Count
+		}
+		return rows[i].Name < rows[j].Name
+	})
+	return rows
+}
@@ -0,0 +1,96 @@
+/**
+ * CONTEXT:   Test suite for tool and activity-type breakdowns
+ * INPUT:     Hand-built activity sequences at fixed timestamps
+ * OUTPUT:    Validation of attributed time, idle gap cap, percentages and ordering
+ * BUSINESS:  Breakdown time must add up to the time actually spent with Claude
+ * CHANGE:    Initial activity breakdown tests
+ * RISK:      Low - Pure computation
+ */
+
+package reporting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildActivityBreakdowns(t *testing.T) {
+	start := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	at := func(minutes float64) time.Time {
+		return start.Add(time.Duration(minutes * float64(time.Minute)))
+	}
+	activity := func(minutes float64, tool, activityType string) *sqlite.Activity {
+		return &sqlite.Activity{Timestamp: at(minutes), ToolName: tool, ActivityType: activityType}
+	}
+
+	activities := []*sqlite.Activity{
+		activity(0, "", "prompt"),      // 2m until Edit
+		activity(2, "Edit", "edit"),    // 3m until Bash
+		activity(5, "Bash", "command"), // 45m break, capped at 5m
+		activity(50, "Edit", "edit"),   // 1m until Read
+		activity(51, "Read", "read"),   // 4m until the end of the period
+	}
+
+	tools, types := BuildActivityBreakdowns(activities, at(55))
+
+	require.Len(t, tools, 4)
+	byName := make(map[string]ActivityBreakdown, len(tools))
+	for _, row := range tools {
+		byName[row.Name] = row
+	}
+	total := 15.0 // 2 + 3 + 5 + 1 + 4 minutes
+
+	bash := byName["Bash"]
+	assert.Equal(t, 1, bash.Count)
+	assert.InDelta(t, 5.0/60, bash.Hours, 1e-9, "the break after Bash is capped at the idle gap")
+	assert.InDelta(t, 5/total*100, bash.Percentage, 1e-9)
+	assert.Zero(t, bash.AverageGapSeconds, "gaps over the idle timeout are not averaged")
+
+	edit := byName["Edit"]
+	assert.Equal(t, 2, edit.Count)
+	assert.InDelta(t, 4.0/60, edit.Hours, 1e-9)
+	assert.InDelta(t, 4/total*100, edit.Percentage, 1e-9)
+	assert.InDelta(t, 120.0, edit.AverageGapSeconds, 1e-9)
+
+	read := byName["Read"]
+	assert.InDelta(t, 4.0/60, read.Hours, 1e-9)
+	assert.Zero(t, read.AverageGapSeconds, "the period end is not a gap")
+
+	assert.Equal(t, 1, byName[noToolName].Count, "events without a tool are grouped")
+
+	// Equal time sorts by count, so Edit (2 events) comes before Read
+	assert.Equal(t, []string{"Bash", "Edit", "Read", noToolName}, breakdownNames(tools))
+
+	percentSum := 0.0
+	for _, row := range types {
+		percentSum += row.Percentage
+	}
+	assert.InDelta(t, 100.0, percentSum, 1e-9)
+	assert.Equal(t, []string{"command", "edit", "read", "prompt"}, breakdownNames(types))
+}
+
+func TestBuildActivityBreakdownsEmptyAndCapped(t *testing.T) {
+	tools, types := BuildActivityBreakdowns(nil, time.Now())
+	assert.Empty(t, tools)
+	assert.Empty(t, types)
+
+	// A single event long before the period end only owns the idle gap
+	start := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	tools, _ = BuildActivityBreakdowns([]*sqlite.Activity{{Timestamp: start, ToolName: "Edit", ActivityType: "edit"}}, start.Add(3*time.Hour))
+	require.Len(t, tools, 1)
+	assert.InDelta(t, activityIdleGap.Hours(), tools[0].Hours, 1e-9)
+	assert.InDelta(t, 100.0, tools[0].Percentage, 1e-9)
+}
+
+// breakdownNames returns the row names in order
+func breakdownNames(rows []ActivityBreakdown) []string {
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		names = append(names, row.Name)
+	}
+	return names
+}
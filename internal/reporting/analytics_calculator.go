@@ -24,7 +24,9 @@ import (
  * CHANGE:    Initial analytics calculator extracted for SRP compliance
  * RISK:      Low - Pure calculation logic with no external dependencies
  */
-type DefaultAnalyticsCalculator struct{}
+type DefaultAnalyticsCalculator struct {
+	rules *InsightRuleEngine
+}
 
 /**
  * CONTEXT:   Constructor for analytics calculator
@@ -35,7 +37,20 @@ type DefaultAnalyticsCalculator struct{}
  * RISK:      Low - Simple constructor for stateless calculator
  */
 func NewDefaultAnalyticsCalculator() *DefaultAnalyticsCalculator {
-	return &DefaultAnalyticsCalculator{}
+	return &DefaultAnalyticsCalculator{rules: DefaultInsightRuleEngine()}
+}
+
+// SetInsightRules replaces the rules used for report insights and trends
+func (calc *DefaultAnalyticsCalculator) SetInsightRules(rules *InsightRuleEngine) {
+	calc.rules = rules
+}
+
+// insightRules returns the configured rules, falling back to the built-in defaults
+func (calc *DefaultAnalyticsCalculator) insightRules() *InsightRuleEngine {
+	if calc.rules == nil {
+		return DefaultInsightRuleEngine()
+	}
+	return calc.rules
 }
 
 /**
@@ -120,30 +135,25 @@ func (calc *DefaultAnalyticsCalculator) CalculateHourlyBreakdown(report *Enhance
  * INPUT:     Enhanced daily report with calculated data
  * OUTPUT:    Updated report with generated insights
  * BUSINESS:  Daily insights provide actionable productivity feedback
- * CHANGE:    Extracted daily insight generation
+ * CHANGE:    Evaluates daily insight rules instead of hard-coded thresholds
  * RISK:      Low - Text generation based on calculated data
  */
 func (calc *DefaultAnalyticsCalculator) GenerateDailyInsights(report *EnhancedDailyReport) {
-	report.Insights = make([]string, 0)
+	metrics := NewInsightMetrics()
+	metrics.Numbers["total_work_hours"] = report.TotalWorkHours
+	metrics.Numbers["deep_work_hours"] = report.DeepWorkHours
+	metrics.Numbers["focus_score"] = report.FocusScore
+	metrics.Numbers["project_count"] = float64(len(report.ProjectBreakdown))
+	metrics.Numbers["total_sessions"] = float64(report.TotalSessions)
+	metrics.Numbers["total_work_blocks"] = float64(report.TotalWorkBlocks)
+	metrics.Numbers["claude_prompts"] = float64(report.ClaudePrompts)
 
-	// Work hours insights
-	report.Insights = append(report.Insights, calc.getWorkHoursInsight(report.TotalWorkHours))
-
-	// Project focus insights
-	if len(report.ProjectBreakdown) > 3 {
-		report.Insights = append(report.Insights, "🔀 Consider focusing on fewer projects for deeper progress")
-	} else if len(report.ProjectBreakdown) == 1 {
-		report.Insights = append(report.Insights, "🎯 Excellent focus on a single project today!")
+	// Peak hour only exists when there is hourly data
+	if peakHour := calc.findPeakHour(report.HourlyBreakdown); peakHour >= 0 {
+		metrics.Numbers["peak_hour"] = float64(peakHour)
 	}
 
-	// Peak productivity insights
-	if len(report.HourlyBreakdown) > 0 {
-		peakHour := calc.findPeakHour(report.HourlyBreakdown)
-		if peakHour >= 0 {
-			report.Insights = append(report.Insights, 
-				fmt.Sprintf("⏰ Peak productivity at %d:00", peakHour))
-		}
-	}
+	report.Insights = insightMessages(calc.insightRules().Evaluate(RuleScopeDaily, metrics))
 }
 
 /**
@@ -151,33 +161,24 @@ func (calc *DefaultAnalyticsCalculator) GenerateDailyInsights(report *EnhancedDa
  * INPUT:     Enhanced weekly report with aggregated data
  * OUTPUT:    Updated report with weekly insights
  * BUSINESS:  Weekly insights provide pattern recognition and improvement suggestions
- * CHANGE:    Extracted weekly insight generation
+ * CHANGE:    Evaluates weekly insight rules instead of hard-coded thresholds
  * RISK:      Low - Analysis of weekly patterns with text generation
  */
 func (calc *DefaultAnalyticsCalculator) GenerateWeeklyInsights(report *EnhancedWeeklyReport) {
-	report.Insights = make([]WeeklyInsight, 0)
+	metrics := NewInsightMetrics()
+	metrics.Numbers["total_work_hours"] = report.TotalWorkHours
+	metrics.Numbers["daily_average"] = report.DailyAverage
+	metrics.Numbers["consistency"] = calc.calculateConsistency(report)
+	metrics.Numbers["claude_usage_percent"] = report.ClaudeUsagePercent
+	metrics.Numbers["weekend_hours"] = report.WeeklyStats.WeekendWork
+	metrics.Numbers["weekend_percent"] = report.WeeklyStats.WeekendPercent
+	metrics.Numbers["project_count"] = float64(len(report.ProjectBreakdown))
 
-	// Overall productivity insight
-	avgHours := report.DailyAverage
-	insightType := "productivity"
-	message := fmt.Sprintf("Weekly average: %.1f hours/day", avgHours)
-	
-	if avgHours < 4 {
-		insightType = "improvement"
-		message += " - aim for consistency"
-	}
-
-	report.Insights = append(report.Insights, WeeklyInsight{
-		Type:    insightType,
-		Message: message,
-	})
-
-	// Consistency analysis
-	consistency := calc.calculateConsistency(report)
-	if consistency < 0.6 {
+	report.Insights = make([]WeeklyInsight, 0)
+	for _, result := range calc.insightRules().Evaluate(RuleScopeWeekly, metrics) {
 		report.Insights = append(report.Insights, WeeklyInsight{
-			Type:    "pattern",
-			Message: "Work pattern varies - consider establishing routine",
+			Type:    result.Type,
+			Message: result.Message,
 		})
 	}
 }
@@ -187,7 +188,7 @@ func (calc *DefaultAnalyticsCalculator) GenerateWeeklyInsights(report *EnhancedW
  * INPUT:     Enhanced weekly report for trend calculation
  * OUTPUT:    Updated report with trend data
  * BUSINESS:  Weekly trends show productivity patterns and momentum
- * CHANGE:    Extracted weekly trend generation
+ * CHANGE:    Evaluates weekly_trend rules on first vs second half of the week
  * RISK:      Low - Trend calculation based on daily data
  */
 func (calc *DefaultAnalyticsCalculator) GenerateWeeklyTrends(report *EnhancedWeeklyReport) {
@@ -196,48 +197,171 @@ func (calc *DefaultAnalyticsCalculator) GenerateWeeklyTrends(report *EnhancedWee
 	if len(report.DailyBreakdown) >= 6 {
 		firstHalf := calc.avgHours(report.DailyBreakdown[:3])
 		secondHalf := calc.avgHours(report.DailyBreakdown[3:6])
-		
-		trendType := "stable"
-		description := "Consistent work pattern"
-		value := 0.0
-		
-		if secondHalf > firstHalf*1.2 {
-			trendType = "increasing"
-			description = "Work hours increasing"
-			value = secondHalf - firstHalf
-		} else if firstHalf > secondHalf*1.2 {
-			trendType = "decreasing"
-			description = "Work hours decreasing"
-			value = firstHalf - secondHalf
+		report.Trends = calc.evaluateHalfTrends(RuleScopeWeeklyTrend, firstHalf, secondHalf)
+	}
+}
+
+/**
+ * CONTEXT:   Generate monthly achievements from goal progress
+ * INPUT:     Enhanced monthly report with evaluated monthly goals
+ * OUTPUT:    Updated report with one achievement per met target or kept cap
+ * BUSINESS:  Met goals become visible achievements that reward consistent work;
+ *            missed and running goals stay in the goal progress section
+ * CHANGE:    Only met goals become achievements
+ * RISK:      Low - Text generation based on evaluated goals
+ */
+func (calc *DefaultAnalyticsCalculator) GenerateMonthlyAchievements(report *EnhancedMonthlyReport) {
+	report.Achievements = make([]Achievement, 0, len(report.Goals))
+
+	for _, goal := range report.Goals {
+		if !goal.Met {
+			continue
+		}
+
+		scope := "overall"
+		if goal.ProjectName != "" {
+			scope = goal.ProjectName
 		}
 
-		report.Trends = append(report.Trends, Trend{
-			Type:        trendType,
-			Description: description,
+		achievement := Achievement{
+			Type:     "goal_" + goal.Kind,
+			Title:    goal.Name,
+			Icon:     "🎯",
+			Achieved: true,
+		}
+		if goal.Kind == "cap" {
+			achievement.Icon = "🛡️"
+			achievement.Description = fmt.Sprintf("Kept %s under %.1fh (%.1fh tracked)", scope, goal.TargetHours, goal.ActualHours)
+		} else {
+			achievement.Description = fmt.Sprintf("Reached %.1fh of %.1fh on %s", goal.ActualHours, goal.TargetHours, scope)
+		}
+
+		report.Achievements = append(report.Achievements, achievement)
+	}
+}
+
+/**
+ * CONTEXT:   Generate trend analysis for monthly reports
+ * INPUT:     Enhanced monthly report with daily heatmap
+ * OUTPUT:    Updated report with trends comparing both halves of the elapsed month
+ * BUSINESS:  Monthly trends show whether momentum built or faded over the month
+ * CHANGE:    Replaced placeholder with monthly_trend rule evaluation
+ * RISK:      Low - Trend calculation based on daily data
+ */
+func (calc *DefaultAnalyticsCalculator) GenerateMonthlyTrends(report *EnhancedMonthlyReport) {
+	report.Trends = make([]Trend, 0)
+
+	// Only days that already happened count, so a running month is not "decreasing"
+	elapsed := make([]DayData, 0, len(report.DailyHeatmap))
+	now := time.Now()
+	for _, day := range report.DailyHeatmap {
+		if day.Date.After(now) {
+			break
+		}
+		elapsed = append(elapsed, day)
+	}
+	if len(elapsed) < 14 {
+		return
+	}
+
+	half := len(elapsed) / 2
+	report.Trends = calc.evaluateHalfTrends(RuleScopeMonthlyTrend, calc.avgDayHours(elapsed[:half]), calc.avgDayHours(elapsed[half:]))
+}
+
+/**
+ * CONTEXT:   Generate insights for monthly productivity patterns
+ * INPUT:     Enhanced monthly report with totals, heatmap, projects and goals
+ * OUTPUT:    Updated report with monthly insights
+ * BUSINESS:  Monthly insights summarize volume, streaks, focus and weekend work
+ * CHANGE:    Replaced placeholder with monthly insight rule evaluation
+ * RISK:      Low - Text generation based on calculated data
+ */
+func (calc *DefaultAnalyticsCalculator) GenerateMonthlyInsights(report *EnhancedMonthlyReport) {
+	metrics := NewInsightMetrics()
+	metrics.Numbers["total_work_hours"] = report.TotalWorkHours
+	metrics.Numbers["working_days"] = float64(report.WorkingDays)
+	metrics.Numbers["average_hours_per_day"] = report.AverageHoursPerDay
+	metrics.Numbers["average_hours_per_working_day"] = report.AverageHoursPerWorkingDay
+	metrics.Numbers["longest_work_streak"] = float64(report.LongestWorkStreak)
+	metrics.Numbers["best_day_hours"] = report.BestDay.Hours
+	metrics.Numbers["project_count"] = float64(len(report.ProjectBreakdown))
+
+	var topProject ProjectBreakdown
+	for _, project := range report.ProjectBreakdown {
+		if project.WorkHours > topProject.WorkHours {
+			topProject = project
+		}
+	}
+	if topProject.ProjectName != "" {
+		metrics.Labels["top_project"] = topProject.ProjectName
+		metrics.Numbers["top_project_percent"] = topProject.Percentage
+	}
+
+	weekendHours := 0.0
+	for _, day := range report.DailyHeatmap {
+		if day.Date.Weekday() == time.Saturday || day.Date.Weekday() == time.Sunday {
+			weekendHours += day.Hours
+		}
+	}
+	metrics.Numbers["weekend_hours"] = weekendHours
+	if report.TotalWorkHours > 0 {
+		metrics.Numbers["weekend_percent"] = weekendHours / report.TotalWorkHours * 100
+	}
+
+	goalsMet := 0
+	for _, goal := range report.Goals {
+		if goal.Met {
+			goalsMet++
+		}
+	}
+	metrics.Numbers["goals_met"] = float64(goalsMet)
+	metrics.Numbers["goals_total"] = float64(len(report.Goals))
+
+	report.Insights = insightMessages(calc.insightRules().Evaluate(RuleScopeMonthly, metrics))
+}
+
+/**
+ * CONTEXT:   Evaluate trend rules comparing two halves of a period
+ * INPUT:     Trend rule scope and average daily hours of both halves
+ * OUTPUT:    Trends from matching rules with the hour difference as value
+ * BUSINESS:  Shared by weekly and monthly trends so both use the same ratios
+ * CHANGE:    Extracted from weekly trend calculation for rule evaluation
+ * RISK:      Low - Pure calculation
+ */
+func (calc *DefaultAnalyticsCalculator) evaluateHalfTrends(scope string, firstHalf, secondHalf float64) []Trend {
+	metrics := NewInsightMetrics()
+	metrics.Numbers["first_half_average"] = firstHalf
+	metrics.Numbers["second_half_average"] = secondHalf
+	metrics.Numbers["growth_ratio"] = halfRatio(secondHalf, firstHalf)
+	metrics.Numbers["decline_ratio"] = halfRatio(firstHalf, secondHalf)
+
+	trends := make([]Trend, 0)
+	for _, result := range calc.insightRules().Evaluate(scope, metrics) {
+		value := 0.0
+		if result.Type != "stable" {
+			value = math.Abs(secondHalf - firstHalf)
+		}
+		trends = append(trends, Trend{
+			Type:        result.Type,
+			Description: result.Message,
 			Value:       value,
 		})
 	}
+	return trends
 }
 
-// Interface compliance methods (placeholder implementations)
-func (calc *DefaultAnalyticsCalculator) GenerateMonthlyAchievements(report *EnhancedMonthlyReport) {}
-func (calc *DefaultAnalyticsCalculator) GenerateMonthlyTrends(report *EnhancedMonthlyReport)        {}
-func (calc *DefaultAnalyticsCalculator) GenerateMonthlyInsights(report *EnhancedMonthlyReport)     {}
-
-// Helper functions
-func (calc *DefaultAnalyticsCalculator) getWorkHoursInsight(hours float64) string {
-	if hours >= 8 {
-		return "🌟 Excellent productivity with 8+ hours"
-	} else if hours >= 6 {
-		return "✅ Good productivity day"
-	} else if hours >= 3 {
-		return "⚡ Moderate work day"
-	} else if hours > 0 {
-		return "🚀 Getting started"
+// halfRatio divides two half-period averages; zero baselines yield +Inf or 1
+func halfRatio(numerator, denominator float64) float64 {
+	if denominator == 0 {
+		if numerator > 0 {
+			return math.Inf(1)
+		}
+		return 1
 	}
-	return "📋 No tracked work today"
+	return numerator / denominator
 }
 
+// Helper functions
 func (calc *DefaultAnalyticsCalculator) findPeakHour(hourlyData []HourlyData) int {
 	if len(hourlyData) == 0 {
 		return -1
@@ -280,6 +404,19 @@ func (calc *DefaultAnalyticsCalculator) calculateConsistency(report *EnhancedWee
 	return math.Max(0, 1-cv)
 }
 
+func (calc *DefaultAnalyticsCalculator) avgDayHours(days []DayData) float64 {
+	if len(days) == 0 {
+		return 0
+	}
+
+	total := 0.0
+	for _, day := range days {
+		total += day.Hours
+	}
+
+	return total / float64(len(days))
+}
+
 func (calc *DefaultAnalyticsCalculator) avgHours(days []DaySummary) float64 {
 	if len(days) == 0 {
 		return 0
@@ -22,28 +22,23 @@ import (
  * INPUT:     Work block duration and activity count for focus level calculation
  * OUTPUT:    Focus level classification (distracted, focused, deep, or flow)
  * BUSINESS:  Focus level classification drives productivity insights and recommendations
- * CHANGE:    Extracted from core engine, maintains classification logic
+ * CHANGE:    Thresholds moved to focus_level insight rules; first matching rule wins
  * RISK:      Low - Classification logic with business rule validation
  */
 func (wae *WorkAnalyticsEngine) classifyFocusLevel(duration time.Duration, activityCount int64) FocusLevel {
 	minutes := duration.Minutes()
-	activityRate := float64(activityCount) / minutes
 
-	// Classification based on duration and activity rate
-	switch {
-	case minutes < 5:
-		return FocusLevelDistracted
-	case minutes < 15:
-		return FocusLevelFocused
-	case minutes >= 25 && activityRate > 0.5: // Pomodoro+ with good activity
-		return FocusLevelDeep
-	case minutes >= 45 && activityRate > 0.3: // Extended focus with sustained activity
-		return FocusLevelFlow
-	case minutes >= 25:
-		return FocusLevelDeep
-	default:
-		return FocusLevelFocused
+	metrics := NewInsightMetrics()
+	metrics.Numbers["minutes"] = minutes
+	metrics.Numbers["activity_rate"] = float64(activityCount) / minutes
+	metrics.Numbers["activity_count"] = float64(activityCount)
+
+	if result, matched := wae.insightRules().FirstMatch(RuleScopeFocusLevel, metrics); matched {
+		if level, ok := ParseFocusLevel(result.Type); ok {
+			return level
+		}
 	}
+	return FocusLevelFocused
 }
 
 /**
@@ -22,6 +22,7 @@ type WorkAnalyticsEngine struct {
 	workBlockRepo *sqlite.WorkBlockRepository
 	activityRepo  *sqlite.ActivityRepository
 	projectRepo   *sqlite.ProjectRepository
+	rules         *InsightRuleEngine
 }
 
 // NewWorkAnalyticsEngine creates a new work analytics engine
@@ -34,9 +35,23 @@ func NewWorkAnalyticsEngine(
 		workBlockRepo: workBlockRepo,
 		activityRepo:  activityRepo,
 		projectRepo:   projectRepo,
+		rules:         DefaultInsightRuleEngine(),
 	}
 }
 
+// SetInsightRules replaces the rules used for focus levels and recommendations
+func (wae *WorkAnalyticsEngine) SetInsightRules(rules *InsightRuleEngine) {
+	wae.rules = rules
+}
+
+// insightRules returns the configured rules, falling back to the built-in defaults
+func (wae *WorkAnalyticsEngine) insightRules() *InsightRuleEngine {
+	if wae.rules == nil {
+		return DefaultInsightRuleEngine()
+	}
+	return wae.rules
+}
+
 /**
  * CONTEXT:   Deep work analysis for focus and productivity insights
  * INPUT:     Work blocks array requiring deep work pattern analysis
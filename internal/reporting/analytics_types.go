@@ -73,6 +73,16 @@ func (fl FocusLevel) String() string {
 	}
 }
 
+// ParseFocusLevel converts a focus level name back into a FocusLevel
+func ParseFocusLevel(name string) (FocusLevel, bool) {
+	for _, level := range []FocusLevel{FocusLevelDistracted, FocusLevelFocused, FocusLevelDeep, FocusLevelFlow} {
+		if level.String() == name {
+			return level, true
+		}
+	}
+	return FocusLevelFocused, false
+}
+
 // ActivityPatternAnalysis contains work rhythm and behavioral insights
 type ActivityPatternAnalysis struct {
 	TotalActivities    int                   `json:"total_activities"`
@@ -0,0 +1,71 @@
+/**
+ * CONTEXT:   Professional display for git branch reports
+ * INPUT:     Branch report with per-branch hours, blocks and commits
+ * OUTPUT:    Bordered branch table with untracked time summary
+ * BUSINESS:  Branch totals make feature effort visible at a glance
+ * CHANGE:    Initial branch report display using shared display constants
+ * RISK:      Low - Display only, no data mutation
+ */
+
+package reporting
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+/**
+ * CONTEXT:   Display complete branch report
+ * INPUT:     Branch report for a period
+ * OUTPUT:    Header, branch table and untracked time note
+ * BUSINESS:  Branch report is the CLI face of branch correlation
+ * CHANGE:    Initial branch report display
+ * RISK:      Low - Display coordination only
+ */
+func DisplayProfessionalBranchReport(report *BranchReport) error {
+	subtitle := describePeriod(report.Period)
+	if !report.Filter.IsEmpty() {
+		subtitle += " · " + report.Filter.Describe()
+	}
+	DisplayProfessionalHeader("BRANCH REPORT", subtitle)
+
+	if len(report.Branches) == 0 {
+		message := "No work on git branches recorded in this period."
+		if report.UntrackedHours > 0 {
+			message += " Enable projects.track_git_branches in ~/.claude/config.json."
+		}
+		DisplayProfessionalEmptyState(message)
+		return nil
+	}
+
+	sectionWidth := DefaultSectionWidth
+
+	fmt.Printf("%s%s%s %s BRANCHES %s",
+		ColorBrightCyan, BoxTopLeft, BoxHorizontal, SymbolProject, strings.Repeat(BoxHorizontal, sectionWidth-13))
+	fmt.Printf("%s%s\n", BoxTopRight, ColorReset)
+
+	header := fmt.Sprintf(" %-20s │ %-12s │ %-8s │ %6s │ %7s", "Branch", "Project", "Time", "%", "Commits")
+	fmt.Printf("%s%s%s%-*s%s%s\n", ColorBrightCyan, BoxVertical, ColorBold, sectionWidth, header, ColorReset, ColorBrightCyan+BoxVertical+ColorReset)
+
+	for _, row := range report.Branches {
+		duration := time.Duration(row.WorkHours * float64(time.Hour))
+		line := fmt.Sprintf(" %s%-20s%s │ %-12s │ %s%-8s%s │ %5.1f%% │ %7d",
+			getProjectColor(row.Percentage), truncateStringPro(row.Branch, 20), ColorReset,
+			truncateStringPro(row.ProjectName, 12),
+			getDurationColor(duration), formatDurationPro(duration), ColorReset,
+			row.Percentage, row.Commits)
+		fmt.Printf("%s%s%s%s%s\n", ColorBrightCyan, BoxVertical, line, ColorBrightCyan+BoxVertical, ColorReset)
+	}
+
+	fmt.Printf("%s%s", ColorBrightCyan, BoxBottomLeft)
+	fmt.Print(strings.Repeat(BoxHorizontal, sectionWidth))
+	fmt.Printf("%s%s\n", BoxBottomRight, ColorReset)
+
+	if report.UntrackedHours > 0 {
+		fmt.Printf("%s%s without branch information%s\n",
+			ColorDim, formatDurationPro(time.Duration(report.UntrackedHours*float64(time.Hour))), ColorReset)
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,208 @@
+/**
+ * CONTEXT:   Git branch report for Claude Monitor work tracking
+ * INPUT:     Work blocks and activities tagged with git branch and HEAD commit
+ * OUTPUT:    Hours, work blocks and commits per project branch for a period
+ * BUSINESS:  Answers "how long did feature/x take" when projects.track_git_branches is on
+ * CHANGE:    Initial branch report generator
+ * RISK:      Low - Read-only aggregation over existing repositories
+ */
+
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+)
+
+/**
+ * CONTEXT:   Time spent on one branch of one project
+ * INPUT:     No input - data structure definition
+ * OUTPUT:    Branch totals with first and last activity
+ * BUSINESS:  Commits counts distinct HEAD commits seen while working on the branch
+ * CHANGE:    Initial branch breakdown structure
+ * RISK:      Low - Data structure with JSON serialization support
+ */
+type BranchBreakdown struct {
+	Branch      string    `json:"branch"`
+	ProjectName string    `json:"project_name"`
+	ProjectPath string    `json:"project_path"`
+	WorkHours   float64   `json:"work_hours"`
+	WorkBlocks  int       `json:"work_blocks"`
+	Commits     int       `json:"commits"`
+	Percentage  float64   `json:"percentage"`
+	FirstSeen   time.Time `json:"first_seen"`
+	LastSeen    time.Time `json:"last_seen"`
+}
+
+/**
+ * CONTEXT:   Branch report for a period
+ * INPUT:     No input - data structure definition
+ * OUTPUT:    Branch rows sorted by hours plus time without branch information
+ * BUSINESS:  Untracked hours show how much work predates or bypasses branch tracking
+ * CHANGE:    Initial branch report structure
+ * RISK:      Low - Data structure with JSON serialization support
+ */
+type BranchReport struct {
+	Period         ReportPeriod      `json:"period"`
+	Filter         ReportFilter      `json:"filter"`
+	TotalHours     float64           `json:"total_hours"`
+	UntrackedHours float64           `json:"untracked_hours"`
+	Branches       []BranchBreakdown `json:"branches"`
+}
+
+/**
+ * CONTEXT:   Branch report generator backed by SQLite repositories
+ * INPUT:     Session, work block, activity and project repositories
+ * OUTPUT:    Branch reports for arbitrary periods
+ * BUSINESS:  Dedicated generator keeps branch grouping out of the daily generator
+ * CHANGE:    Initial branch report generator
+ * RISK:      Low - Read-only repository access
+ */
+type BranchReportGenerator struct {
+	sessionRepo   *sqlite.SessionRepository
+	workBlockRepo *sqlite.WorkBlockRepository
+	activityRepo  *sqlite.ActivityRepository
+	projectRepo   *sqlite.ProjectRepository
+	filter        ReportFilter
+}
+
+// NewBranchReportGenerator creates a branch report generator over the given repositories
+func NewBranchReportGenerator(
+	sessionRepo *sqlite.SessionRepository,
+	workBlockRepo *sqlite.WorkBlockRepository,
+	activityRepo *sqlite.ActivityRepository,
+	projectRepo *sqlite.ProjectRepository,
+) *BranchReportGenerator {
+	return &BranchReportGenerator{
+		sessionRepo:   sessionRepo,
+		workBlockRepo: workBlockRepo,
+		activityRepo:  activityRepo,
+		projectRepo:   projectRepo,
+	}
+}
+
+// SetFilter limits later branch reports to branches matching the filter
+func (brg *BranchReportGenerator) SetFilter(filter ReportFilter) {
+	brg.filter = filter
+}
+
+// branchKey identifies a branch within a project
+type branchKey struct {
+	projectID string
+	branch    string
+}
+
+/**
+ * CONTEXT:   Generate the branch report for a user and period
+ * INPUT:     User ID and report period
+ * OUTPUT:    Branch report with rows sorted by hours, largest first
+ * BUSINESS:  Work blocks carry the branch they were split on, so block time is branch time
+ * CHANGE:    Initial branch report generation
+ * RISK:      Medium - Loads all work blocks and activities of the period into memory
+ */
+func (brg *BranchReportGenerator) Generate(ctx context.Context, userID string, period ReportPeriod) (*BranchReport, error) {
+	blocks, err := loadWorkBlocksInRange(ctx, brg.sessionRepo, brg.workBlockRepo, userID, period.Start, period.End)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &BranchReport{
+		Period:   period,
+		Filter:   brg.filter,
+		Branches: make([]BranchBreakdown, 0),
+	}
+
+	totals := make(map[branchKey]*BranchBreakdown)
+	for _, block := range blocks {
+		end := workBlockEventEnd(block)
+		if !end.After(block.StartTime) {
+			continue
+		}
+		hours := end.Sub(block.StartTime).Hours()
+
+		if block.GitBranch == "" {
+			if brg.filter.Branch == "" && brg.filter.MatchesWorkBlock(block) {
+				report.TotalHours += hours
+				report.UntrackedHours += hours
+			}
+			continue
+		}
+		if !brg.filter.MatchesWorkBlock(block) {
+			continue
+		}
+		report.TotalHours += hours
+
+		key := branchKey{projectID: block.ProjectID, branch: block.GitBranch}
+		row, ok := totals[key]
+		if !ok {
+			row = &BranchBreakdown{Branch: block.GitBranch, ProjectName: "Unknown Project", FirstSeen: block.StartTime}
+			if project, err := brg.projectRepo.GetByID(ctx, block.ProjectID); err == nil && project != nil {
+				row.ProjectName = project.Name
+				row.ProjectPath = project.Path
+			}
+			totals[key] = row
+		}
+		row.WorkHours += hours
+		row.WorkBlocks++
+		if block.StartTime.Before(row.FirstSeen) {
+			row.FirstSeen = block.StartTime
+		}
+		if end.After(row.LastSeen) {
+			row.LastSeen = end
+		}
+	}
+
+	if err := brg.countCommits(ctx, userID, period, totals); err != nil {
+		return nil, err
+	}
+
+	for _, row := range totals {
+		if report.TotalHours > 0 {
+			row.Percentage = row.WorkHours / report.TotalHours * 100
+		}
+		report.Branches = append(report.Branches, *row)
+	}
+	sort.Slice(report.Branches, func(i, j int) bool {
+		if report.Branches[i].WorkHours != report.Branches[j].WorkHours {
+			return report.Branches[i].WorkHours > report.Branches[j].WorkHours
+		}
+		return report.Branches[i].Branch < report.Branches[j].Branch
+	})
+
+	return report, nil
+}
+
+// countCommits fills in the distinct HEAD commits recorded on each branch row
+func (brg *BranchReportGenerator) countCommits(ctx context.Context, userID string, period ReportPeriod, totals map[branchKey]*BranchBreakdown) error {
+	if brg.activityRepo == nil || len(totals) == 0 {
+		return nil
+	}
+
+	activities, err := brg.activityRepo.GetUserActivitiesByTimeRange(ctx, userID, period.Start, period.End)
+	if err != nil {
+		return fmt.Errorf("failed to get activities for branch report: %w", err)
+	}
+
+	commits := make(map[branchKey]map[string]bool)
+	for _, activity := range activities {
+		if activity.GitCommit == "" {
+			continue
+		}
+		key := branchKey{projectID: activity.ProjectID, branch: activity.GitBranch}
+		if totals[key] == nil {
+			continue
+		}
+		if commits[key] == nil {
+			commits[key] = make(map[string]bool)
+		}
+		commits[key][activity.GitCommit] = true
+	}
+	for key, seen := range commits {
+		totals[key].Commits = len(seen)
+	}
+	return nil
+}
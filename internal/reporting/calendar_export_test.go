@@ -0,0 +1,73 @@
+/**
+ * CONTEXT:   Test suite for iCalendar text escaping and line folding
+ * INPUT:     Property values with special characters and long multibyte lines
+ * OUTPUT:    Validation against RFC 5545 escaping and 75-octet folding rules
+ * BUSINESS:  Calendar apps reject or garble feeds with unescaped text or split characters
+ * CHANGE:    Initial calendar export tests
+ * RISK:      Low - Pure string functions
+ */
+
+package reporting
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeICalText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain text", "plain text"},
+		{"api, web", `api\, web`},
+		{"a;b", `a\;b`},
+		{`C:\work\api`, `C:\\work\\api`},
+		{"line one\nline two", `line one\nline two`},
+		{"line one\r\nline two", `line one\nline two`},
+		{`x\,;`, `x\\\,\;`},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, escapeICalText(tt.in), "%q", tt.in)
+	}
+}
+
+func TestFoldICalLine(t *testing.T) {
+	short := "SUMMARY:" + strings.Repeat("a", icalMaxLineOctets-len("SUMMARY:"))
+	assert.Equal(t, short, foldICalLine(short), "exactly 75 octets is not folded")
+
+	long := "DESCRIPTION:" + strings.Repeat("x", 200)
+	assertFolded(t, long, foldICalLine(long))
+
+	// Three-byte characters must never be split across a fold
+	multibyte := "SUMMARY:" + strings.Repeat("€", 60)
+	folded := foldICalLine(multibyte)
+	assertFolded(t, multibyte, folded)
+	for _, line := range strings.Split(folded, "\r\n") {
+		assert.True(t, utf8.ValidString(line), "line %q splits a character", line)
+	}
+
+	// Four-byte emoji right at the boundary move to the next line whole
+	emoji := "SUMMARY:" + strings.Repeat("a", 65) + "🎯🎯"
+	folded = foldICalLine(emoji)
+	assertFolded(t, emoji, folded)
+	assert.Equal(t, "SUMMARY:"+strings.Repeat("a", 65), strings.Split(folded, "\r\n")[0])
+}
+
+// assertFolded checks the octet limit per line and that unfolding restores the input
+func assertFolded(t *testing.T, original, folded string) {
+	t.Helper()
+	lines := strings.Split(folded, "\r\n")
+	assert.Greater(t, len(lines), 1, "long line should be folded")
+	for i, line := range lines {
+		assert.LessOrEqual(t, len(line), icalMaxLineOctets, "line %d is %d octets", i, len(line))
+		if i > 0 {
+			assert.True(t, strings.HasPrefix(line, " "), "continuation line %d must start with a space", i)
+		}
+	}
+	assert.Equal(t, original, strings.ReplaceAll(folded, "\r\n ", ""))
+}
@@ -0,0 +1,165 @@
+/**
+ * CONTEXT:   Professional display for period-over-period comparison reports
+ * INPUT:     Period comparison with metric deltas and project changes
+ * OUTPUT:    Side-by-side comparison tables with colored signed changes
+ * BUSINESS:  Clear comparison output makes productivity changes obvious at a glance
+ * CHANGE:    Initial comparison display using shared display constants
+ * RISK:      Low - Display only, no data mutation
+ */
+
+package reporting
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+/**
+ * CONTEXT:   Display complete period comparison report
+ * INPUT:     Period comparison result
+ * OUTPUT:    Header, metric delta table, project and tag delta tables and peak hours
+ * BUSINESS:  Comparison report is the CLI face of the reusable comparison type
+ * CHANGE:    Added tag hours section
+ * RISK:      Low - Display coordination only
+ */
+func DisplayProfessionalPeriodComparison(comparison *PeriodComparison) error {
+	DisplayProfessionalHeader("PERIOD COMPARISON",
+		fmt.Sprintf("%s  →  %s", describePeriod(comparison.A.Period), describePeriod(comparison.B.Period)))
+
+	if comparison.A.TotalHours == 0 && comparison.B.TotalHours == 0 {
+		DisplayProfessionalEmptyState("No work activity recorded in either period.")
+		return nil
+	}
+
+	sectionWidth := DefaultSectionWidth
+
+	// Metrics section
+	fmt.Printf("%s%s%s %s KEY METRICS %s",
+		ColorBrightBlue, BoxTopLeft, BoxHorizontal, SymbolTrend, strings.Repeat(BoxHorizontal, sectionWidth-16))
+	fmt.Printf("%s%s\n", BoxTopRight, ColorReset)
+
+	header := fmt.Sprintf(" %-18s │ %-10s │ %-10s │ %-10s │ %-8s", "Metric",
+		truncateStringPro(comparison.A.Period.Label, 10), truncateStringPro(comparison.B.Period.Label, 10), "Δ", "Δ%")
+	fmt.Printf("%s%s%s%-*s%s%s\n", ColorBrightBlue, BoxVertical, ColorBold, sectionWidth, header, ColorReset, ColorBrightBlue+BoxVertical+ColorReset)
+
+	rows := []struct {
+		label          string
+		delta          MetricDelta
+		asHours        bool
+		higherIsBetter bool
+	}{
+		{"Total hours", comparison.TotalHours, true, true},
+		{"Deep work", comparison.DeepWorkHours, true, true},
+		{"Focus score", comparison.FocusScore, false, true},
+		{"Context switches", comparison.ContextSwitches, false, false},
+	}
+	for _, row := range rows {
+		displayMetricDeltaRow(row.label, row.delta, row.asHours, row.higherIsBetter, ColorBrightBlue)
+	}
+
+	fmt.Printf("%s%s", ColorBrightBlue, BoxBottomLeft)
+	fmt.Print(strings.Repeat(BoxHorizontal, sectionWidth))
+	fmt.Printf("%s%s\n\n", BoxBottomRight, ColorReset)
+
+	// Project section
+	if len(comparison.Projects) > 0 {
+		fmt.Printf("%s%s%s %s PROJECT HOURS %s",
+			ColorBrightGreen, BoxTopLeft, BoxHorizontal, SymbolProject, strings.Repeat(BoxHorizontal, sectionWidth-18))
+		fmt.Printf("%s%s\n", BoxTopRight, ColorReset)
+		for _, project := range comparison.Projects {
+			displayMetricDeltaRow(truncateStringPro(project.ProjectName, 18), project.Hours, true, true, ColorBrightGreen)
+		}
+		fmt.Printf("%s%s", ColorBrightGreen, BoxBottomLeft)
+		fmt.Print(strings.Repeat(BoxHorizontal, sectionWidth))
+		fmt.Printf("%s%s\n\n", BoxBottomRight, ColorReset)
+	}
+
+	// Tag section
+	if len(comparison.Tags) > 0 {
+		fmt.Printf("%s%s%s %s TAG HOURS %s",
+			ColorBrightMagenta, BoxTopLeft, BoxHorizontal, SymbolProject, strings.Repeat(BoxHorizontal, sectionWidth-14))
+		fmt.Printf("%s%s\n", BoxTopRight, ColorReset)
+		for _, tag := range comparison.Tags {
+			displayMetricDeltaRow(truncateStringPro(tag.Tag, 18), tag.Hours, true, true, ColorBrightMagenta)
+		}
+		fmt.Printf("%s%s", ColorBrightMagenta, BoxBottomLeft)
+		fmt.Print(strings.Repeat(BoxHorizontal, sectionWidth))
+		fmt.Printf("%s%s\n\n", BoxBottomRight, ColorReset)
+	}
+
+	// Peak hours section
+	fmt.Printf("%s%s%s %s PEAK HOURS %s",
+		ColorBrightYellow, BoxTopLeft, BoxHorizontal, SymbolTimeline, strings.Repeat(BoxHorizontal, sectionWidth-15))
+	fmt.Printf("%s%s\n", BoxTopRight, ColorReset)
+	peakLines := []string{
+		fmt.Sprintf("  %s: %s", comparison.A.Period.Label, formatHourList(comparison.PeakHours.A)),
+		fmt.Sprintf("  %s: %s", comparison.B.Period.Label, formatHourList(comparison.PeakHours.B)),
+	}
+	if comparison.PeakHours.Shifted {
+		peakLines = append(peakLines, fmt.Sprintf("  Peak moved: +%s  -%s",
+			formatHourList(comparison.PeakHours.Gained), formatHourList(comparison.PeakHours.Lost)))
+	}
+	for _, line := range peakLines {
+		fmt.Printf("%s%s%-*s%s%s\n", ColorBrightYellow, BoxVertical, sectionWidth, line, BoxVertical, ColorReset)
+	}
+	fmt.Printf("%s%s", ColorBrightYellow, BoxBottomLeft)
+	fmt.Print(strings.Repeat(BoxHorizontal, sectionWidth))
+	fmt.Printf("%s%s\n\n", BoxBottomRight, ColorReset)
+
+	return nil
+}
+
+// displayMetricDeltaRow prints one metric row with a colored signed change
+func displayMetricDeltaRow(label string, delta MetricDelta, asHours, higherIsBetter bool, frameColor string) {
+	format := func(v float64) string {
+		if asHours {
+			return formatDurationPro(time.Duration(v * float64(time.Hour)))
+		}
+		return fmt.Sprintf("%.1f", v)
+	}
+
+	deltaStr := fmt.Sprintf("%+.1f", delta.Delta)
+	if asHours {
+		sign := "+"
+		if delta.Delta < 0 {
+			sign = "-"
+		}
+		deltaStr = sign + formatDurationPro(time.Duration(math.Abs(delta.Delta)*float64(time.Hour)))
+	}
+
+	changeColor := ColorDim
+	improved := delta.Delta > 0 == higherIsBetter
+	if delta.Delta != 0 {
+		changeColor = ColorBrightRed
+		if improved {
+			changeColor = ColorBrightGreen
+		}
+	}
+
+	row := fmt.Sprintf(" %-18s │ %-10s │ %-10s │ %-10s │ %s%-8s%s",
+		label, format(delta.A), format(delta.B), deltaStr, changeColor, delta.FormatPercentChange(), ColorReset)
+	fmt.Printf("%s%s%s%s%s\n", frameColor, BoxVertical, row, BoxVertical, ColorReset)
+}
+
+// describePeriod renders a period label with its concrete date range
+func describePeriod(period ReportPeriod) string {
+	last := period.End.AddDate(0, 0, -1)
+	if period.Kind == PeriodKindDay {
+		return period.Start.Format("Jan 2, 2006")
+	}
+	return fmt.Sprintf("%s (%s – %s)", period.Label, period.Start.Format("Jan 2"), last.Format("Jan 2"))
+}
+
+// formatHourList renders hours as HH:00 values separated by commas
+func formatHourList(hours []int) string {
+	if len(hours) == 0 {
+		return "—"
+	}
+	parts := make([]string, len(hours))
+	for i, hour := range hours {
+		parts[i] = fmt.Sprintf("%02d:00", hour)
+	}
+	return strings.Join(parts, ", ")
+}
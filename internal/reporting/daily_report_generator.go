@@ -30,6 +30,8 @@ type DailyReportGenerator struct {
 	workBlockRepo *sqlite.WorkBlockRepository
 	activityRepo  *sqlite.ActivityRepository
 	projectRepo   *sqlite.ProjectRepository
+	filter        ReportFilter
+	grouping      string
 }
 
 /**
@@ -54,12 +56,24 @@ func NewDailyReportGenerator(
 	}
 }
 
+// SetFilter limits later reports to work blocks and activities matching the filter
+func (drg *DailyReportGenerator) SetFilter(filter ReportFilter) {
+	drg.filter = filter
+}
+
+// SetProjectGrouping rolls the project breakdown up to the given level (see GroupByClient)
+func (drg *DailyReportGenerator) SetProjectGrouping(level string) {
+	drg.grouping = level
+}
+
 /**
  * CONTEXT:   Generate comprehensive daily report from SQLite data sources
  * INPUT:     User ID, date for report generation, timezone context
  * OUTPUT:    Enhanced daily report with work blocks, sessions, projects, and insights
- * BUSINESS:  Daily reports are primary user interface for work tracking analytics
- * CHANGE:    Extracted daily report generation for focused responsibility
+ * BUSINESS:  Daily reports are primary user interface for work tracking analytics;
+ *            parallel Claude Code instances are summed in TotalWorkHours and merged
+ *            in WallClockHours; rollups of purged work blocks keep old days in the totals
+ * CHANGE:    Merged daily rollups left behind by retention purges
  * RISK:      Medium - Core reporting functionality with multiple data source orchestration
  */
 func (drg *DailyReportGenerator) GenerateDaily(ctx context.Context, userID string, date time.Time) (*EnhancedDailyReport, error) {
@@ -76,6 +90,9 @@ func (drg *DailyReportGenerator) GenerateDaily(ctx context.Context, userID strin
 	report := &EnhancedDailyReport{
 		Date:             date,
 		ProjectBreakdown: make([]ProjectBreakdown, 0),
+		ToolBreakdown:    make([]ActivityBreakdown, 0),
+		ActivityTypeBreakdown: make([]ActivityBreakdown, 0),
+		TagBreakdown:     make([]TagBreakdown, 0),
 		HourlyBreakdown:  make([]HourlyData, 0),
 		WorkBlocks:       make([]WorkBlockSummary, 0),
 		Insights:         make([]string, 0),
@@ -84,6 +101,10 @@ func (drg *DailyReportGenerator) GenerateDaily(ctx context.Context, userID strin
 	// Process sessions for the day
 	totalSessions := len(sessions)
 	if totalSessions == 0 {
+		// Days past the retention window survive only as rollups
+		if err := drg.addDailyRollups(ctx, userID, startOfDay, report); err != nil {
+			return nil, err
+		}
 		return report, nil
 	}
 
@@ -110,17 +131,38 @@ func (drg *DailyReportGenerator) GenerateDaily(ctx context.Context, userID strin
 		}
 		allWorkBlocks = append(allWorkBlocks, workBlocks...)
 	}
+	allWorkBlocks = drg.filter.filterWorkBlocks(allWorkBlocks)
 
 	// Process work blocks for the report
+	grouper := newProjectGrouper(drg.projectRepo, drg.grouping)
 	for _, workBlock := range allWorkBlocks {
-		if err := drg.processWorkBlockForReport(ctx, workBlock, report); err != nil {
+		if err := drg.processWorkBlockForReport(ctx, workBlock, grouper, report); err != nil {
 			// Log error but continue processing other blocks
 			continue
 		}
 	}
 
+	// Count overlapping blocks of parallel Claude Code instances once
+	report.WallClockHours = WallClockHours(report.WorkBlocks)
+	report.ClaudeInstances = ClaudeInstanceCount(report.WorkBlocks)
+
+	// Add back work blocks already purged by retention on a partly purged day
+	if err := drg.addDailyRollups(ctx, userID, startOfDay, report); err != nil {
+		return nil, err
+	}
+
+	// Break the day's time down by work block and session tags
+	report.TagBreakdown = BuildTagBreakdown(allWorkBlocks, report.TotalWorkHours)
+
+	// Attribute the day's time to hook tools and activity types
+	report.ToolBreakdown, report.ActivityTypeBreakdown, err = loadActivityBreakdowns(ctx, drg.activityRepo, userID, startOfDay, endOfDay, drg.filter)
+	if err != nil {
+		return nil, err
+	}
+
 	// Set report totals
 	report.TotalSessions = totalSessions
+	report.TotalWorkBlocks = len(report.WorkBlocks)
 	if !firstActivity.IsZero() && !lastActivity.IsZero() {
 		report.ScheduleHours = lastActivity.Sub(firstActivity).Hours()
 	}
@@ -130,13 +172,14 @@ func (drg *DailyReportGenerator) GenerateDaily(ctx context.Context, userID strin
 
 /**
  * CONTEXT:   Process individual work block for daily report integration
- * INPUT:     Work block data and target report for aggregation
+ * INPUT:     Work block data, project grouper and target report for aggregation
  * OUTPUT:    Updated report with work block data incorporated
- * BUSINESS:  Work block processing aggregates detailed work tracking data
- * CHANGE:    Extracted work block processing for focused daily report logic
+ * BUSINESS:  Work block processing aggregates detailed work tracking data; the
+ *            breakdown row follows the report's project grouping level
+ * CHANGE:    Share the breakdown row lookup with the rollup merge
  * RISK:      Low - Data processing with error handling for individual blocks
  */
-func (drg *DailyReportGenerator) processWorkBlockForReport(ctx context.Context, workBlock *sqlite.WorkBlock, report *EnhancedDailyReport) error {
+func (drg *DailyReportGenerator) processWorkBlockForReport(ctx context.Context, workBlock *sqlite.WorkBlock, grouper *projectGrouper, report *EnhancedDailyReport) error {
 	if workBlock.EndTime == nil {
 		// Work block is still active, use current time
 		now := time.Now()
@@ -151,36 +194,22 @@ func (drg *DailyReportGenerator) processWorkBlockForReport(ctx context.Context,
 	}
 	report.TotalWorkHours += duration.Hours()
 
-	// Get project information
-	project, err := drg.projectRepo.GetByID(ctx, workBlock.ProjectID)
-	if err == nil && project != nil {
-		// Find or create project breakdown entry
-		var projectBreakdown *ProjectBreakdown
-		for i := range report.ProjectBreakdown {
-			if report.ProjectBreakdown[i].ProjectName == project.Name {
-				projectBreakdown = &report.ProjectBreakdown[i]
-				break
-			}
-		}
-		
-		if projectBreakdown == nil {
-			report.ProjectBreakdown = append(report.ProjectBreakdown, ProjectBreakdown{
-				ProjectName: project.Name,
-				ProjectPath: project.Path,
-			})
-			projectBreakdown = &report.ProjectBreakdown[len(report.ProjectBreakdown)-1]
-		}
-		
-		projectBreakdown.WorkHours += duration.Hours()
-		projectBreakdown.Sessions++
-	}
+	// Find or create the breakdown entry the project rolls up into
+	projectBreakdown := findProjectBreakdown(report, grouper.groupFor(ctx, workBlock.ProjectID))
+	projectBreakdown.WorkHours += duration.Hours()
+	projectBreakdown.Sessions++
 
 	// Create work block summary
 	summary := WorkBlockSummary{
-		StartTime:   workBlock.StartTime,
-		EndTime:     workBlock.StartTime.Add(duration),
-		Duration:    duration,
-		ProjectName: "Unknown Project", // Default value
+		StartTime:       workBlock.StartTime,
+		EndTime:         workBlock.StartTime.Add(duration),
+		Duration:        duration,
+		ProjectName:     "Unknown Project", // Default value
+		GitBranch:       workBlock.GitBranch,
+		ClaudeSessionID: workBlock.ClaudeSessionID,
+		Tags:            workBlock.Tags,
+		Manual:          workBlock.Source == sqlite.WorkBlockSourceManual,
+		Edited:          workBlock.EditedAt != nil,
 	}
 
 	// Set project name if available
@@ -193,6 +222,54 @@ func (drg *DailyReportGenerator) processWorkBlockForReport(ctx context.Context,
 	return nil
 }
 
+// findProjectBreakdown returns the report's breakdown row for a group, appending it if missing
+func findProjectBreakdown(report *EnhancedDailyReport, group projectGroup) *ProjectBreakdown {
+	for i := range report.ProjectBreakdown {
+		if report.ProjectBreakdown[i].ProjectName == group.Name && report.ProjectBreakdown[i].ProjectPath == group.Path {
+			return &report.ProjectBreakdown[i]
+		}
+	}
+	report.ProjectBreakdown = append(report.ProjectBreakdown, ProjectBreakdown{
+		ProjectName: group.Name,
+		ProjectPath: group.Path,
+	})
+	return &report.ProjectBreakdown[len(report.ProjectBreakdown)-1]
+}
+
+/**
+ * CONTEXT:   Merge retention rollups into a daily report
+ * INPUT:     User ID, start of the report day and the report being built
+ * OUTPUT:    Report with purged work hours added to totals and the project breakdown
+ * BUSINESS:  Rollups are written only for deleted work blocks, so they never double
+ *            count the blocks still on disk; they carry no branch or tags and are
+ *            skipped by filtered reports
+ * CHANGE:    Initial rollup merge
+ * RISK:      Low - Adds hours only; rollups have no times so they are counted as
+ *            wall-clock time and never appear as work block summaries
+ */
+func (drg *DailyReportGenerator) addDailyRollups(ctx context.Context, userID string, startOfDay time.Time, report *EnhancedDailyReport) error {
+	if !drg.filter.IsEmpty() {
+		return nil
+	}
+	rollups, err := drg.workBlockRepo.GetDailyRollups(ctx, userID, startOfDay.Format("2006-01-02"))
+	if err != nil {
+		return fmt.Errorf("failed to get rollups for daily report: %w", err)
+	}
+
+	grouper := newProjectGrouper(drg.projectRepo, drg.grouping)
+	for _, rollup := range rollups {
+		hours := float64(rollup.WorkSeconds) / 3600
+		report.TotalWorkHours += hours
+		report.WallClockHours += hours
+
+		group := grouper.groupFor(ctx, rollup.ProjectID)
+		projectBreakdown := findProjectBreakdown(report, group)
+		projectBreakdown.WorkHours += hours
+		projectBreakdown.Sessions += int(rollup.WorkBlocks)
+	}
+	return nil
+}
+
 /**
  * CONTEXT:   Interface compliance for ReportGenerator
  * INPUT:     Context, user ID, date parameters for different report types
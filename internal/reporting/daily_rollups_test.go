@@ -0,0 +1,93 @@
+/**
+ * CONTEXT:   Test suite for reports over days purged by retention
+ * INPUT:     Old manual entries purged into daily rollups in a temp database
+ * OUTPUT:    Validation that daily, weekly and monthly totals keep purged hours
+ * BUSINESS:  Retention keeps rollups forever so history must not vanish from reports
+ * CHANGE:    Initial rollup report tests
+ * RISK:      Low - Temp database per test
+ */
+
+package reporting
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportsIncludePurgedRollups(t *testing.T) {
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(t.TempDir(), "rollups.db")))
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	projects := sqlite.NewProjectRepository(db.DB())
+	api, err := projects.GetOrCreateNamed(ctx, "/work/api", "api")
+	require.NoError(t, err)
+	web, err := projects.GetOrCreateNamed(ctx, "/work/web", "web")
+	require.NoError(t, err)
+
+	// Wednesday 2025-01-08: 2h on api and 1h on web, long past a 30-day window
+	day := time.Date(2025, 1, 8, 0, 0, 0, 0, time.Local)
+	entries := sqlite.NewEntryRepository(db)
+	for _, entry := range []struct {
+		projectID string
+		hour      int
+		duration  time.Duration
+	}{
+		{api.ID, 9, 2 * time.Hour},
+		{web.ID, 14, time.Hour},
+	} {
+		start := day.Add(time.Duration(entry.hour) * time.Hour)
+		_, err := entries.Add(ctx, sqlite.ManualEntry{
+			UserID: "alice", ProjectID: entry.projectID,
+			StartTime: start, EndTime: start.Add(entry.duration), Reason: "old work",
+		})
+		require.NoError(t, err)
+	}
+
+	service := NewSQLiteReportingService(
+		sqlite.NewSessionRepository(db),
+		sqlite.NewWorkBlockRepository(db.DB()),
+		sqlite.NewActivityRepository(db.DB()),
+		projects,
+	)
+	before, err := service.GenerateDailyReport(ctx, "alice", day)
+	require.NoError(t, err)
+	require.InDelta(t, 3.0, before.TotalWorkHours, 0.01)
+
+	result, err := sqlite.NewRetentionRepository(db).Purge(ctx, sqlite.RetentionPolicy{WorkBlocksDays: 30}, db.Now())
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.WorkBlocks)
+
+	daily, err := service.GenerateDailyReport(ctx, "alice", day)
+	require.NoError(t, err)
+	assert.Empty(t, daily.WorkBlocks, "purged blocks have no summaries")
+	assert.InDelta(t, 3.0, daily.TotalWorkHours, 0.01)
+	assert.InDelta(t, 3.0, daily.WallClockHours, 0.01)
+	hours := make(map[string]float64)
+	for _, row := range daily.ProjectBreakdown {
+		hours[row.ProjectName] = row.WorkHours
+	}
+	assert.InDelta(t, 2.0, hours["api"], 0.01)
+	assert.InDelta(t, 1.0, hours["web"], 0.01)
+
+	weekly, err := service.GenerateWeeklyReport(ctx, "alice", day.AddDate(0, 0, -2))
+	require.NoError(t, err)
+	assert.InDelta(t, 3.0, weekly.TotalWorkHours, 0.01)
+
+	monthly, err := service.GenerateMonthlyReport(ctx, "alice", time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local))
+	require.NoError(t, err)
+	assert.InDelta(t, 3.0, monthly.TotalWorkHours, 0.01)
+
+	// Rollups carry no branch or tags, so filtered reports leave them out
+	service.SetFilter(ReportFilter{Branch: "main"})
+	filtered, err := service.GenerateDailyReport(ctx, "alice", day)
+	require.NoError(t, err)
+	assert.Zero(t, filtered.TotalWorkHours)
+}
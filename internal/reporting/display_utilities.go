@@ -41,6 +41,14 @@ func formatDurationPro(d time.Duration) string {
 	return fmt.Sprintf("%dm", minutes)
 }
 
+// formatGapPro formats short gaps in seconds and longer ones like formatDurationPro
+func formatGapPro(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	return formatDurationPro(d)
+}
+
 /**
  * CONTEXT:   Truncate strings to fit within display constraints
  * INPUT:     String to truncate and maximum length allowed
@@ -0,0 +1,288 @@
+/**
+ * CONTEXT:   Goal and budget progress tracking for Claude Monitor reports
+ * INPUT:     Goals from the goal repository and work blocks for the goal period
+ * OUTPUT:    Goal status with tracked hours, completion percentage and state
+ * BUSINESS:  Targets and caps turn raw tracked time into actionable weekly commitments
+ * CHANGE:    Initial goal tracker shared by reports, CLI and daemon API
+ * RISK:      Low - Read-only evaluation over existing repositories
+ */
+
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+)
+
+// Goal states reported by GoalTracker
+const (
+	GoalStateInProgress   = "in_progress"
+	GoalStateMet          = "met"
+	GoalStateMissed       = "missed"
+	GoalStateWithinBudget = "within_budget"
+	GoalStateExceeded     = "exceeded"
+)
+
+/**
+ * CONTEXT:   Goal progress for one goal over one period
+ * INPUT:     No input - data structure definition
+ * OUTPUT:    Goal definition, period boundaries, tracked hours and state
+ * BUSINESS:  Goal status is what users see in reports and the goals API
+ * CHANGE:    Initial goal status structure
+ * RISK:      Low - Data structure with JSON serialization support
+ */
+type GoalStatus struct {
+	GoalID      string    `json:"goal_id"`
+	Name        string    `json:"name"`
+	Period      string    `json:"period"`
+	Kind        string    `json:"kind"`
+	Metric      string    `json:"metric"`
+	ProjectID   string    `json:"project_id,omitempty"`
+	ProjectName string    `json:"project_name,omitempty"`
+	TargetHours float64   `json:"target_hours"`
+	ActualHours float64   `json:"actual_hours"`
+	Percent     float64   `json:"percent"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	State       string    `json:"state"`
+	Met         bool      `json:"met"`
+}
+
+/**
+ * CONTEXT:   Goal tracker evaluating goals against tracked work blocks
+ * INPUT:     Goal, session, work block and project repositories
+ * OUTPUT:    Goal status evaluation for arbitrary instants and periods
+ * BUSINESS:  Single evaluator keeps progress identical across reports and API
+ * CHANGE:    Initial goal tracker
+ * RISK:      Low - Read-only repository access
+ */
+type GoalTracker struct {
+	goalRepo      *sqlite.GoalRepository
+	sessionRepo   *sqlite.SessionRepository
+	workBlockRepo *sqlite.WorkBlockRepository
+	projectRepo   *sqlite.ProjectRepository
+	analytics     *WorkAnalyticsEngine
+}
+
+/**
+ * CONTEXT:   Constructor for goal tracker
+ * INPUT:     SQLite repositories for goals and tracked time
+ * OUTPUT:    Configured goal tracker ready for use
+ * BUSINESS:  Constructor enables dependency injection for clean architecture
+ * CHANGE:    Initial constructor with repository dependencies
+ * RISK:      Low - Simple constructor with dependency injection
+ */
+func NewGoalTracker(
+	goalRepo *sqlite.GoalRepository,
+	sessionRepo *sqlite.SessionRepository,
+	workBlockRepo *sqlite.WorkBlockRepository,
+	projectRepo *sqlite.ProjectRepository,
+) *GoalTracker {
+	return &GoalTracker{
+		goalRepo:      goalRepo,
+		sessionRepo:   sessionRepo,
+		workBlockRepo: workBlockRepo,
+		projectRepo:   projectRepo,
+		analytics:     NewWorkAnalyticsEngine(workBlockRepo, nil, projectRepo),
+	}
+}
+
+/**
+ * CONTEXT:   Evaluate goals for the periods containing an instant
+ * INPUT:     User ID, evaluation instant and optional period filter
+ * OUTPUT:    Goal status for every matching goal, progress counted up to the instant
+ * BUSINESS:  "How am I doing right now" view for reports and the goals API
+ * CHANGE:    Initial point-in-time goal evaluation
+ * RISK:      Low - Work blocks are loaded once per distinct period range
+ */
+func (gt *GoalTracker) EvaluateAt(ctx context.Context, userID string, at time.Time, periods ...string) ([]GoalStatus, error) {
+	goals, err := gt.listGoals(ctx, userID, periods)
+	if err != nil {
+		return nil, err
+	}
+
+	type periodKey struct{ start, end time.Time }
+	cache := make(map[periodKey][]*sqlite.WorkBlock)
+	statuses := make([]GoalStatus, 0, len(goals))
+	for _, goal := range goals {
+		start, end := GoalPeriodBounds(goal.Period, at)
+		key := periodKey{start, end}
+		blocks, cached := cache[key]
+		if !cached {
+			blocks, err = loadWorkBlocksInRange(ctx, gt.sessionRepo, gt.workBlockRepo, userID, start, end)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load work blocks for goal %s: %w", goal.ID, err)
+			}
+			cache[key] = blocks
+		}
+		statuses = append(statuses, gt.evaluate(ctx, goal, blocks, start, end, at))
+	}
+
+	return statuses, nil
+}
+
+/**
+ * CONTEXT:   Evaluate goals of one period kind over an explicit range
+ * INPUT:     User ID, goal period, range boundaries and evaluation instant
+ * OUTPUT:    Goal status for goals of that period over the given range
+ * BUSINESS:  Weekly and monthly reports evaluate goals over the report's own range
+ * CHANGE:    Initial range-based goal evaluation
+ * RISK:      Low - Single work block load for all goals of the period
+ */
+func (gt *GoalTracker) EvaluateRange(ctx context.Context, userID, period string, start, end, at time.Time) ([]GoalStatus, error) {
+	goals, err := gt.listGoals(ctx, userID, []string{period})
+	if err != nil {
+		return nil, err
+	}
+	if len(goals) == 0 {
+		return []GoalStatus{}, nil
+	}
+
+	blocks, err := loadWorkBlocksInRange(ctx, gt.sessionRepo, gt.workBlockRepo, userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load work blocks for goals: %w", err)
+	}
+
+	statuses := make([]GoalStatus, 0, len(goals))
+	for _, goal := range goals {
+		statuses = append(statuses, gt.evaluate(ctx, goal, blocks, start, end, at))
+	}
+	return statuses, nil
+}
+
+// listGoals returns the user's goals, optionally restricted to the given periods
+func (gt *GoalTracker) listGoals(ctx context.Context, userID string, periods []string) ([]*sqlite.Goal, error) {
+	if len(periods) == 0 {
+		return gt.goalRepo.ListByUser(ctx, userID, "")
+	}
+
+	goals := make([]*sqlite.Goal, 0)
+	for _, period := range periods {
+		periodGoals, err := gt.goalRepo.ListByUser(ctx, userID, period)
+		if err != nil {
+			return nil, err
+		}
+		goals = append(goals, periodGoals...)
+	}
+	return goals, nil
+}
+
+/**
+ * CONTEXT:   Evaluate a single goal against pre-loaded work blocks
+ * INPUT:     Goal, work blocks of the period, period boundaries and evaluation instant
+ * OUTPUT:    Goal status with hours, percentage and state
+ * BUSINESS:  Targets are met when reached; caps are met when the period closes within budget;
+ *            a project goal counts time on the project and all of its sub-projects;
+ *            overlapping blocks from parallel instances count once
+ * CHANGE:    Merge overlapping blocks before summing goal hours
+ * RISK:      Low - Pure aggregation over in-memory blocks
+ */
+func (gt *GoalTracker) evaluate(ctx context.Context, goal *sqlite.Goal, blocks []*sqlite.WorkBlock, start, end, at time.Time) GoalStatus {
+	status := GoalStatus{
+		GoalID:      goal.ID,
+		Name:        goal.Name,
+		Period:      goal.Period,
+		Kind:        goal.Kind,
+		Metric:      goal.Metric,
+		ProjectID:   goal.ProjectID,
+		TargetHours: goal.TargetHours,
+		PeriodStart: start,
+		PeriodEnd:   end,
+	}
+
+	projectIDs := map[string]bool{goal.ProjectID: true}
+	if goal.ProjectID != "" {
+		if project, err := gt.projectRepo.GetByID(ctx, goal.ProjectID); err == nil && project != nil {
+			status.ProjectName = project.Name
+		}
+		if subtree, err := gt.projectRepo.GetSubtreeIDs(ctx, goal.ProjectID); err == nil {
+			for _, id := range subtree {
+				projectIDs[id] = true
+			}
+		}
+	}
+
+	cutoff := end
+	if at.Before(cutoff) {
+		cutoff = at
+	}
+
+	var intervals []workInterval
+	for _, block := range blocks {
+		if goal.ProjectID != "" && !projectIDs[block.ProjectID] {
+			continue
+		}
+		if !block.StartTime.Before(cutoff) {
+			continue
+		}
+
+		blockEnd := cutoff
+		if block.EndTime != nil && block.EndTime.Before(cutoff) {
+			blockEnd = *block.EndTime
+		}
+		duration := blockEnd.Sub(block.StartTime)
+		if duration <= 0 {
+			continue
+		}
+
+		if goal.Metric == sqlite.GoalMetricDeepWorkHours &&
+			gt.analytics.classifyFocusLevel(duration, block.ActivityCount) < FocusLevelDeep {
+			continue
+		}
+		intervals = append(intervals, workInterval{start: block.StartTime, end: blockEnd})
+	}
+	// Parallel Claude instances overlap; count the covered time once
+	status.ActualHours = intervalHours(intervals)
+
+	status.Percent = status.ActualHours / goal.TargetHours * 100
+	closed := !at.Before(end)
+
+	switch goal.Kind {
+	case sqlite.GoalKindCap:
+		switch {
+		case status.ActualHours > goal.TargetHours:
+			status.State = GoalStateExceeded
+		case closed:
+			status.State = GoalStateMet
+		default:
+			status.State = GoalStateWithinBudget
+		}
+	default:
+		switch {
+		case status.ActualHours >= goal.TargetHours:
+			status.State = GoalStateMet
+		case closed:
+			status.State = GoalStateMissed
+		default:
+			status.State = GoalStateInProgress
+		}
+	}
+	status.Met = status.State == GoalStateMet
+
+	return status
+}
+
+/**
+ * CONTEXT:   Compute goal period boundaries containing an instant
+ * INPUT:     Goal period (daily, weekly, monthly) and instant
+ * OUTPUT:    Inclusive start and exclusive end of the period in the instant's location
+ * BUSINESS:  Weekly goals follow ISO weeks starting on Monday
+ * CHANGE:    Initial period boundary calculation
+ * RISK:      Low - Pure date arithmetic
+ */
+func GoalPeriodBounds(period string, at time.Time) (time.Time, time.Time) {
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+	switch period {
+	case sqlite.GoalPeriodWeekly:
+		start := day.AddDate(0, 0, -(int(day.Weekday()+6) % 7))
+		return start, start.AddDate(0, 0, 7)
+	case sqlite.GoalPeriodMonthly:
+		start := time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, at.Location())
+		return start, start.AddDate(0, 1, 0)
+	default:
+		return day, day.AddDate(0, 0, 1)
+	}
+}
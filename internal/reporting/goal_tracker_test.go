@@ -0,0 +1,191 @@
+/**
+ * CONTEXT:   Test suite for goal and budget evaluation
+ * INPUT:     Goals evaluated against in-memory work blocks at fixed instants
+ * OUTPUT:    Validation of target and cap states, project scoping and achievements
+ * BUSINESS:  A goal shown as met when it was missed erodes trust in every report
+ * CHANGE:    Initial goal tracker tests
+ * RISK:      Low - Temp database for project lookups only
+ */
+
+package reporting
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGoalTestTracker opens a temp database and returns a tracker and its project repository
+func newGoalTestTracker(t *testing.T) (*GoalTracker, *sqlite.ProjectRepository) {
+	t.Helper()
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(t.TempDir(), "goals.db")))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	projects := sqlite.NewProjectRepository(db.DB())
+	tracker := NewGoalTracker(
+		sqlite.NewGoalRepository(db.DB()),
+		sqlite.NewSessionRepository(db),
+		sqlite.NewWorkBlockRepository(db.DB()),
+		projects,
+	)
+	return tracker, projects
+}
+
+// goalTestBlock is a finished block on projectID starting at start
+func goalTestBlock(projectID string, start time.Time, duration time.Duration) *sqlite.WorkBlock {
+	end := start.Add(duration)
+	return &sqlite.WorkBlock{ProjectID: projectID, StartTime: start, EndTime: &end, ActivityCount: 10}
+}
+
+func TestGoalTrackerStates(t *testing.T) {
+	tracker, _ := newGoalTestTracker(t)
+	ctx := context.Background()
+
+	// Monday 2026-03-02; four 2h blocks on Monday to Thursday
+	weekStart := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	weekEnd := weekStart.AddDate(0, 0, 7)
+	var blocks []*sqlite.WorkBlock
+	for day := 0; day < 4; day++ {
+		blocks = append(blocks, goalTestBlock("p1", weekStart.AddDate(0, 0, day).Add(9*time.Hour), 2*time.Hour))
+	}
+	midweek := weekStart.AddDate(0, 0, 2).Add(12 * time.Hour) // Wednesday noon: 6h tracked
+
+	tests := []struct {
+		name   string
+		kind   string
+		target float64
+		at     time.Time
+		actual float64
+		state  string
+		met    bool
+	}{
+		{"target on track", sqlite.GoalKindTarget, 10, midweek, 6, GoalStateInProgress, false},
+		{"target reached early", sqlite.GoalKindTarget, 5, midweek, 6, GoalStateMet, true},
+		{"target met at close", sqlite.GoalKindTarget, 8, weekEnd, 8, GoalStateMet, true},
+		{"target missed at close", sqlite.GoalKindTarget, 10, weekEnd, 8, GoalStateMissed, false},
+		{"cap within budget", sqlite.GoalKindCap, 10, midweek, 6, GoalStateWithinBudget, false},
+		{"cap exceeded mid-period", sqlite.GoalKindCap, 5, midweek, 6, GoalStateExceeded, false},
+		{"cap kept at close", sqlite.GoalKindCap, 10, weekEnd, 8, GoalStateMet, true},
+		{"cap exactly used", sqlite.GoalKindCap, 8, weekEnd, 8, GoalStateMet, true},
+		{"cap exceeded at close", sqlite.GoalKindCap, 7, weekEnd.Add(time.Hour), 8, GoalStateExceeded, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goal := &sqlite.Goal{
+				ID: "g", Name: tt.name, Period: sqlite.GoalPeriodWeekly, Kind: tt.kind,
+				Metric: sqlite.GoalMetricWorkHours, TargetHours: tt.target,
+			}
+			status := tracker.evaluate(ctx, goal, blocks, weekStart, weekEnd, tt.at)
+			assert.InDelta(t, tt.actual, status.ActualHours, 1e-9)
+			assert.InDelta(t, tt.actual/tt.target*100, status.Percent, 1e-9)
+			assert.Equal(t, tt.state, status.State)
+			assert.Equal(t, tt.met, status.Met)
+		})
+	}
+}
+
+func TestGoalTrackerProjectScopeAndDeepWork(t *testing.T) {
+	tracker, projects := newGoalTestTracker(t)
+	ctx := context.Background()
+
+	client, err := projects.GetOrCreateNamed(ctx, "/work/acme", "acme")
+	require.NoError(t, err)
+	api, err := projects.GetOrCreateNamed(ctx, "/work/acme/api", "acme-api")
+	require.NoError(t, err)
+	other, err := projects.GetOrCreateNamed(ctx, "/work/other", "other")
+	require.NoError(t, err)
+	require.NoError(t, projects.SetParent(ctx, api.ID, client.ID))
+
+	day := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	blocks := []*sqlite.WorkBlock{
+		goalTestBlock(client.ID, day.Add(8*time.Hour), time.Hour),
+		goalTestBlock(api.ID, day.Add(10*time.Hour), 10*time.Minute),
+		goalTestBlock(other.ID, day.Add(13*time.Hour), 3*time.Hour),
+	}
+	at := day.AddDate(0, 0, 1)
+
+	scoped := tracker.evaluate(ctx, &sqlite.Goal{
+		ID: "g1", Name: "acme", Period: sqlite.GoalPeriodDaily, Kind: sqlite.GoalKindCap,
+		Metric: sqlite.GoalMetricWorkHours, ProjectID: client.ID, TargetHours: 2,
+	}, blocks, day, at, at)
+	assert.Equal(t, "acme", scoped.ProjectName)
+	assert.InDelta(t, 70.0/60, scoped.ActualHours, 1e-9, "sub-project time counts, other projects do not")
+	assert.Equal(t, GoalStateMet, scoped.State)
+
+	child := tracker.evaluate(ctx, &sqlite.Goal{
+		ID: "g2", Name: "api", Period: sqlite.GoalPeriodDaily, Kind: sqlite.GoalKindTarget,
+		Metric: sqlite.GoalMetricWorkHours, ProjectID: api.ID, TargetHours: 1,
+	}, blocks, day, at, at)
+	assert.InDelta(t, 10.0/60, child.ActualHours, 1e-9, "parent time does not count toward a child goal")
+	assert.Equal(t, GoalStateMissed, child.State)
+
+	deep := tracker.evaluate(ctx, &sqlite.Goal{
+		ID: "g3", Name: "deep", Period: sqlite.GoalPeriodDaily, Kind: sqlite.GoalKindTarget,
+		Metric: sqlite.GoalMetricDeepWorkHours, TargetHours: 4,
+	}, blocks, day, at, at)
+	assert.InDelta(t, 4.0, deep.ActualHours, 1e-9, "the 10-minute block is not deep work")
+	assert.Equal(t, GoalStateMet, deep.State)
+}
+
+func TestGoalPeriodBounds(t *testing.T) {
+	sunday := time.Date(2026, 3, 8, 23, 30, 0, 0, time.UTC)
+
+	start, end := GoalPeriodBounds(sqlite.GoalPeriodWeekly, sunday)
+	assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), start, "weeks start on Monday")
+	assert.Equal(t, time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), end)
+
+	start, end = GoalPeriodBounds(sqlite.GoalPeriodMonthly, sunday)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), end)
+
+	start, end = GoalPeriodBounds(sqlite.GoalPeriodDaily, sunday)
+	assert.Equal(t, time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, 24*time.Hour, end.Sub(start))
+}
+
+func TestMonthlyAchievementsOnlyForMetGoals(t *testing.T) {
+	report := &EnhancedMonthlyReport{Goals: []GoalStatus{
+		{Name: "Ship", Kind: sqlite.GoalKindTarget, TargetHours: 80, ActualHours: 90, State: GoalStateMet, Met: true},
+		{Name: "Focus", Kind: sqlite.GoalKindTarget, TargetHours: 40, ActualHours: 12, State: GoalStateMissed},
+		{Name: "Budget", Kind: sqlite.GoalKindCap, ProjectName: "x", TargetHours: 10, ActualHours: 8, State: GoalStateMet, Met: true},
+		{Name: "Meetings", Kind: sqlite.GoalKindCap, TargetHours: 5, ActualHours: 9, State: GoalStateExceeded},
+		{Name: "Running", Kind: sqlite.GoalKindCap, TargetHours: 5, ActualHours: 1, State: GoalStateWithinBudget},
+	}}
+
+	NewDefaultAnalyticsCalculator().GenerateMonthlyAchievements(report)
+
+	require.Len(t, report.Achievements, 2)
+	assert.Equal(t, "Ship", report.Achievements[0].Title)
+	assert.Equal(t, "Budget", report.Achievements[1].Title)
+	assert.Equal(t, "Kept x under 10.0h (8.0h tracked)", report.Achievements[1].Description)
+	for _, achievement := range report.Achievements {
+		assert.True(t, achievement.Achieved)
+	}
+}
+
+func TestGoalTrackerMergesParallelBlocks(t *testing.T) {
+	tracker, _ := newGoalTestTracker(t)
+	ctx := context.Background()
+
+	// Two instances side by side 09:00-11:00 and 10:00-12:00: three hours of wall-clock time
+	day := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	blocks := []*sqlite.WorkBlock{
+		goalTestBlock("p1", day.Add(9*time.Hour), 2*time.Hour),
+		goalTestBlock("p1", day.Add(10*time.Hour), 2*time.Hour),
+	}
+	at := day.AddDate(0, 0, 1)
+
+	status := tracker.evaluate(ctx, &sqlite.Goal{
+		ID: "g", Name: "focus", Period: sqlite.GoalPeriodDaily, Kind: sqlite.GoalKindTarget,
+		Metric: sqlite.GoalMetricWorkHours, TargetHours: 4,
+	}, blocks, day, at, at)
+	assert.InDelta(t, 3.0, status.ActualHours, 1e-9, "overlapping blocks count once")
+	assert.Equal(t, GoalStateMissed, status.State)
+}
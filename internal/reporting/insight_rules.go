@@ -0,0 +1,461 @@
+/**
+ * CONTEXT:   Declarative insight rules engine for reports and work analytics
+ * INPUT:     Rules (metric, comparator, threshold, message template) and per-report metrics
+ * OUTPUT:    Rendered insights, recommendations, trends and focus level classifications
+ * BUSINESS:  Teams tune what counts as deep work or overwork without recompiling
+ * CHANGE:    Rule metrics are checked against the metrics each scope provides
+ * RISK:      Medium - Rules drive every insight string; defaults must match prior behavior
+ */
+
+package reporting
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// Rule scopes select which report or analysis a rule is evaluated against
+const (
+	RuleScopeDaily           = "daily"
+	RuleScopeWeekly          = "weekly"
+	RuleScopeWeeklyTrend     = "weekly_trend"
+	RuleScopeMonthly         = "monthly"
+	RuleScopeMonthlyTrend    = "monthly_trend"
+	RuleScopeDeepWork        = "deep_work"
+	RuleScopeActivityPattern = "activity_pattern"
+	RuleScopeProjectFocus    = "project_focus"
+	RuleScopeFocusLevel      = "focus_level"
+)
+
+// validRuleScopes lists the scopes accepted in rule files
+var validRuleScopes = map[string]bool{
+	RuleScopeDaily:           true,
+	RuleScopeWeekly:          true,
+	RuleScopeWeeklyTrend:     true,
+	RuleScopeMonthly:         true,
+	RuleScopeMonthlyTrend:    true,
+	RuleScopeDeepWork:        true,
+	RuleScopeActivityPattern: true,
+	RuleScopeProjectFocus:    true,
+	RuleScopeFocusLevel:      true,
+}
+
+// ruleScopeMetrics lists the numeric metrics and text labels each scope provides
+var ruleScopeMetrics = map[string]struct{ numbers, labels []string }{
+	RuleScopeDaily: {numbers: []string{
+		"total_work_hours", "deep_work_hours", "focus_score", "project_count",
+		"total_sessions", "total_work_blocks", "claude_prompts", "peak_hour",
+	}},
+	RuleScopeWeekly: {numbers: []string{
+		"total_work_hours", "daily_average", "consistency", "claude_usage_percent",
+		"weekend_hours", "weekend_percent", "project_count",
+	}},
+	RuleScopeWeeklyTrend:  {numbers: halfTrendMetrics},
+	RuleScopeMonthlyTrend: {numbers: halfTrendMetrics},
+	RuleScopeMonthly: {
+		numbers: []string{
+			"total_work_hours", "working_days", "average_hours_per_day", "average_hours_per_working_day",
+			"longest_work_streak", "best_day_hours", "project_count", "top_project_percent",
+			"weekend_hours", "weekend_percent", "goals_met", "goals_total",
+		},
+		labels: []string{"top_project"},
+	},
+	RuleScopeDeepWork: {numbers: []string{
+		"deep_work_percentage", "deep_work_hours", "shallow_work_hours", "context_switches",
+		"fragmentation_score", "flow_sessions", "focus_score",
+	}},
+	RuleScopeActivityPattern: {
+		numbers: []string{"consistency_score", "peak_hour_count", "total_activities"},
+		labels:  []string{"work_rhythm"},
+	},
+	RuleScopeProjectFocus: {numbers: []string{
+		"context_switches", "focus_efficiency", "switching_cost_minutes",
+		"project_sessions", "average_session_minutes",
+	}},
+	RuleScopeFocusLevel: {numbers: []string{"minutes", "activity_rate", "activity_count"}},
+}
+
+// halfTrendMetrics are the metrics of both half-period trend scopes
+var halfTrendMetrics = []string{"first_half_average", "second_half_average", "growth_ratio", "decline_ratio"}
+
+// containsMetric reports whether name is in metrics
+func containsMetric(metrics []string, name string) bool {
+	for _, metric := range metrics {
+		if metric == name {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * CONTEXT:   Single comparison of a metric against a threshold or label
+ * INPUT:     No input - data structure definition
+ * OUTPUT:    Condition evaluated against report metrics
+ * BUSINESS:  Conditions are the building blocks of every insight rule
+ * CHANGE:    Initial condition structure
+ * RISK:      Low - Data structure with JSON serialization support
+ */
+type RuleCondition struct {
+	Metric     string  `json:"metric"`
+	Comparator string  `json:"comparator"`
+	Threshold  float64 `json:"threshold"`
+	Value      string  `json:"value,omitempty"`
+}
+
+/**
+ * CONTEXT:   Declarative insight rule
+ * INPUT:     No input - data structure definition
+ * OUTPUT:    Rule with scope, conditions and message template
+ * BUSINESS:  Rules replace hard-coded thresholds so teams can tune them
+ * CHANGE:    Initial rule structure
+ * RISK:      Low - Data structure with JSON serialization support
+ *
+ * Rules without a group all fire when their conditions hold. Within a group
+ * only the first matching rule fires, which expresses if/else-if tiers.
+ * Focus level rules always behave as one group and Type names the level.
+ */
+type InsightRule struct {
+	Name       string          `json:"name"`
+	Scope      string          `json:"scope"`
+	Group      string          `json:"group,omitempty"`
+	Metric     string          `json:"metric"`
+	Comparator string          `json:"comparator"`
+	Threshold  float64         `json:"threshold"`
+	Value      string          `json:"value,omitempty"`
+	And        []RuleCondition `json:"and,omitempty"`
+	Type       string          `json:"type,omitempty"`
+	Message    string          `json:"message,omitempty"`
+	Disabled   bool            `json:"disabled,omitempty"`
+}
+
+// InsightRuleFile is the on-disk format of a rules file
+type InsightRuleFile struct {
+	ReplaceDefaults bool          `json:"replace_defaults"`
+	Rules           []InsightRule `json:"rules"`
+}
+
+// InsightMetrics holds the numeric metrics and text labels a rule can reference
+type InsightMetrics struct {
+	Numbers map[string]float64
+	Labels  map[string]string
+}
+
+// NewInsightMetrics creates an empty metric set
+func NewInsightMetrics() InsightMetrics {
+	return InsightMetrics{
+		Numbers: make(map[string]float64),
+		Labels:  make(map[string]string),
+	}
+}
+
+// InsightResult is a rule that matched, with its rendered message
+type InsightResult struct {
+	Rule    string  `json:"rule"`
+	Type    string  `json:"type,omitempty"`
+	Message string  `json:"message"`
+	Value   float64 `json:"value"`
+}
+
+// compiledRule pairs a rule with its parsed message template
+type compiledRule struct {
+	rule     InsightRule
+	template *template.Template
+}
+
+/**
+ * CONTEXT:   Insight rule engine evaluating rules per scope
+ * INPUT:     Validated rules with compiled templates
+ * OUTPUT:    Matching rule results for a scope and metric set
+ * BUSINESS:  One engine backs report insights, recommendations and focus levels
+ * CHANGE:    Initial rule engine
+ * RISK:      Low - Immutable after construction, safe for concurrent use
+ */
+type InsightRuleEngine struct {
+	rules   []InsightRule
+	byScope map[string][]compiledRule
+}
+
+/**
+ * CONTEXT:   Constructor for insight rule engine
+ * INPUT:     Rules in evaluation order
+ * OUTPUT:    Engine with validated rules and compiled templates, or first rule error
+ * BUSINESS:  Broken rule files are rejected up front instead of producing odd insights
+ * CHANGE:    Initial constructor with validation
+ * RISK:      Low - Pure validation and template parsing
+ */
+func NewInsightRuleEngine(rules []InsightRule) (*InsightRuleEngine, error) {
+	engine := &InsightRuleEngine{
+		rules:   make([]InsightRule, 0, len(rules)),
+		byScope: make(map[string][]compiledRule),
+	}
+
+	for _, rule := range rules {
+		if rule.Disabled {
+			continue
+		}
+		if err := validateInsightRule(rule); err != nil {
+			return nil, err
+		}
+
+		tmpl, err := template.New(rule.Name).Option("missingkey=zero").Parse(rule.Message)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: invalid message template: %w", rule.Name, err)
+		}
+
+		engine.rules = append(engine.rules, rule)
+		engine.byScope[rule.Scope] = append(engine.byScope[rule.Scope], compiledRule{rule: rule, template: tmpl})
+	}
+
+	return engine, nil
+}
+
+var (
+	defaultRuleEngine     *InsightRuleEngine
+	defaultRuleEngineOnce sync.Once
+)
+
+// DefaultInsightRuleEngine returns the shared engine built from DefaultInsightRules
+func DefaultInsightRuleEngine() *InsightRuleEngine {
+	defaultRuleEngineOnce.Do(func() {
+		engine, err := NewInsightRuleEngine(DefaultInsightRules())
+		if err != nil {
+			panic(fmt.Sprintf("invalid built-in insight rules: %v", err))
+		}
+		defaultRuleEngine = engine
+	})
+	return defaultRuleEngine
+}
+
+/**
+ * CONTEXT:   Load insight rules from a JSON rules file
+ * INPUT:     Path to a rules file
+ * OUTPUT:    Engine with defaults merged with file rules, or descriptive error
+ * BUSINESS:  File rules override defaults by name, add new rules, or replace all defaults
+ * CHANGE:    Initial rules file loader
+ * RISK:      Low - Read-only file access with validation
+ */
+func LoadInsightRules(path string) (*InsightRuleEngine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read insight rules: %w", err)
+	}
+
+	var file InsightRuleFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse insight rules %s: %w", path, err)
+	}
+
+	base := DefaultInsightRules()
+	if file.ReplaceDefaults {
+		base = nil
+	}
+
+	engine, err := NewInsightRuleEngine(MergeInsightRules(base, file.Rules))
+	if err != nil {
+		return nil, fmt.Errorf("invalid insight rules in %s: %w", path, err)
+	}
+	return engine, nil
+}
+
+/**
+ * CONTEXT:   Merge override rules into a base rule list
+ * INPUT:     Base rules and overrides in file order
+ * OUTPUT:    Base rules with same-name overrides replaced in place, new rules appended
+ * BUSINESS:  Overriding by name keeps tier order intact when tuning one threshold
+ * CHANGE:    Initial merge semantics for rules files
+ * RISK:      Low - Pure list manipulation
+ */
+func MergeInsightRules(base, overrides []InsightRule) []InsightRule {
+	merged := make([]InsightRule, len(base))
+	copy(merged, base)
+
+	index := make(map[string]int, len(merged))
+	for i, rule := range merged {
+		index[rule.Name] = i
+	}
+
+	for _, rule := range overrides {
+		if i, exists := index[rule.Name]; exists {
+			merged[i] = rule
+			continue
+		}
+		index[rule.Name] = len(merged)
+		merged = append(merged, rule)
+	}
+
+	return merged
+}
+
+// Rules returns the active rules in evaluation order
+func (e *InsightRuleEngine) Rules() []InsightRule {
+	rules := make([]InsightRule, len(e.rules))
+	copy(rules, e.rules)
+	return rules
+}
+
+/**
+ * CONTEXT:   Evaluate all rules of a scope against a metric set
+ * INPUT:     Rule scope and metrics of the report or analysis
+ * OUTPUT:    Results of matching rules in rule order, first match per group
+ * BUSINESS:  Produces the insight and recommendation lists shown to users
+ * CHANGE:    Initial scope evaluation
+ * RISK:      Low - Pure evaluation; template errors fall back to the raw message
+ */
+func (e *InsightRuleEngine) Evaluate(scope string, metrics InsightMetrics) []InsightResult {
+	results := make([]InsightResult, 0)
+	firedGroups := make(map[string]bool)
+
+	for _, compiled := range e.byScope[scope] {
+		rule := compiled.rule
+		if rule.Group != "" && firedGroups[rule.Group] {
+			continue
+		}
+		if !ruleMatches(rule, metrics) {
+			continue
+		}
+		if rule.Group != "" {
+			firedGroups[rule.Group] = true
+		}
+		results = append(results, renderRule(compiled, metrics))
+	}
+
+	return results
+}
+
+// FirstMatch returns the first matching rule of a scope
+func (e *InsightRuleEngine) FirstMatch(scope string, metrics InsightMetrics) (InsightResult, bool) {
+	for _, compiled := range e.byScope[scope] {
+		if ruleMatches(compiled.rule, metrics) {
+			return renderRule(compiled, metrics), true
+		}
+	}
+	return InsightResult{}, false
+}
+
+// renderRule executes a rule's message template against the metrics
+func renderRule(compiled compiledRule, metrics InsightMetrics) InsightResult {
+	data := make(map[string]interface{}, len(metrics.Numbers)+len(metrics.Labels))
+	for name, value := range metrics.Numbers {
+		data[name] = value
+	}
+	for name, value := range metrics.Labels {
+		data[name] = value
+	}
+
+	message := compiled.rule.Message
+	var sb strings.Builder
+	if err := compiled.template.Execute(&sb, data); err == nil {
+		message = sb.String()
+	}
+
+	return InsightResult{
+		Rule:    compiled.rule.Name,
+		Type:    compiled.rule.Type,
+		Message: message,
+		Value:   metrics.Numbers[compiled.rule.Metric],
+	}
+}
+
+// ruleMatches reports whether the primary condition and all extra conditions hold
+func ruleMatches(rule InsightRule, metrics InsightMetrics) bool {
+	primary := RuleCondition{Metric: rule.Metric, Comparator: rule.Comparator, Threshold: rule.Threshold, Value: rule.Value}
+	if !conditionMatches(primary, metrics) {
+		return false
+	}
+	for _, condition := range rule.And {
+		if !conditionMatches(condition, metrics) {
+			return false
+		}
+	}
+	return true
+}
+
+// conditionMatches compares a label with Value or a number with Threshold
+func conditionMatches(condition RuleCondition, metrics InsightMetrics) bool {
+	if condition.Value != "" {
+		label, exists := metrics.Labels[condition.Metric]
+		if !exists {
+			return false
+		}
+		switch condition.Comparator {
+		case "==":
+			return label == condition.Value
+		case "!=":
+			return label != condition.Value
+		}
+		return false
+	}
+
+	value, exists := metrics.Numbers[condition.Metric]
+	if !exists || math.IsNaN(value) {
+		return false
+	}
+
+	switch condition.Comparator {
+	case ">":
+		return value > condition.Threshold
+	case ">=":
+		return value >= condition.Threshold
+	case "<":
+		return value < condition.Threshold
+	case "<=":
+		return value <= condition.Threshold
+	case "==":
+		return value == condition.Threshold
+	case "!=":
+		return value != condition.Threshold
+	}
+	return false
+}
+
+// validateInsightRule checks scope, metric names, comparators and required fields
+func validateInsightRule(rule InsightRule) error {
+	if rule.Name == "" {
+		return fmt.Errorf("rule name cannot be empty")
+	}
+	if !validRuleScopes[rule.Scope] {
+		return fmt.Errorf("rule %q: unknown scope %q", rule.Name, rule.Scope)
+	}
+	if rule.Scope == RuleScopeFocusLevel {
+		if _, ok := ParseFocusLevel(rule.Type); !ok {
+			return fmt.Errorf("rule %q: focus level rules need type distracted, focused, deep or flow", rule.Name)
+		}
+	} else if rule.Message == "" {
+		return fmt.Errorf("rule %q: message cannot be empty", rule.Name)
+	}
+
+	available := ruleScopeMetrics[rule.Scope]
+	conditions := append([]RuleCondition{{Metric: rule.Metric, Comparator: rule.Comparator, Value: rule.Value}}, rule.And...)
+	for _, condition := range conditions {
+		if condition.Metric == "" {
+			return fmt.Errorf("rule %q: condition metric cannot be empty", rule.Name)
+		}
+		isLabel := containsMetric(available.labels, condition.Metric)
+		if !isLabel && !containsMetric(available.numbers, condition.Metric) {
+			return fmt.Errorf("rule %q: unknown metric %q for scope %s (available: %s)",
+				rule.Name, condition.Metric, rule.Scope, strings.Join(append(available.numbers, available.labels...), ", "))
+		}
+		if isLabel && condition.Value == "" {
+			return fmt.Errorf("rule %q: metric %q is a text label and needs a value", rule.Name, condition.Metric)
+		}
+		if !isLabel && condition.Value != "" {
+			return fmt.Errorf("rule %q: metric %q is numeric and needs a threshold, not a value", rule.Name, condition.Metric)
+		}
+		switch condition.Comparator {
+		case ">", ">=", "<", "<=":
+			if condition.Value != "" {
+				return fmt.Errorf("rule %q: comparator %s needs a numeric threshold", rule.Name, condition.Comparator)
+			}
+		case "==", "!=":
+		default:
+			return fmt.Errorf("rule %q: unknown comparator %q", rule.Name, condition.Comparator)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+/**
+ * CONTEXT:   Built-in insight rules shipped with Claude Monitor
+ * INPUT:     No input - static rule definitions
+ * OUTPUT:    Default rules for every report and analysis scope
+ * BUSINESS:  Defaults reproduce the thresholds reports have always used
+ * CHANGE:    Moved hard-coded insight thresholds into declarative rules
+ * RISK:      Medium - Changing a default changes every user's insights
+ */
+
+package reporting
+
+/**
+ * CONTEXT:   Default insight rules in evaluation order
+ * INPUT:     No input - static rule definitions
+ * OUTPUT:    Fresh copy of the built-in rules
+ * BUSINESS:  Rule names are stable so rules files can override them one by one
+ * CHANGE:    Initial defaults matching previous hard-coded behavior
+ * RISK:      Medium - Rule names are part of the rules file contract
+ */
+func DefaultInsightRules() []InsightRule {
+	return []InsightRule{
+		// Daily report insights
+		{Name: "daily_hours_excellent", Scope: RuleScopeDaily, Group: "daily_hours", Metric: "total_work_hours", Comparator: ">=", Threshold: 8, Message: "🌟 Excellent productivity with 8+ hours"},
+		{Name: "daily_hours_good", Scope: RuleScopeDaily, Group: "daily_hours", Metric: "total_work_hours", Comparator: ">=", Threshold: 6, Message: "✅ Good productivity day"},
+		{Name: "daily_hours_moderate", Scope: RuleScopeDaily, Group: "daily_hours", Metric: "total_work_hours", Comparator: ">=", Threshold: 3, Message: "⚡ Moderate work day"},
+		{Name: "daily_hours_started", Scope: RuleScopeDaily, Group: "daily_hours", Metric: "total_work_hours", Comparator: ">", Threshold: 0, Message: "🚀 Getting started"},
+		{Name: "daily_hours_none", Scope: RuleScopeDaily, Group: "daily_hours", Metric: "total_work_hours", Comparator: "<=", Threshold: 0, Message: "📋 No tracked work today"},
+		{Name: "daily_many_projects", Scope: RuleScopeDaily, Metric: "project_count", Comparator: ">", Threshold: 3, Message: "🔀 Consider focusing on fewer projects for deeper progress"},
+		{Name: "daily_single_project", Scope: RuleScopeDaily, Metric: "project_count", Comparator: "==", Threshold: 1, Message: "🎯 Excellent focus on a single project today!"},
+		{Name: "daily_peak_hour", Scope: RuleScopeDaily, Metric: "peak_hour", Comparator: ">=", Threshold: 0, Message: "⏰ Peak productivity at {{.peak_hour}}:00"},
+
+		// Weekly report insights
+		{Name: "weekly_average_low", Scope: RuleScopeWeekly, Group: "weekly_average", Metric: "daily_average", Comparator: "<", Threshold: 4, Type: "improvement", Message: `Weekly average: {{printf "%.1f" .daily_average}} hours/day - aim for consistency`},
+		{Name: "weekly_average", Scope: RuleScopeWeekly, Group: "weekly_average", Metric: "daily_average", Comparator: ">=", Threshold: 4, Type: "productivity", Message: `Weekly average: {{printf "%.1f" .daily_average}} hours/day`},
+		{Name: "weekly_inconsistent", Scope: RuleScopeWeekly, Metric: "consistency", Comparator: "<", Threshold: 0.6, Type: "pattern", Message: "Work pattern varies - consider establishing routine"},
+
+		// Weekly trends (first three days against the next three)
+		{Name: "weekly_trend_increasing", Scope: RuleScopeWeeklyTrend, Group: "weekly_hours_trend", Metric: "growth_ratio", Comparator: ">", Threshold: 1.2, Type: "increasing", Message: "Work hours increasing"},
+		{Name: "weekly_trend_decreasing", Scope: RuleScopeWeeklyTrend, Group: "weekly_hours_trend", Metric: "decline_ratio", Comparator: ">", Threshold: 1.2, Type: "decreasing", Message: "Work hours decreasing"},
+		{Name: "weekly_trend_stable", Scope: RuleScopeWeeklyTrend, Group: "weekly_hours_trend", Metric: "growth_ratio", Comparator: ">=", Threshold: 0, Type: "stable", Message: "Consistent work pattern"},
+
+		// Monthly trends (first half of the month against the second half)
+		{Name: "monthly_trend_increasing", Scope: RuleScopeMonthlyTrend, Group: "monthly_hours_trend", Metric: "growth_ratio", Comparator: ">", Threshold: 1.2, Type: "increasing", Message: `Work hours increased in the second half of the month ({{printf "%.1f" .first_half_average}}h → {{printf "%.1f" .second_half_average}}h per day)`},
+		{Name: "monthly_trend_decreasing", Scope: RuleScopeMonthlyTrend, Group: "monthly_hours_trend", Metric: "decline_ratio", Comparator: ">", Threshold: 1.2, Type: "decreasing", Message: `Work hours decreased in the second half of the month ({{printf "%.1f" .first_half_average}}h → {{printf "%.1f" .second_half_average}}h per day)`},
+		{Name: "monthly_trend_stable", Scope: RuleScopeMonthlyTrend, Group: "monthly_hours_trend", Metric: "growth_ratio", Comparator: ">=", Threshold: 0, Type: "stable", Message: "Consistent work hours throughout the month"},
+
+		// Monthly report insights
+		{Name: "monthly_no_work", Scope: RuleScopeMonthly, Group: "monthly_volume", Metric: "working_days", Comparator: "==", Threshold: 0, Message: "📋 No tracked work this month"},
+		{Name: "monthly_overwork", Scope: RuleScopeMonthly, Group: "monthly_volume", Metric: "average_hours_per_working_day", Comparator: ">=", Threshold: 9, Message: `⚠️ Averaging {{printf "%.1f" .average_hours_per_working_day}}h per working day - watch for overwork`},
+		{Name: "monthly_strong", Scope: RuleScopeMonthly, Group: "monthly_volume", Metric: "average_hours_per_working_day", Comparator: ">=", Threshold: 6, Message: `🌟 Strong month with {{printf "%.1f" .average_hours_per_working_day}}h per working day`},
+		{Name: "monthly_volume", Scope: RuleScopeMonthly, Group: "monthly_volume", Metric: "working_days", Comparator: ">", Threshold: 0, Message: `⚡ {{printf "%.1f" .total_work_hours}}h tracked across {{.working_days}} working days`},
+		{Name: "monthly_streak", Scope: RuleScopeMonthly, Metric: "longest_work_streak", Comparator: ">=", Threshold: 5, Message: "🔥 Longest streak: {{.longest_work_streak}} consecutive working days"},
+		{Name: "monthly_many_projects", Scope: RuleScopeMonthly, Group: "monthly_projects", Metric: "project_count", Comparator: ">", Threshold: 5, Message: "🔀 Work spread across {{.project_count}} projects - consider narrowing focus"},
+		{Name: "monthly_dominant_project", Scope: RuleScopeMonthly, Group: "monthly_projects", Metric: "top_project_percent", Comparator: ">=", Threshold: 60, Message: `🎯 {{.top_project}} took {{printf "%.0f" .top_project_percent}}% of the month`},
+		{Name: "monthly_weekend_work", Scope: RuleScopeMonthly, Metric: "weekend_percent", Comparator: ">", Threshold: 20, Message: `📅 {{printf "%.0f" .weekend_percent}}% of hours were worked on weekends`},
+		{Name: "monthly_goals", Scope: RuleScopeMonthly, Metric: "goals_total", Comparator: ">", Threshold: 0, Message: "🏆 {{.goals_met}} of {{.goals_total}} monthly goals met"},
+
+		// Deep work recommendations
+		{Name: "deep_work_low_blocks", Scope: RuleScopeDeepWork, Metric: "deep_work_percentage", Comparator: "<", Threshold: 30, Message: "Focus on 25+ minute work blocks for deep work"},
+		{Name: "deep_work_low_distractions", Scope: RuleScopeDeepWork, Metric: "deep_work_percentage", Comparator: "<", Threshold: 30, Message: "Consider reducing distractions during work sessions"},
+		{Name: "deep_work_context_switching", Scope: RuleScopeDeepWork, Metric: "context_switches", Comparator: ">", Threshold: 8, Message: "High context switching detected - try time blocking by project"},
+		{Name: "deep_work_fragmented", Scope: RuleScopeDeepWork, Metric: "fragmentation_score", Comparator: ">", Threshold: 0.5, Message: "Work is highly fragmented - consider longer uninterrupted sessions"},
+		{Name: "deep_work_flow_sessions", Scope: RuleScopeDeepWork, Group: "deep_work_flow", Metric: "flow_sessions", Comparator: ">", Threshold: 0, Message: "Great! {{.flow_sessions}} flow sessions detected - replicate these conditions"},
+		{Name: "deep_work_extend_sessions", Scope: RuleScopeDeepWork, Group: "deep_work_flow", Metric: "deep_work_hours", Comparator: ">", Threshold: 2, Message: "You have good deep work time - try extending sessions to achieve flow state"},
+		{Name: "deep_work_focus_low_pomodoro", Scope: RuleScopeDeepWork, Metric: "focus_score", Comparator: "<", Threshold: 50, Message: "Focus score is low - consider implementing the Pomodoro Technique"},
+		{Name: "deep_work_focus_low_workspace", Scope: RuleScopeDeepWork, Metric: "focus_score", Comparator: "<", Threshold: 50, Message: "Create a dedicated workspace to minimize interruptions"},
+		{Name: "deep_work_focus_excellent", Scope: RuleScopeDeepWork, Metric: "focus_score", Comparator: ">=", Threshold: 80, Message: "Excellent focus score! Maintain your current work patterns"},
+
+		// Activity pattern recommendations
+		{Name: "rhythm_early_bird_schedule", Scope: RuleScopeActivityPattern, Metric: "work_rhythm", Comparator: "==", Value: "early_bird", Message: "Your peak is morning - schedule important work before 10 AM"},
+		{Name: "rhythm_early_bird_protect", Scope: RuleScopeActivityPattern, Metric: "work_rhythm", Comparator: "==", Value: "early_bird", Message: "Protect your morning hours for deep work and complex tasks"},
+		{Name: "rhythm_night_owl_protect", Scope: RuleScopeActivityPattern, Metric: "work_rhythm", Comparator: "==", Value: "night_owl", Message: "Evening productivity detected - protect your late-day focus time"},
+		{Name: "rhythm_night_owl_meetings", Scope: RuleScopeActivityPattern, Metric: "work_rhythm", Comparator: "==", Value: "night_owl", Message: "Schedule meetings and administrative tasks earlier in the day"},
+		{Name: "rhythm_mid_day", Scope: RuleScopeActivityPattern, Metric: "work_rhythm", Comparator: "==", Value: "mid_day", Message: "Mid-day productivity peak - use morning for preparation and afternoon for execution"},
+		{Name: "rhythm_afternoon", Scope: RuleScopeActivityPattern, Metric: "work_rhythm", Comparator: "==", Value: "afternoon", Message: "Afternoon peak detected - save complex work for post-lunch hours"},
+		{Name: "rhythm_distributed_scheduling", Scope: RuleScopeActivityPattern, Metric: "work_rhythm", Comparator: "==", Value: "distributed", Message: "Distributed work pattern - good for varied task scheduling"},
+		{Name: "rhythm_distributed_blocking", Scope: RuleScopeActivityPattern, Metric: "work_rhythm", Comparator: "==", Value: "distributed", Message: "Consider time-blocking different types of work throughout the day"},
+		{Name: "rhythm_irregular_hours", Scope: RuleScopeActivityPattern, Metric: "work_rhythm", Comparator: "==", Value: "irregular", Message: "Irregular work pattern - try establishing consistent work hours"},
+		{Name: "rhythm_irregular_experiment", Scope: RuleScopeActivityPattern, Metric: "work_rhythm", Comparator: "==", Value: "irregular", Message: "Experiment with different times to find your natural peak hours"},
+		{Name: "pattern_inconsistent_hours", Scope: RuleScopeActivityPattern, Metric: "consistency_score", Comparator: "<", Threshold: 50, Message: "Inconsistent work pattern - try establishing regular work hours"},
+		{Name: "pattern_inconsistent_routine", Scope: RuleScopeActivityPattern, Metric: "consistency_score", Comparator: "<", Threshold: 50, Message: "Consider creating a daily work routine to build consistency"},
+		{Name: "pattern_consistent", Scope: RuleScopeActivityPattern, Metric: "consistency_score", Comparator: ">=", Threshold: 80, Message: "Excellent work consistency! Your routine is well-established"},
+		{Name: "pattern_scattered_hours", Scope: RuleScopeActivityPattern, Metric: "peak_hour_count", Comparator: ">", Threshold: 6, Message: "Work scattered across many hours - consider focusing on 3-4 peak hours"},
+		{Name: "pattern_scattered_batching", Scope: RuleScopeActivityPattern, Metric: "peak_hour_count", Comparator: ">", Threshold: 6, Message: "Try batching similar activities within your most productive hours"},
+		{Name: "pattern_low_activity", Scope: RuleScopeActivityPattern, Metric: "total_activities", Comparator: "<", Threshold: 20, Message: "Low activity detected - consider if you're capturing all work sessions"},
+		{Name: "pattern_high_activity", Scope: RuleScopeActivityPattern, Metric: "total_activities", Comparator: ">", Threshold: 200, Message: "High activity volume - ensure you're taking adequate breaks"},
+
+		// Project focus recommendations
+		{Name: "project_switching_batch", Scope: RuleScopeProjectFocus, Metric: "context_switches", Comparator: ">", Threshold: 5, Message: "High project switching - consider batching similar work"},
+		{Name: "project_switching_dedicate", Scope: RuleScopeProjectFocus, Metric: "context_switches", Comparator: ">", Threshold: 5, Message: "Try dedicating specific days or time blocks to individual projects"},
+		{Name: "project_efficiency_low_interruptions", Scope: RuleScopeProjectFocus, Metric: "focus_efficiency", Comparator: "<", Threshold: 70, Message: "Focus efficiency below 70% - reduce interruptions between projects"},
+		{Name: "project_efficiency_low_blocks", Scope: RuleScopeProjectFocus, Metric: "focus_efficiency", Comparator: "<", Threshold: 70, Message: "Consider longer work blocks to minimize switching overhead"},
+		{Name: "project_efficiency_excellent", Scope: RuleScopeProjectFocus, Metric: "focus_efficiency", Comparator: ">=", Threshold: 85, Message: "Excellent focus efficiency! Your project management is working well"},
+		{Name: "project_switching_cost_transitions", Scope: RuleScopeProjectFocus, Metric: "switching_cost_minutes", Comparator: ">", Threshold: 30, Message: "High switching cost detected - minimize project transitions"},
+		{Name: "project_switching_cost_breaks", Scope: RuleScopeProjectFocus, Metric: "switching_cost_minutes", Comparator: ">", Threshold: 30, Message: "Plan project switches during natural break points"},
+		{Name: "project_many_sessions", Scope: RuleScopeProjectFocus, Metric: "project_sessions", Comparator: ">", Threshold: 10, Message: "Many short project sessions - consider consolidating related work"},
+		{Name: "project_short_sessions", Scope: RuleScopeProjectFocus, Metric: "average_session_minutes", Comparator: "<", Threshold: 30, Message: "Average project sessions are short - try extending focus periods"},
+		{Name: "project_long_sessions", Scope: RuleScopeProjectFocus, Metric: "average_session_minutes", Comparator: ">=", Threshold: 120, Message: "Great! Long project sessions indicate strong focus capability"},
+
+		// Focus level classification of work blocks, first match wins
+		{Name: "focus_distracted", Scope: RuleScopeFocusLevel, Metric: "minutes", Comparator: "<", Threshold: 5, Type: "distracted"},
+		{Name: "focus_short", Scope: RuleScopeFocusLevel, Metric: "minutes", Comparator: "<", Threshold: 15, Type: "focused"},
+		{Name: "focus_deep_active", Scope: RuleScopeFocusLevel, Metric: "minutes", Comparator: ">=", Threshold: 25, And: []RuleCondition{{Metric: "activity_rate", Comparator: ">", Threshold: 0.5}}, Type: "deep"},
+		{Name: "focus_flow", Scope: RuleScopeFocusLevel, Metric: "minutes", Comparator: ">=", Threshold: 45, And: []RuleCondition{{Metric: "activity_rate", Comparator: ">", Threshold: 0.3}}, Type: "flow"},
+		{Name: "focus_deep", Scope: RuleScopeFocusLevel, Metric: "minutes", Comparator: ">=", Threshold: 25, Type: "deep"},
+	}
+}
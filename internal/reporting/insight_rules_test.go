@@ -0,0 +1,196 @@
+/**
+ * CONTEXT:   Test suite for the declarative insight rules engine
+ * INPUT:     Fixed reports and analyses evaluated with the built-in rules
+ * OUTPUT:    Validation that defaults match the former hard-coded insights
+ *            and that broken rules are rejected
+ * BUSINESS:  Moving thresholds into rules must not change what users see
+ * CHANGE:    Initial insight rules tests
+ * RISK:      Low - Pure evaluation plus a temp rules file
+ */
+
+package reporting
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The expected strings below are the output of the hard-coded insight logic
+// that the default rules replaced.
+
+func TestDefaultRulesMatchPreviousDailyInsights(t *testing.T) {
+	calc := NewDefaultAnalyticsCalculator()
+
+	focused := &EnhancedDailyReport{
+		TotalWorkHours:   7,
+		ProjectBreakdown: []ProjectBreakdown{{ProjectName: "api", WorkHours: 7}},
+		HourlyBreakdown:  []HourlyData{{Hour: 9, Hours: 1}, {Hour: 10, Hours: 2}, {Hour: 14, Hours: 1.5}},
+	}
+	calc.GenerateDailyInsights(focused)
+	assert.Equal(t, []string{
+		"✅ Good productivity day",
+		"🎯 Excellent focus on a single project today!",
+		"⏰ Peak productivity at 10:00",
+	}, focused.Insights)
+
+	scattered := &EnhancedDailyReport{
+		TotalWorkHours:   9,
+		ProjectBreakdown: []ProjectBreakdown{{ProjectName: "a"}, {ProjectName: "b"}, {ProjectName: "c"}, {ProjectName: "d"}},
+	}
+	calc.GenerateDailyInsights(scattered)
+	assert.Equal(t, []string{
+		"🌟 Excellent productivity with 8+ hours",
+		"🔀 Consider focusing on fewer projects for deeper progress",
+	}, scattered.Insights)
+
+	empty := &EnhancedDailyReport{}
+	calc.GenerateDailyInsights(empty)
+	assert.Equal(t, []string{"📋 No tracked work today"}, empty.Insights)
+}
+
+func TestDefaultRulesMatchPreviousWeeklyInsights(t *testing.T) {
+	calc := NewDefaultAnalyticsCalculator()
+
+	report := &EnhancedWeeklyReport{DailyAverage: 3}
+	for _, hours := range []float64{1, 1, 1, 6, 6, 6, 0} {
+		report.DailyBreakdown = append(report.DailyBreakdown, DaySummary{Hours: hours})
+	}
+
+	calc.GenerateWeeklyInsights(report)
+	assert.Equal(t, []WeeklyInsight{
+		{Type: "improvement", Message: "Weekly average: 3.0 hours/day - aim for consistency"},
+		{Type: "pattern", Message: "Work pattern varies - consider establishing routine"},
+	}, report.Insights)
+
+	calc.GenerateWeeklyTrends(report)
+	require.Len(t, report.Trends, 1)
+	assert.Equal(t, "increasing", report.Trends[0].Type)
+	assert.Equal(t, "Work hours increasing", report.Trends[0].Description)
+	assert.InDelta(t, 5.0, report.Trends[0].Value, 1e-9)
+
+	steady := &EnhancedWeeklyReport{DailyAverage: 5}
+	for i := 0; i < 7; i++ {
+		steady.DailyBreakdown = append(steady.DailyBreakdown, DaySummary{Hours: 5})
+	}
+	calc.GenerateWeeklyInsights(steady)
+	assert.Equal(t, []WeeklyInsight{{Type: "productivity", Message: "Weekly average: 5.0 hours/day"}}, steady.Insights)
+	calc.GenerateWeeklyTrends(steady)
+	assert.Equal(t, []Trend{{Type: "stable", Description: "Consistent work pattern", Value: 0}}, steady.Trends)
+}
+
+func TestDefaultRulesMatchPreviousRecommendations(t *testing.T) {
+	engine := &WorkAnalyticsEngine{}
+
+	assert.Equal(t, []string{
+		"Focus on 25+ minute work blocks for deep work",
+		"Consider reducing distractions during work sessions",
+		"High context switching detected - try time blocking by project",
+		"Work is highly fragmented - consider longer uninterrupted sessions",
+		"You have good deep work time - try extending sessions to achieve flow state",
+		"Focus score is low - consider implementing the Pomodoro Technique",
+		"Create a dedicated workspace to minimize interruptions",
+	}, engine.generateDeepWorkRecommendations(&DeepWorkAnalysis{
+		DeepWorkTime:       3 * time.Hour,
+		DeepWorkPercentage: 20,
+		FocusScore:         40,
+		ContextSwitches:    9,
+		FragmentationScore: 0.6,
+	}))
+
+	assert.Equal(t, []string{
+		"Great! 2 flow sessions detected - replicate these conditions",
+		"Excellent focus score! Maintain your current work patterns",
+	}, engine.generateDeepWorkRecommendations(&DeepWorkAnalysis{
+		DeepWorkTime:       3 * time.Hour,
+		DeepWorkPercentage: 60,
+		FocusScore:         85,
+		FlowSessions:       []FlowSession{{}, {}},
+	}))
+
+	assert.Equal(t, []string{
+		"Your peak is morning - schedule important work before 10 AM",
+		"Protect your morning hours for deep work and complex tasks",
+		"Excellent work consistency! Your routine is well-established",
+		"Work scattered across many hours - consider focusing on 3-4 peak hours",
+		"Try batching similar activities within your most productive hours",
+		"Low activity detected - consider if you're capturing all work sessions",
+	}, engine.generateActivityPatternRecommendations(&ActivityPatternAnalysis{
+		WorkRhythm:       "early_bird",
+		ConsistencyScore: 85,
+		PeakHours:        []int{7, 8, 9, 10, 11, 13, 14},
+		TotalActivities:  10,
+	}))
+
+	assert.Equal(t, []string{
+		"Excellent focus efficiency! Your project management is working well",
+		"Great! Long project sessions indicate strong focus capability",
+	}, engine.generateProjectFocusRecommendations(&ProjectFocusAnalysis{
+		ContextSwitches: 2,
+		FocusEfficiency: 90,
+		SwitchingCost:   10 * time.Minute,
+		ProjectSessions: []ProjectSession{{Duration: 3 * time.Hour}, {Duration: 2 * time.Hour}},
+	}))
+}
+
+func TestDefaultRulesMatchPreviousFocusLevels(t *testing.T) {
+	engine := &WorkAnalyticsEngine{}
+
+	tests := []struct {
+		duration   time.Duration
+		activities int64
+		want       FocusLevel
+	}{
+		{3 * time.Minute, 10, FocusLevelDistracted},
+		{10 * time.Minute, 10, FocusLevelFocused},
+		{20 * time.Minute, 100, FocusLevelFocused},
+		{30 * time.Minute, 30, FocusLevelDeep},
+		{50 * time.Minute, 20, FocusLevelFlow},
+		{30 * time.Minute, 3, FocusLevelDeep},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, engine.classifyFocusLevel(tt.duration, tt.activities), "%s with %d activities", tt.duration, tt.activities)
+	}
+}
+
+func TestInsightRuleValidation(t *testing.T) {
+	valid := InsightRule{Name: "long_day", Scope: RuleScopeDaily, Metric: "total_work_hours", Comparator: ">", Threshold: 10, Message: "Long day"}
+	_, err := NewInsightRuleEngine([]InsightRule{valid})
+	require.NoError(t, err)
+
+	typo := valid
+	typo.Metric = "total_work_hour"
+	_, err = NewInsightRuleEngine([]InsightRule{typo})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown metric "total_work_hour"`)
+
+	wrongScope := valid
+	wrongScope.Metric = "focus_efficiency"
+	_, err = NewInsightRuleEngine([]InsightRule{wrongScope})
+	assert.Error(t, err, "project focus metric is not available to daily rules")
+
+	extra := valid
+	extra.And = []RuleCondition{{Metric: "projects", Comparator: ">", Threshold: 2}}
+	_, err = NewInsightRuleEngine([]InsightRule{extra})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"projects"`)
+
+	label := InsightRule{Name: "owl", Scope: RuleScopeActivityPattern, Metric: "work_rhythm", Comparator: ">", Threshold: 1, Message: "x"}
+	_, err = NewInsightRuleEngine([]InsightRule{label})
+	assert.Error(t, err, "text labels need a value")
+
+	numeric := InsightRule{Name: "hours", Scope: RuleScopeDaily, Metric: "total_work_hours", Comparator: "==", Value: "many", Message: "x"}
+	_, err = NewInsightRuleEngine([]InsightRule{numeric})
+	assert.Error(t, err, "numeric metrics need a threshold")
+
+	path := filepath.Join(t.TempDir(), "rules.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"rules":[{"name":"deep_work_fragmented","scope":"deep_work","metric":"fragmentaton_score","comparator":">","threshold":0.7,"message":"x"}]}`), 0644))
+	_, err = LoadInsightRules(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"fragmentaton_score"`)
+}
@@ -0,0 +1,255 @@
+/**
+ * CONTEXT:   Terminal rendering of the live status dashboard
+ * INPUT:     LiveStatus snapshot, the current instant and the refresh source
+ * OUTPUT:    Professional-palette sections for daemon, session, work block,
+ *            today's totals and recent events
+ * BUSINESS:  Countdowns are recomputed from absolute times at render, so a
+ *            watcher ticks every second without refetching
+ * CHANGE:    Show one work block entry per parallel Claude instance
+ * RISK:      Low - Display only
+ */
+
+package reporting
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ansiPattern matches the color escapes stripped when measuring line width
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// liveProgressWidth is the width of the session window progress bar
+const liveProgressWidth = 40
+
+/**
+ * CONTEXT:   Render the full live dashboard
+ * INPUT:     Writer, snapshot, evaluation instant and a short source label
+ *            (for example "live" or "polling every 5s")
+ * OUTPUT:    Header followed by all dashboard sections
+ * BUSINESS:  Replaces piecing together service status, /status and today
+ * CHANGE:    Initial dashboard
+ * RISK:      Low - Display only
+ */
+func RenderLiveStatus(w io.Writer, status *LiveStatus, now time.Time, source string) {
+	renderLiveHeader(w, now, source)
+	renderLiveDaemon(w, status.Daemon)
+	renderLiveSession(w, status.Session, now)
+	renderLiveWorkBlocks(w, status.WorkBlocks, now)
+	renderLiveToday(w, status.Today)
+	renderLiveRecent(w, status.Recent, now)
+}
+
+// RenderLiveUnavailable shows the dashboard header with the daemon error
+func RenderLiveUnavailable(w io.Writer, err error, now time.Time, source string) {
+	renderLiveHeader(w, now, source)
+	renderLiveSection(w, ColorBrightRed, SymbolWork, "DAEMON", []string{
+		fmt.Sprintf("%sUnreachable:%s %v", ColorBrightRed, ColorReset, err),
+		fmt.Sprintf("%sStart it with 'claude-monitor daemon' or 'claude-monitor service start'%s", ColorDim, ColorReset),
+	})
+}
+
+// renderLiveHeader prints the bordered title with the refresh time and source
+func renderLiveHeader(w io.Writer, now time.Time, source string) {
+	width := DefaultHeaderWidth - 2
+	title := fmt.Sprintf("    %s CLAUDE MONITOR %s LIVE STATUS", SymbolWork, SymbolWork)
+	subtitle := fmt.Sprintf("        %s  ·  %s", now.Local().Format("Mon Jan 2 15:04:05"), source)
+
+	fmt.Fprintf(w, "%s%s%s%s%s\n", ColorBrightCyan, BoxTopLeft, strings.Repeat(BoxHorizontal, width), BoxTopRight, ColorReset)
+	fmt.Fprintf(w, "%s%s%s%s%s%s%s\n", ColorBrightCyan, BoxVertical, ColorBold, padVisible(title, width), ColorReset, ColorBrightCyan+BoxVertical, ColorReset)
+	fmt.Fprintf(w, "%s%s%s%s%s%s%s\n", ColorBrightCyan, BoxVertical, ColorDim, padVisible(subtitle, width), ColorReset, ColorBrightCyan+BoxVertical, ColorReset)
+	fmt.Fprintf(w, "%s%s%s%s%s\n\n", ColorBrightCyan, BoxBottomLeft, strings.Repeat(BoxHorizontal, width), BoxBottomRight, ColorReset)
+}
+
+// renderLiveDaemon prints daemon health and uptime
+func renderLiveDaemon(w io.Writer, daemon LiveDaemon) {
+	statusColor := ColorBrightGreen
+	if daemon.Status != "healthy" && daemon.Status != "running" {
+		statusColor = ColorBrightYellow
+	}
+	line := fmt.Sprintf("Status: %s%s%s    Uptime: %s%s%s",
+		statusColor, daemon.Status, ColorReset,
+		ColorBrightCyan, formatDurationPro(time.Duration(daemon.UptimeSeconds)*time.Second), ColorReset)
+	if daemon.Version != "" {
+		line += fmt.Sprintf("    %sv%s%s", ColorDim, daemon.Version, ColorReset)
+	}
+	renderLiveSection(w, ColorBrightBlue, SymbolClaude, "DAEMON", []string{line})
+}
+
+// renderLiveSession prints the 5-hour window with time remaining and a progress bar
+func renderLiveSession(w io.Writer, session *LiveSession, now time.Time) {
+	if session == nil {
+		renderLiveSection(w, ColorBrightBlue, SymbolSession, "SESSION", []string{
+			fmt.Sprintf("%sNo active session; the next Claude action opens a 5-hour window%s", ColorDim, ColorReset),
+		})
+		return
+	}
+
+	remaining := session.Remaining(now)
+	window := session.EndTime.Sub(session.StartTime)
+	elapsed := window - remaining
+	filled := 0
+	if window > 0 {
+		filled = int(float64(liveProgressWidth) * float64(elapsed) / float64(window))
+	}
+	remainingColor := ColorBrightGreen
+	switch {
+	case remaining < 30*time.Minute:
+		remainingColor = ColorBrightRed
+	case remaining < time.Hour:
+		remainingColor = ColorBrightYellow
+	}
+
+	renderLiveSection(w, ColorBrightBlue, SymbolSession, "SESSION", []string{
+		fmt.Sprintf("Window: %s – %s    Remaining: %s%s%s",
+			session.StartTime.Local().Format("15:04"), session.EndTime.Local().Format("15:04"),
+			remainingColor, formatCountdown(remaining), ColorReset),
+		fmt.Sprintf("%s%s%s%s%s  %d events",
+			remainingColor, strings.Repeat("█", filled), ColorDim, strings.Repeat("░", liveProgressWidth-filled), ColorReset,
+			session.ActivityCount),
+	})
+}
+
+// renderLiveWorkBlocks prints each active block's project, running time and idle
+// countdown; parallel Claude instances get one entry each
+func renderLiveWorkBlocks(w io.Writer, blocks []LiveWorkBlock, now time.Time) {
+	if len(blocks) == 0 {
+		renderLiveSection(w, ColorBrightGreen, SymbolProject, "WORK BLOCK", []string{
+			fmt.Sprintf("%sIdle; no work block is open%s", ColorDim, ColorReset),
+		})
+		return
+	}
+
+	title := "WORK BLOCK"
+	if len(blocks) > 1 {
+		title = fmt.Sprintf("WORK BLOCKS · %d INSTANCES", len(blocks))
+	}
+	var lines []string
+	for i := range blocks {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, liveWorkBlockLines(&blocks[i], now)...)
+	}
+	renderLiveSection(w, ColorBrightGreen, SymbolProject, title, lines)
+}
+
+// liveWorkBlockLines describes one active block
+func liveWorkBlockLines(block *LiveWorkBlock, now time.Time) []string {
+	project := block.ProjectName
+	if block.GitBranch != "" {
+		project += fmt.Sprintf(" %s(%s)%s", ColorDim, block.GitBranch, ColorReset)
+	}
+	idleIn := block.IdleIn(now)
+	idleColor := ColorBrightGreen
+	if idleIn < time.Minute {
+		idleColor = ColorBrightYellow
+	}
+
+	lines := []string{
+		fmt.Sprintf("Project: %s%s%s", ColorBrightWhite, project, ColorReset),
+		fmt.Sprintf("Running: %s%s%s since %s    Idle in: %s%s%s",
+			getDurationColor(now.Sub(block.StartTime)), formatDurationPro(now.Sub(block.StartTime)), ColorReset,
+			block.StartTime.Local().Format("15:04"),
+			idleColor, formatCountdown(idleIn), ColorReset),
+	}
+	if len(block.Tags) > 0 {
+		lines = append(lines, fmt.Sprintf("Tags: %s%s%s", ColorCyan, strings.Join(block.Tags, ", "), ColorReset))
+	}
+	return lines
+}
+
+// renderLiveToday prints today's totals
+func renderLiveToday(w io.Writer, today LiveToday) {
+	lines := []string{
+		fmt.Sprintf("Active work: %s%s%s    Sessions: %s%d%s    Blocks: %s%d%s",
+			ColorBrightGreen, formatDurationPro(time.Duration(today.WorkHours*float64(time.Hour))), ColorReset,
+			ColorBrightCyan, today.Sessions, ColorReset,
+			ColorBrightCyan, today.WorkBlocks, ColorReset),
+	}
+	if today.TopProject != "" {
+		lines = append(lines, fmt.Sprintf("Projects: %d    Top: %s%s%s",
+			today.Projects, ColorBrightWhite, truncateStringPro(today.TopProject, MaxProjectNameLen), ColorReset))
+	}
+	renderLiveSection(w, ColorBrightMagenta, SymbolTrend, "TODAY", lines)
+}
+
+// renderLiveRecent prints the latest activities, newest first
+func renderLiveRecent(w io.Writer, events []LiveEvent, now time.Time) {
+	if len(events) == 0 {
+		renderLiveSection(w, ColorBrightCyan, SymbolTimeline, "RECENT EVENTS", []string{
+			fmt.Sprintf("%sNo activity recorded yet%s", ColorDim, ColorReset),
+		})
+		return
+	}
+
+	var lines []string
+	for _, event := range events {
+		tool := event.ToolName
+		if tool == "" {
+			tool = "-"
+		}
+		lines = append(lines, fmt.Sprintf("%s%s%s  %-10s %-12s %s  %s%s ago%s",
+			ColorDim, event.Timestamp.Local().Format("15:04:05"), ColorReset,
+			truncateStringPro(event.ActivityType, 10), truncateStringPro(tool, 12),
+			truncateStringPro(event.ProjectName, MaxProjectNameLen),
+			ColorDim, formatGapPro(now.Sub(event.Timestamp)), ColorReset))
+	}
+	renderLiveSection(w, ColorBrightCyan, SymbolTimeline, "RECENT EVENTS", lines)
+}
+
+// renderLiveSection prints a bordered section with the title in its top edge
+func renderLiveSection(w io.Writer, color, symbol, title string, lines []string) {
+	width := DefaultSectionWidth
+	heading := fmt.Sprintf("%s %s %s ", BoxHorizontal, symbol, title)
+	fmt.Fprintf(w, "%s%s%s%s%s%s\n", color, BoxTopLeft, heading,
+		strings.Repeat(BoxHorizontal, max(width-visibleWidth(heading), 0)), BoxTopRight, ColorReset)
+	for _, line := range lines {
+		fmt.Fprintf(w, "%s%s%s  %s%s%s%s\n", color, BoxVertical, ColorReset,
+			padVisible(line, width-2), color, BoxVertical, ColorReset)
+	}
+	fmt.Fprintf(w, "%s%s%s%s%s\n\n", color, BoxBottomLeft, strings.Repeat(BoxHorizontal, width), BoxBottomRight, ColorReset)
+}
+
+// formatCountdown formats a countdown as h:mm:ss or m:ss
+func formatCountdown(d time.Duration) string {
+	d = d.Round(time.Second)
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%d:%02d", minutes, seconds)
+}
+
+// visibleWidth counts terminal columns, ignoring color escapes; the palette's
+// emoji take two columns, as does a symbol followed by an emoji selector
+func visibleWidth(s string) int {
+	width, last := 0, 0
+	for _, r := range ansiPattern.ReplaceAllString(s, "") {
+		switch {
+		case r == '\uFE0F':
+			width += 2 - last
+			last = 2
+		case r >= 0x1F000 || r == '\u26A1':
+			width += 2
+			last = 2
+		default:
+			width++
+			last = 1
+		}
+	}
+	return width
+}
+
+// padVisible pads s with spaces to width visible columns
+func padVisible(s string, width int) string {
+	if pad := width - visibleWidth(s); pad > 0 {
+		return s + strings.Repeat(" ", pad)
+	}
+	return s
+}
@@ -0,0 +1,266 @@
+/**
+ * CONTEXT:   Live status snapshot for the terminal dashboard
+ * INPUT:     Active session, open work blocks, recent activity and today's report
+ * OUTPUT:    LiveStatus with session window, active block, idle deadline and totals
+ * BUSINESS:  One snapshot answers "how long is left in my window and what am I
+ *            working on" without combining service status, /status and today
+ * CHANGE:    Initial live status generator
+ * RISK:      Low - Read-only queries; the daily report dominates the cost
+ */
+
+package reporting
+
+import (
+	"context"
+	"time"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+)
+
+// liveRecentEvents is how many recent activities a snapshot carries
+const liveRecentEvents = 8
+
+// LiveStatus is the dashboard snapshot served by GET /api/v1/live.
+// WorkBlocks holds one active block per Claude Code instance, most recently
+// active first; WorkBlock is the first of them.
+type LiveStatus struct {
+	UserID      string          `json:"user_id"`
+	GeneratedAt time.Time       `json:"generated_at"`
+	Daemon      LiveDaemon      `json:"daemon"`
+	Session     *LiveSession    `json:"session,omitempty"`
+	WorkBlock   *LiveWorkBlock  `json:"work_block,omitempty"`
+	WorkBlocks  []LiveWorkBlock `json:"work_blocks"`
+	Today       LiveToday       `json:"today"`
+	Recent      []LiveEvent     `json:"recent"`
+}
+
+// LiveDaemon is the daemon's own health as seen by the dashboard
+type LiveDaemon struct {
+	Status        string `json:"status"`
+	UptimeSeconds int64  `json:"uptime_seconds"`
+	Version       string `json:"version,omitempty"`
+}
+
+// LiveSession is the open 5-hour session window
+type LiveSession struct {
+	ID            string    `json:"id"`
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+	ActivityCount int64     `json:"activity_count"`
+}
+
+// LiveWorkBlock is the work block still inside its idle timeout
+type LiveWorkBlock struct {
+	ID               string    `json:"id"`
+	ClaudeSessionID  string    `json:"claude_session_id,omitempty"`
+	ProjectName      string    `json:"project_name"`
+	ProjectPath      string    `json:"project_path,omitempty"`
+	GitBranch        string    `json:"git_branch,omitempty"`
+	Tags             []string  `json:"tags,omitempty"`
+	StartTime        time.Time `json:"start_time"`
+	LastActivityTime time.Time `json:"last_activity_time"`
+	IdleAt           time.Time `json:"idle_at"`
+	ActivityCount    int64     `json:"activity_count"`
+}
+
+// LiveToday holds today's totals from the daily report
+type LiveToday struct {
+	WorkHours  float64 `json:"work_hours"`
+	Sessions   int     `json:"sessions"`
+	WorkBlocks int     `json:"work_blocks"`
+	Projects   int     `json:"projects"`
+	TopProject string  `json:"top_project,omitempty"`
+}
+
+// LiveEvent is one recorded activity, also pushed on the live event stream
+type LiveEvent struct {
+	UserID       string    `json:"user_id,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+	ActivityType string    `json:"activity_type"`
+	ToolName     string    `json:"tool_name,omitempty"`
+	ProjectName  string    `json:"project_name,omitempty"`
+	GitBranch    string    `json:"git_branch,omitempty"`
+}
+
+// Remaining returns the time left in the session window at now
+func (s *LiveSession) Remaining(now time.Time) time.Duration {
+	if s == nil || now.After(s.EndTime) {
+		return 0
+	}
+	return s.EndTime.Sub(now)
+}
+
+// IdleIn returns how long until the block goes idle at now
+func (b *LiveWorkBlock) IdleIn(now time.Time) time.Duration {
+	if b == nil || now.After(b.IdleAt) {
+		return 0
+	}
+	return b.IdleAt.Sub(now)
+}
+
+/**
+ * CONTEXT:   Live status generator over the tracking repositories
+ * INPUT:     Session, work block, activity and project repositories
+ * OUTPUT:    Generator producing LiveStatus snapshots
+ * BUSINESS:  Kept beside the report generators so the daemon and tests share it
+ * CHANGE:    Initial generator
+ * RISK:      Low - Read-only
+ */
+type LiveStatusGenerator struct {
+	sessionRepo   *sqlite.SessionRepository
+	workBlockRepo *sqlite.WorkBlockRepository
+	activityRepo  *sqlite.ActivityRepository
+	projectRepo   *sqlite.ProjectRepository
+}
+
+// NewLiveStatusGenerator creates a live status generator
+func NewLiveStatusGenerator(
+	sessionRepo *sqlite.SessionRepository,
+	workBlockRepo *sqlite.WorkBlockRepository,
+	activityRepo *sqlite.ActivityRepository,
+	projectRepo *sqlite.ProjectRepository,
+) *LiveStatusGenerator {
+	return &LiveStatusGenerator{
+		sessionRepo:   sessionRepo,
+		workBlockRepo: workBlockRepo,
+		activityRepo:  activityRepo,
+		projectRepo:   projectRepo,
+	}
+}
+
+/**
+ * CONTEXT:   Build the session, work block and recent activity parts of a snapshot
+ * INPUT:     User ID, evaluation instant and the work block idle timeout
+ * OUTPUT:    LiveStatus without daemon health or today's totals
+ * BUSINESS:  A block whose last activity is older than the idle timeout is
+ *            shown as idle even before the next hook call closes it; parallel
+ *            instances each contribute their own active block
+ * CHANGE:    List every instance's open block instead of the latest one
+ * RISK:      Low - Read-only
+ */
+func (g *LiveStatusGenerator) Generate(ctx context.Context, userID string, now time.Time, idleTimeout time.Duration) (*LiveStatus, error) {
+	status := &LiveStatus{UserID: userID, GeneratedAt: now, WorkBlocks: []LiveWorkBlock{}, Recent: []LiveEvent{}}
+	projects := map[string]*sqlite.Project{}
+
+	sessions, err := g.sessionRepo.GetActiveSessionsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) > 0 {
+		session := sessions[0]
+		status.Session = &LiveSession{
+			ID:            session.ID,
+			StartTime:     session.StartTime,
+			EndTime:       session.EndTime,
+			ActivityCount: session.ActivityCount,
+		}
+	}
+
+	blocks, err := g.workBlockRepo.GetOpenByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	instances := map[string]bool{}
+	for _, block := range blocks {
+		if now.Sub(block.LastActivityTime) > idleTimeout {
+			continue
+		}
+		// One block per instance; blocks recorded without a session ID are all kept
+		if block.ClaudeSessionID != "" {
+			if instances[block.ClaudeSessionID] {
+				continue
+			}
+			instances[block.ClaudeSessionID] = true
+		}
+		live := LiveWorkBlock{
+			ID:               block.ID,
+			ClaudeSessionID:  block.ClaudeSessionID,
+			GitBranch:        block.GitBranch,
+			Tags:             block.Tags,
+			StartTime:        block.StartTime,
+			LastActivityTime: block.LastActivityTime,
+			IdleAt:           block.LastActivityTime.Add(idleTimeout),
+			ActivityCount:    block.ActivityCount,
+		}
+		if project := g.project(ctx, projects, block.ProjectID); project != nil {
+			live.ProjectName = project.Name
+			live.ProjectPath = project.Path
+		}
+		status.WorkBlocks = append(status.WorkBlocks, live)
+	}
+	if len(status.WorkBlocks) > 0 {
+		latest := status.WorkBlocks[0]
+		status.WorkBlock = &latest
+	}
+
+	activities, err := g.activityRepo.GetRecentByUser(ctx, userID, liveRecentEvents)
+	if err != nil {
+		return nil, err
+	}
+	for _, activity := range activities {
+		event := LiveEvent{
+			UserID:       activity.UserID,
+			Timestamp:    activity.Timestamp,
+			ActivityType: activity.ActivityType,
+			ToolName:     activity.ToolName,
+			GitBranch:    activity.GitBranch,
+		}
+		if project := g.project(ctx, projects, activity.ProjectID); project != nil {
+			event.ProjectName = project.Name
+		}
+		status.Recent = append(status.Recent, event)
+	}
+	return status, nil
+}
+
+// project looks a project up once per snapshot; unknown IDs yield nil
+func (g *LiveStatusGenerator) project(ctx context.Context, cache map[string]*sqlite.Project, id string) *sqlite.Project {
+	if id == "" {
+		return nil
+	}
+	if project, ok := cache[id]; ok {
+		return project
+	}
+	project, err := g.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		project = nil
+	}
+	cache[id] = project
+	return project
+}
+
+/**
+ * CONTEXT:   Full live status for the dashboard
+ * INPUT:     User ID, evaluation instant and work block idle timeout
+ * OUTPUT:    Snapshot with session, active block, recent events and today's totals
+ * BUSINESS:  Totals come from the same daily report as `claude-monitor today`;
+ *            hours are wall-clock so parallel instances are not counted twice
+ * CHANGE:    Report wall-clock hours as today's work time
+ * RISK:      Low - Read-only; runs one daily report per call
+ */
+func (srs *SQLiteReportingService) GetLiveStatus(ctx context.Context, userID string, now time.Time, idleTimeout time.Duration) (*LiveStatus, error) {
+	status, err := srs.liveGenerator.Generate(ctx, userID, now, idleTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := srs.dailyGenerator.GenerateDaily(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	srs.analyticsCalculator.CalculateProjectBreakdown(report)
+	status.Today = LiveToday{
+		WorkHours:  report.WallClockHours,
+		Sessions:   report.TotalSessions,
+		WorkBlocks: report.TotalWorkBlocks,
+		Projects:   len(report.ProjectBreakdown),
+	}
+	topHours := 0.0
+	for _, project := range report.ProjectBreakdown {
+		if project.WorkHours > topHours {
+			topHours = project.WorkHours
+			status.Today.TopProject = project.ProjectName
+		}
+	}
+	return status, nil
+}
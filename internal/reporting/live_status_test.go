@@ -0,0 +1,131 @@
+/**
+ * CONTEXT:   Test suite for the live status view
+ * INPUT:     Hook events recorded by the tracker into a temp database
+ * OUTPUT:    Validation of the active session, work block, idle cut-off,
+ *            recent events and per-user isolation
+ * BUSINESS:  The prompt and `live` show this status; a stale or foreign block
+ *            shown as active misleads the user in real time
+ * CHANGE:    Initial live status tests
+ * RISK:      Low - Temp database per test
+ */
+
+package reporting
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/tracking"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiveStatusSnapshot(t *testing.T) {
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(t.TempDir(), "tracker.db")))
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	service := NewSQLiteReportingService(
+		sqlite.NewSessionRepository(db),
+		sqlite.NewWorkBlockRepository(db.DB()),
+		sqlite.NewActivityRepository(db.DB()),
+		sqlite.NewProjectRepository(db.DB()),
+	)
+
+	empty, err := service.GetLiveStatus(ctx, "alice", time.Now(), 5*time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, empty.Session)
+	assert.Nil(t, empty.WorkBlock)
+	assert.Empty(t, empty.Recent)
+
+	tracker := tracking.NewActivityTracker(db)
+	for _, tool := range []string{"Read", "Edit", "Bash"} {
+		_, err := tracker.Record(ctx, tracking.ActivityEvent{
+			UserID:      "alice",
+			ProjectPath: "/home/alice/code/api",
+			HookEvent:   "PostToolUse",
+			ToolName:    tool,
+			GitBranch:   "main",
+		})
+		require.NoError(t, err)
+	}
+
+	now := time.Now()
+	status, err := service.GetLiveStatus(ctx, "alice", now, 5*time.Minute)
+	require.NoError(t, err)
+
+	require.NotNil(t, status.Session)
+	assert.Equal(t, 5*time.Hour, status.Session.EndTime.Sub(status.Session.StartTime))
+	assert.InDelta(t, (5 * time.Hour).Seconds(), status.Session.Remaining(now).Seconds(), 60)
+
+	require.NotNil(t, status.WorkBlock)
+	assert.Equal(t, "api", strings.ToLower(status.WorkBlock.ProjectName))
+	assert.Equal(t, "main", status.WorkBlock.GitBranch)
+	assert.Equal(t, status.WorkBlock.LastActivityTime.Add(5*time.Minute), status.WorkBlock.IdleAt)
+	assert.Greater(t, status.WorkBlock.IdleIn(now), 4*time.Minute)
+
+	require.Len(t, status.Recent, 3)
+	assert.Equal(t, "Bash", status.Recent[0].ToolName, "newest event first")
+	assert.Equal(t, "Read", status.Recent[2].ToolName)
+	assert.Equal(t, status.WorkBlock.ProjectName, status.Recent[0].ProjectName)
+	assert.Equal(t, 1, status.Today.Sessions)
+	assert.Equal(t, 1, status.Today.Projects)
+
+	// Past the idle timeout the block is no longer shown as active
+	later, err := service.GetLiveStatus(ctx, "alice", now.Add(6*time.Minute), 5*time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, later.WorkBlock)
+	assert.NotNil(t, later.Session)
+
+	other, err := service.GetLiveStatus(ctx, "bob", now, 5*time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, other.Session)
+	assert.Empty(t, other.Recent)
+}
+
+func TestLiveStatusParallelInstances(t *testing.T) {
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(t.TempDir(), "tracker.db")))
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	service := NewSQLiteReportingService(
+		sqlite.NewSessionRepository(db),
+		sqlite.NewWorkBlockRepository(db.DB()),
+		sqlite.NewActivityRepository(db.DB()),
+		sqlite.NewProjectRepository(db.DB()),
+	)
+
+	// Two Claude instances on different projects, each with its own open block
+	tracker := tracking.NewActivityTracker(db)
+	for _, event := range []tracking.ActivityEvent{
+		{ProjectPath: "/home/alice/code/api", ClaudeSessionID: "claude-1", ToolName: "Edit"},
+		{ProjectPath: "/home/alice/code/web", ClaudeSessionID: "claude-2", ToolName: "Bash"},
+		{ProjectPath: "/home/alice/code/api", ClaudeSessionID: "claude-1", ToolName: "Read"},
+	} {
+		event.UserID = "alice"
+		event.HookEvent = "PostToolUse"
+		_, err := tracker.Record(ctx, event)
+		require.NoError(t, err)
+	}
+
+	status, err := service.GetLiveStatus(ctx, "alice", time.Now(), 5*time.Minute)
+	require.NoError(t, err)
+
+	require.Len(t, status.WorkBlocks, 2, "one active block per instance")
+	assert.Equal(t, "claude-1", status.WorkBlocks[0].ClaudeSessionID, "most recently active first")
+	assert.Equal(t, "api", strings.ToLower(status.WorkBlocks[0].ProjectName))
+	assert.Equal(t, "web", strings.ToLower(status.WorkBlocks[1].ProjectName))
+	require.NotNil(t, status.WorkBlock)
+	assert.Equal(t, status.WorkBlocks[0].ID, status.WorkBlock.ID)
+	assert.Equal(t, 2, NewStatusSnapshot(status, SnapshotDaemonRunning).Instances)
+
+	var out strings.Builder
+	RenderLiveStatus(&out, status, time.Now(), "live")
+	assert.Contains(t, out.String(), "WORK BLOCKS · 2 INSTANCES")
+}
@@ -62,7 +62,7 @@ func NewMonthlyReportGenerator(
  * INPUT:     User ID, month start date for full month analysis
  * OUTPUT:    Enhanced monthly report with daily progress, achievements, and trends
  * BUSINESS:  Monthly reports provide long-term productivity insights and goal tracking
- * CHANGE:    Extracted monthly report generation for focused responsibility
+ * CHANGE:    Sum daily wall-clock hours alongside the per-instance total
  * RISK:      Medium - Month-long data aggregation with complex achievement calculation
  */
 func (mrg *MonthlyReportGenerator) GenerateMonthly(ctx context.Context, userID string, monthStart time.Time) (*EnhancedMonthlyReport, error) {
@@ -77,6 +77,9 @@ func (mrg *MonthlyReportGenerator) GenerateMonthly(ctx context.Context, userID s
 		MonthEnd:         monthEnd,
 		DailyHeatmap:     make([]DayData, 0),
 		ProjectBreakdown: make([]ProjectBreakdown, 0),
+		ToolBreakdown:    make([]ActivityBreakdown, 0),
+		ActivityTypeBreakdown: make([]ActivityBreakdown, 0),
+		TagBreakdown:     make([]TagBreakdown, 0),
 		Achievements:     make([]Achievement, 0),
 		Trends:           make([]Trend, 0),
 		Insights:         make([]string, 0),
@@ -84,6 +87,7 @@ func (mrg *MonthlyReportGenerator) GenerateMonthly(ctx context.Context, userID s
 
 	// Process each day in the month
 	projectTotals := make(map[string]*ProjectBreakdown)
+	tagTotals := make(map[string]*TagBreakdown)
 	totalWorkHours := 0.0
 	workingDays := 0
 	bestDayHours := 0.0
@@ -112,6 +116,7 @@ func (mrg *MonthlyReportGenerator) GenerateMonthly(ctx context.Context, userID s
 		report.DailyHeatmap = append(report.DailyHeatmap, heatmapData)
 
 		totalWorkHours += dailyReport.TotalWorkHours
+		report.WallClockHours += dailyReport.WallClockHours
 
 		// Track working days and streaks
 		if dailyReport.TotalWorkHours > 0 {
@@ -132,6 +137,7 @@ func (mrg *MonthlyReportGenerator) GenerateMonthly(ctx context.Context, userID s
 
 		// Aggregate project data
 		mrg.aggregateMonthlyProjectData(dailyReport.ProjectBreakdown, projectTotals)
+		mergeTagBreakdown(tagTotals, dailyReport)
 	}
 
 	// Set monthly totals and averages
@@ -145,6 +151,14 @@ func (mrg *MonthlyReportGenerator) GenerateMonthly(ctx context.Context, userID s
 
 	// Finalize project breakdown
 	mrg.finalizeMonthlyProjectBreakdown(projectTotals, totalWorkHours, report)
+	report.TagBreakdown = finalizeTagBreakdown(tagTotals, totalWorkHours)
+
+	// Attribute the month's time to hook tools and activity types
+	var err error
+	report.ToolBreakdown, report.ActivityTypeBreakdown, err = loadActivityBreakdowns(ctx, mrg.activityRepo, userID, monthStart, monthEnd, mrg.dailyReportGenerator.filter)
+	if err != nil {
+		return nil, err
+	}
 
 	return report, nil
 }
@@ -175,16 +189,17 @@ func (mrg *MonthlyReportGenerator) calculateHeatmapLevel(hours float64) int {
  * INPUT:     Daily project breakdown and monthly project totals
  * OUTPUT:    Updated monthly project totals
  * BUSINESS:  Monthly project aggregation shows long-term project focus
- * CHANGE:    Extracted monthly aggregation logic
+ * CHANGE:    Key totals by name and path so same-named rows stay apart
  * RISK:      Low - Data aggregation similar to weekly but extended
  */
 func (mrg *MonthlyReportGenerator) aggregateMonthlyProjectData(dailyProjects []ProjectBreakdown, projectTotals map[string]*ProjectBreakdown) {
 	for _, project := range dailyProjects {
-		if existing, exists := projectTotals[project.ProjectName]; exists {
+		key := projectBreakdownKey(project)
+		if existing, exists := projectTotals[key]; exists {
 			existing.WorkHours += project.WorkHours
 			existing.Sessions += project.Sessions
 		} else {
-			projectTotals[project.ProjectName] = &ProjectBreakdown{
+			projectTotals[key] = &ProjectBreakdown{
 				ProjectName: project.ProjectName,
 				ProjectPath: project.ProjectPath,
 				WorkHours:   project.WorkHours,
@@ -0,0 +1,79 @@
+/**
+ * CONTEXT:   Wall-clock time across parallel Claude Code instances
+ * INPUT:     Work block summaries of one report period
+ * OUTPUT:    Hours with overlapping blocks merged and the number of instances
+ * BUSINESS:  Instances running side by side each keep their own work blocks;
+ *            summed block time is per-instance effort, merged time is how long
+ *            the user was actually working
+ * CHANGE:    Share the interval merge with goals and period comparisons
+ * RISK:      Low - Pure computation over report data
+ */
+
+package reporting
+
+import (
+	"sort"
+	"time"
+)
+
+// workInterval is a span of tracked work time
+type workInterval struct {
+	start time.Time
+	end   time.Time
+}
+
+// WallClockHours merges overlapping work blocks and returns the covered hours
+func WallClockHours(blocks []WorkBlockSummary) float64 {
+	intervals := make([]workInterval, 0, len(blocks))
+	for _, block := range blocks {
+		intervals = append(intervals, workInterval{start: block.StartTime, end: block.EndTime})
+	}
+	return intervalHours(intervals)
+}
+
+// mergeIntervals sorts the intervals by start and joins the ones that overlap or touch
+func mergeIntervals(intervals []workInterval) []workInterval {
+	if len(intervals) == 0 {
+		return nil
+	}
+	sorted := make([]workInterval, len(intervals))
+	copy(sorted, intervals)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].start.Before(sorted[j].start) })
+
+	merged := []workInterval{sorted[0]}
+	for _, interval := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if interval.start.After(last.end) {
+			merged = append(merged, interval)
+			continue
+		}
+		if interval.end.After(last.end) {
+			last.end = interval.end
+		}
+	}
+	return merged
+}
+
+// intervalHours returns the hours covered by the intervals, counting overlaps once
+func intervalHours(intervals []workInterval) float64 {
+	var total time.Duration
+	for _, interval := range mergeIntervals(intervals) {
+		total += interval.end.Sub(interval.start)
+	}
+	return total.Hours()
+}
+
+// ClaudeInstanceCount counts the distinct Claude Code sessions behind the blocks;
+// blocks recorded without a session ID count as one instance together
+func ClaudeInstanceCount(blocks []WorkBlockSummary) int {
+	seen := make(map[string]bool)
+	for _, block := range blocks {
+		seen[block.ClaudeSessionID] = true
+	}
+	return len(seen)
+}
+
+// hasParallelWork reports whether merging overlaps saved at least a minute
+func hasParallelWork(summedHours, wallClockHours float64) bool {
+	return time.Duration((summedHours-wallClockHours)*float64(time.Hour)) >= time.Minute
+}
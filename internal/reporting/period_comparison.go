@@ -0,0 +1,569 @@
+/**
+ * CONTEXT:   Period-over-period comparison for Claude Monitor reporting
+ * INPUT:     Two reporting periods (ISO weeks, months, days or custom ranges) and user ID
+ * OUTPUT:    Reusable comparison structure with signed deltas for key productivity metrics
+ * BUSINESS:  Comparing concrete periods shows whether work habits actually changed
+ * CHANGE:    Initial period comparison supporting CLI and API consumers
+ * RISK:      Low - Read-only aggregation over existing repositories
+ */
+
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+)
+
+// Period kinds accepted by ParseReportPeriod
+const (
+	PeriodKindDay    = "day"
+	PeriodKindWeek   = "week"
+	PeriodKindMonth  = "month"
+	PeriodKindCustom = "custom"
+)
+
+// PeakHourCount is the number of busiest hours reported per period
+const PeakHourCount = 3
+
+var (
+	isoWeekPattern = regexp.MustCompile(`^(\d{4})-W(\d{1,2})$`)
+	monthPattern   = regexp.MustCompile(`^(\d{4})-(\d{2})$`)
+	dayPattern     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+)
+
+/**
+ * CONTEXT:   Concrete reporting period with inclusive start and exclusive end
+ * INPUT:     No input - data structure definition
+ * OUTPUT:    Period boundaries with human readable label
+ * BUSINESS:  Explicit periods make comparisons reproducible across CLI and API
+ * CHANGE:    Initial period definition for comparison reports
+ * RISK:      Low - Data structure with JSON serialization support
+ */
+type ReportPeriod struct {
+	Label string    `json:"label"`
+	Kind  string    `json:"kind"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+/**
+ * CONTEXT:   Parse period specification into concrete time boundaries
+ * INPUT:     Specification such as 2026-W40, 2026-10, 2026-10-01 or 2026-10-01..2026-10-15
+ * OUTPUT:    Report period in the given location or parse error
+ * BUSINESS:  Single parser keeps period semantics identical for every consumer
+ * CHANGE:    Initial parser supporting ISO weeks, months, days and custom ranges
+ * RISK:      Low - Pure parsing with explicit validation
+ */
+func ParseReportPeriod(spec string, loc *time.Location) (ReportPeriod, error) {
+	spec = strings.TrimSpace(spec)
+	if loc == nil {
+		loc = time.Local
+	}
+
+	if parts := strings.Split(spec, ".."); len(parts) == 2 {
+		from, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(parts[0]), loc)
+		if err != nil {
+			return ReportPeriod{}, fmt.Errorf("invalid range start %q (use YYYY-MM-DD): %w", parts[0], err)
+		}
+		to, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(parts[1]), loc)
+		if err != nil {
+			return ReportPeriod{}, fmt.Errorf("invalid range end %q (use YYYY-MM-DD): %w", parts[1], err)
+		}
+		if to.Before(from) {
+			return ReportPeriod{}, fmt.Errorf("invalid range %q: end is before start", spec)
+		}
+		return ReportPeriod{Label: spec, Kind: PeriodKindCustom, Start: from, End: to.AddDate(0, 0, 1)}, nil
+	}
+
+	if m := isoWeekPattern.FindStringSubmatch(spec); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		week, _ := strconv.Atoi(m[2])
+		if week < 1 || week > 53 {
+			return ReportPeriod{}, fmt.Errorf("invalid ISO week %q", spec)
+		}
+		start := isoWeekStart(year, week, loc)
+		if y, w := start.ISOWeek(); y != year || w != week {
+			return ReportPeriod{}, fmt.Errorf("ISO week %q does not exist", spec)
+		}
+		return ReportPeriod{Label: spec, Kind: PeriodKindWeek, Start: start, End: start.AddDate(0, 0, 7)}, nil
+	}
+
+	if m := monthPattern.FindStringSubmatch(spec); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		if month < 1 || month > 12 {
+			return ReportPeriod{}, fmt.Errorf("invalid month %q", spec)
+		}
+		start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc)
+		return ReportPeriod{Label: spec, Kind: PeriodKindMonth, Start: start, End: start.AddDate(0, 1, 0)}, nil
+	}
+
+	if dayPattern.MatchString(spec) {
+		day, err := time.ParseInLocation("2006-01-02", spec, loc)
+		if err != nil {
+			return ReportPeriod{}, fmt.Errorf("invalid date %q: %w", spec, err)
+		}
+		return ReportPeriod{Label: spec, Kind: PeriodKindDay, Start: day, End: day.AddDate(0, 0, 1)}, nil
+	}
+
+	return ReportPeriod{}, fmt.Errorf("unrecognized period %q (use YYYY-Www, YYYY-MM, YYYY-MM-DD or YYYY-MM-DD..YYYY-MM-DD)", spec)
+}
+
+// isoWeekStart returns the Monday starting the given ISO week
+func isoWeekStart(year, week int, loc *time.Location) time.Time {
+	// January 4th is always in ISO week 1
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+	offset := int(jan4.Weekday()+6) % 7
+	return jan4.AddDate(0, 0, -offset+(week-1)*7)
+}
+
+/**
+ * CONTEXT:   Aggregated metrics for a single period
+ * INPUT:     No input - data structure definition
+ * OUTPUT:    Period totals, per-project hours and hourly distribution
+ * BUSINESS:  Period metrics are the raw material for comparison deltas
+ * CHANGE:    Added hours per tag
+ * RISK:      Low - Data structure with JSON serialization support
+ */
+type PeriodMetrics struct {
+	Period          ReportPeriod       `json:"period"`
+	TotalHours      float64            `json:"total_hours"`
+	DeepWorkHours   float64            `json:"deep_work_hours"`
+	FocusScore      float64            `json:"focus_score"`
+	ContextSwitches int                `json:"context_switches"`
+	WorkBlocks      int                `json:"work_blocks"`
+	ProjectHours    map[string]float64 `json:"project_hours"`
+	TagHours        map[string]float64 `json:"tag_hours"`
+	HourlyHours     map[int]float64    `json:"hourly_hours"`
+	PeakHours       []int              `json:"peak_hours"`
+}
+
+/**
+ * CONTEXT:   Signed change of a single metric between two periods
+ * INPUT:     No input - data structure definition
+ * OUTPUT:    Baseline, comparison value, absolute and relative change
+ * BUSINESS:  Signed deltas make improvements and regressions explicit
+ * CHANGE:    Initial delta structure for comparison reports
+ * RISK:      Low - PercentChange is only meaningful when HasBaseline is true
+ */
+type MetricDelta struct {
+	Metric        string  `json:"metric"`
+	A             float64 `json:"a"`
+	B             float64 `json:"b"`
+	Delta         float64 `json:"delta"`
+	PercentChange float64 `json:"percent_change"`
+	HasBaseline   bool    `json:"has_baseline"`
+}
+
+/**
+ * CONTEXT:   Per-project hours delta between two periods
+ * INPUT:     No input - data structure definition
+ * OUTPUT:    Project name with hours delta
+ * BUSINESS:  Project deltas reveal shifts in focus between periods
+ * CHANGE:    Initial project delta structure
+ * RISK:      Low - Data structure with JSON serialization support
+ */
+type ProjectDelta struct {
+	ProjectName string      `json:"project_name"`
+	Hours       MetricDelta `json:"hours"`
+}
+
+// TagDelta is the hours delta of one tag between two periods
+type TagDelta struct {
+	Tag   string      `json:"tag"`
+	Hours MetricDelta `json:"hours"`
+}
+
+/**
+ * CONTEXT:   Peak hour comparison between two periods
+ * INPUT:     No input - data structure definition
+ * OUTPUT:    Busiest hours of each period and the hours that changed
+ * BUSINESS:  Peak hour shifts show when productive time moved during the day
+ * CHANGE:    Initial peak hour delta structure
+ * RISK:      Low - Data structure with JSON serialization support
+ */
+type PeakHoursDelta struct {
+	A       []int `json:"a"`
+	B       []int `json:"b"`
+	Gained  []int `json:"gained"`
+	Lost    []int `json:"lost"`
+	Shifted bool  `json:"shifted"`
+}
+
+/**
+ * CONTEXT:   Complete period-over-period comparison result
+ * INPUT:     No input - data structure definition
+ * OUTPUT:    Both period metrics plus deltas for every compared metric
+ * BUSINESS:  Reusable comparison serves CLI output and API responses alike
+ * CHANGE:    Added tag deltas
+ * RISK:      Low - Data structure with JSON serialization support
+ */
+type PeriodComparison struct {
+	A               PeriodMetrics  `json:"a"`
+	B               PeriodMetrics  `json:"b"`
+	TotalHours      MetricDelta    `json:"total_hours"`
+	DeepWorkHours   MetricDelta    `json:"deep_work_hours"`
+	FocusScore      MetricDelta    `json:"focus_score"`
+	ContextSwitches MetricDelta    `json:"context_switches"`
+	Projects        []ProjectDelta `json:"projects"`
+	Tags            []TagDelta     `json:"tags"`
+	PeakHours       PeakHoursDelta `json:"peak_hours"`
+	GeneratedAt     time.Time      `json:"generated_at"`
+}
+
+/**
+ * CONTEXT:   Period comparison generator backed by SQLite repositories
+ * INPUT:     Session, work block and project repositories plus analytics engine
+ * OUTPUT:    Period metrics and comparisons for arbitrary date ranges
+ * BUSINESS:  Dedicated generator keeps comparison logic out of daily/weekly generators
+ * CHANGE:    Initial comparison generator
+ * RISK:      Low - Read-only repository access
+ */
+type PeriodComparisonGenerator struct {
+	sessionRepo   *sqlite.SessionRepository
+	workBlockRepo *sqlite.WorkBlockRepository
+	projectRepo   *sqlite.ProjectRepository
+	analytics     *WorkAnalyticsEngine
+	filter        ReportFilter
+	grouping      string
+}
+
+/**
+ * CONTEXT:   Constructor for period comparison generator
+ * INPUT:     SQLite repositories and analytics engine for deep work metrics
+ * OUTPUT:    Configured comparison generator ready for use
+ * BUSINESS:  Constructor enables dependency injection for clean architecture
+ * CHANGE:    Initial constructor with repository dependencies
+ * RISK:      Low - Simple constructor with dependency injection
+ */
+func NewPeriodComparisonGenerator(
+	sessionRepo *sqlite.SessionRepository,
+	workBlockRepo *sqlite.WorkBlockRepository,
+	projectRepo *sqlite.ProjectRepository,
+	analytics *WorkAnalyticsEngine,
+) *PeriodComparisonGenerator {
+	return &PeriodComparisonGenerator{
+		sessionRepo:   sessionRepo,
+		workBlockRepo: workBlockRepo,
+		projectRepo:   projectRepo,
+		analytics:     analytics,
+	}
+}
+
+// SetFilter limits later comparisons to work blocks matching the filter
+func (pcg *PeriodComparisonGenerator) SetFilter(filter ReportFilter) {
+	pcg.filter = filter
+}
+
+// SetProjectGrouping rolls per-project hours up to the given level (see GroupByClient)
+func (pcg *PeriodComparisonGenerator) SetProjectGrouping(level string) {
+	pcg.grouping = level
+}
+
+/**
+ * CONTEXT:   Compare two periods for a user
+ * INPUT:     User ID, baseline period A and comparison period B
+ * OUTPUT:    Period comparison with signed deltas (B relative to A)
+ * BUSINESS:  Period comparison answers "did this week go better than last week"
+ * CHANGE:    Initial comparison implementation
+ * RISK:      Low - Two read-only metric collections combined in memory
+ */
+func (pcg *PeriodComparisonGenerator) Compare(ctx context.Context, userID string, a, b ReportPeriod) (*PeriodComparison, error) {
+	metricsA, err := pcg.CollectMetrics(ctx, userID, a)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect metrics for %s: %w", a.Label, err)
+	}
+	metricsB, err := pcg.CollectMetrics(ctx, userID, b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect metrics for %s: %w", b.Label, err)
+	}
+
+	return ComparePeriodMetrics(metricsA, metricsB), nil
+}
+
+/**
+ * CONTEXT:   Collect aggregated metrics for a single period
+ * INPUT:     User ID and report period
+ * OUTPUT:    Period metrics computed from work blocks starting within the period
+ * BUSINESS:  Work blocks are the source of truth for tracked time; overlapping
+ *            blocks from parallel instances count once
+ * CHANGE:    Merge overlapping blocks before summing hours
+ * RISK:      Medium - Loads all work blocks of the period into memory
+ */
+func (pcg *PeriodComparisonGenerator) CollectMetrics(ctx context.Context, userID string, period ReportPeriod) (*PeriodMetrics, error) {
+	blocks, err := loadWorkBlocksInRange(ctx, pcg.sessionRepo, pcg.workBlockRepo, userID, period.Start, period.End)
+	if err != nil {
+		return nil, err
+	}
+	blocks = pcg.filter.filterWorkBlocks(blocks)
+
+	metrics := &PeriodMetrics{
+		Period:       period,
+		WorkBlocks:   len(blocks),
+		ProjectHours: make(map[string]float64),
+		TagHours:     make(map[string]float64),
+		HourlyHours:  make(map[int]float64),
+		PeakHours:    make([]int, 0, PeakHourCount),
+	}
+
+	// Parallel Claude instances overlap; every total counts the covered time once
+	var all []workInterval
+	projectIntervals := make(map[string][]workInterval)
+	tagIntervals := make(map[string][]workInterval)
+
+	grouper := newProjectGrouper(pcg.projectRepo, pcg.grouping)
+	for _, block := range blocks {
+		end := time.Now()
+		if block.EndTime != nil {
+			end = *block.EndTime
+		}
+		if !end.After(block.StartTime) {
+			continue
+		}
+		interval := workInterval{start: block.StartTime, end: end}
+
+		all = append(all, interval)
+		name := grouper.groupFor(ctx, block.ProjectID).Name
+		projectIntervals[name] = append(projectIntervals[name], interval)
+		for _, tag := range block.Tags {
+			tagIntervals[tag] = append(tagIntervals[tag], interval)
+		}
+	}
+
+	for _, interval := range mergeIntervals(all) {
+		metrics.TotalHours += interval.end.Sub(interval.start).Hours()
+		addHourlyDistribution(metrics.HourlyHours, interval.start, interval.end)
+	}
+	for name, intervals := range projectIntervals {
+		metrics.ProjectHours[name] = intervalHours(intervals)
+	}
+	for tag, intervals := range tagIntervals {
+		metrics.TagHours[tag] = intervalHours(intervals)
+	}
+
+	if pcg.analytics != nil && len(blocks) > 0 {
+		deepWork := pcg.analytics.AnalyzeDeepWork(ctx, blocks)
+		var deepIntervals []workInterval
+		for _, focus := range deepWork.FocusBlocks {
+			if focus.FocusLevel >= FocusLevelDeep {
+				deepIntervals = append(deepIntervals, workInterval{start: focus.StartTime, end: focus.EndTime})
+			}
+		}
+		metrics.DeepWorkHours = intervalHours(deepIntervals)
+		metrics.FocusScore = deepWork.FocusScore
+		metrics.ContextSwitches = deepWork.ContextSwitches
+	}
+
+	metrics.PeakHours = topHours(metrics.HourlyHours, PeakHourCount)
+
+	return metrics, nil
+}
+
+/**
+ * CONTEXT:   Build comparison from two pre-computed period metrics
+ * INPUT:     Baseline metrics A and comparison metrics B
+ * OUTPUT:    Period comparison with signed deltas for every metric
+ * BUSINESS:  Pure comparison allows API and tests to reuse the delta logic
+ * CHANGE:    Compare tag hours alongside project hours
+ * RISK:      Low - Pure calculation with no side effects
+ */
+func ComparePeriodMetrics(a, b *PeriodMetrics) *PeriodComparison {
+	comparison := &PeriodComparison{
+		A:               *a,
+		B:               *b,
+		TotalHours:      NewMetricDelta("total_hours", a.TotalHours, b.TotalHours),
+		DeepWorkHours:   NewMetricDelta("deep_work_hours", a.DeepWorkHours, b.DeepWorkHours),
+		FocusScore:      NewMetricDelta("focus_score", a.FocusScore, b.FocusScore),
+		ContextSwitches: NewMetricDelta("context_switches", float64(a.ContextSwitches), float64(b.ContextSwitches)),
+		Projects:        make([]ProjectDelta, 0),
+		Tags:            make([]TagDelta, 0),
+		PeakHours:       comparePeakHours(a.PeakHours, b.PeakHours),
+		GeneratedAt:     time.Now(),
+	}
+
+	projects := make(map[string]bool)
+	for name := range a.ProjectHours {
+		projects[name] = true
+	}
+	for name := range b.ProjectHours {
+		projects[name] = true
+	}
+	for name := range projects {
+		comparison.Projects = append(comparison.Projects, ProjectDelta{
+			ProjectName: name,
+			Hours:       NewMetricDelta("project_hours", a.ProjectHours[name], b.ProjectHours[name]),
+		})
+	}
+
+	// Largest absolute movers first, then by name for stable output
+	sort.Slice(comparison.Projects, func(i, j int) bool {
+		di := math.Abs(comparison.Projects[i].Hours.Delta)
+		dj := math.Abs(comparison.Projects[j].Hours.Delta)
+		if di != dj {
+			return di > dj
+		}
+		return comparison.Projects[i].ProjectName < comparison.Projects[j].ProjectName
+	})
+
+	tags := make(map[string]bool)
+	for tag := range a.TagHours {
+		tags[tag] = true
+	}
+	for tag := range b.TagHours {
+		tags[tag] = true
+	}
+	for tag := range tags {
+		comparison.Tags = append(comparison.Tags, TagDelta{
+			Tag:   tag,
+			Hours: NewMetricDelta("tag_hours", a.TagHours[tag], b.TagHours[tag]),
+		})
+	}
+	sort.Slice(comparison.Tags, func(i, j int) bool {
+		di := math.Abs(comparison.Tags[i].Hours.Delta)
+		dj := math.Abs(comparison.Tags[j].Hours.Delta)
+		if di != dj {
+			return di > dj
+		}
+		return comparison.Tags[i].Tag < comparison.Tags[j].Tag
+	})
+
+	return comparison
+}
+
+/**
+ * CONTEXT:   Create signed metric delta between baseline and comparison values
+ * INPUT:     Metric name, baseline value A and comparison value B
+ * OUTPUT:    Metric delta with absolute and percentage change
+ * BUSINESS:  Percentage change is undefined for zero baselines and flagged as such
+ * CHANGE:    Initial delta calculation
+ * RISK:      Low - Pure calculation guarding division by zero
+ */
+func NewMetricDelta(metric string, a, b float64) MetricDelta {
+	delta := MetricDelta{
+		Metric: metric,
+		A:      a,
+		B:      b,
+		Delta:  b - a,
+	}
+	if a != 0 {
+		delta.PercentChange = (b - a) / math.Abs(a) * 100
+		delta.HasBaseline = true
+	}
+	return delta
+}
+
+// FormatPercentChange renders the signed percentage change, "new" or "—" without baseline
+func (md MetricDelta) FormatPercentChange() string {
+	if !md.HasBaseline {
+		if md.B == 0 {
+			return "—"
+		}
+		return "new"
+	}
+	return fmt.Sprintf("%+.1f%%", md.PercentChange)
+}
+
+/**
+ * CONTEXT:   Load work blocks starting within a time range for a user
+ * INPUT:     Session and work block repositories, user ID and [start, end) range
+ * OUTPUT:    Work blocks sorted by start time
+ * BUSINESS:  Shared loader keeps comparison and goal progress on identical data
+ * CHANGE:    Extracted from comparison metrics collection for reuse by goal tracking
+ * RISK:      Medium - Loads all work blocks of the range into memory
+ */
+func loadWorkBlocksInRange(ctx context.Context, sessionRepo *sqlite.SessionRepository, workBlockRepo *sqlite.WorkBlockRepository, userID string, start, end time.Time) ([]*sqlite.WorkBlock, error) {
+	// Sessions may start up to 5 hours before the range and still contribute blocks
+	sessions, err := sessionRepo.FindByUserAndTimeRange(ctx, userID, start.Add(-5*time.Hour), end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	blocks := make([]*sqlite.WorkBlock, 0)
+	for _, session := range sessions {
+		sessionBlocks, err := workBlockRepo.GetBySession(ctx, session.ID, 0)
+		if err != nil {
+			continue // Skip failed sessions but continue processing
+		}
+		for _, block := range sessionBlocks {
+			if !block.StartTime.Before(start) && block.StartTime.Before(end) {
+				blocks = append(blocks, block)
+			}
+		}
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].StartTime.Before(blocks[j].StartTime)
+	})
+
+	return blocks, nil
+}
+
+// addHourlyDistribution spreads a time range over the hour-of-day buckets it covers
+func addHourlyDistribution(hourly map[int]float64, start, end time.Time) {
+	for cursor := start; cursor.Before(end); {
+		nextHour := cursor.Truncate(time.Hour).Add(time.Hour)
+		if nextHour.After(end) {
+			nextHour = end
+		}
+		hourly[cursor.Hour()] += nextHour.Sub(cursor).Hours()
+		cursor = nextHour
+	}
+}
+
+// topHours returns up to n hours with the most tracked time, busiest first
+func topHours(hourly map[int]float64, n int) []int {
+	hours := make([]int, 0, len(hourly))
+	for hour, value := range hourly {
+		if value > 0 {
+			hours = append(hours, hour)
+		}
+	}
+	sort.Slice(hours, func(i, j int) bool {
+		if hourly[hours[i]] != hourly[hours[j]] {
+			return hourly[hours[i]] > hourly[hours[j]]
+		}
+		return hours[i] < hours[j]
+	})
+	if len(hours) > n {
+		hours = hours[:n]
+	}
+	return hours
+}
+
+// comparePeakHours reports which peak hours appeared or disappeared between periods
+func comparePeakHours(a, b []int) PeakHoursDelta {
+	delta := PeakHoursDelta{
+		A:      append([]int{}, a...),
+		B:      append([]int{}, b...),
+		Gained: make([]int, 0),
+		Lost:   make([]int, 0),
+	}
+
+	inA := make(map[int]bool, len(a))
+	for _, hour := range a {
+		inA[hour] = true
+	}
+	inB := make(map[int]bool, len(b))
+	for _, hour := range b {
+		inB[hour] = true
+		if !inA[hour] {
+			delta.Gained = append(delta.Gained, hour)
+		}
+	}
+	for _, hour := range a {
+		if !inB[hour] {
+			delta.Lost = append(delta.Lost, hour)
+		}
+	}
+
+	delta.Shifted = len(a) > 0 && len(b) > 0 && a[0] != b[0]
+	return delta
+}
@@ -0,0 +1,129 @@
+/**
+ * CONTEXT:   Test suite for period parsing and period-over-period deltas
+ * INPUT:     Period specifications and hand-built period metrics
+ * OUTPUT:    Validation of period boundaries and signed percentage changes
+ * BUSINESS:  Comparisons are only meaningful when both periods have the
+ *            boundaries the user asked for
+ * CHANGE:    Initial period comparison tests
+ * RISK:      Low - Pure functions only
+ */
+
+package reporting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReportPeriod(t *testing.T) {
+	loc := time.UTC
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, loc)
+	}
+
+	tests := []struct {
+		spec  string
+		kind  string
+		start time.Time
+		end   time.Time
+	}{
+		{"2026-10-05", PeriodKindDay, date(2026, 10, 5), date(2026, 10, 6)},
+		{"2026-12-31", PeriodKindDay, date(2026, 12, 31), date(2027, 1, 1)},
+		{"2026-W40", PeriodKindWeek, date(2026, 9, 28), date(2026, 10, 5)},
+		{"2026-W1", PeriodKindWeek, date(2025, 12, 29), date(2026, 1, 5)},
+		{"2021-W01", PeriodKindWeek, date(2021, 1, 4), date(2021, 1, 11)},
+		{"2026-W53", PeriodKindWeek, date(2026, 12, 28), date(2027, 1, 4)},
+		{"2026-01", PeriodKindMonth, date(2026, 1, 1), date(2026, 2, 1)},
+		{"2026-02", PeriodKindMonth, date(2026, 2, 1), date(2026, 3, 1)},
+		{"2028-02", PeriodKindMonth, date(2028, 2, 1), date(2028, 3, 1)},
+		{"2026-04", PeriodKindMonth, date(2026, 4, 1), date(2026, 5, 1)},
+		{"2026-12", PeriodKindMonth, date(2026, 12, 1), date(2027, 1, 1)},
+		{"2026-10-01..2026-10-15", PeriodKindCustom, date(2026, 10, 1), date(2026, 10, 16)},
+		{" 2026-10-01 .. 2026-10-01 ", PeriodKindCustom, date(2026, 10, 1), date(2026, 10, 2)},
+	}
+
+	for _, tt := range tests {
+		period, err := ParseReportPeriod(tt.spec, loc)
+		require.NoError(t, err, tt.spec)
+		assert.Equal(t, tt.kind, period.Kind, tt.spec)
+		assert.True(t, tt.start.Equal(period.Start), "%s start: got %s", tt.spec, period.Start)
+		assert.True(t, tt.end.Equal(period.End), "%s end: got %s", tt.spec, period.End)
+	}
+
+	feb, err := ParseReportPeriod("2028-02", loc)
+	require.NoError(t, err)
+	assert.Equal(t, 29*24*time.Hour, feb.End.Sub(feb.Start), "leap-year February")
+}
+
+func TestParseReportPeriodRejectsInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"2026-W0",
+		"2026-W54",
+		"2025-W53", // 2025 has 52 ISO weeks
+		"2026-13",
+		"2026-00",
+		"2026-02-30",
+		"2026-10-15..2026-10-01",
+		"2026-10-01..tomorrow",
+		"last week",
+	} {
+		_, err := ParseReportPeriod(spec, time.UTC)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestComparePeriodMetrics(t *testing.T) {
+	a := &PeriodMetrics{
+		TotalHours:      20,
+		DeepWorkHours:   0,
+		FocusScore:      80,
+		ContextSwitches: 10,
+		ProjectHours:    map[string]float64{"api": 12, "web": 8},
+		TagHours:        map[string]float64{"review": 2},
+		PeakHours:       []int{10, 14, 11},
+	}
+	b := &PeriodMetrics{
+		TotalHours:      25,
+		DeepWorkHours:   6,
+		FocusScore:      60,
+		ContextSwitches: 10,
+		ProjectHours:    map[string]float64{"api": 12, "docs": 5},
+		PeakHours:       []int{14, 10, 16},
+	}
+
+	comparison := ComparePeriodMetrics(a, b)
+
+	assert.Equal(t, 5.0, comparison.TotalHours.Delta)
+	assert.InDelta(t, 25.0, comparison.TotalHours.PercentChange, 1e-9)
+	assert.Equal(t, "+25.0%", comparison.TotalHours.FormatPercentChange())
+	assert.InDelta(t, -25.0, comparison.FocusScore.PercentChange, 1e-9)
+	assert.Equal(t, "-25.0%", comparison.FocusScore.FormatPercentChange())
+	assert.Equal(t, "+0.0%", comparison.ContextSwitches.FormatPercentChange())
+
+	// A zero baseline has no percentage change
+	assert.False(t, comparison.DeepWorkHours.HasBaseline)
+	assert.Zero(t, comparison.DeepWorkHours.PercentChange)
+	assert.Equal(t, 6.0, comparison.DeepWorkHours.Delta)
+	assert.Equal(t, "new", comparison.DeepWorkHours.FormatPercentChange())
+	assert.Equal(t, "—", NewMetricDelta("x", 0, 0).FormatPercentChange())
+
+	require.Len(t, comparison.Projects, 3)
+	assert.Equal(t, "web", comparison.Projects[0].ProjectName, "largest absolute mover first")
+	assert.Equal(t, -8.0, comparison.Projects[0].Hours.Delta)
+	assert.InDelta(t, -100.0, comparison.Projects[0].Hours.PercentChange, 1e-9)
+	assert.Equal(t, "docs", comparison.Projects[1].ProjectName)
+	assert.False(t, comparison.Projects[1].Hours.HasBaseline)
+	assert.Equal(t, "api", comparison.Projects[2].ProjectName)
+	assert.Zero(t, comparison.Projects[2].Hours.Delta)
+
+	require.Len(t, comparison.Tags, 1)
+	assert.Equal(t, -2.0, comparison.Tags[0].Hours.Delta)
+
+	assert.Equal(t, []int{16}, comparison.PeakHours.Gained)
+	assert.Equal(t, []int{11}, comparison.PeakHours.Lost)
+	assert.True(t, comparison.PeakHours.Shifted)
+}
@@ -46,6 +46,10 @@ type WorkBlockData struct {
 	EndTime       time.Time
 	Duration      time.Duration
 	ProjectName   string
+	GitBranch     string
+	Tags          []string
+	Manual        bool
+	Edited        bool
 	ActivityCount int
 }
 
@@ -146,6 +150,42 @@ func DisplayMetricsDashboard(activeWork time.Duration, totalTime time.Duration,
 	fmt.Printf("%s%s\n\n", BoxBottomRight, ColorReset)
 }
 
+/**
+ * CONTEXT:   Parallel Claude Code instances section
+ * INPUT:     Summed per-instance hours, merged wall-clock hours, instance count
+ * OUTPUT:    Both totals side by side, only when instances overlapped
+ * BUSINESS:  Two instances working for an hour in parallel are two hours of
+ *            instance time but one hour at the desk; users need both numbers
+ * CHANGE:    Initial parallel work section
+ * RISK:      Low - Metrics display enhancement
+ */
+func DisplayProfessionalParallelWork(summedHours, wallClockHours float64, instances int) {
+	if !hasParallelWork(summedHours, wallClockHours) {
+		return
+	}
+	
+	sectionWidth := DefaultSectionWidth
+	
+	// Section header
+	fmt.Printf("%s%s%s %s PARALLEL INSTANCES %s", 
+		ColorBrightBlue, BoxTopLeft, BoxHorizontal, SymbolClaude, strings.Repeat(BoxHorizontal, sectionWidth-23))
+	fmt.Printf("%s%s\n", BoxTopRight, ColorReset)
+	
+	wallClockStr := formatDurationPro(time.Duration(wallClockHours * float64(time.Hour)))
+	summedStr := formatDurationPro(time.Duration(summedHours * float64(time.Hour)))
+	line := fmt.Sprintf("  %s Wall Clock: %s%s%s     %s Summed: %s%s%s (%d instances)", 
+		SymbolTimeline, ColorBrightGreen, wallClockStr, ColorReset,
+		SymbolWork, ColorBrightCyan, summedStr, ColorReset, instances)
+	
+	fmt.Printf("%s%s%-*s%s%s\n", 
+		ColorBrightBlue, BoxVertical, sectionWidth, line, BoxVertical, ColorReset)
+	
+	// Bottom border
+	fmt.Printf("%s%s", ColorBrightBlue, BoxBottomLeft)
+	fmt.Print(strings.Repeat(BoxHorizontal, sectionWidth))
+	fmt.Printf("%s%s\n\n", BoxBottomRight, ColorReset)
+}
+
 /**
  * CONTEXT:   Professional project breakdown table
  * INPUT:     Project data with time allocation and activity counts
@@ -206,12 +246,108 @@ func DisplayProfessionalProjectBreakdown(projects []ProjectData) {
 	fmt.Printf("%s%s\n\n", BoxBottomRight, ColorReset)
 }
 
+/**
+ * CONTEXT:   Professional tool and activity-type breakdown table
+ * INPUT:     Section title, symbol and breakdown rows
+ * OUTPUT:    Table with event count, attributed time, share and average gap
+ * BUSINESS:  Shows how much time went to editing, commands, reads and searches
+ * CHANGE:    Initial breakdown table for daily and monthly reports
+ * RISK:      Low - Breakdown display enhancement
+ */
+func DisplayProfessionalActivityBreakdown(title, symbol string, rows []ActivityBreakdown) {
+	if len(rows) == 0 {
+		return
+	}
+	
+	sectionWidth := DefaultSectionWidth
+	
+	// Section header
+	fmt.Printf("%s%s%s %s %s %s", 
+		ColorBrightMagenta, BoxTopLeft, BoxHorizontal, symbol, title, strings.Repeat(BoxHorizontal, sectionWidth-len(title)-5))
+	fmt.Printf("%s%s\n", BoxTopRight, ColorReset)
+	
+	// Table header
+	headerLine := fmt.Sprintf(" %-18s │ %6s │ %-8s │ %6s │ %-8s ", "Name", "Events", "Time", "%", "Avg gap")
+	fmt.Printf("%s%s%s%s%s\n", 
+		ColorBrightMagenta, BoxVertical, ColorBold, headerLine, ColorReset + ColorBrightMagenta + BoxVertical + ColorReset)
+	
+	// Header separator
+	fmt.Printf("%s%s", ColorBrightMagenta, BoxTeeRight)
+	fmt.Print(strings.Repeat(BoxHorizontal, 20) + BoxTeeDown + strings.Repeat(BoxHorizontal, 8) + BoxTeeDown + strings.Repeat(BoxHorizontal, 10) + BoxTeeDown + strings.Repeat(BoxHorizontal, 8) + BoxTeeDown + strings.Repeat(BoxHorizontal, 10))
+	fmt.Printf("%s%s\n", BoxTeeLeft, ColorReset)
+	
+	// Breakdown rows
+	for _, row := range rows {
+		name := truncateStringPro(row.Name, 18)
+		timeStr := formatDurationPro(time.Duration(row.Hours * float64(time.Hour)))
+		percentStr := fmt.Sprintf("%.1f%%", row.Percentage)
+		gapStr := formatGapPro(time.Duration(row.AverageGapSeconds * float64(time.Second)))
+		
+		line := fmt.Sprintf(" %s%-18s%s │ %6d │ %-8s │ %6s │ %-8s ", 
+			getProjectColor(row.Percentage), name, ColorReset, row.Count, timeStr, percentStr, gapStr)
+		
+		fmt.Printf("%s%s%s%s%s\n", 
+			ColorBrightMagenta, BoxVertical, line, BoxVertical, ColorReset)
+	}
+	
+	// Bottom border
+	fmt.Printf("%s%s", ColorBrightMagenta, BoxBottomLeft)
+	fmt.Print(strings.Repeat(BoxHorizontal, 20) + BoxTeeUp + strings.Repeat(BoxHorizontal, 8) + BoxTeeUp + strings.Repeat(BoxHorizontal, 10) + BoxTeeUp + strings.Repeat(BoxHorizontal, 8) + BoxTeeUp + strings.Repeat(BoxHorizontal, 10))
+	fmt.Printf("%s%s\n\n", BoxBottomRight, ColorReset)
+}
+
+/**
+ * CONTEXT:   Professional tag breakdown table
+ * INPUT:     Tag breakdown rows, untagged time last
+ * OUTPUT:    Table with work blocks, time and share per tag
+ * BUSINESS:  Shows how long labelled work such as an incident took
+ * CHANGE:    Initial tag section for daily and monthly reports
+ * RISK:      Low - Breakdown display enhancement
+ */
+func DisplayProfessionalTagBreakdown(rows []TagBreakdown) {
+	if len(rows) == 0 {
+		return
+	}
+	
+	sectionWidth := DefaultSectionWidth
+	
+	// Section header
+	fmt.Printf("%s%s%s %s TAGS %s", 
+		ColorBrightMagenta, BoxTopLeft, BoxHorizontal, SymbolProject, strings.Repeat(BoxHorizontal, sectionWidth-9))
+	fmt.Printf("%s%s\n", BoxTopRight, ColorReset)
+	
+	// Table header
+	headerLine := fmt.Sprintf(" %-31s │ %6s │ %-10s │ %8s ", "Tag", "Blocks", "Time", "%")
+	fmt.Printf("%s%s%s%s%s\n", 
+		ColorBrightMagenta, BoxVertical, ColorBold, headerLine, ColorReset + ColorBrightMagenta + BoxVertical + ColorReset)
+	
+	// Tag rows
+	for _, row := range rows {
+		nameColor := getProjectColor(row.Percentage)
+		if row.Tag == untaggedName {
+			nameColor = ColorDim
+		}
+		timeStr := formatDurationPro(time.Duration(row.Hours * float64(time.Hour)))
+		
+		line := fmt.Sprintf(" %s%-31s%s │ %6d │ %-10s │ %7.1f%% ", 
+			nameColor, truncateStringPro(row.Tag, 31), ColorReset, row.WorkBlocks, timeStr, row.Percentage)
+		
+		fmt.Printf("%s%s%s%s%s\n", 
+			ColorBrightMagenta, BoxVertical, line, BoxVertical, ColorReset)
+	}
+	
+	// Bottom border
+	fmt.Printf("%s%s", ColorBrightMagenta, BoxBottomLeft)
+	fmt.Print(strings.Repeat(BoxHorizontal, sectionWidth))
+	fmt.Printf("%s%s\n\n", BoxBottomRight, ColorReset)
+}
+
 /**
  * CONTEXT:   Professional work timeline with visual connectors
  * INPUT:     Work block data with time ranges and activities
  * OUTPUT:    Timeline visualization with professional styling
  * BUSINESS:  Visual work timeline helps identify productivity patterns
- * CHANGE:    Timeline with extracted utility functions for formatting
+ * CHANGE:    Mark manual and edited work blocks next to their tags or git branch
  * RISK:      Low - Timeline display enhancement
  */
 func DisplayProfessionalWorkTimeline(workBlocks []WorkBlockData) {
@@ -227,6 +363,7 @@ func DisplayProfessionalWorkTimeline(workBlocks []WorkBlockData) {
 	fmt.Printf("%s%s\n", BoxTopRight, ColorReset)
 	
 	// Timeline entries
+	corrected := false
 	for i, block := range workBlocks {
 		timeRange := fmt.Sprintf("%s-%s", 
 			block.StartTime.Format("15:04"), 
@@ -235,6 +372,16 @@ func DisplayProfessionalWorkTimeline(workBlocks []WorkBlockData) {
 		durationStr := formatDurationPro(block.Duration)
 		projectName := truncateStringPro(block.ProjectName, 15)
 		activityStr := fmt.Sprintf("%d activities", block.ActivityCount)
+		if block.GitBranch != "" {
+			activityStr = "⎇ " + truncateStringPro(block.GitBranch, 24)
+		}
+		if len(block.Tags) > 0 {
+			activityStr = truncateStringPro("#"+strings.Join(block.Tags, " #"), 24)
+		}
+		if marker := workBlockMarker(block); marker != "" {
+			activityStr = truncateStringPro(marker+" "+activityStr, 24)
+			corrected = true
+		}
 		
 		// Visual connector
 		connector := "├──"
@@ -254,12 +401,83 @@ func DisplayProfessionalWorkTimeline(workBlocks []WorkBlockData) {
 			ColorBrightYellow, BoxVertical, sectionWidth, timeline, BoxVertical, ColorReset)
 	}
 	
+	// Legend for corrected time
+	if corrected {
+		legend := fmt.Sprintf(" %s[M] manual entry  [E] edited - see 'entry history'%s", ColorDim, ColorReset)
+		fmt.Printf("%s%s%-*s%s%s\n", 
+			ColorBrightYellow, BoxVertical, sectionWidth+len(ColorDim)+len(ColorReset), legend, BoxVertical, ColorReset)
+	}
+	
 	// Bottom border
 	fmt.Printf("%s%s", ColorBrightYellow, BoxBottomLeft)
 	fmt.Print(strings.Repeat(BoxHorizontal, sectionWidth))
 	fmt.Printf("%s%s\n\n", BoxBottomRight, ColorReset)
 }
 
+// workBlockMarker flags manual and edited blocks in the timeline
+func workBlockMarker(block WorkBlockData) string {
+	switch {
+	case block.Manual:
+		return "[M]"
+	case block.Edited:
+		return "[E]"
+	}
+	return ""
+}
+
+/**
+ * CONTEXT:   Professional goal progress display with progress bars
+ * INPUT:     Evaluated goal status list
+ * OUTPUT:    Goal section with progress bar, hours and state per goal
+ * BUSINESS:  Visible goal progress keeps targets and budgets top of mind
+ * CHANGE:    Initial goal progress section for daily and monthly reports
+ * RISK:      Low - Goal display enhancement
+ */
+func DisplayProfessionalGoalProgress(goals []GoalStatus) {
+	if len(goals) == 0 {
+		return
+	}
+	
+	sectionWidth := DefaultSectionWidth
+	barWidth := 20
+	
+	// Section header
+	fmt.Printf("%s%s%s %s GOALS %s", 
+		ColorBrightCyan, BoxTopLeft, BoxHorizontal, SymbolEfficiency, strings.Repeat(BoxHorizontal, sectionWidth-10))
+	fmt.Printf("%s%s\n", BoxTopRight, ColorReset)
+	
+	for _, goal := range goals {
+		filled := int(goal.Percent / 100 * float64(barWidth))
+		if filled > barWidth {
+			filled = barWidth
+		}
+		if filled < 0 {
+			filled = 0
+		}
+		
+		barColor := ColorBrightYellow
+		switch goal.State {
+		case GoalStateMet, GoalStateWithinBudget:
+			barColor = ColorBrightGreen
+		case GoalStateExceeded, GoalStateMissed:
+			barColor = ColorBrightRed
+		}
+		
+		bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+		label := truncateStringPro(fmt.Sprintf("%s (%s)", goal.Name, goal.Period), 24)
+		line := fmt.Sprintf(" %-24s %s%s%s %5.1f/%-5.1fh %s", 
+			label, barColor, bar, ColorReset, goal.ActualHours, goal.TargetHours, strings.ReplaceAll(goal.State, "_", " "))
+		
+		fmt.Printf("%s%s%s%s%s\n", 
+			ColorBrightCyan, BoxVertical, line, BoxVertical, ColorReset)
+	}
+	
+	// Bottom border
+	fmt.Printf("%s%s", ColorBrightCyan, BoxBottomLeft)
+	fmt.Print(strings.Repeat(BoxHorizontal, sectionWidth))
+	fmt.Printf("%s%s\n\n", BoxBottomRight, ColorReset)
+}
+
 /**
  * CONTEXT:   Professional insights display with enhanced formatting
  * INPUT:     Generated insights and recommendations
@@ -366,7 +584,7 @@ func DisplayProfessionalEmptyState(message string) {
  * INPUT:     Enhanced daily report with work data and time metrics
  * OUTPUT:    Complete daily report display with all sections
  * BUSINESS:  Professional daily reports are core user interface
- * CHANGE:    Main display orchestration using separated utility functions
+ * CHANGE:    Show parallel instance time after the metrics dashboard
  * RISK:      Low - Display coordination with enhanced visual appeal
  */
 func DisplayProfessionalDailyReport(report *EnhancedDailyReport, activeWork, totalTime time.Duration) error {
@@ -379,6 +597,9 @@ func DisplayProfessionalDailyReport(report *EnhancedDailyReport, activeWork, tot
 		time.Duration(report.ClaudeProcessingTime*float64(time.Hour)),
 	)
 	
+	// Display wall-clock vs summed time when Claude Code instances overlapped
+	DisplayProfessionalParallelWork(report.TotalWorkHours, report.WallClockHours, report.ClaudeInstances)
+	
 	// Display project breakdown if available
 	if len(report.ProjectBreakdown) > 0 {
 		projects := make([]ProjectData, len(report.ProjectBreakdown))
@@ -393,6 +614,13 @@ func DisplayProfessionalDailyReport(report *EnhancedDailyReport, activeWork, tot
 		DisplayProfessionalProjectBreakdown(projects)
 	}
 	
+	// Display tool and activity-type breakdown
+	DisplayProfessionalActivityBreakdown("TOOL BREAKDOWN", SymbolClaude, report.ToolBreakdown)
+	DisplayProfessionalActivityBreakdown("ACTIVITY TYPES", SymbolSession, report.ActivityTypeBreakdown)
+	
+	// Display tag breakdown
+	DisplayProfessionalTagBreakdown(report.TagBreakdown)
+	
 	// Display work timeline if available
 	if len(report.WorkBlocks) > 0 {
 		workBlocks := make([]WorkBlockData, len(report.WorkBlocks))
@@ -402,12 +630,19 @@ func DisplayProfessionalDailyReport(report *EnhancedDailyReport, activeWork, tot
 				EndTime:       block.EndTime,
 				Duration:      block.Duration,
 				ProjectName:   block.ProjectName,
+				GitBranch:     block.GitBranch,
+				Tags:          block.Tags,
+				Manual:        block.Manual,
+				Edited:        block.Edited,
 				ActivityCount: 1, // Simplified for display
 			}
 		}
 		DisplayProfessionalWorkTimeline(workBlocks)
 	}
 	
+	// Display goal progress
+	DisplayProfessionalGoalProgress(report.Goals)
+	
 	// Display insights
 	if len(report.Insights) > 0 {
 		DisplayProfessionalInsights(report.Insights)
@@ -424,7 +659,7 @@ func DisplayProfessionalDailyReport(report *EnhancedDailyReport, activeWork, tot
  * INPUT:     Enhanced monthly report with heatmap and analytics
  * OUTPUT:    Complete monthly report display with all sections
  * BUSINESS:  Professional monthly reports provide long-term insights
- * CHANGE:    Monthly display using extracted utilities for consistent formatting
+ * CHANGE:    Add a wall-clock line when parallel instances overlapped
  * RISK:      Low - Monthly report formatting with visual enhancements
  */
 func DisplayProfessionalMonthlyReport(report *EnhancedMonthlyReport) error {
@@ -476,6 +711,16 @@ func DisplayProfessionalMonthlyReport(report *EnhancedMonthlyReport) error {
 			ColorBrightCyan, BoxVertical, sectionWidth, line3, BoxVertical, ColorReset)
 	}
 	
+	// Wall-clock time when parallel Claude Code instances overlapped
+	if hasParallelWork(report.TotalWorkHours, report.WallClockHours) {
+		wallClockStr := formatDurationPro(time.Duration(report.WallClockHours * float64(time.Hour)))
+		line4 := fmt.Sprintf("  %s Wall Clock: %s%s%s (total sums parallel instances)", 
+			SymbolClaude, ColorBrightGreen, wallClockStr, ColorReset)
+		
+		fmt.Printf("%s%s%-*s%s%s\n", 
+			ColorBrightCyan, BoxVertical, sectionWidth, line4, BoxVertical, ColorReset)
+	}
+	
 	// Bottom border
 	fmt.Printf("%s%s", ColorBrightCyan, BoxBottomLeft)
 	fmt.Print(strings.Repeat(BoxHorizontal, sectionWidth))
@@ -495,6 +740,16 @@ func DisplayProfessionalMonthlyReport(report *EnhancedMonthlyReport) error {
 		DisplayProfessionalProjectBreakdown(projects)
 	}
 	
+	// Display tool and activity-type breakdown
+	DisplayProfessionalActivityBreakdown("TOOL BREAKDOWN", SymbolClaude, report.ToolBreakdown)
+	DisplayProfessionalActivityBreakdown("ACTIVITY TYPES", SymbolSession, report.ActivityTypeBreakdown)
+	
+	// Display tag breakdown
+	DisplayProfessionalTagBreakdown(report.TagBreakdown)
+	
+	// Display goal progress
+	DisplayProfessionalGoalProgress(report.Goals)
+	
 	// Display insights if available
 	if len(report.Insights) > 0 {
 		DisplayProfessionalInsights(report.Insights)
@@ -0,0 +1,108 @@
+/**
+ * CONTEXT:   Project roll-up for report aggregation
+ * INPUT:     Projects with parent and client attributes and a grouping level
+ * OUTPUT:    Breakdown name and path each project's time is reported under
+ * BUSINESS:  Monorepos and client engagements report by client, product or repository
+ * CHANGE:    Initial grouping by project, parent and client
+ * RISK:      Low - Read-only lookups cached per report
+ */
+
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+)
+
+// Project grouping levels accepted by --group-by
+const (
+	GroupByProject = "project"
+	GroupByParent  = "parent"
+	GroupByClient  = "client"
+)
+
+// NoClientName labels time on projects without a client in client roll-ups
+const NoClientName = "No client"
+
+// ParseProjectGrouping validates a --group-by value, defaulting to per-project breakdowns
+func ParseProjectGrouping(value string) (string, error) {
+	switch level := strings.ToLower(strings.TrimSpace(value)); level {
+	case "":
+		return GroupByProject, nil
+	case GroupByProject, GroupByParent, GroupByClient:
+		return level, nil
+	default:
+		return "", fmt.Errorf("invalid grouping %q (use %s, %s or %s)", value, GroupByClient, GroupByParent, GroupByProject)
+	}
+}
+
+// projectBreakdownKey identifies a breakdown row when aggregating daily reports
+func projectBreakdownKey(breakdown ProjectBreakdown) string {
+	return breakdown.ProjectName + "\x00" + breakdown.ProjectPath
+}
+
+// projectGroup is the breakdown row a project's time rolls up into
+type projectGroup struct {
+	Name string
+	Path string
+}
+
+/**
+ * CONTEXT:   Resolve projects to their breakdown row for one report
+ * INPUT:     Project repository and grouping level
+ * OUTPUT:    Cached project-to-group mapping
+ * BUSINESS:  parent rolls a project into its direct parent; client uses the nearest
+ *            client set on the project or an ancestor
+ * CHANGE:    Initial project grouper
+ * RISK:      Low - Unknown projects fall back to "Unknown Project"
+ */
+type projectGrouper struct {
+	projectRepo *sqlite.ProjectRepository
+	level       string
+	groups      map[string]projectGroup
+}
+
+// newProjectGrouper creates a grouper; an empty level groups by project
+func newProjectGrouper(projectRepo *sqlite.ProjectRepository, level string) *projectGrouper {
+	if level == "" {
+		level = GroupByProject
+	}
+	return &projectGrouper{
+		projectRepo: projectRepo,
+		level:       level,
+		groups:      make(map[string]projectGroup),
+	}
+}
+
+// groupFor returns the breakdown row for a project ID
+func (pg *projectGrouper) groupFor(ctx context.Context, projectID string) projectGroup {
+	if group, ok := pg.groups[projectID]; ok {
+		return group
+	}
+
+	group := projectGroup{Name: "Unknown Project"}
+	lineage, err := pg.projectRepo.GetAncestors(ctx, projectID)
+	if err == nil && len(lineage) > 0 {
+		group = projectGroup{Name: lineage[0].Name, Path: lineage[0].Path}
+		switch pg.level {
+		case GroupByParent:
+			if len(lineage) > 1 {
+				group = projectGroup{Name: lineage[1].Name, Path: lineage[1].Path}
+			}
+		case GroupByClient:
+			group = projectGroup{Name: NoClientName}
+			for _, project := range lineage {
+				if project.Client != "" {
+					group = projectGroup{Name: project.Client}
+					break
+				}
+			}
+		}
+	}
+
+	pg.groups[projectID] = group
+	return group
+}
@@ -0,0 +1,257 @@
+/**
+ * CONTEXT:   Prompt and status bar rendering from the cached status snapshot
+ * INPUT:     StatusSnapshot, the current instant and a preset name or Go template
+ * OUTPUT:    One line such as "api · 2h14m today · session 3h10m left"
+ * BUSINESS:  Shell prompts, tmux, starship and waybar show tracking state
+ *            without a daemon round trip
+ * CHANGE:    Initial prompt templates and presets
+ * RISK:      Low - Pure formatting; user templates only see PromptData
+ */
+
+package reporting
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// promptSessionEnding is when the session countdown turns urgent
+const promptSessionEnding = 30 * time.Minute
+
+// promptPresets are the ready-made templates selectable by name
+var promptPresets = map[string]string{
+	"plain":    `{{.Summary}}`,
+	"bash":     `{{with .Summary}}[{{.}}] {{end}}`,
+	"zsh":      `{{with .Summary}}%F{ {{- if $.SessionEnding}}red{{else}}cyan{{end}}}{{zshesc .}}%f {{end}}`,
+	"starship": `{{.Summary}}`,
+	"tmux":     `{{with .Summary}}#[fg={{if $.SessionEnding}}colour203{{else}}colour39{{end}}]{{tmuxesc .}}#[default]{{end}}`,
+	"waybar":   `{"text":{{json .Summary}},"tooltip":{{json .Tooltip}},"class":{{json .Class}},"percentage":{{.SessionPercent}}}`,
+}
+
+// promptFuncs are the helpers available to prompt templates
+var promptFuncs = template.FuncMap{
+	"dur":     formatPromptDuration,
+	"hours":   func(h float64) string { return fmt.Sprintf("%.1f", h) },
+	"json":    promptJSON,
+	"trunc":   truncatePrompt,
+	"zshesc":  func(s string) string { return strings.ReplaceAll(s, "%", "%%") },
+	"tmuxesc": func(s string) string { return strings.ReplaceAll(s, "#", "##") },
+}
+
+/**
+ * CONTEXT:   Template data for prompts
+ * INPUT:     Built from a StatusSnapshot at render time
+ * OUTPUT:    Fields and helpers usable as {{.Field}} in templates
+ * BUSINESS:  Countdowns tick between daemon writes because they are computed
+ *            here from the snapshot's absolute times
+ * CHANGE:    Initial prompt data
+ * RISK:      Low - Read-only view
+ */
+type PromptData struct {
+	Running     bool          // daemon wrote the snapshot and has not stopped
+	Active      bool          // a work block is open and not yet idle
+	InSession   bool          // the 5-hour session window is still open
+	Project     string        // project of the most recently active work block
+	ProjectPath string        // path of that project
+	Branch      string        // git branch of the open work block
+	Tags        []string      // tags on the open work block
+	Instances   int           // Claude instances with an open work block
+	LastTool    string        // tool of the most recent activity
+	Today       time.Duration // wall-clock work today as of the last event
+	Sessions    int           // sessions started today
+	SessionLeft time.Duration // time left in the session window
+	SessionEnd  time.Time     // when the session window closes
+	IdleIn      time.Duration // time until the work block goes idle
+	Working     time.Duration // time since the work block started
+	UpdatedAt   time.Time     // when the daemon last wrote the snapshot
+}
+
+// NewPromptData evaluates snapshot at now; a nil snapshot reads as not running
+func NewPromptData(snapshot *StatusSnapshot, now time.Time) PromptData {
+	data := PromptData{}
+	if snapshot == nil {
+		return data
+	}
+
+	data.Running = snapshot.Daemon == SnapshotDaemonRunning
+	data.UpdatedAt = snapshot.UpdatedAt
+	data.LastTool = snapshot.LastTool
+	// Totals from yesterday's last event do not count toward today
+	if sameDay(snapshot.UpdatedAt, now) {
+		data.Today = time.Duration(snapshot.TodayHours * float64(time.Hour))
+		data.Sessions = snapshot.TodaySessions
+	}
+	if snapshot.SessionEnd != nil && now.Before(*snapshot.SessionEnd) {
+		data.InSession = true
+		data.SessionEnd = *snapshot.SessionEnd
+		data.SessionLeft = snapshot.SessionEnd.Sub(now)
+	}
+	if snapshot.IdleAt != nil && now.Before(*snapshot.IdleAt) {
+		data.Active = true
+		data.Project = snapshot.Project
+		data.ProjectPath = snapshot.ProjectPath
+		data.Branch = snapshot.GitBranch
+		data.Tags = snapshot.Tags
+		data.Instances = snapshot.Instances
+		data.IdleIn = snapshot.IdleAt.Sub(now)
+		if snapshot.BlockStart != nil {
+			data.Working = now.Sub(*snapshot.BlockStart)
+		}
+	}
+	return data
+}
+
+// Summary is "project · 2h14m today · session 3h10m left", empty when the daemon is down;
+// parallel instances show as "project +1"
+func (d PromptData) Summary() string {
+	if !d.Running {
+		return ""
+	}
+	var parts []string
+	if d.Active && d.Project != "" {
+		project := d.Project
+		if d.Instances > 1 {
+			project += fmt.Sprintf(" +%d", d.Instances-1)
+		}
+		parts = append(parts, project)
+	}
+	parts = append(parts, formatPromptDuration(d.Today)+" today")
+	if d.InSession {
+		parts = append(parts, "session "+formatPromptDuration(d.SessionLeft)+" left")
+	}
+	return strings.Join(parts, " · ")
+}
+
+// Tooltip is a multi-line description for status bars that show one on hover
+func (d PromptData) Tooltip() string {
+	if !d.Running {
+		return "Claude Monitor daemon is not running"
+	}
+	lines := []string{fmt.Sprintf("Today: %s over %d sessions", formatPromptDuration(d.Today), d.Sessions)}
+	if d.InSession {
+		lines = append(lines, fmt.Sprintf("Session ends %s (%s left)", d.SessionEnd.Local().Format("15:04"), formatPromptDuration(d.SessionLeft)))
+	} else {
+		lines = append(lines, "No active session")
+	}
+	if d.Active {
+		project := d.Project
+		if d.Branch != "" {
+			project += " (" + d.Branch + ")"
+		}
+		lines = append(lines, fmt.Sprintf("Working on %s for %s, idle in %s", project, formatPromptDuration(d.Working), formatPromptDuration(d.IdleIn)))
+		if d.Instances > 1 {
+			lines = append(lines, fmt.Sprintf("%d Claude instances active; today counts overlapping time once", d.Instances))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Class is "active", "idle" or "stopped" for status bar styling
+func (d PromptData) Class() string {
+	switch {
+	case !d.Running:
+		return "stopped"
+	case d.Active:
+		return "active"
+	default:
+		return "idle"
+	}
+}
+
+// SessionEnding reports whether less than 30 minutes are left in the session
+func (d PromptData) SessionEnding() bool {
+	return d.InSession && d.SessionLeft < promptSessionEnding
+}
+
+// SessionPercent is the share of the session window used, 0 to 100
+func (d PromptData) SessionPercent() int {
+	if !d.InSession {
+		return 0
+	}
+	const window = 5 * time.Hour
+	used := window - d.SessionLeft
+	if used < 0 {
+		return 0
+	}
+	return int(100 * used / window)
+}
+
+/**
+ * CONTEXT:   Render a prompt line
+ * INPUT:     Writer, preset name or Go template text, and prompt data
+ * OUTPUT:    Rendered line followed by a newline; error on a bad template
+ * BUSINESS:  Preset names cover common shells and bars; anything else is a template
+ * CHANGE:    Initial renderer
+ * RISK:      Low - Template errors surface to the user configuring the prompt
+ */
+func RenderPrompt(w io.Writer, format string, data PromptData) error {
+	text := format
+	if preset, ok := promptPresets[format]; ok {
+		text = preset
+	}
+	tmpl, err := template.New("prompt").Funcs(promptFuncs).Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid prompt template: %w", err)
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return fmt.Errorf("failed to render prompt: %w", err)
+	}
+	_, err = fmt.Fprintln(w, out.String())
+	return err
+}
+
+// PromptPreset returns the template behind a preset name
+func PromptPreset(name string) (string, bool) {
+	preset, ok := promptPresets[name]
+	return preset, ok
+}
+
+// PromptPresetNames lists the preset names in order
+func PromptPresetNames() []string {
+	names := make([]string, 0, len(promptPresets))
+	for name := range promptPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// formatPromptDuration formats a duration compactly as 2h14m or 45m
+func formatPromptDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	minutes := int(d / time.Minute)
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+}
+
+// promptJSON encodes v as JSON for machine-readable bars such as waybar
+func promptJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+// truncatePrompt shortens s to n runes with an ellipsis
+func truncatePrompt(n int, s string) string {
+	runes := []rune(s)
+	if len(runes) <= n || n < 1 {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// sameDay reports whether a and b fall on the same local calendar day
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Local().Date()
+	by, bm, bd := b.Local().Date()
+	return ay == by && am == bm && ad == bd
+}
@@ -0,0 +1,130 @@
+/**
+ * CONTEXT:   Report filter narrowing reports to a subset of tracked work
+ * INPUT:     Filter criteria from CLI flags (git branch and tags)
+ * OUTPUT:    Work block and activity predicates applied by every generator
+ * BUSINESS:  Filtered reports answer "how long did feature/x take" with the usual views
+ * CHANGE:    Added tag matching
+ * RISK:      Low - Pure predicates; an empty filter matches everything
+ */
+
+package reporting
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+)
+
+/**
+ * CONTEXT:   Criteria applied to work blocks and activities in reports
+ * INPUT:     No input - data structure definition
+ * OUTPUT:    Filter shared by daily, weekly, monthly and comparison generators
+ * BUSINESS:  Branch accepts an exact name or a glob such as feature/*; work must
+ *            carry every listed tag, on the work block or its session
+ * CHANGE:    Added normalized tags
+ * RISK:      Low - Data structure with JSON serialization support
+ */
+type ReportFilter struct {
+	Branch string   `json:"branch,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+}
+
+// IsEmpty reports whether the filter matches all work
+func (f ReportFilter) IsEmpty() bool {
+	return f.Branch == "" && len(f.Tags) == 0
+}
+
+// Validate checks that the branch pattern is a valid glob and tags are normalized
+func (f ReportFilter) Validate() error {
+	if _, err := path.Match(f.Branch, ""); err != nil {
+		return fmt.Errorf("invalid branch pattern %q: %w", f.Branch, err)
+	}
+	for _, tag := range f.Tags {
+		if normalized, err := sqlite.NormalizeTag(tag); err != nil || normalized != tag {
+			return fmt.Errorf("invalid tag filter %q", tag)
+		}
+	}
+	return nil
+}
+
+// Describe returns a short human readable form of the filter
+func (f ReportFilter) Describe() string {
+	var parts []string
+	if f.Branch != "" {
+		parts = append(parts, "branch "+f.Branch)
+	}
+	switch len(f.Tags) {
+	case 0:
+	case 1:
+		parts = append(parts, "tag "+f.Tags[0])
+	default:
+		parts = append(parts, "tags "+strings.Join(f.Tags, " + "))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// MatchesWorkBlock reports whether a work block passes the filter
+func (f ReportFilter) MatchesWorkBlock(block *sqlite.WorkBlock) bool {
+	return f.matchesBranch(block.GitBranch) && f.matchesTags(block.Tags)
+}
+
+// MatchesActivity reports whether an activity passes the filter
+func (f ReportFilter) MatchesActivity(activity *sqlite.Activity) bool {
+	return f.matchesBranch(activity.GitBranch) && f.matchesTags(activity.Tags)
+}
+
+// matchesTags reports whether every filter tag is among the recorded tags
+func (f ReportFilter) matchesTags(tags []string) bool {
+	for _, want := range f.Tags {
+		found := false
+		for _, tag := range tags {
+			if tag == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesBranch compares a recorded branch against the exact name or glob
+func (f ReportFilter) matchesBranch(branch string) bool {
+	if f.Branch == "" || f.Branch == branch {
+		return true
+	}
+	matched, err := path.Match(f.Branch, branch)
+	return err == nil && matched && branch != ""
+}
+
+// filterWorkBlocks keeps the work blocks matching the filter
+func (f ReportFilter) filterWorkBlocks(blocks []*sqlite.WorkBlock) []*sqlite.WorkBlock {
+	if f.IsEmpty() {
+		return blocks
+	}
+	filtered := make([]*sqlite.WorkBlock, 0, len(blocks))
+	for _, block := range blocks {
+		if f.MatchesWorkBlock(block) {
+			filtered = append(filtered, block)
+		}
+	}
+	return filtered
+}
+
+// filterActivities keeps the activities matching the filter
+func (f ReportFilter) filterActivities(activities []*sqlite.Activity) []*sqlite.Activity {
+	if f.IsEmpty() {
+		return activities
+	}
+	filtered := make([]*sqlite.Activity, 0, len(activities))
+	for _, activity := range activities {
+		if f.MatchesActivity(activity) {
+			filtered = append(filtered, activity)
+		}
+	}
+	return filtered
+}
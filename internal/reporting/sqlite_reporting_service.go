@@ -11,6 +11,7 @@ package reporting
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/claude-monitor/system/internal/database/sqlite"
@@ -21,14 +22,22 @@ import (
  * INPUT:     Dedicated generators for each report type and analytics calculator
  * OUTPUT:    Orchestrated reporting capability with clean separation of concerns
  * BUSINESS:  Coordinator pattern enables focused generators while maintaining unified interface
- * CHANGE:    Refactored to use extracted generators following Single Responsibility Principle
+ * CHANGE:    Added the live status generator for the dashboard
  * RISK:      Low - Delegation pattern with clear generator responsibilities
  */
 type SQLiteReportingService struct {
 	dailyGenerator    *DailyReportGenerator
 	weeklyGenerator   *WeeklyReportGenerator
 	monthlyGenerator  *MonthlyReportGenerator
+	comparisonGenerator *PeriodComparisonGenerator
+	branchGenerator     *BranchReportGenerator
+	goalTracker         *GoalTracker
+	liveGenerator       *LiveStatusGenerator
+	sessionRepo         *sqlite.SessionRepository
+	workBlockRepo       *sqlite.WorkBlockRepository
+	projectRepo         *sqlite.ProjectRepository
 	analyticsCalculator AnalyticsCalculator
+	insightRules        *InsightRuleEngine
 }
 
 /**
@@ -36,7 +45,7 @@ type SQLiteReportingService struct {
  * INPUT:     SQLite repositories for generator initialization and analytics calculator
  * OUTPUT:    Configured reporting service with specialized generators ready for use
  * BUSINESS:  Constructor creates focused generators and injects dependencies cleanly
- * CHANGE:    Constructor now creates generators instead of storing repositories directly
+ * CHANGE:    Also creates the live status generator
  * RISK:      Low - Constructor with generator creation and dependency injection
  */
 func NewSQLiteReportingService(
@@ -49,6 +58,9 @@ func NewSQLiteReportingService(
 	dailyGen := NewDailyReportGenerator(sessionRepo, workBlockRepo, activityRepo, projectRepo)
 	weeklyGen := NewWeeklyReportGenerator(sessionRepo, workBlockRepo, activityRepo, projectRepo, dailyGen)
 	monthlyGen := NewMonthlyReportGenerator(sessionRepo, workBlockRepo, activityRepo, projectRepo, dailyGen)
+	comparisonGen := NewPeriodComparisonGenerator(sessionRepo, workBlockRepo, projectRepo,
+		NewWorkAnalyticsEngine(workBlockRepo, activityRepo, projectRepo))
+	branchGen := NewBranchReportGenerator(sessionRepo, workBlockRepo, activityRepo, projectRepo)
 	
 	// Create analytics calculator for enhanced insights
 	calculator := NewDefaultAnalyticsCalculator()
@@ -57,10 +69,96 @@ func NewSQLiteReportingService(
 		dailyGenerator:      dailyGen,
 		weeklyGenerator:     weeklyGen,
 		monthlyGenerator:    monthlyGen,
+		comparisonGenerator: comparisonGen,
+		branchGenerator:     branchGen,
+		liveGenerator:       NewLiveStatusGenerator(sessionRepo, workBlockRepo, activityRepo, projectRepo),
+		sessionRepo:         sessionRepo,
+		workBlockRepo:       workBlockRepo,
+		projectRepo:         projectRepo,
 		analyticsCalculator: calculator,
 	}
 }
 
+/**
+ * CONTEXT:   Enable goal progress tracking in generated reports
+ * INPUT:     Goal repository holding user targets and caps
+ * OUTPUT:    Reporting service that attaches goal status to reports
+ * BUSINESS:  Goals are optional; reports without a goal repository stay unchanged
+ * CHANGE:    Added goal tracking hook for daily, weekly and monthly reports
+ * RISK:      Low - Additive report enrichment
+ */
+func (srs *SQLiteReportingService) EnableGoalTracking(goalRepo *sqlite.GoalRepository) {
+	if goalRepo == nil {
+		srs.goalTracker = nil
+		return
+	}
+	srs.goalTracker = NewGoalTracker(goalRepo, srs.sessionRepo, srs.workBlockRepo, srs.projectRepo)
+	if srs.insightRules != nil {
+		srs.goalTracker.analytics.SetInsightRules(srs.insightRules)
+	}
+}
+
+/**
+ * CONTEXT:   Configure insight rules for all report generation
+ * INPUT:     Insight rule engine, typically loaded from a rules file
+ * OUTPUT:    Calculator, comparison and goal evaluation using the same rules
+ * BUSINESS:  One rules file changes insights and deep work classification everywhere
+ * CHANGE:    Added configurable insight rules
+ * RISK:      Low - Nil restores the built-in defaults
+ */
+func (srs *SQLiteReportingService) SetInsightRules(rules *InsightRuleEngine) {
+	srs.insightRules = rules
+	if calculator, ok := srs.analyticsCalculator.(interface{ SetInsightRules(*InsightRuleEngine) }); ok {
+		calculator.SetInsightRules(rules)
+	}
+	srs.comparisonGenerator.analytics.SetInsightRules(rules)
+	if srs.goalTracker != nil {
+		srs.goalTracker.analytics.SetInsightRules(rules)
+	}
+}
+
+/**
+ * CONTEXT:   Restrict generated reports to matching work
+ * INPUT:     Report filter, typically from a --branch flag
+ * OUTPUT:    Daily, weekly, monthly, comparison and branch reports using the filter
+ * BUSINESS:  One filter narrows every report view to the same slice of work
+ * CHANGE:    Added report filtering by git branch
+ * RISK:      Low - Goal status stays unfiltered; an empty filter restores full reports
+ */
+func (srs *SQLiteReportingService) SetFilter(filter ReportFilter) {
+	srs.dailyGenerator.SetFilter(filter)
+	srs.comparisonGenerator.SetFilter(filter)
+	srs.branchGenerator.SetFilter(filter)
+}
+
+/**
+ * CONTEXT:   Choose the level project time rolls up to in reports
+ * INPUT:     Grouping level from ParseProjectGrouping (project, parent or client)
+ * OUTPUT:    Daily, weekly, monthly and comparison breakdowns grouped at that level
+ * BUSINESS:  Client and product totals come from the same work blocks as project totals
+ * CHANGE:    Added project hierarchy roll-up
+ * RISK:      Low - Display grouping only; tracked data is unchanged
+ */
+func (srs *SQLiteReportingService) SetProjectGrouping(level string) {
+	srs.dailyGenerator.SetProjectGrouping(level)
+	srs.comparisonGenerator.SetProjectGrouping(level)
+}
+
+/**
+ * CONTEXT:   Current goal status for a user
+ * INPUT:     User ID and evaluation instant
+ * OUTPUT:    Goal status for every goal in the period containing the instant
+ * BUSINESS:  Goal status backs the goals API and CLI listing
+ * CHANGE:    Added goal status accessor
+ * RISK:      Low - Returns error when goal tracking is not enabled
+ */
+func (srs *SQLiteReportingService) GetGoalStatus(ctx context.Context, userID string, at time.Time) ([]GoalStatus, error) {
+	if srs.goalTracker == nil {
+		return nil, fmt.Errorf("goal tracking not enabled")
+	}
+	return srs.goalTracker.EvaluateAt(ctx, userID, at)
+}
+
 /**
  * CONTEXT:   Generate enhanced daily report using dedicated daily generator
  * INPUT:     User ID, date for report generation with timezone context
@@ -81,6 +179,16 @@ func (srs *SQLiteReportingService) GenerateDailyReport(ctx context.Context, user
 	srs.analyticsCalculator.CalculateHourlyBreakdown(report)
 	srs.analyticsCalculator.GenerateDailyInsights(report)
 	
+	// Attach goal progress as of the end of the report day
+	if srs.goalTracker != nil {
+		dayEnd := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location()).AddDate(0, 0, 1)
+		goals, err := srs.goalTracker.EvaluateAt(ctx, userID, earliest(dayEnd.Add(-time.Nanosecond), time.Now()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate goals: %w", err)
+		}
+		report.Goals = goals
+	}
+	
 	return report, nil
 }
 
@@ -103,6 +211,16 @@ func (srs *SQLiteReportingService) GenerateWeeklyReport(ctx context.Context, use
 	srs.analyticsCalculator.GenerateWeeklyInsights(report)
 	srs.analyticsCalculator.GenerateWeeklyTrends(report)
 	
+	// Attach weekly goal progress over the report week
+	if srs.goalTracker != nil {
+		weekEnd := report.WeekEnd.Add(time.Nanosecond)
+		goals, err := srs.goalTracker.EvaluateRange(ctx, userID, sqlite.GoalPeriodWeekly, report.WeekStart, weekEnd, earliest(weekEnd, time.Now()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate goals: %w", err)
+		}
+		report.Goals = goals
+	}
+	
 	return report, nil
 }
 
@@ -121,6 +239,16 @@ func (srs *SQLiteReportingService) GenerateMonthlyReport(ctx context.Context, us
 		return nil, err
 	}
 	
+	// Attach monthly goal progress before achievements are derived from it
+	if srs.goalTracker != nil {
+		monthEnd := report.MonthEnd.Add(time.Nanosecond)
+		goals, err := srs.goalTracker.EvaluateRange(ctx, userID, sqlite.GoalPeriodMonthly, report.MonthStart, monthEnd, earliest(monthEnd, time.Now()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate goals: %w", err)
+		}
+		report.Goals = goals
+	}
+	
 	// Enhance report with analytics calculations
 	srs.analyticsCalculator.GenerateMonthlyAchievements(report)
 	srs.analyticsCalculator.GenerateMonthlyTrends(report)
@@ -129,6 +257,38 @@ func (srs *SQLiteReportingService) GenerateMonthlyReport(ctx context.Context, us
 	return report, nil
 }
 
+/**
+ * CONTEXT:   Compare two reporting periods using dedicated comparison generator
+ * INPUT:     User ID, baseline period A and comparison period B
+ * OUTPUT:    Period comparison with signed deltas for key metrics
+ * BUSINESS:  Period comparison is shared by CLI and API consumers
+ * CHANGE:    Added period-over-period comparison to reporting service
+ * RISK:      Low - Clean delegation to focused comparison generator
+ */
+func (srs *SQLiteReportingService) ComparePeriods(ctx context.Context, userID string, a, b ReportPeriod) (*PeriodComparison, error) {
+	return srs.comparisonGenerator.Compare(ctx, userID, a, b)
+}
+
+/**
+ * CONTEXT:   Generate branch report using dedicated branch generator
+ * INPUT:     User ID and report period
+ * OUTPUT:    Hours, work blocks and commits per project branch
+ * BUSINESS:  Branch report shows how long each feature branch took
+ * CHANGE:    Added git branch report
+ * RISK:      Low - Clean delegation to focused branch generator
+ */
+func (srs *SQLiteReportingService) GenerateBranchReport(ctx context.Context, userID string, period ReportPeriod) (*BranchReport, error) {
+	return srs.branchGenerator.Generate(ctx, userID, period)
+}
+
+// earliest returns the earlier of two instants
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
 // Coordinator interface compliance ensures consistent service contract
 var _ ReportingService = (*SQLiteReportingService)(nil)
 
@@ -145,5 +305,6 @@ type ReportingService interface {
 	GenerateDailyReport(ctx context.Context, userID string, date time.Time) (*EnhancedDailyReport, error)
 	GenerateWeeklyReport(ctx context.Context, userID string, weekStart time.Time) (*EnhancedWeeklyReport, error)
 	GenerateMonthlyReport(ctx context.Context, userID string, monthStart time.Time) (*EnhancedMonthlyReport, error)
+	ComparePeriods(ctx context.Context, userID string, a, b ReportPeriod) (*PeriodComparison, error)
 }
 
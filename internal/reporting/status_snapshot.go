@@ -0,0 +1,136 @@
+/**
+ * CONTEXT:   Cached status snapshot for shell prompts and status bars
+ * INPUT:     LiveStatus from the daemon after each state change
+ * OUTPUT:    Small JSON file written atomically, and its reader
+ * BUSINESS:  Prompts render on every command; reading one small file keeps
+ *            them fast and never touches SQLite
+ * CHANGE:    Initial status snapshot file
+ * RISK:      Low - Readers only ever see a complete file thanks to rename
+ */
+
+package reporting
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// StatusSnapshotVersion is bumped when snapshot fields change meaning
+const StatusSnapshotVersion = 1
+
+// Daemon states recorded in the snapshot
+const (
+	SnapshotDaemonRunning = "running"
+	SnapshotDaemonStopped = "stopped"
+)
+
+// StatusSnapshot is the cached status read by `claude-monitor prompt`.
+// Times are absolute so readers compute countdowns at render time. The block
+// fields describe the most recently active Claude instance, Instances counts
+// all instances with an active block, and TodayHours is wall-clock time.
+type StatusSnapshot struct {
+	Version       int        `json:"version"`
+	UserID        string     `json:"user_id"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	Daemon        string     `json:"daemon"`
+	Project       string     `json:"project,omitempty"`
+	ProjectPath   string     `json:"project_path,omitempty"`
+	GitBranch     string     `json:"git_branch,omitempty"`
+	Tags          []string   `json:"tags,omitempty"`
+	SessionStart  *time.Time `json:"session_start,omitempty"`
+	SessionEnd    *time.Time `json:"session_end,omitempty"`
+	BlockStart    *time.Time `json:"block_start,omitempty"`
+	IdleAt        *time.Time `json:"idle_at,omitempty"`
+	Instances     int        `json:"instances,omitempty"`
+	TodayHours    float64    `json:"today_hours"`
+	TodaySessions int        `json:"today_sessions"`
+	TodayProjects int        `json:"today_projects"`
+	LastTool      string     `json:"last_tool,omitempty"`
+}
+
+// NewStatusSnapshot condenses a live status into the prompt snapshot
+func NewStatusSnapshot(status *LiveStatus, daemonState string) *StatusSnapshot {
+	snapshot := &StatusSnapshot{
+		Version:       StatusSnapshotVersion,
+		UserID:        status.UserID,
+		UpdatedAt:     status.GeneratedAt,
+		Daemon:        daemonState,
+		TodayHours:    status.Today.WorkHours,
+		TodaySessions: status.Today.Sessions,
+		TodayProjects: status.Today.Projects,
+		Instances:     len(status.WorkBlocks),
+	}
+	if session := status.Session; session != nil {
+		snapshot.SessionStart = &session.StartTime
+		snapshot.SessionEnd = &session.EndTime
+	}
+	if block := status.WorkBlock; block != nil {
+		snapshot.Project = block.ProjectName
+		snapshot.ProjectPath = block.ProjectPath
+		snapshot.GitBranch = block.GitBranch
+		snapshot.Tags = block.Tags
+		snapshot.BlockStart = &block.StartTime
+		snapshot.IdleAt = &block.IdleAt
+	}
+	if len(status.Recent) > 0 {
+		snapshot.LastTool = status.Recent[0].ToolName
+	}
+	return snapshot
+}
+
+/**
+ * CONTEXT:   Atomic snapshot write
+ * INPUT:     Destination path and snapshot
+ * OUTPUT:    File replaced in one rename; error on failure
+ * BUSINESS:  A prompt reading mid-write must never see a truncated file
+ * CHANGE:    Initial writer
+ * RISK:      Low - Temp file lives in the destination directory so rename is atomic
+ */
+func WriteStatusSnapshot(path string, snapshot *StatusSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode status snapshot: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create status directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".status-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create status snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write status snapshot: %w", err)
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write status snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write status snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace status snapshot: %w", err)
+	}
+	return nil
+}
+
+// ReadStatusSnapshot loads the snapshot at path
+func ReadStatusSnapshot(path string) (*StatusSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot StatusSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("invalid status snapshot %s: %w", path, err)
+	}
+	return &snapshot, nil
+}
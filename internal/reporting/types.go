@@ -17,8 +17,10 @@ import (
  * CONTEXT:   Enhanced daily report structure with comprehensive work tracking data
  * INPUT:     No input - data structure definition
  * OUTPUT:    Daily report structure with work blocks, projects, and insights
- * BUSINESS:  Daily reports are primary interface for work tracking analytics
- * CHANGE:    Extracted type definition for better code organization
+ * BUSINESS:  Daily reports are primary interface for work tracking analytics;
+ *            TotalWorkHours sums every Claude Code instance's blocks while
+ *            WallClockHours counts overlapping parallel blocks once
+ * CHANGE:    Added wall-clock hours and the Claude Code instance count
  * RISK:      Low - Data structure with JSON serialization support
  */
 type EnhancedDailyReport struct {
@@ -26,6 +28,8 @@ type EnhancedDailyReport struct {
 	StartTime                time.Time             `json:"start_time"`
 	EndTime                  time.Time             `json:"end_time"`
 	TotalWorkHours           float64               `json:"total_work_hours"`
+	WallClockHours           float64               `json:"wall_clock_hours"`
+	ClaudeInstances          int                   `json:"claude_instances"`
 	DeepWorkHours            float64               `json:"deep_work_hours"`
 	FocusScore               float64               `json:"focus_score"`
 	ScheduleHours            float64               `json:"schedule_hours"`
@@ -36,11 +40,15 @@ type EnhancedDailyReport struct {
 	ClaudePrompts            int                   `json:"claude_prompts"`
 	TotalWorkBlocks          int                   `json:"total_work_blocks"`
 	ProjectBreakdown         []ProjectBreakdown    `json:"project_breakdown"`
+	ToolBreakdown            []ActivityBreakdown   `json:"tool_breakdown"`
+	ActivityTypeBreakdown    []ActivityBreakdown   `json:"activity_type_breakdown"`
+	TagBreakdown             []TagBreakdown        `json:"tag_breakdown"`
 	HourlyBreakdown          []HourlyData          `json:"hourly_breakdown"`
 	WorkBlocks               []WorkBlockSummary    `json:"work_blocks"`
 	Insights                 []string              `json:"insights"`
 	SessionSummary           SessionSummary        `json:"session_summary"`
 	ClaudeActivity           ClaudeActivity        `json:"claude_activity"`
+	Goals                    []GoalStatus          `json:"goals,omitempty"`
 }
 
 /**
@@ -48,7 +56,7 @@ type EnhancedDailyReport struct {
  * INPUT:     No input - data structure definition
  * OUTPUT:    Weekly report structure with daily patterns and productivity analysis
  * BUSINESS:  Weekly reports show work patterns and consistency over time
- * CHANGE:    Extracted type definition for better code organization
+ * CHANGE:    Added wall-clock hours next to the summed per-instance total
  * RISK:      Low - Data structure with JSON serialization support
  */
 type EnhancedWeeklyReport struct {
@@ -57,15 +65,20 @@ type EnhancedWeeklyReport struct {
 	WeekNumber          int                   `json:"week_number"`
 	Year                int                   `json:"year"`
 	TotalWorkHours      float64               `json:"total_work_hours"`
+	WallClockHours      float64               `json:"wall_clock_hours"`
 	DailyAverage        float64               `json:"daily_average"`
 	ClaudeUsageHours    float64               `json:"claude_usage_hours"`
 	ClaudeUsagePercent  float64               `json:"claude_usage_percent"`
 	MostProductiveDay   DaySummary            `json:"most_productive_day"`
 	DailyBreakdown      []DaySummary          `json:"daily_breakdown"`
 	ProjectBreakdown    []ProjectBreakdown    `json:"project_breakdown"`
+	ToolBreakdown       []ActivityBreakdown   `json:"tool_breakdown"`
+	ActivityTypeBreakdown []ActivityBreakdown `json:"activity_type_breakdown"`
+	TagBreakdown        []TagBreakdown        `json:"tag_breakdown"`
 	Insights            []WeeklyInsight       `json:"insights"`
 	Trends              []Trend               `json:"trends"`
 	WeeklyStats         WeeklyStats           `json:"weekly_stats"`
+	Goals               []GoalStatus          `json:"goals,omitempty"`
 }
 
 /**
@@ -73,7 +86,7 @@ type EnhancedWeeklyReport struct {
  * INPUT:     No input - data structure definition
  * OUTPUT:    Monthly report structure with daily progress and long-term insights
  * BUSINESS:  Monthly reports provide comprehensive productivity tracking and goals
- * CHANGE:    Extracted type definition for better code organization
+ * CHANGE:    Added wall-clock hours next to the summed per-instance total
  * RISK:      Low - Data structure with JSON serialization support
  */
 type EnhancedMonthlyReport struct {
@@ -82,6 +95,7 @@ type EnhancedMonthlyReport struct {
 	MonthStart       time.Time         `json:"month_start"`
 	MonthEnd         time.Time         `json:"month_end"`
 	TotalWorkHours   float64           `json:"total_work_hours"`
+	WallClockHours   float64           `json:"wall_clock_hours"`
 	WorkingDays      int               `json:"working_days"`
 	AverageHoursPerDay float64         `json:"average_hours_per_day"`
 	AverageHoursPerWorkingDay float64  `json:"average_hours_per_working_day"`
@@ -89,9 +103,13 @@ type EnhancedMonthlyReport struct {
 	BestDay          DayData           `json:"best_day"`
 	DailyHeatmap     []DayData         `json:"daily_heatmap"`
 	ProjectBreakdown []ProjectBreakdown `json:"project_breakdown"`
+	ToolBreakdown    []ActivityBreakdown `json:"tool_breakdown"`
+	ActivityTypeBreakdown []ActivityBreakdown `json:"activity_type_breakdown"`
+	TagBreakdown     []TagBreakdown      `json:"tag_breakdown"`
 	Achievements     []Achievement      `json:"achievements"`
 	Trends           []Trend           `json:"trends"`
 	Insights         []string          `json:"insights"`
+	Goals            []GoalStatus      `json:"goals,omitempty"`
 }
 
 /**
@@ -110,6 +128,22 @@ type ProjectBreakdown struct {
 	Sessions    int       `json:"sessions"`
 }
 
+/**
+ * CONTEXT:   Tool or activity-type breakdown row for time attribution
+ * INPUT:     No input - data structure definition
+ * OUTPUT:    Event count, attributed time, share of time and average gap
+ * BUSINESS:  Shows how much of a period was editing files versus running commands
+ * CHANGE:    Initial breakdown row for tool and activity-type sections
+ * RISK:      Low - Data structure with JSON serialization support
+ */
+type ActivityBreakdown struct {
+	Name              string  `json:"name"`
+	Count             int     `json:"count"`
+	Hours             float64 `json:"hours"`
+	Percentage        float64 `json:"percentage"`
+	AverageGapSeconds float64 `json:"average_gap_seconds"` // time to the next event, idle breaks excluded
+}
+
 /**
  * CONTEXT:   Hourly data structure for daily productivity timeline
  * INPUT:     No input - data structure definition
@@ -159,14 +193,19 @@ type DayData struct {
  * INPUT:     No input - data structure definition
  * OUTPUT:    Work block data with timing and project information
  * BUSINESS:  Work blocks are fundamental units of work time tracking
- * CHANGE:    Extracted type definition for better code organization
+ * CHANGE:    Carry the Claude Code session that recorded the block
  * RISK:      Low - Data structure with JSON serialization support
  */
 type WorkBlockSummary struct {
-	StartTime   time.Time     `json:"start_time"`
-	EndTime     time.Time     `json:"end_time"`
-	Duration    time.Duration `json:"duration"`
-	ProjectName string        `json:"project_name"`
+	StartTime       time.Time     `json:"start_time"`
+	EndTime         time.Time     `json:"end_time"`
+	Duration        time.Duration `json:"duration"`
+	ProjectName     string        `json:"project_name"`
+	GitBranch       string        `json:"git_branch,omitempty"`
+	ClaudeSessionID string        `json:"claude_session_id,omitempty"`
+	Tags            []string      `json:"tags,omitempty"`
+	Manual          bool          `json:"manual,omitempty"`
+	Edited          bool          `json:"edited,omitempty"`
 }
 
 /**
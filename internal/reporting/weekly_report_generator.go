@@ -63,7 +63,7 @@ func NewWeeklyReportGenerator(
  * INPUT:     User ID, week start date for 7-day period analysis
  * OUTPUT:    Enhanced weekly report with trends, insights, and project breakdown
  * BUSINESS:  Weekly reports provide work pattern analysis and productivity trends
- * CHANGE:    Extracted weekly report generation for focused responsibility
+ * CHANGE:    Sum daily wall-clock hours alongside the per-instance total
  * RISK:      Medium - Multi-day aggregation with trend calculation logic
  */
 func (wrg *WeeklyReportGenerator) GenerateWeekly(ctx context.Context, userID string, weekStart time.Time) (*EnhancedWeeklyReport, error) {
@@ -78,6 +78,9 @@ func (wrg *WeeklyReportGenerator) GenerateWeekly(ctx context.Context, userID str
 		Year:             weekStart.Year(),
 		DailyBreakdown:   make([]DaySummary, 7),
 		ProjectBreakdown: make([]ProjectBreakdown, 0),
+		ToolBreakdown:    make([]ActivityBreakdown, 0),
+		ActivityTypeBreakdown: make([]ActivityBreakdown, 0),
+		TagBreakdown:     make([]TagBreakdown, 0),
 		Insights:         make([]WeeklyInsight, 0),
 		Trends:           make([]Trend, 0),
 	}
@@ -94,6 +97,7 @@ func (wrg *WeeklyReportGenerator) GenerateWeekly(ctx context.Context, userID str
 
 	// Process each day's data using daily generator
 	projectTotals := make(map[string]*ProjectBreakdown)
+	tagTotals := make(map[string]*TagBreakdown)
 	totalWorkHours := 0.0
 	bestDayHours := 0.0
 
@@ -112,6 +116,7 @@ func (wrg *WeeklyReportGenerator) GenerateWeekly(ctx context.Context, userID str
 		report.DailyBreakdown[i].Status = wrg.calculateProductivityStatus(dailyReport.TotalWorkHours)
 
 		totalWorkHours += dailyReport.TotalWorkHours
+		report.WallClockHours += dailyReport.WallClockHours
 
 		// Track most productive day
 		if dailyReport.TotalWorkHours > bestDayHours {
@@ -121,6 +126,7 @@ func (wrg *WeeklyReportGenerator) GenerateWeekly(ctx context.Context, userID str
 
 		// Aggregate project data
 		wrg.aggregateProjectData(dailyReport.ProjectBreakdown, projectTotals)
+		mergeTagBreakdown(tagTotals, dailyReport)
 	}
 
 	// Set weekly totals and averages
@@ -129,6 +135,14 @@ func (wrg *WeeklyReportGenerator) GenerateWeekly(ctx context.Context, userID str
 
 	// Convert project totals to slice and calculate percentages
 	wrg.finalizeProjectBreakdown(projectTotals, totalWorkHours, report)
+	report.TagBreakdown = finalizeTagBreakdown(tagTotals, totalWorkHours)
+
+	// Attribute the week's time to hook tools and activity types
+	var err error
+	report.ToolBreakdown, report.ActivityTypeBreakdown, err = loadActivityBreakdowns(ctx, wrg.activityRepo, userID, weekStart, weekEnd, wrg.dailyReportGenerator.filter)
+	if err != nil {
+		return nil, err
+	}
 
 	return report, nil
 }
@@ -156,17 +170,19 @@ func (wrg *WeeklyReportGenerator) calculateProductivityStatus(hours float64) str
  * CONTEXT:   Aggregate project data from daily reports
  * INPUT:     Daily project breakdown and project totals map
  * OUTPUT:    Updated project totals with accumulated data
- * BUSINESS:  Project aggregation enables weekly project analysis
- * CHANGE:    Extracted aggregation logic for better organization
+ * BUSINESS:  Project aggregation enables weekly project analysis; daily rows are
+ *            already rolled up to the requested client, parent or project level
+ * CHANGE:    Key totals by name and path so same-named rows stay apart
  * RISK:      Low - Data aggregation with map operations
  */
 func (wrg *WeeklyReportGenerator) aggregateProjectData(dailyProjects []ProjectBreakdown, projectTotals map[string]*ProjectBreakdown) {
 	for _, project := range dailyProjects {
-		if existing, exists := projectTotals[project.ProjectName]; exists {
+		key := projectBreakdownKey(project)
+		if existing, exists := projectTotals[key]; exists {
 			existing.WorkHours += project.WorkHours
 			existing.Sessions += project.Sessions
 		} else {
-			projectTotals[project.ProjectName] = &ProjectBreakdown{
+			projectTotals[key] = &ProjectBreakdown{
 				ProjectName: project.ProjectName,
 				ProjectPath: project.ProjectPath,
 				WorkHours:   project.WorkHours,
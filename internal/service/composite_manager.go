@@ -89,6 +89,12 @@ func NewCompositeServiceManager() (*CompositeServiceManager, error) {
 	}, nil
 }
 
+// NewCompositeServiceManagerWith wraps a platform manager supplied by the caller,
+// such as the CLI's systemd implementation
+func NewCompositeServiceManagerWith(platformManager PlatformServiceManager) *CompositeServiceManager {
+	return &CompositeServiceManager{platformManager: platformManager}
+}
+
 // =============================================================================
 // ServiceInstaller Interface Implementation
 // =============================================================================
@@ -69,12 +69,13 @@ const (
  * INPUT:     Service logging system entries with timestamps and metadata
  * OUTPUT:    Structured log entry with timestamp, level, message, and source
  * BUSINESS:  Log entries enable troubleshooting and service behavior analysis
- * CHANGE:    Shared log entry structure used by monitor interface
+ * CHANGE:    Structured attributes (request_id, user_id, ...) kept as fields
  * RISK:      Low - Log structure providing read-only diagnostic information
  */
 type LogEntry struct {
-	Timestamp time.Time `json:"timestamp"`
-	Level     string    `json:"level"`
-	Message   string    `json:"message"`
-	Source    string    `json:"source"`
+	Timestamp time.Time         `json:"timestamp"`
+	Level     string            `json:"level"`
+	Message   string            `json:"message"`
+	Source    string            `json:"source"`
+	Fields    map[string]string `json:"fields,omitempty"`
 }
\ No newline at end of file
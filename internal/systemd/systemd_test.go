@@ -0,0 +1,164 @@
+/**
+ * CONTEXT:   Test suite for sd_notify and socket activation
+ * INPUT:     A fake notify socket in a temp dir and a re-executed test binary
+ *            holding an inherited listener
+ * OUTPUT:    Validation of notification datagrams, watchdog parsing and LISTEN_FDS
+ * BUSINESS:  systemd must see READY, STATUS, WATCHDOG and STOPPING exactly as sent
+ * CHANGE:    Initial systemd tests
+ * RISK:      Low - Temp sockets only
+ */
+
+package systemd
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNotifySocket listens like systemd does and returns the received datagrams
+func fakeNotifySocket(t *testing.T) func() string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	t.Setenv(envNotifySocket, path)
+
+	return func() string {
+		buf := make([]byte, 4096)
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+		n, err := conn.Read(buf)
+		require.NoError(t, err)
+		return string(buf[:n])
+	}
+}
+
+func TestNotifierSendsStates(t *testing.T) {
+	receive := fakeNotifySocket(t)
+	t.Setenv(envWatchdogUsec, "30000000")
+	t.Setenv(envWatchdogPID, strconv.Itoa(os.Getpid()))
+
+	n := NewNotifier()
+	require.True(t, n.Enabled())
+	assert.Equal(t, 30*time.Second, n.WatchdogInterval())
+
+	require.NoError(t, n.Ready("Serving on 127.0.0.1:9193"))
+	assert.Equal(t, "READY=1\nSTATUS=Serving on 127.0.0.1:9193", receive())
+
+	require.NoError(t, n.Status("two\nlines"))
+	assert.Equal(t, "STATUS=two lines", receive())
+
+	require.NoError(t, n.Watchdog())
+	assert.Equal(t, "WATCHDOG=1", receive())
+
+	require.NoError(t, n.Stopping("Shutting down"))
+	assert.Equal(t, "STOPPING=1\nSTATUS=Shutting down", receive())
+}
+
+func TestNotifierDisabledOutsideSystemd(t *testing.T) {
+	t.Setenv(envNotifySocket, "")
+	t.Setenv(envWatchdogUsec, "30000000")
+
+	n := NewNotifier()
+	assert.False(t, n.Enabled())
+	assert.Zero(t, n.WatchdogInterval())
+	assert.NoError(t, n.Ready("ignored"))
+
+	var nilNotifier *Notifier
+	assert.NoError(t, nilNotifier.Watchdog())
+}
+
+func TestNotifierReportsMissingSocket(t *testing.T) {
+	t.Setenv(envNotifySocket, filepath.Join(t.TempDir(), "gone.sock"))
+	assert.Error(t, NewNotifier().Ready("nobody listening"))
+}
+
+func TestWatchdogInterval(t *testing.T) {
+	assert.Equal(t, 10*time.Second, watchdogInterval("10000000", "", 42))
+	assert.Equal(t, 10*time.Second, watchdogInterval("10000000", "42", 42))
+	assert.Zero(t, watchdogInterval("10000000", "43", 42), "the watchdog belongs to another process")
+	assert.Zero(t, watchdogInterval("", "", 42))
+	assert.Zero(t, watchdogInterval("soon", "", 42))
+	assert.Zero(t, watchdogInterval("0", "", 42))
+}
+
+func TestListenersWithoutActivation(t *testing.T) {
+	t.Setenv(envListenPID, "1")
+	t.Setenv(envListenFDs, "1")
+	listeners, err := Listeners()
+	require.NoError(t, err)
+	assert.Empty(t, listeners, "LISTEN_PID for another process is ignored")
+	_, set := os.LookupEnv(envListenFDs)
+	assert.False(t, set, "activation variables are cleared")
+}
+
+// listenerChildEnv marks the re-executed test binary that plays the activated daemon
+const listenerChildEnv = "SYSTEMD_TEST_LISTENER_CHILD"
+
+func TestListenersInherited(t *testing.T) {
+	if os.Getenv(listenerChildEnv) == "1" {
+		serveInheritedListener()
+		return
+	}
+
+	parent, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer parent.Close()
+	file, err := parent.(*net.TCPListener).File()
+	require.NoError(t, err)
+	defer file.Close()
+
+	// LISTEN_PID must name the child, which only exists after Start, so a
+	// shell sets it in the exec'd process just as systemd would
+	cmd := exec.Command("/bin/sh", "-c", `LISTEN_PID=$$ exec "$0" -test.run '^TestListenersInherited$'`, os.Args[0])
+	cmd.Env = append(os.Environ(), listenerChildEnv+"=1", envListenFDs+"=1", envListenFDNames+"=hooks")
+	cmd.ExtraFiles = []*os.File{file}
+	cmd.Stderr = os.Stderr
+	require.NoError(t, cmd.Start())
+	defer cmd.Process.Kill()
+
+	// The child serves on the inherited socket; connections queued before it
+	// accepts are still answered
+	url := "http://" + parent.Addr().String() + "/"
+	var body string
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(url)
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		buf := make([]byte, 64)
+		n, _ := resp.Body.Read(buf)
+		body = string(buf[:n])
+		return true
+	}, 10*time.Second, 50*time.Millisecond)
+	assert.Equal(t, "inherited", body)
+}
+
+// serveInheritedListener runs in the child and answers requests on the inherited socket
+func serveInheritedListener() {
+	listeners, err := Listeners()
+	if err != nil || len(listeners) != 1 {
+		os.Exit(2)
+	}
+	name := strings.TrimSpace(os.Getenv(envListenFDNames))
+	if name != "" {
+		os.Exit(3) // variables must be cleared after use
+	}
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("inherited"))
+	})}
+	go server.Serve(listeners[0])
+	time.Sleep(10 * time.Second)
+	os.Exit(0)
+}
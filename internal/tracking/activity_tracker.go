@@ -0,0 +1,304 @@
+/**
+ * CONTEXT:   Activity tracker turning hook events into sessions, work blocks and events
+ * INPUT:     Activity events from the Claude Code hook via the daemon API
+ * OUTPUT:    Persisted activity events linked to the active session and work block
+ * BUSINESS:  Sessions are 5-hour windows and work blocks close after 5 idle minutes;
+ *            each Claude Code instance gets its own blocks inside the shared window
+ * CHANGE:    Key work blocks by the Claude Code session ID from the hook payload
+ * RISK:      Medium - Writes sessions, work blocks and events on every hook call
+ */
+
+package tracking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/logging"
+)
+
+// sessionDuration is the fixed Claude session window enforced by the sessions table
+const sessionDuration = 5 * time.Hour
+
+// ErrPathIgnored is returned when a project rule marks the activity path as not tracked
+var ErrPathIgnored = errors.New("path ignored by project rules")
+
+// ActivityEvent is a single activity reported by the hook
+type ActivityEvent struct {
+	UserID          string            `json:"user_id"`
+	ProjectPath     string            `json:"project_path"`
+	HookEvent       string            `json:"hook_event,omitempty"`
+	ToolName        string            `json:"tool_name,omitempty"`
+	ActivityType    string            `json:"activity_type,omitempty"`
+	ClaudeSessionID string            `json:"claude_session_id,omitempty"`
+	GitBranch       string            `json:"git_branch,omitempty"`
+	GitCommit       string            `json:"git_commit,omitempty"`
+	Tags            []string          `json:"tags,omitempty"`
+	Command         string            `json:"command,omitempty"`
+	Description     string            `json:"description,omitempty"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+}
+
+/**
+ * CONTEXT:   Activity tracker with serialized session and work block updates
+ * INPUT:     Initialized SQLite database
+ * OUTPUT:    Tracker ready to record hook events
+ * BUSINESS:  Concurrent hook calls must not open duplicate sessions or work blocks
+ * CHANGE:    Initial tracker
+ * RISK:      Low - Single mutex around a short sequence of writes
+ */
+type ActivityTracker struct {
+	mu            sync.Mutex
+	db            *sqlite.SQLiteDB
+	userRepo      *sqlite.UserRepository
+	sessionRepo   *sqlite.SessionRepository
+	workBlockRepo *sqlite.WorkBlockRepository
+	projectRepo   *sqlite.ProjectRepository
+	activityRepo  *sqlite.ActivityRepository
+	tagRepo       *sqlite.TagRepository
+	projectRules  *ProjectRules
+}
+
+// NewActivityTracker creates an activity tracker on the given database
+func NewActivityTracker(db *sqlite.SQLiteDB) *ActivityTracker {
+	return &ActivityTracker{
+		db:            db,
+		userRepo:      sqlite.NewUserRepository(db.DB()),
+		sessionRepo:   sqlite.NewSessionRepository(db),
+		workBlockRepo: sqlite.NewWorkBlockRepository(db.DB()),
+		projectRepo:   sqlite.NewProjectRepository(db.DB()),
+		activityRepo:  sqlite.NewActivityRepository(db.DB()),
+		tagRepo:       sqlite.NewTagRepository(db.DB()),
+	}
+}
+
+// SetProjectRules applies path rules to later events; nil restores plain path detection
+func (t *ActivityTracker) SetProjectRules(rules *ProjectRules) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.projectRules = rules
+}
+
+/**
+ * CONTEXT:   Record one hook activity at the time it is received
+ * INPUT:     Activity event with user, project path and tool information
+ * OUTPUT:    Saved activity event with session, work block and project IDs,
+ *            or ErrPathIgnored when a project rule excludes the path
+ * BUSINESS:  Every event extends the active session and work block or starts new ones;
+ *            a branch switch closes the block so each block belongs to one branch.
+ *            The session is the user's usage window, while work blocks are per
+ *            Claude Code session so instances run side by side in parallel blocks
+ * CHANGE:    Expire ended sessions so past windows no longer read as active
+ * RISK:      Medium - Multi-table writes; a failure leaves earlier steps applied
+ */
+func (t *ActivityTracker) Record(ctx context.Context, event ActivityEvent) (*sqlite.Activity, error) {
+	if event.UserID == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+	if event.ProjectPath == "" {
+		return nil, fmt.Errorf("project path cannot be empty")
+	}
+	tags, err := sqlite.ParseTags(event.Tags...)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	resolution := t.projectRules.Resolve(event.ProjectPath)
+	if resolution.Ignored {
+		return nil, ErrPathIgnored
+	}
+
+	now := t.db.Now()
+
+	if err := t.userRepo.EnsureExists(ctx, event.UserID); err != nil {
+		return nil, err
+	}
+
+	// Close work blocks idle since the last event and sessions whose window
+	// ended before extending anything
+	if _, err := t.workBlockRepo.MarkIdleWorkBlocks(ctx, now); err != nil {
+		return nil, err
+	}
+	if _, err := t.sessionRepo.MarkExpiredSessions(ctx); err != nil {
+		return nil, err
+	}
+
+	session, err := t.currentSession(ctx, event.UserID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := t.resolveProject(ctx, resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	workBlock, err := t.currentWorkBlock(ctx, session.ID, project.ID, event.ClaudeSessionID, event.GitBranch, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) > 0 {
+		if _, err := t.tagRepo.TagWorkBlock(ctx, workBlock.ID, tags, sqlite.TagSourceHook); err != nil {
+			return nil, err
+		}
+	}
+
+	metadata := make(map[string]string, len(event.Metadata)+2)
+	for key, value := range event.Metadata {
+		metadata[key] = value
+	}
+	if event.HookEvent != "" {
+		metadata["hook_event"] = event.HookEvent
+	}
+	if event.ClaudeSessionID != "" {
+		metadata["claude_session_id"] = event.ClaudeSessionID
+	}
+
+	activity := &sqlite.Activity{
+		ID:             sqlite.NewEntityID("activity"),
+		UserID:         event.UserID,
+		SessionID:      session.ID,
+		WorkBlockID:    workBlock.ID,
+		ProjectID:      project.ID,
+		Timestamp:      now,
+		ActivityType:   ResolveActivityType(event.ActivityType, event.ToolName, event.HookEvent),
+		ActivitySource: "hook",
+		ToolName:       event.ToolName,
+		GitBranch:      event.GitBranch,
+		GitCommit:      event.GitCommit,
+		Command:        event.Command,
+		Description:    event.Description,
+		Metadata:       metadata,
+		CreatedAt:      now,
+	}
+
+	if err := t.activityRepo.SaveActivity(activity); err != nil {
+		return nil, err
+	}
+
+	return activity, nil
+}
+
+// resolveProject finds or creates the resolved project and syncs rule-owned labels
+func (t *ActivityTracker) resolveProject(ctx context.Context, resolution ProjectResolution) (*sqlite.Project, error) {
+	project, err := t.projectRepo.GetOrCreateNamed(ctx, resolution.ProjectPath, resolution.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if resolution.RuleIndex >= 0 && (project.Group != resolution.Group || !slices.Equal(project.Tags, resolution.Tags)) {
+		if err := t.projectRepo.SetLabels(ctx, project.ID, resolution.Group, resolution.Tags); err != nil {
+			return nil, err
+		}
+		project.Group = resolution.Group
+		project.Tags = resolution.Tags
+	}
+	return project, nil
+}
+
+// currentSession extends the user's active session or opens a new 5-hour window
+func (t *ActivityTracker) currentSession(ctx context.Context, userID string, now time.Time) (*sqlite.Session, error) {
+	sessions, err := t.sessionRepo.GetActiveSessionsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sessions) > 0 {
+		session := sessions[0]
+		session.LastActivityTime = now
+		session.ActivityCount++
+		if err := t.sessionRepo.Update(ctx, session); err != nil {
+			return nil, err
+		}
+		return session, nil
+	}
+
+	session := &sqlite.Session{
+		ID:                sqlite.NewEntityID("session"),
+		UserID:            userID,
+		StartTime:         now,
+		EndTime:           now.Add(sessionDuration),
+		State:             "active",
+		FirstActivityTime: now,
+		LastActivityTime:  now,
+		ActivityCount:     1,
+		DurationHours:     sessionDuration.Hours(),
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := t.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Session started", logging.KeySession, session.ID, "end_time", session.EndTime)
+	return session, nil
+}
+
+// currentWorkBlock extends the Claude Code instance's open work block for the project and
+// branch or starts a new one
+func (t *ActivityTracker) currentWorkBlock(ctx context.Context, sessionID, projectID, claudeSessionID, gitBranch string, now time.Time) (*sqlite.WorkBlock, error) {
+	workBlock, err := t.workBlockRepo.GetActiveByInstance(ctx, sessionID, projectID, claudeSessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A different branch ends the block; events without a branch keep extending it
+	if workBlock != nil && gitBranch != "" && workBlock.GitBranch != "" && workBlock.GitBranch != gitBranch {
+		if err := t.workBlockRepo.FinishWorkBlock(ctx, workBlock.ID, now); err != nil {
+			return nil, err
+		}
+		workBlock = nil
+	}
+
+	if workBlock != nil {
+		if err := t.workBlockRepo.RecordActivity(ctx, workBlock.ID, now); err != nil {
+			return nil, err
+		}
+		if workBlock.GitBranch == "" && gitBranch != "" {
+			if err := t.workBlockRepo.SetGitBranch(ctx, workBlock.ID, gitBranch); err != nil {
+				return nil, err
+			}
+			workBlock.GitBranch = gitBranch
+		}
+		// Blocks opened before the hook sent a session ID belong to the first instance seen
+		if workBlock.ClaudeSessionID == "" && claudeSessionID != "" {
+			if err := t.workBlockRepo.SetClaudeSessionID(ctx, workBlock.ID, claudeSessionID); err != nil {
+				return nil, err
+			}
+			workBlock.ClaudeSessionID = claudeSessionID
+		}
+		return workBlock, nil
+	}
+
+	workBlock = &sqlite.WorkBlock{
+		ID:               sqlite.NewEntityID("block"),
+		SessionID:        sessionID,
+		ProjectID:        projectID,
+		StartTime:        now,
+		State:            "active",
+		LastActivityTime: now,
+		ActivityCount:    1,
+		GitBranch:        gitBranch,
+		ClaudeSessionID:  claudeSessionID,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if err := t.workBlockRepo.Create(ctx, workBlock); err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Work block started",
+		logging.KeySession, sessionID,
+		logging.KeyWorkBlock, workBlock.ID,
+		logging.KeyProject, projectID,
+		"git_branch", gitBranch,
+		"claude_session_id", claudeSessionID)
+	return workBlock, nil
+}
@@ -0,0 +1,146 @@
+/**
+ * CONTEXT:   Test suite for hook activity ingestion
+ * INPUT:     Hook events recorded against a temporary SQLite database
+ * OUTPUT:    Validation of session, work block and tool attribution
+ * BUSINESS:  Tool breakdowns in reports depend on correctly recorded events
+ * CHANGE:    Initial tracker and activity type tests
+ * RISK:      Low - Test-only database in a temp directory
+ */
+
+package tracking
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+)
+
+func TestResolveActivityType(t *testing.T) {
+	tests := []struct {
+		activityType string
+		toolName     string
+		hookEvent    string
+		expected     string
+	}{
+		{"", "Bash", "PostToolUse", ActivityTypeCommand},
+		{"", "Edit", "PostToolUse", ActivityTypeFileEdit},
+		{"", "Write", "PostToolUse", ActivityTypeFileEdit},
+		{"", "Read", "PostToolUse", ActivityTypeFileRead},
+		{"", "Grep", "PostToolUse", ActivityTypeSearch},
+		{"", "WebFetch", "PostToolUse", ActivityTypeNavigation},
+		{"", "Task", "PostToolUse", ActivityTypeGeneration},
+		{"", "mcp__github__create_issue", "PostToolUse", ActivityTypeOther},
+		{"", "", HookEventUserPromptSubmit, ActivityTypeGeneration},
+		{"", "", "Stop", ActivityTypeOther},
+		{ActivityTypeSearch, "Bash", "PostToolUse", ActivityTypeSearch},
+		{"pre-request", "Read", "", ActivityTypeFileRead},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, ResolveActivityType(tt.activityType, tt.toolName, tt.hookEvent),
+			"type=%q tool=%q event=%q", tt.activityType, tt.toolName, tt.hookEvent)
+	}
+}
+
+func TestActivityTrackerRecord(t *testing.T) {
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(t.TempDir(), "tracker.db")))
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	tracker := NewActivityTracker(db)
+
+	first, err := tracker.Record(ctx, ActivityEvent{
+		UserID:          "alice",
+		ProjectPath:     "/home/alice/code/api",
+		HookEvent:       "PostToolUse",
+		ToolName:        "Edit",
+		ClaudeSessionID: "claude-123",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ActivityTypeFileEdit, first.ActivityType)
+	assert.Equal(t, "claude-123", first.Metadata["claude_session_id"])
+
+	second, err := tracker.Record(ctx, ActivityEvent{
+		UserID:      "alice",
+		ProjectPath: "/home/alice/code/api",
+		HookEvent:   "PostToolUse",
+		ToolName:    "Bash",
+		Command:     "go test ./...",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, first.SessionID, second.SessionID)
+	assert.Equal(t, first.WorkBlockID, second.WorkBlockID)
+
+	other, err := tracker.Record(ctx, ActivityEvent{
+		UserID:      "alice",
+		ProjectPath: "/home/alice/code/web",
+		HookEvent:   HookEventUserPromptSubmit,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, first.SessionID, other.SessionID)
+	assert.NotEqual(t, first.WorkBlockID, other.WorkBlockID)
+	assert.Equal(t, ActivityTypeGeneration, other.ActivityType)
+
+	activities, err := sqlite.NewActivityRepository(db.DB()).GetUserActivitiesByTimeRange(
+		ctx, "alice", time.Now().Add(-time.Hour).In(db.Timezone()), time.Now().Add(time.Hour).In(db.Timezone()))
+	require.NoError(t, err)
+	require.Len(t, activities, 3)
+	assert.Equal(t, "Edit", activities[0].ToolName)
+	assert.Equal(t, "Bash", activities[1].ToolName)
+	assert.Equal(t, "go test ./...", activities[1].Command)
+	assert.Equal(t, "", activities[2].ToolName)
+
+	_, err = tracker.Record(ctx, ActivityEvent{UserID: "alice"})
+	assert.Error(t, err)
+}
+
+func TestActivityTrackerClosesIdleBlocksAndExpiredSessions(t *testing.T) {
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(t.TempDir(), "tracker.db")))
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	tracker := NewActivityTracker(db)
+	record := func() *sqlite.Activity {
+		activity, err := tracker.Record(ctx, ActivityEvent{UserID: "alice", ProjectPath: "/work/api", ToolName: "Edit"})
+		require.NoError(t, err)
+		return activity
+	}
+
+	// The window closed 10 minutes ago, 2 minutes after the block's last event
+	first := record()
+	now := db.Now()
+	sessionEnd := now.Add(-10 * time.Minute)
+	lastEvent := now.Add(-12 * time.Minute)
+	_, err = db.DB().ExecContext(ctx, `
+		UPDATE sessions SET start_time = ?, end_time = ?, first_activity_time = ?, last_activity_time = ?
+		WHERE id = ?`, sessionEnd.Add(-sessionDuration), sessionEnd, now.Add(-time.Hour), lastEvent, first.SessionID)
+	require.NoError(t, err)
+	_, err = db.DB().ExecContext(ctx, `UPDATE work_blocks SET start_time = ?, last_activity_time = ? WHERE id = ?`,
+		now.Add(-time.Hour), lastEvent, first.WorkBlockID)
+	require.NoError(t, err)
+
+	next := record()
+	assert.NotEqual(t, first.SessionID, next.SessionID)
+
+	block, err := sqlite.NewWorkBlockRepository(db.DB()).GetByID(ctx, first.WorkBlockID)
+	require.NoError(t, err)
+	require.NotNil(t, block.EndTime)
+	assert.True(t, block.EndTime.Equal(sessionEnd), "idle end is clamped to the session end, got %v", block.EndTime)
+
+	var rawEnd string
+	require.NoError(t, db.DB().QueryRowContext(ctx, `SELECT CAST(end_time AS TEXT) FROM work_blocks WHERE id = ?`, first.WorkBlockID).Scan(&rawEnd))
+	assert.True(t, strings.HasSuffix(rawEnd, sessionEnd.Format("-07:00")), "end time keeps the database offset: %s", rawEnd)
+
+	var state string
+	require.NoError(t, db.DB().QueryRowContext(ctx, `SELECT state FROM sessions WHERE id = ?`, first.SessionID).Scan(&state))
+	assert.Equal(t, "expired", state)
+}
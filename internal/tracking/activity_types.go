@@ -0,0 +1,82 @@
+/**
+ * CONTEXT:   Activity type classification for Claude Code hook events
+ * INPUT:     Tool names and hook event names reported by Claude Code
+ * OUTPUT:    activity_events.activity_type values
+ * BUSINESS:  Activity types group tools into edits, reads, commands and searches
+ * CHANGE:    Initial tool-to-activity-type mapping
+ * RISK:      Low - Pure mapping with "other" fallback
+ */
+
+package tracking
+
+// Activity types accepted by the activity_events table
+const (
+	ActivityTypeCommand    = "command"
+	ActivityTypeFileEdit   = "file_edit"
+	ActivityTypeFileRead   = "file_read"
+	ActivityTypeNavigation = "navigation"
+	ActivityTypeSearch     = "search"
+	ActivityTypeGeneration = "generation"
+	ActivityTypeOther      = "other"
+)
+
+// HookEventUserPromptSubmit is the hook event sent when the user submits a prompt
+const HookEventUserPromptSubmit = "UserPromptSubmit"
+
+// toolActivityTypes maps Claude Code tool names to activity types
+var toolActivityTypes = map[string]string{
+	"Bash":         ActivityTypeCommand,
+	"BashOutput":   ActivityTypeCommand,
+	"KillShell":    ActivityTypeCommand,
+	"Edit":         ActivityTypeFileEdit,
+	"MultiEdit":    ActivityTypeFileEdit,
+	"Write":        ActivityTypeFileEdit,
+	"NotebookEdit": ActivityTypeFileEdit,
+	"Read":         ActivityTypeFileRead,
+	"NotebookRead": ActivityTypeFileRead,
+	"Grep":         ActivityTypeSearch,
+	"Glob":         ActivityTypeSearch,
+	"LS":           ActivityTypeSearch,
+	"WebSearch":    ActivityTypeSearch,
+	"WebFetch":     ActivityTypeNavigation,
+	"Task":         ActivityTypeGeneration,
+}
+
+// ActivityTypeForTool returns the activity type for a Claude Code tool name
+func ActivityTypeForTool(toolName string) string {
+	if activityType, ok := toolActivityTypes[toolName]; ok {
+		return activityType
+	}
+	return ActivityTypeOther
+}
+
+// IsValidActivityType reports whether the activity_events table accepts the type
+func IsValidActivityType(activityType string) bool {
+	switch activityType {
+	case ActivityTypeCommand, ActivityTypeFileEdit, ActivityTypeFileRead, ActivityTypeNavigation,
+		ActivityTypeSearch, ActivityTypeGeneration, ActivityTypeOther:
+		return true
+	}
+	return false
+}
+
+/**
+ * CONTEXT:   Resolve the activity type for an incoming hook event
+ * INPUT:     Explicit activity type, tool name and hook event name
+ * OUTPUT:    Activity type stored on the event
+ * BUSINESS:  Tool name wins over hook event so breakdowns follow what Claude did
+ * CHANGE:    Initial activity type resolution
+ * RISK:      Low - Unknown input falls back to "other"
+ */
+func ResolveActivityType(activityType, toolName, hookEvent string) string {
+	if IsValidActivityType(activityType) {
+		return activityType
+	}
+	if toolName != "" {
+		return ActivityTypeForTool(toolName)
+	}
+	if hookEvent == HookEventUserPromptSubmit {
+		return ActivityTypeGeneration
+	}
+	return ActivityTypeOther
+}
@@ -0,0 +1,164 @@
+/**
+ * CONTEXT:   Git branch and commit detection from repository files
+ * INPUT:     Working directory reported by the Claude Code hook
+ * OUTPUT:    Current branch name and HEAD commit of the enclosing repository
+ * BUSINESS:  Branch correlation answers "how long did feature/x take" per work block
+ * CHANGE:    Initial .git reader for worktrees, submodules and packed refs
+ * RISK:      Low - Read-only file access; never shells out to git
+ */
+
+package tracking
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxGitHeadSize bounds the HEAD, ref and gitdir files read per hook call
+const maxGitHeadSize = 4096
+
+// errNoGitRepository reports a directory outside any git repository
+var errNoGitRepository = errors.New("not inside a git repository")
+
+/**
+ * CONTEXT:   Git position of a working directory
+ * INPUT:     No input - data structure definition
+ * OUTPUT:    Branch (empty when detached) and full HEAD commit hash (empty before the first commit)
+ * BUSINESS:  Stored on activity events and used to split work blocks by branch
+ * CHANGE:    Initial git info structure
+ * RISK:      Low - Data structure with JSON serialization support
+ */
+type GitInfo struct {
+	Branch string `json:"branch,omitempty"`
+	Commit string `json:"commit,omitempty"`
+}
+
+/**
+ * CONTEXT:   Read the branch and HEAD commit for a working directory
+ * INPUT:     Any directory inside a repository, worktree or submodule
+ * OUTPUT:    Git info, or an error when no repository encloses the directory
+ * BUSINESS:  Runs on every hook call, so it reads a few small files instead of running git
+ * CHANGE:    Initial HEAD resolution through loose refs and packed-refs
+ * RISK:      Low - Unresolvable refs leave the commit empty rather than failing
+ */
+func ReadGitInfo(dir string) (GitInfo, error) {
+	gitDir, err := findGitDir(dir)
+	if err != nil {
+		return GitInfo{}, err
+	}
+
+	head, err := readGitFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return GitInfo{}, err
+	}
+
+	ref, symbolic := strings.CutPrefix(head, "ref:")
+	if !symbolic {
+		return GitInfo{Commit: head}, nil // detached HEAD
+	}
+
+	ref = strings.TrimSpace(ref)
+	info := GitInfo{Branch: strings.TrimPrefix(ref, "refs/heads/")}
+	info.Commit = resolveGitRef(gitDir, ref)
+	return info, nil
+}
+
+// findGitDir walks up from dir to the repository's git directory, following gitdir: files
+func findGitDir(dir string) (string, error) {
+	current, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		candidate := filepath.Join(current, ".git")
+		if info, err := os.Stat(candidate); err == nil {
+			if info.IsDir() {
+				return candidate, nil
+			}
+			// Worktrees and submodules use a .git file pointing at the real git directory
+			content, err := readGitFile(candidate)
+			if err != nil {
+				return "", err
+			}
+			target, ok := strings.CutPrefix(content, "gitdir:")
+			if !ok {
+				return "", errNoGitRepository
+			}
+			target = strings.TrimSpace(target)
+			if !filepath.IsAbs(target) {
+				target = filepath.Join(current, target)
+			}
+			return filepath.Clean(target), nil
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", errNoGitRepository
+		}
+		current = parent
+	}
+}
+
+// resolveGitRef looks a ref up as a loose file, then in packed-refs, in the git and common dirs
+func resolveGitRef(gitDir, ref string) string {
+	dirs := []string{gitDir}
+	if common, err := readGitFile(filepath.Join(gitDir, "commondir")); err == nil {
+		if !filepath.IsAbs(common) {
+			common = filepath.Join(gitDir, common)
+		}
+		dirs = append(dirs, filepath.Clean(common))
+	}
+
+	for _, dir := range dirs {
+		if commit, err := readGitFile(filepath.Join(dir, filepath.FromSlash(ref))); err == nil {
+			return commit
+		}
+	}
+	for _, dir := range dirs {
+		if commit := lookupPackedRef(filepath.Join(dir, "packed-refs"), ref); commit != "" {
+			return commit
+		}
+	}
+	return ""
+}
+
+// lookupPackedRef finds a ref in a packed-refs file
+func lookupPackedRef(path, ref string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || line[0] == '#' || line[0] == '^' {
+			continue
+		}
+		if commit, name, ok := strings.Cut(line, " "); ok && name == ref {
+			return commit
+		}
+	}
+	return ""
+}
+
+// readGitFile reads a small git metadata file and trims surrounding whitespace
+func readGitFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	buf := make([]byte, maxGitHeadSize)
+	n, err := file.Read(buf)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(buf[:n])), nil
+}
@@ -0,0 +1,126 @@
+/**
+ * CONTEXT:   Test suite for git branch detection and branch-split work blocks
+ * INPUT:     Synthetic .git layouts and hook events with branches
+ * OUTPUT:    Validation of HEAD resolution and work block splitting by branch
+ * BUSINESS:  Branch correlation must work without a git binary
+ * CHANGE:    Initial git info tests
+ * RISK:      Low - Temporary directories only
+ */
+
+package tracking
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+)
+
+const (
+	testCommitA = "1111111111111111111111111111111111111111"
+	testCommitB = "2222222222222222222222222222222222222222"
+)
+
+// writeTestFile creates parent directories and writes content
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestReadGitInfo(t *testing.T) {
+	root := t.TempDir()
+
+	repo := filepath.Join(root, "repo")
+	writeTestFile(t, filepath.Join(repo, ".git", "HEAD"), "ref: refs/heads/feature/login\n")
+	writeTestFile(t, filepath.Join(repo, ".git", "refs", "heads", "feature", "login"), testCommitA+"\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "src", "pkg"), 0755))
+
+	packed := filepath.Join(root, "packed")
+	writeTestFile(t, filepath.Join(packed, ".git", "HEAD"), "ref: refs/heads/main\n")
+	writeTestFile(t, filepath.Join(packed, ".git", "packed-refs"),
+		"# pack-refs with: peeled fully-peeled sorted\n"+testCommitB+" refs/heads/main\n^"+testCommitA+"\n")
+
+	detached := filepath.Join(root, "detached")
+	writeTestFile(t, filepath.Join(detached, ".git", "HEAD"), testCommitB+"\n")
+
+	worktree := filepath.Join(root, "worktree")
+	worktreeGitDir := filepath.Join(repo, ".git", "worktrees", "wt")
+	writeTestFile(t, filepath.Join(worktree, ".git"), "gitdir: "+worktreeGitDir+"\n")
+	writeTestFile(t, filepath.Join(worktreeGitDir, "HEAD"), "ref: refs/heads/feature/login\n")
+	writeTestFile(t, filepath.Join(worktreeGitDir, "commondir"), "../..\n")
+
+	unborn := filepath.Join(root, "unborn")
+	writeTestFile(t, filepath.Join(unborn, ".git", "HEAD"), "ref: refs/heads/main\n")
+
+	tests := []struct {
+		dir  string
+		want GitInfo
+	}{
+		{filepath.Join(repo, "src", "pkg"), GitInfo{Branch: "feature/login", Commit: testCommitA}},
+		{packed, GitInfo{Branch: "main", Commit: testCommitB}},
+		{detached, GitInfo{Commit: testCommitB}},
+		{worktree, GitInfo{Branch: "feature/login", Commit: testCommitA}},
+		{unborn, GitInfo{Branch: "main"}},
+	}
+	for _, tt := range tests {
+		got, err := ReadGitInfo(tt.dir)
+		require.NoError(t, err, tt.dir)
+		assert.Equal(t, tt.want, got, tt.dir)
+	}
+
+	outside := filepath.Join(root, "plain")
+	require.NoError(t, os.MkdirAll(outside, 0755))
+	_, err := ReadGitInfo(outside)
+	if err == nil {
+		t.Skip("temporary directory is inside a git repository")
+	}
+	assert.ErrorIs(t, err, errNoGitRepository)
+}
+
+func TestActivityTrackerSplitsWorkBlocksByBranch(t *testing.T) {
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(t.TempDir(), "branches.db")))
+	require.NoError(t, err)
+	defer db.Close()
+
+	tracker := NewActivityTracker(db)
+	ctx := context.Background()
+	record := func(branch string) *sqlite.Activity {
+		activity, err := tracker.Record(ctx, ActivityEvent{
+			UserID: "alice", ProjectPath: "/work/api", ToolName: "Edit", GitBranch: branch, GitCommit: testCommitA,
+		})
+		require.NoError(t, err)
+		return activity
+	}
+
+	untagged := record("")
+	tagged := record("main")
+	assert.Equal(t, untagged.WorkBlockID, tagged.WorkBlockID, "first branch tags the open block")
+
+	unknown := record("")
+	assert.Equal(t, tagged.WorkBlockID, unknown.WorkBlockID, "events without a branch extend the block")
+
+	switched := record("feature/login")
+	assert.NotEqual(t, tagged.WorkBlockID, switched.WorkBlockID, "branch switch starts a new block")
+
+	workBlockRepo := sqlite.NewWorkBlockRepository(db.DB())
+	first, err := workBlockRepo.GetByID(ctx, tagged.WorkBlockID)
+	require.NoError(t, err)
+	assert.Equal(t, "main", first.GitBranch)
+	assert.NotNil(t, first.EndTime, "previous branch block is finished")
+
+	second, err := workBlockRepo.GetByID(ctx, switched.WorkBlockID)
+	require.NoError(t, err)
+	assert.Equal(t, "feature/login", second.GitBranch)
+
+	activities, err := sqlite.NewActivityRepository(db.DB()).GetActivitiesByWorkBlock(switched.WorkBlockID)
+	require.NoError(t, err)
+	require.Len(t, activities, 1)
+	assert.Equal(t, "feature/login", activities[0].GitBranch)
+	assert.Equal(t, testCommitA, activities[0].GitCommit)
+}
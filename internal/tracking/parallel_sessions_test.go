@@ -0,0 +1,93 @@
+/**
+ * CONTEXT:   Test suite for parallel Claude Code instances in one usage window
+ * INPUT:     Hook events from two Claude Code sessions against a temp database
+ * OUTPUT:    Validation of per-instance work blocks and summed versus wall-clock hours
+ * BUSINESS:  Two instances running side by side are both billable work, but the
+ *            day only had so many hours
+ * CHANGE:    Initial parallel session tests
+ * RISK:      Low - Temp database per test
+ */
+
+package tracking
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/claude-monitor/system/internal/database/sqlite"
+	"github.com/claude-monitor/system/internal/reporting"
+)
+
+func TestParallelClaudeSessions(t *testing.T) {
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(t.TempDir(), "parallel.db")))
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	tracker := NewActivityTracker(db)
+	record := func(claudeSessionID string) *sqlite.Activity {
+		activity, err := tracker.Record(ctx, ActivityEvent{
+			UserID:          "alice",
+			ProjectPath:     "/work/api",
+			ToolName:        "Edit",
+			ClaudeSessionID: claudeSessionID,
+		})
+		require.NoError(t, err)
+		return activity
+	}
+
+	first := record("claude-a")
+	second := record("claude-b")
+	again := record("claude-a")
+	unkeyed := record("")
+
+	assert.Equal(t, first.SessionID, second.SessionID, "instances share the usage window")
+	assert.NotEqual(t, first.WorkBlockID, second.WorkBlockID, "each instance keeps its own block")
+	assert.Equal(t, first.WorkBlockID, again.WorkBlockID)
+	assert.Equal(t, first.WorkBlockID, unkeyed.WorkBlockID, "events without a session ID join the latest block")
+
+	blocks := sqlite.NewWorkBlockRepository(db.DB())
+	block, err := blocks.GetByID(ctx, second.WorkBlockID)
+	require.NoError(t, err)
+	assert.Equal(t, "claude-b", block.ClaudeSessionID)
+
+	doctor, err := sqlite.NewDoctor(db).Run(ctx, false)
+	require.NoError(t, err)
+	assert.Zero(t, doctor.Problems(), "parallel instances are not overlaps")
+
+	// Pin the session and both blocks to a fixed midday so the report never
+	// straddles midnight: a 2h block and a 1h30m block overlapping it by an hour
+	day := time.Date(2026, 3, 4, 0, 0, 0, 0, time.Local)
+	at := func(hour, minute int) time.Time {
+		return db.ToDBTime(day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute))
+	}
+	_, err = db.DB().ExecContext(ctx, `
+		UPDATE sessions SET start_time = ?, end_time = ?, first_activity_time = ?, last_activity_time = ?
+		WHERE id = ?`, at(10, 0), at(15, 0), at(10, 0), at(12, 30), first.SessionID)
+	require.NoError(t, err)
+	pin := func(id string, start, end time.Time) {
+		_, err := db.DB().ExecContext(ctx, `
+			UPDATE work_blocks SET start_time = ?, end_time = ?, last_activity_time = ?, state = 'finished'
+			WHERE id = ?`, start, end, end, id)
+		require.NoError(t, err)
+	}
+	pin(first.WorkBlockID, at(10, 0), at(12, 0))
+	pin(second.WorkBlockID, at(11, 0), at(12, 30))
+
+	service := reporting.NewSQLiteReportingService(
+		sqlite.NewSessionRepository(db),
+		blocks,
+		sqlite.NewActivityRepository(db.DB()),
+		sqlite.NewProjectRepository(db.DB()),
+	)
+	report, err := service.GenerateDailyReport(ctx, "alice", day)
+	require.NoError(t, err)
+	assert.InDelta(t, 3.5, report.TotalWorkHours, 0.01, "per-instance time is summed")
+	assert.InDelta(t, 2.5, report.WallClockHours, 0.01, "the overlapping hour counts once")
+	assert.Equal(t, 2, report.ClaudeInstances)
+}
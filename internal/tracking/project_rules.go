@@ -0,0 +1,230 @@
+/**
+ * CONTEXT:   Path-pattern rules for project detection and naming at ingestion time
+ * INPUT:     Ordered glob/regex rules and custom names from configuration
+ * OUTPUT:    Project root, name, group and tags for a working directory, or an ignore decision
+ * BUSINESS:  Users control how directories map to projects instead of fixed heuristics
+ * CHANGE:    Initial rule engine used by the activity tracker and `project resolve`
+ * RISK:      Medium - Wrong rules attribute time to the wrong project; dry-run available
+ */
+
+package tracking
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	cfg "github.com/claude-monitor/system/internal/config"
+)
+
+/**
+ * CONTEXT:   Outcome of resolving one path against the project rules
+ * INPUT:     No input - data structure definition
+ * OUTPUT:    Project root and labels, the matching rule, or an ignore decision
+ * BUSINESS:  Same structure drives ingestion and the `project resolve` dry-run
+ * CHANGE:    Initial resolution result
+ * RISK:      Low - Data structure with JSON serialization support
+ */
+type ProjectResolution struct {
+	Path        string   `json:"path"`
+	ProjectPath string   `json:"project_path"`
+	Name        string   `json:"name,omitempty"`
+	Group       string   `json:"group,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Ignored     bool     `json:"ignored"`
+	Rule        string   `json:"rule,omitempty"`
+	RuleIndex   int      `json:"rule_index"`
+}
+
+// projectRule is a compiled configuration rule
+type projectRule struct {
+	config  cfg.ProjectRuleConfig
+	label   string
+	pattern *regexp.Regexp
+	isRegex bool
+}
+
+/**
+ * CONTEXT:   Ordered, compiled project rules
+ * INPUT:     Rules from configuration followed by custom names
+ * OUTPUT:    First-match-wins path resolution
+ * BUSINESS:  Rule order is the user's priority order
+ * CHANGE:    Initial rule set
+ * RISK:      Low - Immutable after construction, safe for concurrent use
+ */
+type ProjectRules struct {
+	rules []projectRule
+}
+
+/**
+ * CONTEXT:   Compile project rules from configuration
+ * INPUT:     Ordered rules and custom names keyed by path
+ * OUTPUT:    Compiled rules or error naming the invalid rule
+ * BUSINESS:  Custom names are exact path rules evaluated after explicit rules
+ * CHANGE:    Initial rule compilation
+ * RISK:      Low - Invalid patterns are rejected up front
+ */
+func NewProjectRules(rules []cfg.ProjectRuleConfig, customNames map[string]string) (*ProjectRules, error) {
+	compiled := &ProjectRules{}
+
+	for i, rule := range rules {
+		entry, err := compileProjectRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("project rule %d: %w", i+1, err)
+		}
+		compiled.rules = append(compiled.rules, entry)
+	}
+
+	// Longest paths first so a custom name for a subdirectory beats its parent
+	paths := make([]string, 0, len(customNames))
+	for path := range customNames {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if len(paths[i]) != len(paths[j]) {
+			return len(paths[i]) > len(paths[j])
+		}
+		return paths[i] < paths[j]
+	})
+	for _, path := range paths {
+		entry, err := compileProjectRule(cfg.ProjectRuleConfig{Match: path, Name: customNames[path]})
+		if err != nil {
+			return nil, fmt.Errorf("custom name %q: %w", path, err)
+		}
+		entry.label = "custom_names: " + path
+		compiled.rules = append(compiled.rules, entry)
+	}
+
+	return compiled, nil
+}
+
+// compileProjectRule validates one rule and compiles its pattern
+func compileProjectRule(rule cfg.ProjectRuleConfig) (projectRule, error) {
+	switch {
+	case rule.Match != "" && rule.Regex != "":
+		return projectRule{}, fmt.Errorf("set either match or regex, not both")
+	case rule.Regex != "":
+		pattern, err := regexp.Compile(rule.Regex)
+		if err != nil {
+			return projectRule{}, fmt.Errorf("invalid regex %q: %w", rule.Regex, err)
+		}
+		return projectRule{config: rule, label: "regex: " + rule.Regex, pattern: pattern, isRegex: true}, nil
+	case rule.Match != "":
+		pattern, err := regexp.Compile(globToRegexp(normalizeRulePath(rule.Match)))
+		if err != nil {
+			return projectRule{}, fmt.Errorf("invalid match %q: %w", rule.Match, err)
+		}
+		return projectRule{config: rule, label: "match: " + rule.Match, pattern: pattern}, nil
+	default:
+		return projectRule{}, fmt.Errorf("match or regex is required")
+	}
+}
+
+/**
+ * CONTEXT:   Resolve a working directory to a project
+ * INPUT:     Working directory path from the hook
+ * OUTPUT:    Resolution from the first matching rule, or the path itself when none match
+ * BUSINESS:  Glob rules match the shortest ancestor, so ~/code/* turns ~/code/api/src
+ *            into project ~/code/api; regex rules use the matched prefix and expand
+ *            $1 or ${name} in name and group
+ * CHANGE:    Initial resolution
+ * RISK:      Low - Pure function of the path and rules
+ */
+func (pr *ProjectRules) Resolve(path string) ProjectResolution {
+	normalized := normalizeRulePath(path)
+	resolution := ProjectResolution{Path: path, ProjectPath: normalized, RuleIndex: -1}
+	if pr == nil {
+		return resolution
+	}
+
+	for i, rule := range pr.rules {
+		root, name, group, ok := rule.apply(normalized)
+		if !ok {
+			continue
+		}
+		resolution.ProjectPath = root
+		resolution.Name = name
+		resolution.Group = group
+		resolution.Tags = rule.config.Tags
+		resolution.Ignored = rule.config.Ignore
+		resolution.Rule = rule.label
+		resolution.RuleIndex = i
+		return resolution
+	}
+	return resolution
+}
+
+// apply matches the rule against a normalized path and expands its labels
+func (r projectRule) apply(path string) (root, name, group string, ok bool) {
+	if r.isRegex {
+		match := r.pattern.FindStringSubmatchIndex(path)
+		if match == nil {
+			return "", "", "", false
+		}
+		root = strings.TrimSuffix(path[:match[1]], "/")
+		if root == "" {
+			root = "/"
+		}
+		name = string(r.pattern.ExpandString(nil, r.config.Name, path, match))
+		group = string(r.pattern.ExpandString(nil, r.config.Group, path, match))
+		return root, name, group, true
+	}
+
+	for _, ancestor := range ancestorsShortestFirst(path) {
+		if r.pattern.MatchString(ancestor) {
+			return ancestor, r.config.Name, r.config.Group, true
+		}
+	}
+	return "", "", "", false
+}
+
+// ancestorsShortestFirst lists a path and its parents starting at the root
+func ancestorsShortestFirst(path string) []string {
+	var ancestors []string
+	for current := path; ; current = filepath.ToSlash(filepath.Dir(current)) {
+		ancestors = append(ancestors, current)
+		if parent := filepath.ToSlash(filepath.Dir(current)); parent == current {
+			break
+		}
+	}
+	for i, j := 0, len(ancestors)-1; i < j; i, j = i+1, j-1 {
+		ancestors[i], ancestors[j] = ancestors[j], ancestors[i]
+	}
+	return ancestors
+}
+
+// normalizeRulePath expands ~ and cleans a path the same way projects store paths
+func normalizeRulePath(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[1:])
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+// globToRegexp converts a glob with *, ** and ? into an anchored regular expression
+func globToRegexp(glob string) string {
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				pattern.WriteString(".*")
+				i++
+			} else {
+				pattern.WriteString("[^/]*")
+			}
+		case '?':
+			pattern.WriteString("[^/]")
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	pattern.WriteString("$")
+	return pattern.String()
+}
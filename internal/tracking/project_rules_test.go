@@ -0,0 +1,150 @@
+/**
+ * CONTEXT:   Test suite for path-pattern project rules
+ * INPUT:     Rule configurations and working directory paths
+ * OUTPUT:    Validation of matching order, project roots, labels, ignores and hierarchy
+ * BUSINESS:  Rules decide which project receives tracked time
+ * CHANGE:    Hierarchy test expects merges into a sub-project to be rejected
+ * RISK:      Low - Pure function tests
+ */
+
+package tracking
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/claude-monitor/system/internal/config"
+	"github.com/claude-monitor/system/internal/database/sqlite"
+)
+
+func TestProjectRulesResolve(t *testing.T) {
+	rules, err := NewProjectRules([]cfg.ProjectRuleConfig{
+		{Match: "/tmp/**", Ignore: true},
+		{Match: "/work/clients/acme/*", Group: "Acme", Tags: []string{"billable"}},
+		{Regex: `^/srv/(?P<team>[^/]+)/(?P<repo>[^/]+)`, Name: "${repo}", Group: "${team}"},
+		{Match: "/work/**", Name: "Work"},
+	}, map[string]string{
+		"/home/dev/notes":      "Notes",
+		"/home/dev/notes/blog": "Blog",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path        string
+		projectPath string
+		name        string
+		group       string
+		ignored     bool
+		ruleIndex   int
+	}{
+		{"/tmp/scratch/a", "/tmp/scratch", "", "", true, 0},
+		{"/work/clients/acme/portal/src", "/work/clients/acme/portal", "", "Acme", false, 1},
+		{"/srv/payments/api/cmd/server", "/srv/payments/api", "api", "payments", false, 2},
+		{"/work/clients/globex/site", "/work/clients", "Work", "", false, 3},
+		{"/home/dev/notes/blog/posts", "/home/dev/notes/blog", "Blog", "", false, 4},
+		{"/home/dev/notes/todo", "/home/dev/notes", "Notes", "", false, 5},
+		{"/home/dev/code/api/", "/home/dev/code/api", "", "", false, -1},
+	}
+
+	for _, tt := range tests {
+		resolution := rules.Resolve(tt.path)
+		assert.Equal(t, tt.projectPath, resolution.ProjectPath, tt.path)
+		assert.Equal(t, tt.name, resolution.Name, tt.path)
+		assert.Equal(t, tt.group, resolution.Group, tt.path)
+		assert.Equal(t, tt.ignored, resolution.Ignored, tt.path)
+		assert.Equal(t, tt.ruleIndex, resolution.RuleIndex, tt.path)
+	}
+
+	assert.Equal(t, []string{"billable"}, rules.Resolve("/work/clients/acme/portal").Tags)
+}
+
+func TestProjectRulesInvalid(t *testing.T) {
+	_, err := NewProjectRules([]cfg.ProjectRuleConfig{{Regex: "(unclosed"}}, nil)
+	assert.Error(t, err)
+
+	_, err = NewProjectRules([]cfg.ProjectRuleConfig{{Match: "/a", Regex: "^/a"}}, nil)
+	assert.Error(t, err)
+
+	_, err = NewProjectRules([]cfg.ProjectRuleConfig{{Name: "No pattern"}}, nil)
+	assert.Error(t, err)
+}
+
+func TestActivityTrackerProjectRules(t *testing.T) {
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(t.TempDir(), "rules.db")))
+	require.NoError(t, err)
+	defer db.Close()
+
+	rules, err := NewProjectRules([]cfg.ProjectRuleConfig{
+		{Match: "/tmp/**", Ignore: true},
+		{Match: "/work/*", Name: "Shared Name", Group: "Clients", Tags: []string{"billable", "acme"}},
+	}, nil)
+	require.NoError(t, err)
+
+	tracker := NewActivityTracker(db)
+	tracker.SetProjectRules(rules)
+	ctx := context.Background()
+
+	_, err = tracker.Record(ctx, ActivityEvent{UserID: "alice", ProjectPath: "/tmp/scratch"})
+	assert.ErrorIs(t, err, ErrPathIgnored)
+
+	first, err := tracker.Record(ctx, ActivityEvent{UserID: "alice", ProjectPath: "/work/api/src", ToolName: "Edit"})
+	require.NoError(t, err)
+	second, err := tracker.Record(ctx, ActivityEvent{UserID: "alice", ProjectPath: "/work/web", ToolName: "Edit"})
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ProjectID, second.ProjectID, "projects sharing a rule name must stay distinct")
+
+	project, err := sqlite.NewProjectRepository(db.DB()).GetByID(ctx, first.ProjectID)
+	require.NoError(t, err)
+	assert.Equal(t, "/work/api", project.Path)
+	assert.Equal(t, "Shared Name", project.Name)
+	assert.Equal(t, "Clients", project.Group)
+	assert.Equal(t, []string{"billable", "acme"}, project.Tags)
+}
+
+func TestProjectHierarchy(t *testing.T) {
+	db, err := sqlite.NewSQLiteDB(sqlite.DefaultConnectionConfig(filepath.Join(t.TempDir(), "hierarchy.db")))
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(db.DB())
+	create := func(path string) *sqlite.Project {
+		project, err := projectRepo.GetOrCreate(ctx, path)
+		require.NoError(t, err)
+		return project
+	}
+
+	product := create("/work/acme/portal")
+	api := create("/work/acme/portal/api")
+	web := create("/work/acme/portal/web")
+	require.NoError(t, projectRepo.SetParent(ctx, api.ID, product.ID))
+	require.NoError(t, projectRepo.SetParent(ctx, web.ID, product.ID))
+	require.NoError(t, projectRepo.SetClient(ctx, product.ID, "Acme"))
+
+	assert.Error(t, projectRepo.SetParent(ctx, product.ID, product.ID), "self parent")
+	assert.Error(t, projectRepo.SetParent(ctx, product.ID, api.ID), "cycle through child")
+
+	lineage, err := projectRepo.GetAncestors(ctx, api.ID)
+	require.NoError(t, err)
+	require.Len(t, lineage, 2)
+	assert.Equal(t, product.ID, lineage[1].ID)
+	assert.Equal(t, "Acme", lineage[1].Client)
+
+	subtree, err := projectRepo.GetSubtreeIDs(ctx, product.ID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{product.ID, api.ID, web.ID}, subtree)
+
+	_, err = projectRepo.Merge(ctx, product.ID, web.ID)
+	assert.Error(t, err, "a project cannot be merged into its own sub-project")
+
+	portal := create("/work/acme/portal-v2")
+	_, err = projectRepo.Merge(ctx, product.ID, portal.ID)
+	require.NoError(t, err)
+	children, err := projectRepo.GetChildren(ctx, portal.ID)
+	require.NoError(t, err)
+	assert.Len(t, children, 2, "merged project keeps the source's children")
+}